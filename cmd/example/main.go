@@ -3,7 +3,7 @@ package main
 import (
 	"fmt"
 
-	"github.com/yourusername/signature-validator/pkg/signvalidator"
+	"github.com/huangchunlong818/sign-chao/pkg/signvalidator"
 )
 
 func main() {
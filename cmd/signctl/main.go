@@ -0,0 +1,74 @@
+// signctl 签名工具命令行
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/huangchunlong818/sign-chao/pkg/signvalidator"
+)
+
+const usage = `用法: signctl <命令> [参数]
+
+命令:
+  keygen    为非对称签名算法生成 PEM 密钥对
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "keygen":
+		err = keygen(os.Args[2:])
+	case "-h", "-help", "--help", "help":
+		fmt.Print(usage)
+		return
+	default:
+		fmt.Fprintf(os.Stderr, "未知命令: %s\n\n%s", os.Args[1], usage)
+		os.Exit(2)
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "signctl: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// keygen 生成密钥对，指定 -out 时写入 <out>.pem 与 <out>.pub.pem，否则输出到标准输出
+func keygen(args []string) error {
+	fs := flag.NewFlagSet("keygen", flag.ExitOnError)
+	alg := fs.String("alg", string(signvalidator.RSA_SHA256), "签名算法，如 RSA2、RSA_PSS_SHA256、ES256、ED25519、SM2")
+	out := fs.String("out", "", "输出文件前缀，为空时输出到标准输出")
+	fs.Parse(args)
+
+	algorithm, err := signvalidator.ParseAlgorithm(*alg)
+	if err != nil {
+		return err
+	}
+
+	privateKeyPEM, publicKeyPEM, err := signvalidator.GenerateKeyPair(algorithm)
+	if err != nil {
+		return err
+	}
+
+	if *out == "" {
+		fmt.Print(privateKeyPEM)
+		fmt.Print(publicKeyPEM)
+		return nil
+	}
+
+	// 私钥仅允许当前用户读写
+	if err := os.WriteFile(*out+".pem", []byte(privateKeyPEM), 0o600); err != nil {
+		return fmt.Errorf("写入私钥失败: %w", err)
+	}
+	if err := os.WriteFile(*out+".pub.pem", []byte(publicKeyPEM), 0o644); err != nil {
+		return fmt.Errorf("写入公钥失败: %w", err)
+	}
+	fmt.Printf("已生成 %s 密钥对: %s.pem, %s.pub.pem\n", algorithm, *out, *out)
+	return nil
+}
@@ -1,3 +1,3 @@
 module github.com/huangchunlong818/sign-chao
 
-go 1.20 
\ No newline at end of file
+go 1.24 
\ No newline at end of file
@@ -0,0 +1,192 @@
+package signvalidator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// ErrAlgorithmNotAllowed 请求指定的签名算法不在允许列表中
+var ErrAlgorithmNotAllowed = errors.New("签名算法不在允许列表中")
+
+// ErrUnsupportedAlgorithm 不支持的签名算法
+var ErrUnsupportedAlgorithm = errors.New("不支持的签名算法")
+
+// algorithmAliases 网关、Java 及 JWT 常用的算法名称，键为去除分隔符后的小写名称
+var algorithmAliases = map[string]SignAlgorithm{
+	"rsa2":             RSA_SHA256,
+	"sha256withrsa":    RSA_SHA256,
+	"rs256":            RSA_SHA256,
+	"sha256withrsapss": RSA_PSS_SHA256,
+	"ps256":            RSA_PSS_SHA256,
+	"sha512withrsapss": RSA_PSS_SHA512,
+	"ps512":            RSA_PSS_SHA512,
+	"sha256withecdsa":  ECDSA_P256_SHA256,
+	"es256":            ECDSA_P256_SHA256,
+	"sha384withecdsa":  ECDSA_P384_SHA384,
+	"es384":            ECDSA_P384_SHA384,
+	"eddsa":            ED25519,
+	"sm3withsm2":       SM2,
+	"hs256":            HMAC_SHA256,
+}
+
+// knownAlgorithms 所有支持的签名算法
+var knownAlgorithms = []SignAlgorithm{
+	MD5, SHA1, SHA256, HMAC_MD5, HMAC_SHA1, HMAC_SHA256,
+	SM3, HMAC_SM3, SM2,
+	RSA_SHA256, RSA_PSS_SHA256, RSA_PSS_SHA512,
+	ECDSA_P256_SHA256, ECDSA_P384_SHA384, ED25519,
+	BLAKE2B_256, BLAKE2B_512, KEYED_BLAKE2B_256, KEYED_BLAKE2B_512,
+	BLAKE3, KEYED_BLAKE3,
+	SHA3_256, SHA3_512, HMAC_SHA3_256, HMAC_SHA3_512,
+	CMAC_AES128, CMAC_AES256, CHACHA20_POLY1305,
+	CRC32C, XXHASH64,
+}
+
+// normalizeAlgorithmName 转为小写并去除空白、"-"、"_" 与 "/" 分隔符
+func normalizeAlgorithmName(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '-', '_', '/', ' ', '\t':
+			return -1
+		}
+		return unicode.ToLower(r)
+	}, strings.TrimSpace(name))
+}
+
+// ParseAlgorithm 按名称解析签名算法，忽略大小写与分隔符，
+// 支持 "SHA-256"、"HmacSHA256"、"RSA2"、"SHA256withRSA"、"ES256" 等常见写法
+func ParseAlgorithm(name string) (SignAlgorithm, error) {
+	normalized := normalizeAlgorithmName(name)
+	if algorithm, ok := algorithmAliases[normalized]; ok {
+		return algorithm, nil
+	}
+	for _, algorithm := range knownAlgorithms {
+		if normalizeAlgorithmName(string(algorithm)) == normalized {
+			return algorithm, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s", ErrUnsupportedAlgorithm, name)
+}
+
+// UnmarshalText 实现 encoding.TextUnmarshaler，使 JSON、YAML 等配置中的算法名称经 ParseAlgorithm 解析
+func (a *SignAlgorithm) UnmarshalText(text []byte) error {
+	algorithm, err := ParseAlgorithm(string(text))
+	if err != nil {
+		return err
+	}
+	*a = algorithm
+	return nil
+}
+
+// parseAlgorithms 将配置中的 Algorithm、AllowedAlgorithms、AcceptAlgorithms 与摘要轮次算法解析为对应常量，
+// 使 "SHA-256" 等写法在创建时即被识别或报错
+func (v *SignValidator) parseAlgorithms() error {
+	algorithm, err := ParseAlgorithm(string(v.config.Algorithm))
+	if err != nil {
+		return err
+	}
+	v.config.Algorithm = algorithm
+
+	if v.config.AllowedAlgorithms, err = normalizeAlgorithms(v.config.AllowedAlgorithms); err != nil {
+		return err
+	}
+	if v.config.AcceptAlgorithms, err = normalizeAlgorithms(v.config.AcceptAlgorithms); err != nil {
+		return err
+	}
+
+	if len(v.config.Rounds) > 0 {
+		rounds := make([]DigestRound, len(v.config.Rounds))
+		for i, round := range v.config.Rounds {
+			if round.Algorithm, err = ParseAlgorithm(string(round.Algorithm)); err != nil {
+				return fmt.Errorf("第 %d 轮摘要: %w", i+1, err)
+			}
+			rounds[i] = round
+		}
+		v.config.Rounds = rounds
+	}
+	return nil
+}
+
+// normalizeAlgorithms 将配置中的算法名称解析为对应常量，无法识别时返回错误
+func normalizeAlgorithms(algorithms []SignAlgorithm) ([]SignAlgorithm, error) {
+	if len(algorithms) == 0 {
+		return algorithms, nil
+	}
+	normalized := make([]SignAlgorithm, len(algorithms))
+	for i, algorithm := range algorithms {
+		parsed, err := ParseAlgorithm(string(algorithm))
+		if err != nil {
+			return nil, err
+		}
+		normalized[i] = parsed
+	}
+	return normalized, nil
+}
+
+// negotiate 根据 AlgorithmKey 参数选择本次请求使用的签名算法，参数缺失时返回 Algorithm
+func (v *SignValidator) negotiate(params map[string]interface{}) (SignAlgorithm, error) {
+	if v.config.AlgorithmKey == "" {
+		return v.config.Algorithm, nil
+	}
+
+	value, exists := v.lookup(params, v.config.AlgorithmKey)
+	if !exists {
+		return v.config.Algorithm, nil
+	}
+
+	algorithm, err := ParseAlgorithm(convertToString(value))
+	if err != nil {
+		return "", err
+	}
+	if !v.isAllowed(algorithm) {
+		return "", fmt.Errorf("%w: %s", ErrAlgorithmNotAllowed, algorithm)
+	}
+	if err := v.checkAlgorithm(algorithm); err != nil {
+		return "", err
+	}
+
+	return algorithm, nil
+}
+
+// candidates 返回验证时依次尝试的签名算法
+func (v *SignValidator) candidates(params map[string]interface{}) ([]SignAlgorithm, error) {
+	if v.config.AlgorithmKey != "" {
+		if _, exists := v.lookup(params, v.config.AlgorithmKey); exists {
+			algorithm, err := v.negotiate(params)
+			if err != nil {
+				return nil, err
+			}
+			return []SignAlgorithm{algorithm}, nil
+		}
+	}
+
+	if len(v.config.AcceptAlgorithms) > 0 {
+		return v.config.AcceptAlgorithms, nil
+	}
+	return []SignAlgorithm{v.config.Algorithm}, nil
+}
+
+// isAllowed 判断协商得到的算法是否被允许
+func (v *SignValidator) isAllowed(algorithm SignAlgorithm) bool {
+	if len(v.config.AllowedAlgorithms) == 0 {
+		return algorithm == v.config.Algorithm
+	}
+	for _, allowed := range v.config.AllowedAlgorithms {
+		if allowed == algorithm {
+			return true
+		}
+	}
+	return false
+}
+
+// withAlgorithm 返回使用指定算法、其余配置相同的验证器副本
+func (v *SignValidator) withAlgorithm(algorithm SignAlgorithm) *SignValidator {
+	if algorithm == v.config.Algorithm {
+		return v
+	}
+	c := *v
+	c.config.Algorithm = algorithm
+	return &c
+}
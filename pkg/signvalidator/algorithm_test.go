@@ -0,0 +1,243 @@
+package signvalidator
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestSignValidator_AlgorithmNegotiation(t *testing.T) {
+	skipFIPS(t)
+	validator := NewSignValidator(Config{
+		Secret:            "testSecret",
+		Algorithm:         MD5,
+		AlgorithmKey:      "sign_type",
+		AllowedAlgorithms: []SignAlgorithm{MD5, HMAC_SHA256},
+	})
+
+	params := map[string]interface{}{
+		"id":        123,
+		"sign_type": "HMAC-SHA256",
+	}
+
+	signature, err := validator.GenerateSignature(params)
+	if err != nil {
+		t.Fatalf("生成签名失败: %v", err)
+	}
+
+	// 结果应与直接使用 HMAC_SHA256 一致
+	expected, err := NewSignValidator(Config{Secret: "testSecret", Algorithm: HMAC_SHA256}).GenerateSignature(params)
+	if err != nil {
+		t.Fatalf("生成签名失败: %v", err)
+	}
+	if signature != expected {
+		t.Errorf("协商签名 = %s, 期望 %s", signature, expected)
+	}
+
+	valid, err := validator.Validate(params, signature)
+	if err != nil {
+		t.Fatalf("验证签名失败: %v", err)
+	}
+	if !valid {
+		t.Errorf("签名验证失败")
+	}
+
+	// 未携带算法参数时使用默认算法
+	delete(params, "sign_type")
+	signature, err = validator.GenerateSignature(params)
+	if err != nil {
+		t.Fatalf("生成签名失败: %v", err)
+	}
+	expected, _ = NewSignValidator(Config{Secret: "testSecret", Algorithm: MD5}).GenerateSignature(params)
+	if signature != expected {
+		t.Errorf("默认签名 = %s, 期望 %s", signature, expected)
+	}
+}
+
+func TestSignValidator_AlgorithmNegotiation_CaseInsensitive(t *testing.T) {
+	validator := NewSignValidator(Config{
+		Secret:              "testSecret",
+		Algorithm:           SHA256,
+		AlgorithmKey:        "sign_type",
+		AllowedAlgorithms:   []SignAlgorithm{SHA256, HMAC_SHA256},
+		CaseInsensitiveKeys: true,
+	})
+
+	params := map[string]interface{}{"id": 123, "SIGN_TYPE": "HMAC-SHA256"}
+	signature, err := validator.GenerateSignature(params)
+	if err != nil {
+		t.Fatalf("生成签名失败: %v", err)
+	}
+	expected, _ := NewSignValidator(Config{Secret: "testSecret", Algorithm: HMAC_SHA256}).GenerateSignature(params)
+	if signature != expected {
+		t.Errorf("大小写不同的算法参数应参与协商: 签名 = %s, 期望 %s", signature, expected)
+	}
+
+	// 验证时只尝试参数指定的算法
+	sha256Signature, _ := NewSignValidator(Config{Secret: "testSecret", Algorithm: SHA256}).GenerateSignature(params)
+	if valid, _ := validator.Validate(params, sha256Signature); valid {
+		t.Error("指定算法后不应接受其他候选算法的签名")
+	}
+	if valid, err := validator.Validate(params, signature); err != nil || !valid {
+		t.Errorf("签名验证失败: valid=%v, err=%v", valid, err)
+	}
+}
+
+func TestSignValidator_AlgorithmNotAllowed(t *testing.T) {
+	validator := NewSignValidator(Config{
+		Secret:       "testSecret",
+		Algorithm:    HMAC_SHA256,
+		AlgorithmKey: "sign_type",
+	})
+
+	// 未配置允许列表时只允许 Algorithm
+	_, err := validator.Validate(map[string]interface{}{"id": 1, "sign_type": "MD5"}, "xxx")
+	if !errors.Is(err, ErrAlgorithmNotAllowed) {
+		t.Errorf("错误 = %v, 期望 ErrAlgorithmNotAllowed", err)
+	}
+
+	if _, err := validator.Validate(map[string]interface{}{"id": 1, "sign_type": "UNKNOWN"}, "xxx"); err == nil {
+		t.Errorf("未知算法应该返回错误")
+	}
+
+	if _, err := validator.Validate(map[string]interface{}{"id": 1, "sign_type": "hmac_sha256"}, "xxx"); err != nil {
+		t.Errorf("允许的算法不应返回错误: %v", err)
+	}
+}
+
+func TestSignValidator_AcceptAlgorithms(t *testing.T) {
+	skipFIPS(t)
+	params := map[string]interface{}{
+		"id":   123,
+		"name": "test",
+	}
+
+	oldSignature, err := NewSignValidator(Config{Secret: "testSecret", Algorithm: HMAC_SHA1}).GenerateSignature(params)
+	if err != nil {
+		t.Fatalf("生成签名失败: %v", err)
+	}
+	newSignature, err := NewSignValidator(Config{Secret: "testSecret", Algorithm: HMAC_SHA256}).GenerateSignature(params)
+	if err != nil {
+		t.Fatalf("生成签名失败: %v", err)
+	}
+
+	validator := NewSignValidator(Config{
+		Secret:           "testSecret",
+		Algorithm:        HMAC_SHA256,
+		AcceptAlgorithms: []SignAlgorithm{HMAC_SHA256, HMAC_SHA1},
+	})
+
+	testCases := []struct {
+		signature string
+		matched   SignAlgorithm
+		valid     bool
+	}{
+		{newSignature, HMAC_SHA256, true},
+		{oldSignature, HMAC_SHA1, true},
+		{"invalid", "", false},
+	}
+
+	for _, tc := range testCases {
+		matched, valid, err := validator.ValidateAlgorithms(params, tc.signature)
+		if err != nil {
+			t.Fatalf("验证签名失败: %v", err)
+		}
+		if matched != tc.matched || valid != tc.valid {
+			t.Errorf("ValidateAlgorithms(%s) = (%s, %v), 期望 (%s, %v)", tc.signature, matched, valid, tc.matched, tc.valid)
+		}
+	}
+
+	// 生成签名始终使用 Algorithm
+	signature, err := validator.GenerateSignature(params)
+	if err != nil {
+		t.Fatalf("生成签名失败: %v", err)
+	}
+	if signature != newSignature {
+		t.Errorf("签名 = %s, 期望 %s", signature, newSignature)
+	}
+}
+
+func TestParseAlgorithm(t *testing.T) {
+	testCases := []struct {
+		name     string
+		expected SignAlgorithm
+	}{
+		{"SHA-256", SHA256},
+		{"sha256", SHA256},
+		{"HmacSHA256", HMAC_SHA256},
+		{"HMAC-SHA256", HMAC_SHA256},
+		{"HS256", HMAC_SHA256},
+		{"HmacSM3", HMAC_SM3},
+		{"SHA3-256", SHA3_256},
+		{"HMAC_SHA3_512", HMAC_SHA3_512},
+		{"RSA2", RSA_SHA256},
+		{"SHA256withRSA", RSA_SHA256},
+		{"SHA256withRSA/PSS", RSA_PSS_SHA256},
+		{"ES384", ECDSA_P384_SHA384},
+		{"SM3withSM2", SM2},
+		{"Ed25519", ED25519},
+		{" blake2b-256 ", BLAKE2B_256},
+	}
+
+	for _, tc := range testCases {
+		algorithm, err := ParseAlgorithm(tc.name)
+		if err != nil {
+			t.Errorf("解析 %q 失败: %v", tc.name, err)
+			continue
+		}
+		if algorithm != tc.expected {
+			t.Errorf("ParseAlgorithm(%q) = %s, 期望 %s", tc.name, algorithm, tc.expected)
+		}
+	}
+
+	if _, err := ParseAlgorithm("SHA-999"); !errors.Is(err, ErrUnsupportedAlgorithm) {
+		t.Errorf("错误 = %v, 期望 ErrUnsupportedAlgorithm", err)
+	}
+
+	// 去除分隔符后的名称不能相互冲突
+	seen := make(map[string]SignAlgorithm)
+	for _, algorithm := range knownAlgorithms {
+		name := normalizeAlgorithmName(string(algorithm))
+		if other, exists := seen[name]; exists {
+			t.Errorf("%s 与 %s 的名称冲突", algorithm, other)
+		}
+		seen[name] = algorithm
+	}
+	for alias := range algorithmAliases {
+		if other, exists := seen[alias]; exists {
+			t.Errorf("别名 %s 与 %s 冲突", alias, other)
+		}
+	}
+}
+
+func TestConfig_AlgorithmNames(t *testing.T) {
+	// 配置中的算法名称在创建时解析
+	validator, err := New(Config{Secret: "testSecret", Algorithm: "HmacSHA256"})
+	if err != nil {
+		t.Fatalf("创建验证器失败: %v", err)
+	}
+	params := map[string]interface{}{"id": 123}
+	signature, err := validator.GenerateSignature(params)
+	if err != nil {
+		t.Fatalf("生成签名失败: %v", err)
+	}
+	expected, _ := NewSignValidator(Config{Secret: "testSecret", Algorithm: HMAC_SHA256}).GenerateSignature(params)
+	if signature != expected {
+		t.Errorf("签名 = %s, 期望 %s", signature, expected)
+	}
+
+	if _, err := New(Config{Secret: "testSecret", Algorithm: "SHA-999"}); !errors.Is(err, ErrUnsupportedAlgorithm) {
+		t.Errorf("错误 = %v, 期望 ErrUnsupportedAlgorithm", err)
+	}
+
+	// JSON 配置通过 UnmarshalText 解析
+	var config struct {
+		Algorithm SignAlgorithm `json:"algorithm"`
+	}
+	if err := json.Unmarshal([]byte(`{"algorithm": "SHA-256"}`), &config); err != nil {
+		t.Fatalf("解析配置失败: %v", err)
+	}
+	if config.Algorithm != SHA256 {
+		t.Errorf("算法 = %s, 期望 %s", config.Algorithm, SHA256)
+	}
+}
@@ -0,0 +1,111 @@
+// Package aliyunkms 使用阿里云 KMS 非对称密钥计算签名（AsymmetricSign），
+// 私钥始终保存在 KMS 中，进程内只持有密钥 ID 与版本 ID。支持 RSA、RSA-PSS、ECDSA 与 SM2。
+//
+// Client 可使用本包的 HTTPClient（基于 RPC 签名直接调用 KMS API），也可以将阿里云 SDK 客户端包装为 Client。
+package aliyunkms
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/huangchunlong818/sign-chao/pkg/signvalidator"
+	"github.com/huangchunlong818/sign-chao/pkg/signvalidator/internal/sm2"
+)
+
+// Client 阿里云 KMS API 的子集
+type Client interface {
+	// AsymmetricSign 调用 AsymmetricSign，digest 为摘要，返回签名
+	AsymmetricSign(ctx context.Context, keyID, keyVersionID, algorithm string, digest []byte) ([]byte, error)
+	// GetPublicKey 调用 GetPublicKey，返回 PEM 编码的公钥
+	GetPublicKey(ctx context.Context, keyID, keyVersionID string) (string, error)
+}
+
+// defaultTimeout 调用 KMS 的默认超时时间
+const defaultTimeout = 5 * time.Second
+
+// Signer 使用阿里云 KMS 非对称密钥签名的 crypto.Signer
+type Signer struct {
+	client       Client
+	keyID        string
+	keyVersionID string
+	public       crypto.PublicKey
+}
+
+// NewSigner 创建 KMS 签名器，创建时通过 GetPublicKey 获取公钥
+func NewSigner(client Client, keyID, keyVersionID string) (*Signer, error) {
+	if client == nil {
+		return nil, errors.New("KMS 客户端为空")
+	}
+	if keyID == "" || keyVersionID == "" {
+		return nil, errors.New("KMS 密钥 ID 与版本 ID 不能为空")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+	publicKeyPEM, err := client.GetPublicKey(ctx, keyID, keyVersionID)
+	if err != nil {
+		return nil, fmt.Errorf("KMS GetPublicKey 失败: %w", err)
+	}
+	public, err := signvalidator.ParsePublicKey(publicKeyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("解析 KMS 公钥失败: %w", err)
+	}
+	switch public.(type) {
+	case *rsa.PublicKey, *ecdsa.PublicKey, *signvalidator.SM2PublicKey:
+	default:
+		return nil, fmt.Errorf("不支持的公钥类型: %T", public)
+	}
+
+	return &Signer{client: client, keyID: keyID, keyVersionID: keyVersionID, public: public}, nil
+}
+
+// Public 返回 KMS 密钥的公钥
+func (s *Signer) Public() crypto.PublicKey {
+	return s.public
+}
+
+// Sign 使用 KMS 签名：RSA 与 ECDSA 传入摘要，SM2 传入原始消息（使用默认用户标识计算 SM3(Z||M) 后签名），
+// ECDSA 与 SM2 签名为 ASN.1 DER 格式
+func (s *Signer) Sign(_ io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	var algorithm string
+	switch pub := s.public.(type) {
+	case *signvalidator.SM2PublicKey:
+		algorithm = "SM2DSA"
+		digest = sm2.Digest(pub, sm2.DefaultUID, digest)
+	case *rsa.PublicKey:
+		if opts == nil || opts.HashFunc() != crypto.SHA256 {
+			return nil, errors.New("KMS RSA 签名只支持 SHA256")
+		}
+		algorithm = "RSA_PKCS1_SHA_256"
+		if pss, ok := opts.(*rsa.PSSOptions); ok {
+			// KMS 的 PSS 盐长度固定为摘要长度
+			if pss.SaltLength != rsa.PSSSaltLengthEqualsHash && pss.SaltLength != crypto.SHA256.Size() {
+				return nil, errors.New("KMS 的 PSS 盐长度必须与摘要长度相同")
+			}
+			algorithm = "RSA_PSS_SHA_256"
+		}
+	case *ecdsa.PublicKey:
+		if pub.Curve != elliptic.P256() || opts == nil || opts.HashFunc() != crypto.SHA256 {
+			return nil, errors.New("KMS ECDSA 签名只支持 P-256 与 SHA256")
+		}
+		algorithm = "ECDSA_SHA_256"
+	}
+	if algorithm != "SM2DSA" && len(digest) != crypto.SHA256.Size() {
+		return nil, errors.New("摘要长度与摘要算法不匹配")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+	signature, err := s.client.AsymmetricSign(ctx, s.keyID, s.keyVersionID, algorithm, digest)
+	if err != nil {
+		return nil, fmt.Errorf("KMS AsymmetricSign 失败: %w", err)
+	}
+	return signature, nil
+}
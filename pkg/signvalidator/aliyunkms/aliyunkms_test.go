@@ -0,0 +1,141 @@
+package aliyunkms
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/huangchunlong818/sign-chao/pkg/signvalidator"
+	"github.com/huangchunlong818/sign-chao/pkg/signvalidator/internal/sm2"
+)
+
+// fakeKMS 模拟阿里云 KMS RPC API，私钥只保存在服务端
+type fakeKMS struct {
+	secret  string
+	signers map[string]crypto.Signer
+}
+
+func (k *fakeKMS) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	r.ParseForm()
+	if r.PostForm.Get("Signature") != rpcSignature(r.Method, r.PostForm, k.secret) {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(map[string]string{"Code": "SignatureDoesNotMatch", "RequestId": "req-1"})
+		return
+	}
+
+	signer, ok := k.signers[r.PostForm.Get("KeyId")]
+	if !ok || r.PostForm.Get("KeyVersionId") != "v1" {
+		w.WriteHeader(http.StatusNotFound)
+		json.NewEncoder(w).Encode(map[string]string{"Code": "Forbidden.KeyNotFound", "RequestId": "req-2"})
+		return
+	}
+
+	switch r.PostForm.Get("Action") {
+	case "GetPublicKey":
+		public, _ := signvalidator.MarshalPublicKeyPEM(signer.Public())
+		json.NewEncoder(w).Encode(map[string]string{"PublicKey": public})
+	case "AsymmetricSign":
+		digest, _ := base64.StdEncoding.DecodeString(r.PostForm.Get("Digest"))
+		var signature []byte
+		var err error
+		switch r.PostForm.Get("Algorithm") {
+		case "SM2DSA":
+			signature, err = sm2.SignDigest(rand.Reader, signer.(*sm2.PrivateKey), digest)
+		case "RSA_PSS_SHA_256":
+			signature, err = signer.Sign(rand.Reader, digest, &rsa.PSSOptions{Hash: crypto.SHA256, SaltLength: rsa.PSSSaltLengthEqualsHash})
+		case "RSA_PKCS1_SHA_256", "ECDSA_SHA_256":
+			signature, err = signer.Sign(rand.Reader, digest, crypto.SHA256)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"Value": base64.StdEncoding.EncodeToString(signature)})
+	}
+}
+
+func TestSigner(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("生成密钥失败: %v", err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("生成密钥失败: %v", err)
+	}
+	sm2Key, err := signvalidator.GenerateSM2Key()
+	if err != nil {
+		t.Fatalf("生成密钥失败: %v", err)
+	}
+
+	server := httptest.NewServer(&fakeKMS{
+		secret:  "testsecret",
+		signers: map[string]crypto.Signer{"rsa-key": rsaKey, "ec-key": ecKey, "sm2-key": sm2Key},
+	})
+	defer server.Close()
+
+	client, err := NewHTTPClient(HTTPConfig{
+		RegionID:    "cn-hangzhou",
+		Endpoint:    server.URL,
+		Credentials: Credentials{AccessKeyID: "testid", AccessKeySecret: "testsecret"},
+	})
+	if err != nil {
+		t.Fatalf("创建客户端失败: %v", err)
+	}
+
+	testCases := []struct {
+		keyID     string
+		algorithm signvalidator.SignAlgorithm
+	}{
+		{"rsa-key", signvalidator.RSA_SHA256},
+		{"rsa-key", signvalidator.RSA_PSS_SHA256},
+		{"ec-key", signvalidator.ECDSA_P256_SHA256},
+		{"sm2-key", signvalidator.SM2},
+	}
+
+	params := map[string]interface{}{"id": 123, "name": "test"}
+	for _, tc := range testCases {
+		if signvalidator.FIPSBuild && !signvalidator.IsFIPSApproved(tc.algorithm) {
+			continue
+		}
+		signer, err := NewSigner(client, tc.keyID, "v1")
+		if err != nil {
+			t.Fatalf("创建签名器失败: %v", err)
+		}
+		validator := signvalidator.NewSignValidator(signvalidator.Config{Algorithm: tc.algorithm, Signer: signer})
+
+		signature, err := validator.GenerateSignature(params)
+		if err != nil {
+			t.Fatalf("%s 生成签名失败: %v", tc.algorithm, err)
+		}
+		valid, err := validator.Validate(params, signature)
+		if err != nil {
+			t.Fatalf("%s 验证签名失败: %v", tc.algorithm, err)
+		}
+		if !valid {
+			t.Errorf("%s 签名验证失败", tc.algorithm)
+		}
+	}
+
+	// KMS 不支持 RSA-PSS SHA512
+	signer, _ := NewSigner(client, "rsa-key", "v1")
+	if _, err := signvalidator.NewSignValidator(signvalidator.Config{Algorithm: signvalidator.RSA_PSS_SHA512, Signer: signer}).GenerateSignature(params); err == nil {
+		t.Errorf("不支持的摘要算法应该返回错误")
+	}
+
+	var apiErr *APIError
+	if _, err := NewSigner(client, "missing", "v1"); !errors.As(err, &apiErr) || apiErr.Code != "Forbidden.KeyNotFound" {
+		t.Errorf("错误 = %v, 期望 Forbidden.KeyNotFound", err)
+	}
+}
@@ -0,0 +1,187 @@
+package aliyunkms
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/huangchunlong818/sign-chao/pkg/signvalidator"
+)
+
+// Credentials 阿里云访问凭证
+type Credentials struct {
+	// AccessKeyID 访问密钥 ID
+	AccessKeyID string
+	// AccessKeySecret 访问密钥
+	AccessKeySecret string
+	// SecurityToken STS 临时凭证的安全令牌，可为空
+	SecurityToken string
+}
+
+// HTTPConfig KMS HTTP 客户端配置
+type HTTPConfig struct {
+	// RegionID 区域，如 "cn-hangzhou"
+	RegionID string
+	// Endpoint 自定义地址（如 VPC 地址），默认为 https://kms.<RegionID>.aliyuncs.com
+	Endpoint string
+	// Credentials 访问凭证
+	Credentials Credentials
+	// Client HTTP 客户端，默认为 http.DefaultClient
+	Client *http.Client
+	// Clock 生成请求签名时间使用的时钟，默认为 signvalidator.SystemClock
+	Clock signvalidator.Clock
+}
+
+// HTTPClient 使用 RPC 签名直接调用 KMS API 的 Client，无需依赖阿里云 SDK
+type HTTPClient struct {
+	config HTTPConfig
+}
+
+// APIError KMS 返回的错误
+type APIError struct {
+	// StatusCode HTTP 状态码
+	StatusCode int
+	// Code 错误码，如 "Forbidden.KeyNotFound"
+	Code string `json:"Code"`
+	// Message 错误信息
+	Message string `json:"Message"`
+	// RequestID 请求 ID
+	RequestID string `json:"RequestId"`
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("KMS 错误 %s（HTTP %d，RequestId %s）: %s", e.Code, e.StatusCode, e.RequestID, e.Message)
+}
+
+// NewHTTPClient 创建 KMS HTTP 客户端
+func NewHTTPClient(config HTTPConfig) (*HTTPClient, error) {
+	if config.RegionID == "" {
+		return nil, errors.New("KMS 区域为空")
+	}
+	if config.Credentials.AccessKeyID == "" || config.Credentials.AccessKeySecret == "" {
+		return nil, errors.New("阿里云访问凭证为空")
+	}
+	if config.Endpoint == "" {
+		config.Endpoint = "https://kms." + config.RegionID + ".aliyuncs.com"
+	}
+	if config.Client == nil {
+		config.Client = http.DefaultClient
+	}
+	if config.Clock == nil {
+		config.Clock = signvalidator.SystemClock
+	}
+	return &HTTPClient{config: config}, nil
+}
+
+// AsymmetricSign 调用 AsymmetricSign
+func (c *HTTPClient) AsymmetricSign(ctx context.Context, keyID, keyVersionID, algorithm string, digest []byte) ([]byte, error) {
+	var output struct {
+		Value string `json:"Value"`
+	}
+	params := url.Values{
+		"KeyId":        {keyID},
+		"KeyVersionId": {keyVersionID},
+		"Algorithm":    {algorithm},
+		"Digest":       {base64.StdEncoding.EncodeToString(digest)},
+	}
+	if err := c.call(ctx, "AsymmetricSign", params, &output); err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(output.Value)
+}
+
+// GetPublicKey 调用 GetPublicKey
+func (c *HTTPClient) GetPublicKey(ctx context.Context, keyID, keyVersionID string) (string, error) {
+	var output struct {
+		PublicKey string `json:"PublicKey"`
+	}
+	params := url.Values{"KeyId": {keyID}, "KeyVersionId": {keyVersionID}}
+	if err := c.call(ctx, "GetPublicKey", params, &output); err != nil {
+		return "", err
+	}
+	return output.PublicKey, nil
+}
+
+// call 以 POST 表单调用 KMS RPC API
+func (c *HTTPClient) call(ctx context.Context, action string, params url.Values, output interface{}) error {
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+
+	params.Set("Action", action)
+	params.Set("Format", "JSON")
+	params.Set("Version", "2016-01-20")
+	params.Set("AccessKeyId", c.config.Credentials.AccessKeyID)
+	params.Set("SignatureMethod", "HMAC-SHA1")
+	params.Set("SignatureVersion", "1.0")
+	params.Set("SignatureNonce", hex.EncodeToString(nonce))
+	params.Set("Timestamp", c.config.Clock.Now().UTC().Format("2006-01-02T15:04:05Z"))
+	if c.config.Credentials.SecurityToken != "" {
+		params.Set("SecurityToken", c.config.Credentials.SecurityToken)
+	}
+	params.Set("Signature", rpcSignature(http.MethodPost, params, c.config.Credentials.AccessKeySecret))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.config.Endpoint, strings.NewReader(params.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.config.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		apiErr := &APIError{StatusCode: resp.StatusCode}
+		json.Unmarshal(data, apiErr)
+		return apiErr
+	}
+	if err := json.Unmarshal(data, output); err != nil {
+		return fmt.Errorf("解析 KMS 响应失败: %w", err)
+	}
+	return nil
+}
+
+// rpcSignature 计算阿里云 RPC 风格签名：Base64(HMAC-SHA1(AccessKeySecret+"&", Method&%2F&编码后的规范化参数))
+func rpcSignature(method string, params url.Values, secret string) string {
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		if key != "Signature" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, percentEncode(key)+"="+percentEncode(params.Get(key)))
+	}
+	stringToSign := method + "&" + percentEncode("/") + "&" + percentEncode(strings.Join(pairs, "&"))
+
+	h := hmac.New(sha1.New, []byte(secret+"&"))
+	h.Write([]byte(stringToSign))
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
+
+// percentEncode 按 RFC 3986 编码，空格编码为 "%20"
+func percentEncode(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
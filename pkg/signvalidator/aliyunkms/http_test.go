@@ -0,0 +1,38 @@
+package aliyunkms
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestRPCSignature(t *testing.T) {
+	// 阿里云文档中的 RPC 签名示例（DescribeRegions）
+	params := url.Values{
+		"AccessKeyId":      {"testid"},
+		"Action":           {"DescribeRegions"},
+		"Format":           {"XML"},
+		"SignatureMethod":  {"HMAC-SHA1"},
+		"SignatureNonce":   {"3ee8c1b8-83d3-44af-a94f-4e0ad82fd6cf"},
+		"SignatureVersion": {"1.0"},
+		"Timestamp":        {"2016-02-23T12:46:24Z"},
+		"Version":          {"2014-05-26"},
+	}
+	if signature := rpcSignature(http.MethodGet, params, "testsecret"); signature != "OLeaidS1JvxuMvnyHOwuJ+uX5qY=" {
+		t.Errorf("签名 = %s, 期望 OLeaidS1JvxuMvnyHOwuJ+uX5qY=", signature)
+	}
+}
+
+func TestPercentEncode(t *testing.T) {
+	testCases := map[string]string{
+		"a b": "a%20b",
+		"a*b": "a%2Ab",
+		"a~b": "a~b",
+		"a/b": "a%2Fb",
+	}
+	for input, expected := range testCases {
+		if result := percentEncode(input); result != expected {
+			t.Errorf("percentEncode(%q) = %s, 期望 %s", input, result, expected)
+		}
+	}
+}
@@ -0,0 +1,342 @@
+package signvalidator
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	// 注册 crypto.SHA384 与 crypto.SHA512
+	_ "crypto/sha512"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/huangchunlong818/sign-chao/pkg/signvalidator/internal/sm2"
+)
+
+// PSSSaltLengthAuto RSA-PSS 验证时自动识别盐长度，签名时使用最大盐长度
+const PSSSaltLengthAuto = -1
+
+// errTruncateAsymmetric 非对称签名被截断后无法验证
+var errTruncateAsymmetric = errors.New("非对称签名算法不支持截断签名")
+
+// ECDSAFormat 表示 ECDSA 签名的编码格式
+type ECDSAFormat string
+
+const (
+	// ECDSAFormatASN1 ASN.1 DER 编码格式
+	ECDSAFormatASN1 ECDSAFormat = "asn1"
+	// ECDSAFormatRaw 定长 R||S 拼接格式（JWS 等协议使用）
+	ECDSAFormatRaw ECDSAFormat = "raw"
+)
+
+// SM2PrivateKey SM2 私钥；签名实现不是常数时间的，不能抵御计时侧信道，服务端签名应使用 KMS 或 HSM（如 aliyunkms、pkcs11）
+type SM2PrivateKey = sm2.PrivateKey
+
+// SM2PublicKey SM2 公钥
+type SM2PublicKey = sm2.PublicKey
+
+// GenerateSM2Key 生成新的 SM2 密钥对
+func GenerateSM2Key() (*SM2PrivateKey, error) {
+	return sm2.GenerateKey(rand.Reader)
+}
+
+// ParseSM2PrivateKey 解析十六进制编码的 SM2 私钥
+func ParseSM2PrivateKey(hexKey string) (*SM2PrivateKey, error) {
+	key, err := hex.DecodeString(strings.TrimSpace(hexKey))
+	if err != nil {
+		return nil, fmt.Errorf("SM2 私钥不是有效的十六进制字符串: %w", err)
+	}
+	return sm2.NewPrivateKey(key)
+}
+
+// ParseSM2PublicKey 解析十六进制编码的 SM2 公钥，支持 04||X||Y 或 X||Y 格式
+func ParseSM2PublicKey(hexKey string) (*SM2PublicKey, error) {
+	key, err := hex.DecodeString(strings.TrimSpace(hexKey))
+	if err != nil {
+		return nil, fmt.Errorf("SM2 公钥不是有效的十六进制字符串: %w", err)
+	}
+	return sm2.NewPublicKey(key)
+}
+
+// NewVerifier 创建仅持有公钥的非对称签名验证器
+func NewVerifier(algorithm SignAlgorithm, publicKey crypto.PublicKey) (Verifier, error) {
+	if !isAsymmetric(algorithm) {
+		return nil, fmt.Errorf("签名算法 %s 不是非对称算法", algorithm)
+	}
+	if err := checkKeyType(algorithm, publicKey); err != nil {
+		return nil, err
+	}
+	return New(Config{
+		Algorithm: algorithm,
+		PublicKey: publicKey,
+	})
+}
+
+// ParseEd25519PrivateKey 解析十六进制或 Base64 编码的 Ed25519 私钥，支持 32 字节种子或 64 字节私钥
+func ParseEd25519PrivateKey(key string) (ed25519.PrivateKey, error) {
+	data, err := decodeRawKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("Ed25519 私钥编码无效: %w", err)
+	}
+
+	switch len(data) {
+	case ed25519.SeedSize:
+		return ed25519.NewKeyFromSeed(data), nil
+	case ed25519.PrivateKeySize:
+		return ed25519.PrivateKey(data), nil
+	}
+	return nil, fmt.Errorf("Ed25519 私钥长度无效: %d", len(data))
+}
+
+// ParseEd25519PublicKey 解析十六进制或 Base64 编码的 32 字节 Ed25519 公钥
+func ParseEd25519PublicKey(key string) (ed25519.PublicKey, error) {
+	data, err := decodeRawKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("Ed25519 公钥编码无效: %w", err)
+	}
+
+	if len(data) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("Ed25519 公钥长度无效: %d", len(data))
+	}
+	return ed25519.PublicKey(data), nil
+}
+
+// decodeRawKey 解码十六进制或 Base64（含 URL 安全格式）编码的原始密钥
+func decodeRawKey(key string) ([]byte, error) {
+	key = strings.TrimSpace(key)
+	if data, err := hex.DecodeString(key); err == nil {
+		return data, nil
+	}
+	for _, encoding := range []*base64.Encoding{base64.StdEncoding, base64.RawStdEncoding, base64.URLEncoding, base64.RawURLEncoding} {
+		if data, err := encoding.DecodeString(key); err == nil {
+			return data, nil
+		}
+	}
+	return nil, errors.New("既不是十六进制也不是 Base64 格式")
+}
+
+// isAsymmetric 判断算法是否为非对称签名算法
+func isAsymmetric(algorithm SignAlgorithm) bool {
+	switch algorithm {
+	case SM2, RSA_SHA256, RSA_PSS_SHA256, RSA_PSS_SHA512, ECDSA_P256_SHA256, ECDSA_P384_SHA384, ED25519:
+		return true
+	}
+	return false
+}
+
+// signAsymmetric 使用私钥对待签名字符串签名
+func (v *SignValidator) signAsymmetric(stringToSign string) ([]byte, error) {
+	if v.config.TruncateLength > 0 {
+		return nil, errTruncateAsymmetric
+	}
+
+	signer, err := v.signer()
+	if err != nil {
+		return nil, err
+	}
+	if err := checkKeyType(v.config.Algorithm, signer.Public()); err != nil {
+		return nil, err
+	}
+
+	switch v.config.Algorithm {
+	case SM2:
+		// SM2 对原文计算 Z 值，直接传入原始消息
+		return signer.Sign(rand.Reader, []byte(stringToSign), nil)
+	case RSA_SHA256:
+		return signer.Sign(rand.Reader, digest(crypto.SHA256, stringToSign), crypto.SHA256)
+	case RSA_PSS_SHA256, RSA_PSS_SHA512:
+		opts := v.pssOptions()
+		return signer.Sign(rand.Reader, digest(opts.Hash, stringToSign), opts)
+	case ECDSA_P256_SHA256, ECDSA_P384_SHA384:
+		h := ecdsaHash(v.config.Algorithm)
+		signBytes, err := signer.Sign(rand.Reader, digest(h, stringToSign), h)
+		if err != nil || v.config.ECDSAFormat != ECDSAFormatRaw {
+			return signBytes, err
+		}
+		return ecdsaASN1ToRaw(signBytes, ecdsaKeySize(v.config.Algorithm))
+	case ED25519:
+		// Ed25519 对原文签名，不预先计算摘要
+		return signer.Sign(rand.Reader, []byte(stringToSign), crypto.Hash(0))
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrUnsupportedAlgorithm, v.config.Algorithm)
+}
+
+// verifyAsymmetric 使用公钥验证待签名字符串的签名
+func (v *SignValidator) verifyAsymmetric(stringToSign, signature string) (bool, error) {
+	if v.config.TruncateLength > 0 {
+		return false, errTruncateAsymmetric
+	}
+
+	publicKey, err := v.publicKey()
+	if err != nil {
+		return false, err
+	}
+	if err := checkKeyType(v.config.Algorithm, publicKey); err != nil {
+		return false, err
+	}
+
+	signBytes, err := v.decodeSignature(signature)
+	if err != nil {
+		// 无法解码的签名视为无效签名
+		return false, nil
+	}
+
+	switch v.config.Algorithm {
+	case SM2:
+		return sm2.VerifyASN1(publicKey.(*SM2PublicKey), []byte(stringToSign), signBytes), nil
+	case RSA_SHA256:
+		return rsa.VerifyPKCS1v15(publicKey.(*rsa.PublicKey), crypto.SHA256, digest(crypto.SHA256, stringToSign), signBytes) == nil, nil
+	case RSA_PSS_SHA256, RSA_PSS_SHA512:
+		opts := v.pssOptions()
+		return rsa.VerifyPSS(publicKey.(*rsa.PublicKey), opts.Hash, digest(opts.Hash, stringToSign), signBytes, opts) == nil, nil
+	case ECDSA_P256_SHA256, ECDSA_P384_SHA384:
+		if v.config.ECDSAFormat == ECDSAFormatRaw {
+			if signBytes, err = ecdsaRawToASN1(signBytes, ecdsaKeySize(v.config.Algorithm)); err != nil {
+				return false, nil
+			}
+		}
+		h := ecdsaHash(v.config.Algorithm)
+		return ecdsa.VerifyASN1(publicKey.(*ecdsa.PublicKey), digest(h, stringToSign), signBytes), nil
+	case ED25519:
+		return ed25519.Verify(publicKey.(ed25519.PublicKey), []byte(stringToSign), signBytes), nil
+	}
+
+	return false, fmt.Errorf("%w: %s", ErrUnsupportedAlgorithm, v.config.Algorithm)
+}
+
+// signer 返回用于生成签名的签名器，优先使用 Signer，其次使用 PrivateKey
+func (v *SignValidator) signer() (crypto.Signer, error) {
+	if v.config.Signer != nil {
+		return v.config.Signer, nil
+	}
+	if v.config.PrivateKey == nil {
+		return nil, errors.New("非对称签名算法需要配置私钥或签名器")
+	}
+
+	signer, ok := v.config.PrivateKey.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("不支持的私钥类型: %T", v.config.PrivateKey)
+	}
+	return signer, nil
+}
+
+// publicKey 返回用于验证的公钥，未配置时从签名器或私钥推导
+func (v *SignValidator) publicKey() (crypto.PublicKey, error) {
+	if v.config.PublicKey != nil {
+		return v.config.PublicKey, nil
+	}
+	if v.config.Signer != nil {
+		return v.config.Signer.Public(), nil
+	}
+	if signer, ok := v.config.PrivateKey.(crypto.Signer); ok {
+		return signer.Public(), nil
+	}
+	return nil, errors.New("非对称签名算法需要配置公钥")
+}
+
+// checkKeyType 检查公钥类型是否与签名算法匹配
+func checkKeyType(algorithm SignAlgorithm, publicKey crypto.PublicKey) error {
+	var ok bool
+	switch algorithm {
+	case SM2:
+		_, ok = publicKey.(*SM2PublicKey)
+	case RSA_SHA256, RSA_PSS_SHA256, RSA_PSS_SHA512:
+		_, ok = publicKey.(*rsa.PublicKey)
+	case ECDSA_P256_SHA256, ECDSA_P384_SHA384:
+		var key *ecdsa.PublicKey
+		key, ok = publicKey.(*ecdsa.PublicKey)
+		ok = ok && key.Curve == ecdsaCurve(algorithm)
+	case ED25519:
+		var key ed25519.PublicKey
+		key, ok = publicKey.(ed25519.PublicKey)
+		ok = ok && len(key) == ed25519.PublicKeySize
+	}
+	if !ok {
+		return fmt.Errorf("密钥类型 %T 与签名算法 %s 不匹配", publicKey, algorithm)
+	}
+	return nil
+}
+
+// pssOptions 返回 RSA-PSS 签名参数
+func (v *SignValidator) pssOptions() *rsa.PSSOptions {
+	opts := &rsa.PSSOptions{Hash: crypto.SHA256}
+	if v.config.Algorithm == RSA_PSS_SHA512 {
+		opts.Hash = crypto.SHA512
+	}
+
+	switch v.config.PSSSaltLength {
+	case 0:
+		opts.SaltLength = rsa.PSSSaltLengthEqualsHash
+	case PSSSaltLengthAuto:
+		opts.SaltLength = rsa.PSSSaltLengthAuto
+	default:
+		opts.SaltLength = v.config.PSSSaltLength
+	}
+	return opts
+}
+
+// digest 使用指定哈希算法计算待签名字符串的摘要
+func digest(h crypto.Hash, stringToSign string) []byte {
+	hasher := h.New()
+	hasher.Write([]byte(stringToSign))
+	return hasher.Sum(nil)
+}
+
+// ecdsaCurve 返回 ECDSA 算法对应的曲线
+func ecdsaCurve(algorithm SignAlgorithm) elliptic.Curve {
+	if algorithm == ECDSA_P384_SHA384 {
+		return elliptic.P384()
+	}
+	return elliptic.P256()
+}
+
+// ecdsaHash 返回 ECDSA 算法对应的哈希算法
+func ecdsaHash(algorithm SignAlgorithm) crypto.Hash {
+	if algorithm == ECDSA_P384_SHA384 {
+		return crypto.SHA384
+	}
+	return crypto.SHA256
+}
+
+// ecdsaKeySize 返回 ECDSA 算法中 R、S 的定长字节数
+func ecdsaKeySize(algorithm SignAlgorithm) int {
+	return (ecdsaCurve(algorithm).Params().BitSize + 7) / 8
+}
+
+type ecdsaSignature struct {
+	R, S *big.Int
+}
+
+// ecdsaASN1ToRaw 将 ASN.1 DER 编码的 ECDSA 签名转换为 R||S 格式
+func ecdsaASN1ToRaw(sig []byte, size int) ([]byte, error) {
+	var parsed ecdsaSignature
+	if _, err := asn1.Unmarshal(sig, &parsed); err != nil {
+		return nil, err
+	}
+	if parsed.R.BitLen() > size*8 || parsed.S.BitLen() > size*8 {
+		return nil, errors.New("ECDSA 签名长度无效")
+	}
+	raw := make([]byte, size*2)
+	parsed.R.FillBytes(raw[:size])
+	parsed.S.FillBytes(raw[size:])
+	return raw, nil
+}
+
+// ecdsaRawToASN1 将 R||S 格式的 ECDSA 签名转换为 ASN.1 DER 编码
+func ecdsaRawToASN1(sig []byte, size int) ([]byte, error) {
+	if len(sig) != size*2 {
+		return nil, errors.New("ECDSA 签名长度无效")
+	}
+	return asn1.Marshal(ecdsaSignature{
+		R: new(big.Int).SetBytes(sig[:size]),
+		S: new(big.Int).SetBytes(sig[size:]),
+	})
+}
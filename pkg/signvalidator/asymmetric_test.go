@@ -0,0 +1,442 @@
+package signvalidator
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"io"
+	"testing"
+)
+
+// hsmSigner 模拟密钥保存在硬件中的签名器，只暴露 crypto.Signer 接口
+type hsmSigner struct {
+	key   crypto.Signer
+	calls int
+}
+
+func (s *hsmSigner) Public() crypto.PublicKey {
+	return s.key.Public()
+}
+
+func (s *hsmSigner) Sign(rand io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	s.calls++
+	return s.key.Sign(rand, digest, opts)
+}
+
+func TestSignValidator_SM2(t *testing.T) {
+	skipFIPS(t)
+	privateKey, err := GenerateSM2Key()
+	if err != nil {
+		t.Fatalf("生成密钥失败: %v", err)
+	}
+
+	signer := NewSignValidator(Config{
+		Algorithm:  SM2,
+		PrivateKey: privateKey,
+	})
+
+	params := map[string]interface{}{
+		"id":     123,
+		"name":   "test",
+		"amount": 100.50,
+	}
+
+	signature, err := signer.GenerateSignature(params)
+	if err != nil {
+		t.Fatalf("生成签名失败: %v", err)
+	}
+
+	// 验证方只持有公钥
+	publicKey, err := ParseSM2PublicKey(hex.EncodeToString(privateKey.PublicKey.Bytes()))
+	if err != nil {
+		t.Fatalf("解析公钥失败: %v", err)
+	}
+
+	verifier := NewSignValidator(Config{
+		Algorithm: SM2,
+		PublicKey: publicKey,
+	})
+
+	params["sign"] = signature
+	valid, err := verifier.ValidateWithSignInParams(params)
+	if err != nil {
+		t.Fatalf("验证签名失败: %v", err)
+	}
+
+	if !valid {
+		t.Errorf("签名验证失败")
+	}
+
+	// 修改参数应导致验证失败
+	params["amount"] = 200.00
+	valid, err = verifier.ValidateWithSignInParams(params)
+	if err != nil {
+		t.Fatalf("验证签名失败: %v", err)
+	}
+
+	if valid {
+		t.Errorf("签名验证应该失败，但通过了")
+	}
+}
+
+func TestSignValidator_SM2_MissingKey(t *testing.T) {
+	validator := NewSignValidator(Config{Algorithm: SM2})
+
+	if _, err := validator.GenerateSignature(map[string]interface{}{"id": 1}); err == nil {
+		t.Errorf("未配置私钥时应该返回错误")
+	}
+
+	if _, err := validator.Validate(map[string]interface{}{"id": 1}, "00"); err == nil {
+		t.Errorf("未配置公钥时应该返回错误")
+	}
+}
+
+func TestParseSM2PrivateKey(t *testing.T) {
+	privateKey, err := ParseSM2PrivateKey("3945208F7B2144B13F36E38AC6D39F95889393692860B51A42FB81EF4DF7C5B8")
+	if err != nil {
+		t.Fatalf("解析私钥失败: %v", err)
+	}
+
+	expected := "0409f9df311e5421a150dd7d161e4bc5c672179fad1833fc076bb08ff356f35020" +
+		"ccea490ce26775a52dc6ea718cc1aa600aed05fbf35e084a6632f6072da9ad13"
+	if result := hex.EncodeToString(privateKey.PublicKey.Bytes()); result != expected {
+		t.Errorf("公钥 = %s, 期望 %s", result, expected)
+	}
+
+	if _, err := ParseSM2PrivateKey("not-hex"); err == nil {
+		t.Errorf("非十六进制私钥应该返回错误")
+	}
+}
+
+func TestSignValidator_RSA_SHA256(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("生成密钥失败: %v", err)
+	}
+
+	pkix, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("编码公钥失败: %v", err)
+	}
+
+	signer, err := New(Config{
+		Algorithm:     RSA_SHA256,
+		PrivateKeyPEM: string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})),
+	})
+	if err != nil {
+		t.Fatalf("创建签名验证器失败: %v", err)
+	}
+
+	verifier, err := New(Config{
+		Algorithm:    RSA_SHA256,
+		PublicKeyPEM: string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pkix})),
+	})
+	if err != nil {
+		t.Fatalf("创建签名验证器失败: %v", err)
+	}
+
+	params := map[string]interface{}{
+		"app_id":    "2021000000000000",
+		"method":    "alipay.trade.pay",
+		"timestamp": "2024-01-01 00:00:00",
+	}
+
+	signature, err := signer.GenerateSignature(params)
+	if err != nil {
+		t.Fatalf("生成签名失败: %v", err)
+	}
+
+	valid, err := verifier.Validate(params, signature)
+	if err != nil {
+		t.Fatalf("验证签名失败: %v", err)
+	}
+
+	if !valid {
+		t.Errorf("签名验证失败")
+	}
+
+	params["method"] = "alipay.trade.refund"
+	valid, err = verifier.Validate(params, signature)
+	if err != nil {
+		t.Fatalf("验证签名失败: %v", err)
+	}
+
+	if valid {
+		t.Errorf("签名验证应该失败，但通过了")
+	}
+}
+
+func TestSignValidator_KeyTypeMismatch(t *testing.T) {
+	privateKey, err := GenerateSM2Key()
+	if err != nil {
+		t.Fatalf("生成密钥失败: %v", err)
+	}
+
+	validator := NewSignValidator(Config{
+		Algorithm:  RSA_SHA256,
+		PrivateKey: privateKey,
+	})
+
+	if _, err := validator.GenerateSignature(map[string]interface{}{"id": 1}); err == nil {
+		t.Errorf("密钥类型与算法不匹配时应该返回错误")
+	}
+}
+
+func TestNew_InvalidPEM(t *testing.T) {
+	if _, err := New(Config{Algorithm: RSA_SHA256, PrivateKeyPEM: "invalid"}); err == nil {
+		t.Errorf("无效私钥应该返回错误")
+	}
+
+	validator := NewSignValidator(Config{Algorithm: RSA_SHA256, PublicKeyPEM: "invalid"})
+	if _, err := validator.Validate(map[string]interface{}{"id": 1}, "00"); err == nil {
+		t.Errorf("无效公钥应该在验证时返回错误")
+	}
+}
+
+func TestSignValidator_RSA_PSS(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("生成密钥失败: %v", err)
+	}
+
+	params := map[string]interface{}{
+		"id":   123,
+		"name": "test",
+	}
+
+	for _, algorithm := range []SignAlgorithm{RSA_PSS_SHA256, RSA_PSS_SHA512} {
+		signer := NewSignValidator(Config{
+			Algorithm:     algorithm,
+			PrivateKey:    key,
+			PSSSaltLength: 20,
+		})
+
+		signature, err := signer.GenerateSignature(params)
+		if err != nil {
+			t.Fatalf("%s: 生成签名失败: %v", algorithm, err)
+		}
+
+		// 相同盐长度可以验证通过
+		valid, err := signer.Validate(params, signature)
+		if err != nil {
+			t.Fatalf("%s: 验证签名失败: %v", algorithm, err)
+		}
+		if !valid {
+			t.Errorf("%s: 签名验证失败", algorithm)
+		}
+
+		// 自动识别盐长度也可以验证通过
+		verifier := NewSignValidator(Config{
+			Algorithm:     algorithm,
+			PublicKey:     &key.PublicKey,
+			PSSSaltLength: PSSSaltLengthAuto,
+		})
+		valid, err = verifier.Validate(params, signature)
+		if err != nil {
+			t.Fatalf("%s: 验证签名失败: %v", algorithm, err)
+		}
+		if !valid {
+			t.Errorf("%s: 自动识别盐长度时签名验证失败", algorithm)
+		}
+
+		// 盐长度不一致时验证失败
+		mismatched := NewSignValidator(Config{
+			Algorithm: algorithm,
+			PublicKey: &key.PublicKey,
+		})
+		valid, err = mismatched.Validate(params, signature)
+		if err != nil {
+			t.Fatalf("%s: 验证签名失败: %v", algorithm, err)
+		}
+		if valid {
+			t.Errorf("%s: 盐长度不一致时验证应该失败，但通过了", algorithm)
+		}
+	}
+}
+
+func TestSignValidator_ECDSA(t *testing.T) {
+	testCases := []struct {
+		algorithm SignAlgorithm
+		curve     elliptic.Curve
+		rawSize   int
+	}{
+		{ECDSA_P256_SHA256, elliptic.P256(), 64},
+		{ECDSA_P384_SHA384, elliptic.P384(), 96},
+	}
+
+	params := map[string]interface{}{
+		"id":   123,
+		"name": "test",
+	}
+
+	for _, tc := range testCases {
+		key, err := ecdsa.GenerateKey(tc.curve, rand.Reader)
+		if err != nil {
+			t.Fatalf("生成密钥失败: %v", err)
+		}
+
+		for _, format := range []ECDSAFormat{ECDSAFormatASN1, ECDSAFormatRaw} {
+			signer := NewSignValidator(Config{
+				Algorithm:   tc.algorithm,
+				PrivateKey:  key,
+				ECDSAFormat: format,
+			})
+
+			signature, err := signer.GenerateSignature(params)
+			if err != nil {
+				t.Fatalf("%s/%s: 生成签名失败: %v", tc.algorithm, format, err)
+			}
+
+			if format == ECDSAFormatRaw && len(signature) != tc.rawSize*2 {
+				t.Errorf("%s/%s: 签名长度 = %d, 期望 %d", tc.algorithm, format, len(signature), tc.rawSize*2)
+			}
+
+			verifier := NewSignValidator(Config{
+				Algorithm:   tc.algorithm,
+				PublicKey:   &key.PublicKey,
+				ECDSAFormat: format,
+			})
+
+			valid, err := verifier.Validate(params, signature)
+			if err != nil {
+				t.Fatalf("%s/%s: 验证签名失败: %v", tc.algorithm, format, err)
+			}
+			if !valid {
+				t.Errorf("%s/%s: 签名验证失败", tc.algorithm, format)
+			}
+		}
+	}
+}
+
+func TestSignValidator_ECDSA_CurveMismatch(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatalf("生成密钥失败: %v", err)
+	}
+
+	validator := NewSignValidator(Config{
+		Algorithm:  ECDSA_P256_SHA256,
+		PrivateKey: key,
+	})
+
+	if _, err := validator.GenerateSignature(map[string]interface{}{"id": 1}); err == nil {
+		t.Errorf("曲线与算法不匹配时应该返回错误")
+	}
+}
+
+// RFC 8032 第 7.1 节测试向量 1（空消息）
+func TestSignValidator_ED25519(t *testing.T) {
+	privateKey, err := ParseEd25519PrivateKey("9d61b19deffd5a60ba844af492ec2cc44449c5697b326919703bac031cae7f60")
+	if err != nil {
+		t.Fatalf("解析私钥失败: %v", err)
+	}
+
+	signer := NewSignValidator(Config{
+		Algorithm:  ED25519,
+		PrivateKey: privateKey,
+	})
+
+	signature, err := signer.GenerateSignature(map[string]interface{}{})
+	if err != nil {
+		t.Fatalf("生成签名失败: %v", err)
+	}
+
+	expected := "e5564300c360ac729086e2cc806e828a84877f1eb8e5d974d873e065224901555fb8821590a33bacc61e39701cf9b46bd25bf5f0595bbe24655141438e7a100b"
+	if signature != expected {
+		t.Errorf("签名 = %s, 期望 %s", signature, expected)
+	}
+
+	publicKey, err := ParseEd25519PublicKey("11qYAYKxCrfVS/7TyWQHOg7hcvPapiMlrwIaaPcHURo=")
+	if err != nil {
+		t.Fatalf("解析公钥失败: %v", err)
+	}
+
+	verifier, err := NewVerifier(ED25519, publicKey)
+	if err != nil {
+		t.Fatalf("创建验证器失败: %v", err)
+	}
+
+	valid, err := verifier.Validate(map[string]interface{}{}, signature)
+	if err != nil {
+		t.Fatalf("验证签名失败: %v", err)
+	}
+	if !valid {
+		t.Errorf("签名验证失败")
+	}
+
+	valid, err = verifier.ValidateWithSignInParams(map[string]interface{}{"id": 1, "sign": signature})
+	if err != nil {
+		t.Fatalf("验证签名失败: %v", err)
+	}
+	if valid {
+		t.Errorf("修改参数后签名验证应该失败，但通过了")
+	}
+}
+
+func TestNewVerifier_Invalid(t *testing.T) {
+	if _, err := NewVerifier(HMAC_SHA256, nil); err == nil {
+		t.Errorf("对称算法应该返回错误")
+	}
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("生成密钥失败: %v", err)
+	}
+	if _, err := NewVerifier(ED25519, &key.PublicKey); err == nil {
+		t.Errorf("公钥类型与算法不匹配时应该返回错误")
+	}
+}
+
+func TestSignValidator_Signer(t *testing.T) {
+	testCases := []struct {
+		algorithm SignAlgorithm
+		newKey    func() (crypto.Signer, error)
+	}{
+		{RSA_SHA256, func() (crypto.Signer, error) { return rsa.GenerateKey(rand.Reader, 2048) }},
+		{ECDSA_P256_SHA256, func() (crypto.Signer, error) { return ecdsa.GenerateKey(elliptic.P256(), rand.Reader) }},
+		{SM2, func() (crypto.Signer, error) { return GenerateSM2Key() }},
+	}
+
+	params := map[string]interface{}{
+		"id":   123,
+		"name": "test",
+	}
+
+	for _, tc := range testCases {
+		if FIPSBuild && !IsFIPSApproved(tc.algorithm) {
+			continue
+		}
+		key, err := tc.newKey()
+		if err != nil {
+			t.Fatalf("生成密钥失败: %v", err)
+		}
+
+		signer := &hsmSigner{key: key}
+		validator := NewSignValidator(Config{
+			Algorithm: tc.algorithm,
+			Signer:    signer,
+		})
+
+		signature, err := validator.GenerateSignature(params)
+		if err != nil {
+			t.Fatalf("%s: 生成签名失败: %v", tc.algorithm, err)
+		}
+		if signer.calls != 1 {
+			t.Errorf("%s: 签名器调用次数 = %d, 期望 1", tc.algorithm, signer.calls)
+		}
+
+		// 未配置公钥时从签名器推导
+		valid, err := validator.Validate(params, signature)
+		if err != nil {
+			t.Fatalf("%s: 验证签名失败: %v", tc.algorithm, err)
+		}
+		if !valid {
+			t.Errorf("%s: 签名验证失败", tc.algorithm)
+		}
+	}
+}
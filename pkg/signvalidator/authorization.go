@@ -0,0 +1,119 @@
+package signvalidator
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ErrInvalidAuthorization Authorization 请求头格式无效
+var ErrInvalidAuthorization = errors.New("Authorization 请求头格式无效")
+
+// AuthorizationScheme Authorization 请求头的签名格式，支持两种形式：
+// 未设置 KeyIDField 与 SignatureField 时为 "<Scheme> <密钥标识><Separator><签名>"，如 "ACS app_id:signature"；
+// 设置后为逗号分隔的字段，如 "HMAC-SHA256 Credential=app_id, Signature=signature"
+type AuthorizationScheme struct {
+	// Scheme 方案名称，如 "ACS"、"OSS"，解析时不区分大小写
+	Scheme string
+	// Header 请求头名称，默认为 "Authorization"
+	Header string
+	// Separator 密钥标识与签名之间的分隔符，默认为 ":"，解析时按第一个分隔符拆分
+	Separator string
+	// KeyIDField 逗号分隔形式中密钥标识的字段名，如 "Credential"
+	KeyIDField string
+	// SignatureField 逗号分隔形式中签名的字段名，如 "Signature"
+	SignatureField string
+}
+
+// Authorization 解析后的 Authorization 请求头
+type Authorization struct {
+	// Scheme 方案名称
+	Scheme string
+	// KeyID 密钥标识
+	KeyID string
+	// Signature 签名
+	Signature string
+}
+
+// checkAuthorization 检查 Authorization 配置
+func (v *SignValidator) checkAuthorization() error {
+	s := v.config.Authorization
+	if s == nil {
+		return nil
+	}
+	switch {
+	case s.Scheme == "" || strings.ContainsAny(s.Scheme, " \t,"):
+		return errors.New("Authorization 方案名称为空或包含空白、逗号")
+	case (s.KeyIDField == "") != (s.SignatureField == ""):
+		return errors.New("Authorization 的 KeyIDField 与 SignatureField 需要同时设置")
+	case s.KeyIDField != "" && s.KeyIDField == s.SignatureField:
+		return errors.New("Authorization 的 KeyIDField 与 SignatureField 不能相同")
+	}
+	return nil
+}
+
+// header 返回请求头名称
+func (s *AuthorizationScheme) header() string {
+	if s.Header == "" {
+		return "Authorization"
+	}
+	return s.Header
+}
+
+// separator 返回密钥标识与签名之间的分隔符
+func (s *AuthorizationScheme) separator() string {
+	if s.Separator == "" {
+		return ":"
+	}
+	return s.Separator
+}
+
+// Format 生成 Authorization 请求头的值
+func (s *AuthorizationScheme) Format(keyID, signature string) string {
+	if s.KeyIDField != "" {
+		return fmt.Sprintf("%s %s=%s, %s=%s", s.Scheme, s.KeyIDField, keyID, s.SignatureField, signature)
+	}
+	return s.Scheme + " " + keyID + s.separator() + signature
+}
+
+// Parse 解析 Authorization 请求头的值，方案名称不符、缺少密钥标识或签名时返回 ErrInvalidAuthorization
+func (s *AuthorizationScheme) Parse(value string) (*Authorization, error) {
+	scheme, credentials, ok := strings.Cut(strings.TrimSpace(value), " ")
+	if !ok || !strings.EqualFold(scheme, s.Scheme) {
+		return nil, fmt.Errorf("%w: 期望方案 %s", ErrInvalidAuthorization, s.Scheme)
+	}
+	credentials = strings.TrimSpace(credentials)
+
+	auth := &Authorization{Scheme: scheme}
+	if s.KeyIDField == "" {
+		auth.KeyID, auth.Signature, ok = strings.Cut(credentials, s.separator())
+	} else {
+		for _, field := range strings.Split(credentials, ",") {
+			name, value, _ := strings.Cut(strings.TrimSpace(field), "=")
+			value = strings.Trim(strings.TrimSpace(value), `"`)
+			switch strings.TrimSpace(name) {
+			case s.KeyIDField:
+				auth.KeyID = value
+			case s.SignatureField:
+				auth.Signature = value
+			}
+		}
+	}
+	if auth.KeyID == "" || auth.Signature == "" {
+		return nil, fmt.Errorf("%w: 缺少密钥标识或签名", ErrInvalidAuthorization)
+	}
+	return auth, nil
+}
+
+// authorization 解析请求的 Authorization 请求头，未配置方案或请求头为空时返回 nil
+func (v *SignValidator) authorization(r *http.Request) (*Authorization, error) {
+	if v.config.Authorization == nil {
+		return nil, nil
+	}
+	value := r.Header.Get(v.config.Authorization.header())
+	if value == "" {
+		return nil, nil
+	}
+	return v.config.Authorization.Parse(value)
+}
@@ -0,0 +1,94 @@
+package signvalidator
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAuthorizationScheme(t *testing.T) {
+	tests := []struct {
+		scheme AuthorizationScheme
+		value  string
+	}{
+		{AuthorizationScheme{Scheme: "ACS"}, "ACS app_1:c2lnbg=="},
+		{AuthorizationScheme{Scheme: "OSS", Separator: "/"}, "OSS app_1/c2lnbg=="},
+		{AuthorizationScheme{Scheme: "HMAC-SHA256", KeyIDField: "Credential", SignatureField: "Signature"}, "HMAC-SHA256 Credential=app_1, Signature=c2lnbg=="},
+	}
+	for _, tt := range tests {
+		if value := tt.scheme.Format("app_1", "c2lnbg=="); value != tt.value {
+			t.Errorf("Format = %q, 期望 %q", value, tt.value)
+		}
+		auth, err := tt.scheme.Parse(tt.value)
+		if err != nil {
+			t.Fatalf("%s: 解析失败: %v", tt.value, err)
+		}
+		if auth.KeyID != "app_1" || auth.Signature != "c2lnbg==" {
+			t.Errorf("%s: 解析结果 = %+v", tt.value, auth)
+		}
+	}
+
+	fields := AuthorizationScheme{Scheme: "TC3", KeyIDField: "Credential", SignatureField: "Signature"}
+	if auth, err := fields.Parse(`tc3  Signature="abc",Credential=k1 , SignedHeaders=host`); err != nil || auth.KeyID != "k1" || auth.Signature != "abc" {
+		t.Errorf("应忽略大小写、顺序、引号与其他字段: %+v, err=%v", auth, err)
+	}
+
+	acs := AuthorizationScheme{Scheme: "ACS"}
+	for _, value := range []string{"", "ACS", "Bearer app_1:sig", "ACS app_1", "ACS :sig", "ACS app_1:"} {
+		if _, err := acs.Parse(value); !errors.Is(err, ErrInvalidAuthorization) {
+			t.Errorf("%q: 应返回 ErrInvalidAuthorization，实际 %v", value, err)
+		}
+	}
+}
+
+func TestAuthorization_Request(t *testing.T) {
+	scheme := &AuthorizationScheme{Scheme: "ACS"}
+	client := NewSignValidator(Config{Secret: "secret-2", Algorithm: HMAC_SHA256, KeyID: "app_2", Authorization: scheme})
+	server := NewSignValidator(Config{
+		Algorithm:     HMAC_SHA256,
+		Keys:          map[string]string{"app_1": "secret-1", "app_2": "secret-2"},
+		KeyID:         "app_1",
+		Authorization: scheme,
+	})
+
+	r := httptest.NewRequest("GET", "/orders?a=1", nil)
+	if err := client.SignHTTPRequest(r); err != nil {
+		t.Fatalf("签名请求失败: %v", err)
+	}
+	if auth, err := scheme.Parse(r.Header.Get("Authorization")); err != nil || auth.KeyID != "app_2" {
+		t.Fatalf("Authorization 请求头 = %q, err=%v", r.Header.Get("Authorization"), err)
+	}
+	if r.URL.Query().Has("sign") || r.URL.Query().Has("kid") {
+		t.Errorf("签名与密钥标识不应写入查询参数: %s", r.URL.RawQuery)
+	}
+
+	if _, valid, err := server.ValidateRequestParams(r, ""); err != nil || !valid {
+		t.Errorf("应按 Authorization 中的密钥标识验证通过: valid=%v, err=%v", valid, err)
+	}
+	if kid := server.KeyID(map[string]interface{}{}, r); kid != "app_2" {
+		t.Errorf("KeyID = %s, 期望 app_2", kid)
+	}
+
+	r.Header.Set("Authorization", "Bearer token")
+	if _, valid, err := server.ValidateRequestParams(r, ""); valid || !errors.Is(err, ErrInvalidAuthorization) {
+		t.Errorf("方案不符时应返回 ErrInvalidAuthorization: valid=%v, err=%v", valid, err)
+	}
+}
+
+func TestAuthorization_Invalid(t *testing.T) {
+	for _, scheme := range []AuthorizationScheme{
+		{},
+		{Scheme: "A B"},
+		{Scheme: "ACS", KeyIDField: "Credential"},
+		{Scheme: "ACS", KeyIDField: "X", SignatureField: "X"},
+	} {
+		if _, err := New(Config{Secret: "s", Authorization: &scheme}); err == nil {
+			t.Errorf("%+v: 应返回错误", scheme)
+		}
+	}
+
+	v := NewSignValidator(Config{Secret: "s", Authorization: &AuthorizationScheme{Scheme: "ACS"}})
+	if err := v.SignHTTPRequest(httptest.NewRequest("GET", "/", nil)); err == nil {
+		t.Error("未配置 KeyID 时生成 Authorization 请求头应返回错误")
+	}
+}
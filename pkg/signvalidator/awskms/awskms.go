@@ -0,0 +1,254 @@
+// Package awskms 使用 AWS KMS 计算签名：对称算法通过 GenerateMac，非对称算法通过 Sign，
+// 密钥始终保存在 KMS 中，进程内只持有密钥 ID。
+//
+// Client 可使用本包的 HTTPClient（基于 SigV4 签名直接调用 KMS API），
+// 也可以将 AWS SDK 的 kms.Client 包装为 Client。
+package awskms
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/huangchunlong818/sign-chao/pkg/signvalidator"
+)
+
+// Client AWS KMS API 的子集
+type Client interface {
+	// GenerateMac 调用 GenerateMac，返回 MAC
+	GenerateMac(ctx context.Context, keyID, macAlgorithm string, message []byte) ([]byte, error)
+	// Sign 以 DIGEST 消息类型调用 Sign，返回签名
+	Sign(ctx context.Context, keyID, signingAlgorithm string, digest []byte) ([]byte, error)
+	// GetPublicKey 调用 GetPublicKey，返回 DER 编码（PKIX）的公钥
+	GetPublicKey(ctx context.Context, keyID string) ([]byte, error)
+}
+
+// defaultTimeout 调用 KMS 的默认超时时间
+const defaultTimeout = 5 * time.Second
+
+// macAlgorithms 签名算法对应的 KMS MAC 算法
+var macAlgorithms = map[signvalidator.SignAlgorithm]string{
+	signvalidator.HMAC_SHA256: "HMAC_SHA_256",
+}
+
+// MACConfig KMS MAC 后端配置
+type MACConfig struct {
+	// KeyID KMS HMAC 密钥的 ID、ARN 或别名
+	KeyID string
+	// Timeout 单次调用超时时间，默认为 5 秒
+	Timeout time.Duration
+	// CacheTTL 本地缓存 MAC 结果的时间，0 表示不缓存；相同消息的重复验证在有效期内不再调用 KMS
+	CacheTTL time.Duration
+	// CacheSize 缓存的最大条目数，默认为 10000
+	CacheSize int
+	// Clock 判断缓存是否过期使用的时钟，默认为 signvalidator.SystemClock
+	Clock signvalidator.Clock
+}
+
+// MACBackend 调用 KMS GenerateMac 的 signvalidator.MACBackend
+type MACBackend struct {
+	client Client
+	config MACConfig
+
+	mu    sync.Mutex
+	cache map[[sha256.Size]byte]cachedMAC
+}
+
+// cachedMAC 缓存的 MAC 结果
+type cachedMAC struct {
+	mac       []byte
+	expiresAt time.Time
+}
+
+// NewMACBackend 创建 KMS MAC 后端
+func NewMACBackend(client Client, config MACConfig) (*MACBackend, error) {
+	if client == nil {
+		return nil, errors.New("KMS 客户端为空")
+	}
+	if config.KeyID == "" {
+		return nil, errors.New("KMS 密钥 ID 为空")
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = defaultTimeout
+	}
+	if config.CacheSize <= 0 {
+		config.CacheSize = 10000
+	}
+	if config.Clock == nil {
+		config.Clock = signvalidator.SystemClock
+	}
+	return &MACBackend{
+		client: client,
+		config: config,
+		cache:  make(map[[sha256.Size]byte]cachedMAC),
+	}, nil
+}
+
+// MAC 使用 KMS 计算 MAC，目前支持 HMAC_SHA256
+func (b *MACBackend) MAC(algorithm signvalidator.SignAlgorithm, data []byte) ([]byte, error) {
+	macAlgorithm, ok := macAlgorithms[algorithm]
+	if !ok {
+		return nil, fmt.Errorf("%w: KMS 不支持 %s", signvalidator.ErrUnsupportedAlgorithm, algorithm)
+	}
+
+	key := cacheKey(macAlgorithm, data)
+	if mac, ok := b.cached(key); ok {
+		return mac, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), b.config.Timeout)
+	defer cancel()
+	mac, err := b.client.GenerateMac(ctx, b.config.KeyID, macAlgorithm, data)
+	if err != nil {
+		return nil, fmt.Errorf("KMS GenerateMac 失败: %w", err)
+	}
+
+	b.store(key, mac)
+	return mac, nil
+}
+
+// cacheKey 以算法和消息的摘要作为缓存键，避免缓存中保存原始消息
+func cacheKey(algorithm string, data []byte) [sha256.Size]byte {
+	h := sha256.New()
+	h.Write([]byte(algorithm))
+	h.Write([]byte{0})
+	h.Write(data)
+	var key [sha256.Size]byte
+	h.Sum(key[:0])
+	return key
+}
+
+// cached 返回未过期的缓存结果
+func (b *MACBackend) cached(key [sha256.Size]byte) ([]byte, bool) {
+	if b.config.CacheTTL <= 0 {
+		return nil, false
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	entry, ok := b.cache[key]
+	if !ok {
+		return nil, false
+	}
+	if b.config.Clock.Now().After(entry.expiresAt) {
+		delete(b.cache, key)
+		return nil, false
+	}
+	return entry.mac, true
+}
+
+// store 缓存 MAC 结果，缓存已满时先清理过期条目，仍然已满则清空缓存
+func (b *MACBackend) store(key [sha256.Size]byte, mac []byte) {
+	if b.config.CacheTTL <= 0 {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.config.Clock.Now()
+	if len(b.cache) >= b.config.CacheSize {
+		for k, entry := range b.cache {
+			if now.After(entry.expiresAt) {
+				delete(b.cache, k)
+			}
+		}
+		if len(b.cache) >= b.config.CacheSize {
+			b.cache = make(map[[sha256.Size]byte]cachedMAC)
+		}
+	}
+	b.cache[key] = cachedMAC{mac: mac, expiresAt: now.Add(b.config.CacheTTL)}
+}
+
+// Signer 使用 KMS 非对称密钥签名的 crypto.Signer，支持 RSA（PKCS#1 v1.5、PSS）与 ECDSA（P-256、P-384）
+type Signer struct {
+	client  Client
+	keyID   string
+	public  crypto.PublicKey
+	timeout time.Duration
+}
+
+// NewSigner 创建 KMS 签名器，创建时通过 GetPublicKey 获取公钥
+func NewSigner(client Client, keyID string) (*Signer, error) {
+	if client == nil {
+		return nil, errors.New("KMS 客户端为空")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), defaultTimeout)
+	defer cancel()
+	der, err := client.GetPublicKey(ctx, keyID)
+	if err != nil {
+		return nil, fmt.Errorf("KMS GetPublicKey 失败: %w", err)
+	}
+	public, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("解析 KMS 公钥失败: %w", err)
+	}
+	switch public.(type) {
+	case *rsa.PublicKey, *ecdsa.PublicKey:
+	default:
+		return nil, fmt.Errorf("不支持的公钥类型: %T", public)
+	}
+
+	return &Signer{client: client, keyID: keyID, public: public, timeout: defaultTimeout}, nil
+}
+
+// Public 返回 KMS 密钥的公钥
+func (s *Signer) Public() crypto.PublicKey {
+	return s.public
+}
+
+// Sign 使用 KMS 对摘要签名，ECDSA 签名为 ASN.1 DER 格式
+func (s *Signer) Sign(_ io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	algorithm, err := s.signingAlgorithm(opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(digest) != opts.HashFunc().Size() {
+		return nil, errors.New("摘要长度与摘要算法不匹配")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+	signature, err := s.client.Sign(ctx, s.keyID, algorithm, digest)
+	if err != nil {
+		return nil, fmt.Errorf("KMS Sign 失败: %w", err)
+	}
+	return signature, nil
+}
+
+// signingAlgorithm 返回签名选项对应的 KMS 签名算法
+func (s *Signer) signingAlgorithm(opts crypto.SignerOpts) (string, error) {
+	hashes := map[crypto.Hash]string{crypto.SHA256: "SHA_256", crypto.SHA384: "SHA_384", crypto.SHA512: "SHA_512"}
+	suffix, ok := hashes[opts.HashFunc()]
+	if !ok {
+		return "", fmt.Errorf("KMS 不支持的摘要算法: %v", opts.HashFunc())
+	}
+
+	switch pub := s.public.(type) {
+	case *rsa.PublicKey:
+		if pss, ok := opts.(*rsa.PSSOptions); ok {
+			// KMS 的 PSS 盐长度固定为摘要长度
+			if pss.SaltLength != rsa.PSSSaltLengthEqualsHash && pss.SaltLength != opts.HashFunc().Size() {
+				return "", errors.New("KMS 的 PSS 盐长度必须与摘要长度相同")
+			}
+			return "RSASSA_PSS_" + suffix, nil
+		}
+		return "RSASSA_PKCS1_V1_5_" + suffix, nil
+	case *ecdsa.PublicKey:
+		curves := map[elliptic.Curve]crypto.Hash{elliptic.P256(): crypto.SHA256, elliptic.P384(): crypto.SHA384}
+		if curves[pub.Curve] != opts.HashFunc() {
+			return "", errors.New("ECDSA 曲线与摘要算法不匹配")
+		}
+		return "ECDSA_" + suffix, nil
+	}
+	return "", fmt.Errorf("不支持的公钥类型: %T", s.public)
+}
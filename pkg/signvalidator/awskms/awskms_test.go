@@ -0,0 +1,212 @@
+package awskms
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/huangchunlong818/sign-chao/pkg/signvalidator"
+)
+
+// fakeKMS 模拟 KMS JSON API，密钥只保存在服务端
+type fakeKMS struct {
+	hmacKey []byte
+	signers map[string]crypto.Signer
+
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+func (k *fakeKMS) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/") {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"__type":"MissingAuthenticationTokenException","message":"missing"}`))
+		return
+	}
+
+	action := strings.TrimPrefix(r.Header.Get("X-Amz-Target"), "TrentService.")
+	k.mu.Lock()
+	k.calls[action]++
+	k.mu.Unlock()
+
+	var input struct {
+		KeyId            string
+		MacAlgorithm     string
+		SigningAlgorithm string
+		Message          []byte
+	}
+	json.NewDecoder(r.Body).Decode(&input)
+
+	var output interface{}
+	switch action {
+	case "GenerateMac":
+		h := hmac.New(sha256.New, k.hmacKey)
+		h.Write(input.Message)
+		output = map[string]interface{}{"Mac": h.Sum(nil)}
+	case "GetPublicKey", "Sign":
+		signer, ok := k.signers[input.KeyId]
+		if !ok {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"__type":"NotFoundException","message":"key not found"}`))
+			return
+		}
+		if action == "GetPublicKey" {
+			der, _ := x509.MarshalPKIXPublicKey(signer.Public())
+			output = map[string]interface{}{"PublicKey": der}
+			break
+		}
+		var opts crypto.SignerOpts = crypto.SHA256
+		switch input.SigningAlgorithm {
+		case "RSASSA_PSS_SHA_256":
+			opts = &rsa.PSSOptions{Hash: crypto.SHA256, SaltLength: rsa.PSSSaltLengthEqualsHash}
+		case "RSASSA_PKCS1_V1_5_SHA_256", "ECDSA_SHA_256":
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		signature, _ := signer.Sign(rand.Reader, input.Message, opts)
+		output = map[string]interface{}{"Signature": signature}
+	}
+	json.NewEncoder(w).Encode(output)
+}
+
+func (k *fakeKMS) count(action string) int {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return k.calls[action]
+}
+
+func newFakeKMS(t *testing.T) (*fakeKMS, *HTTPClient) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("生成密钥失败: %v", err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("生成密钥失败: %v", err)
+	}
+
+	kms := &fakeKMS{
+		hmacKey: []byte("kmsHmacKey"),
+		signers: map[string]crypto.Signer{"rsa-key": rsaKey, "ec-key": ecKey},
+		calls:   make(map[string]int),
+	}
+	server := httptest.NewServer(kms)
+	t.Cleanup(server.Close)
+
+	client, err := NewHTTPClient(HTTPConfig{
+		Region:      "us-east-1",
+		Endpoint:    server.URL,
+		Credentials: Credentials{AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "secret"},
+	})
+	if err != nil {
+		t.Fatalf("创建客户端失败: %v", err)
+	}
+	return kms, client
+}
+
+func TestMACBackend(t *testing.T) {
+	kms, client := newFakeKMS(t)
+
+	clock := signvalidator.NewFakeClock(time.Now())
+	backend, err := NewMACBackend(client, MACConfig{KeyID: "hmac-key", CacheTTL: time.Minute, Clock: clock})
+	if err != nil {
+		t.Fatalf("创建 MAC 后端失败: %v", err)
+	}
+	validator := signvalidator.NewSignValidator(signvalidator.Config{
+		Algorithm:  signvalidator.HMAC_SHA256,
+		MACBackend: backend,
+	})
+
+	params := map[string]interface{}{"id": 123, "name": "test"}
+	signature, err := validator.GenerateSignature(params)
+	if err != nil {
+		t.Fatalf("生成签名失败: %v", err)
+	}
+
+	// 与持有密钥时本地计算的 HMAC 一致
+	h := hmac.New(sha256.New, kms.hmacKey)
+	h.Write([]byte("id=123&name=test"))
+	if expected := hex.EncodeToString(h.Sum(nil)); signature != expected {
+		t.Errorf("签名 = %s, 期望 %s", signature, expected)
+	}
+
+	valid, err := validator.Validate(params, signature)
+	if err != nil {
+		t.Fatalf("验证签名失败: %v", err)
+	}
+	if !valid {
+		t.Errorf("签名验证失败")
+	}
+
+	// 相同消息的验证命中缓存
+	if calls := kms.count("GenerateMac"); calls != 1 {
+		t.Errorf("GenerateMac 调用次数 = %d, 期望 1", calls)
+	}
+
+	// 缓存过期后重新调用
+	clock.Advance(2 * time.Minute)
+	if _, err := validator.Validate(params, signature); err != nil {
+		t.Fatalf("验证签名失败: %v", err)
+	}
+	if calls := kms.count("GenerateMac"); calls != 2 {
+		t.Errorf("GenerateMac 调用次数 = %d, 期望 2", calls)
+	}
+
+	if _, err := backend.MAC(signvalidator.HMAC_MD5, []byte("data")); !errors.Is(err, signvalidator.ErrUnsupportedAlgorithm) {
+		t.Errorf("错误 = %v, 期望 ErrUnsupportedAlgorithm", err)
+	}
+}
+
+func TestSigner(t *testing.T) {
+	_, client := newFakeKMS(t)
+
+	testCases := []struct {
+		keyID     string
+		algorithm signvalidator.SignAlgorithm
+	}{
+		{"rsa-key", signvalidator.RSA_SHA256},
+		{"rsa-key", signvalidator.RSA_PSS_SHA256},
+		{"ec-key", signvalidator.ECDSA_P256_SHA256},
+	}
+
+	params := map[string]interface{}{"id": 123, "name": "test"}
+	for _, tc := range testCases {
+		signer, err := NewSigner(client, tc.keyID)
+		if err != nil {
+			t.Fatalf("创建签名器失败: %v", err)
+		}
+		validator := signvalidator.NewSignValidator(signvalidator.Config{Algorithm: tc.algorithm, Signer: signer})
+
+		signature, err := validator.GenerateSignature(params)
+		if err != nil {
+			t.Fatalf("%s 生成签名失败: %v", tc.algorithm, err)
+		}
+		valid, err := validator.Validate(params, signature)
+		if err != nil {
+			t.Fatalf("%s 验证签名失败: %v", tc.algorithm, err)
+		}
+		if !valid {
+			t.Errorf("%s 签名验证失败", tc.algorithm)
+		}
+	}
+
+	var apiErr *APIError
+	if _, err := NewSigner(client, "missing"); !errors.As(err, &apiErr) || apiErr.Type != "NotFoundException" {
+		t.Errorf("错误 = %v, 期望 NotFoundException", err)
+	}
+}
@@ -0,0 +1,137 @@
+package awskms
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/huangchunlong818/sign-chao/pkg/signvalidator"
+)
+
+// HTTPConfig KMS HTTP 客户端配置
+type HTTPConfig struct {
+	// Region 区域，如 "ap-northeast-1"
+	Region string
+	// Endpoint 自定义地址（如 VPC 终端节点），默认为 https://kms.<Region>.amazonaws.com
+	Endpoint string
+	// Credentials 访问凭证
+	Credentials Credentials
+	// Client HTTP 客户端，默认为 http.DefaultClient
+	Client *http.Client
+	// Clock 生成请求签名时间使用的时钟，默认为 signvalidator.SystemClock
+	Clock signvalidator.Clock
+}
+
+// HTTPClient 使用 SigV4 签名直接调用 KMS JSON API 的 Client，无需依赖 AWS SDK
+type HTTPClient struct {
+	config HTTPConfig
+}
+
+// APIError KMS 返回的错误
+type APIError struct {
+	// StatusCode HTTP 状态码
+	StatusCode int
+	// Type 错误类型，如 "NotFoundException"
+	Type string `json:"__type"`
+	// Message 错误信息
+	Message string `json:"message"`
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("KMS 错误 %s（HTTP %d）: %s", e.Type, e.StatusCode, e.Message)
+}
+
+// NewHTTPClient 创建 KMS HTTP 客户端
+func NewHTTPClient(config HTTPConfig) (*HTTPClient, error) {
+	if config.Region == "" {
+		return nil, errors.New("KMS 区域为空")
+	}
+	if config.Credentials.AccessKeyID == "" || config.Credentials.SecretAccessKey == "" {
+		return nil, errors.New("AWS 访问凭证为空")
+	}
+	if config.Endpoint == "" {
+		config.Endpoint = "https://kms." + config.Region + ".amazonaws.com"
+	}
+	if config.Client == nil {
+		config.Client = http.DefaultClient
+	}
+	if config.Clock == nil {
+		config.Clock = signvalidator.SystemClock
+	}
+	return &HTTPClient{config: config}, nil
+}
+
+// GenerateMac 调用 GenerateMac
+func (c *HTTPClient) GenerateMac(ctx context.Context, keyID, macAlgorithm string, message []byte) ([]byte, error) {
+	var output struct {
+		Mac []byte `json:"Mac"`
+	}
+	input := map[string]interface{}{"KeyId": keyID, "MacAlgorithm": macAlgorithm, "Message": message}
+	if err := c.call(ctx, "GenerateMac", input, &output); err != nil {
+		return nil, err
+	}
+	return output.Mac, nil
+}
+
+// Sign 以 DIGEST 消息类型调用 Sign
+func (c *HTTPClient) Sign(ctx context.Context, keyID, signingAlgorithm string, digest []byte) ([]byte, error) {
+	var output struct {
+		Signature []byte `json:"Signature"`
+	}
+	input := map[string]interface{}{"KeyId": keyID, "SigningAlgorithm": signingAlgorithm, "Message": digest, "MessageType": "DIGEST"}
+	if err := c.call(ctx, "Sign", input, &output); err != nil {
+		return nil, err
+	}
+	return output.Signature, nil
+}
+
+// GetPublicKey 调用 GetPublicKey
+func (c *HTTPClient) GetPublicKey(ctx context.Context, keyID string) ([]byte, error) {
+	var output struct {
+		PublicKey []byte `json:"PublicKey"`
+	}
+	if err := c.call(ctx, "GetPublicKey", map[string]interface{}{"KeyId": keyID}, &output); err != nil {
+		return nil, err
+	}
+	return output.PublicKey, nil
+}
+
+// call 调用 KMS API，[]byte 字段按 API 要求以 Base64 编码
+func (c *HTTPClient) call(ctx context.Context, action string, input, output interface{}) error {
+	body, err := json.Marshal(input)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.config.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "TrentService."+action)
+	signV4(req, body, c.config.Credentials, c.config.Region, "kms", c.config.Clock.Now())
+
+	resp, err := c.config.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		apiErr := &APIError{StatusCode: resp.StatusCode}
+		json.Unmarshal(data, apiErr)
+		return apiErr
+	}
+	if err := json.Unmarshal(data, output); err != nil {
+		return fmt.Errorf("解析 KMS 响应失败: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package awskms
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSignV4(t *testing.T) {
+	// AWS 文档中的 SigV4 签名示例（IAM ListUsers）
+	req, err := http.NewRequest(http.MethodGet, "https://iam.amazonaws.com/?Action=ListUsers&Version=2010-05-08", nil)
+	if err != nil {
+		t.Fatalf("创建请求失败: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+
+	credentials := Credentials{AccessKeyID: "AKIDEXAMPLE", SecretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY"}
+	signV4(req, nil, credentials, "us-east-1", "iam", time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC))
+
+	expected := "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/iam/aws4_request, " +
+		"SignedHeaders=content-type;host;x-amz-date, " +
+		"Signature=5d672d79c15b13162d9279b0855cfba6789a8edb4c82c400e06b5924a6f2b5d7"
+	if authorization := req.Header.Get("Authorization"); authorization != expected {
+		t.Errorf("Authorization = %s, 期望 %s", authorization, expected)
+	}
+}
+
+func TestCanonicalQuery(t *testing.T) {
+	req, _ := http.NewRequest(http.MethodGet, "https://example.com/?b=2&a=hello world&a=x~y", nil)
+	if query := canonicalQuery(req.URL.Query()); query != "a=hello%20world&a=x~y&b=2" {
+		t.Errorf("规范查询字符串 = %s", query)
+	}
+	if !strings.Contains(uriEncode("a/b"), "%2F") {
+		t.Errorf("斜杠应该被编码")
+	}
+}
@@ -0,0 +1,107 @@
+package signvalidator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// errBodyReplay 原始请求体签名不覆盖时间戳、随机数与序号，无法进行防重放检查
+var errBodyReplay = errors.New("原始请求体签名不覆盖时间戳、随机数与序号，不能与 TimestampKey、NonceStore 或 SequenceStore 同时使用，请改用 SignedPaths 或 CanonicalRequest")
+
+// GenerateBodySignature 对原始请求体生成签名，待签名字符串为请求体本身（开启 CanonicalBody 时为 RFC 8785
+// 重新编码后的 JSON），之后按 SecretJoin 追加密钥；用于对字面请求体计算 HMAC 的 REST 接口。
+// 配置了 SignedPaths 时仅提取指定 JSON 路径的值按参数规则签名
+func (v *SignValidator) GenerateBodySignature(body []byte) (string, error) {
+	if v.err != nil {
+		return "", v.err
+	}
+	c, params, err := v.withBody(body)
+	if err != nil {
+		return "", err
+	}
+	return c.GenerateSignature(params)
+}
+
+// GenerateBodySignatureContext 对原始请求体生成签名，ctx 传给 SecretProvider
+func (v *SignValidator) GenerateBodySignatureContext(ctx context.Context, body []byte) (string, error) {
+	return v.withContext(ctx).GenerateBodySignature(body)
+}
+
+// ValidateBody 验证原始请求体的签名是否有效；未配置 SignedPaths 且配置了 TimestampKey、NonceStore 或 SequenceStore 时返回错误，
+// 避免在无法检查重放的情况下静默通过
+func (v *SignValidator) ValidateBody(body []byte, signature string) (bool, error) {
+	if v.err != nil {
+		return false, v.err
+	}
+	if err := v.checkBodyReplay(); err != nil {
+		return false, err
+	}
+	c, params, err := v.withBody(body)
+	if err != nil {
+		return false, err
+	}
+	return c.Validate(params, signature)
+}
+
+// ValidateRequestBody 验证 HTTP 请求体的签名是否有效，查询参数用于算法协商与密钥派生（配置了 SignedPaths 时不使用），
+// 读取后恢复请求体；防重放配置的限制同 ValidateBody
+func (v *SignValidator) ValidateRequestBody(r *http.Request, signature string) (bool, error) {
+	if v.err != nil {
+		return false, v.err
+	}
+	if err := v.checkBodyReplay(); err != nil {
+		return false, err
+	}
+	body, err := readBody(r, v.config.MaxBodyBytes)
+	if err != nil {
+		return false, err
+	}
+	c, params, err := v.withIncoming(r).withBody(body)
+	if err != nil {
+		return false, err
+	}
+	if params == nil {
+		params = queryParams(r.URL.Query())
+	}
+	return c.Validate(params, signature)
+}
+
+// checkBodyReplay 检查原始请求体签名模式下是否配置了无法执行的防重放检查
+func (v *SignValidator) checkBodyReplay() error {
+	if len(v.paths) > 0 {
+		return nil
+	}
+	if v.config.TimestampKey != "" || v.config.NonceStore != nil || v.config.SequenceStore != nil {
+		return errBodyReplay
+	}
+	return nil
+}
+
+// withBody 返回请求体签名使用的验证器副本及参数，请求体签名不检查 RequiredKeys；
+// 配置了 SignedPaths 时返回提取的参数，否则以请求体作为待签名字符串，此时不检查 TimestampKey、NonceStore 与 SequenceStore（验证前由 checkBodyReplay 拒绝这些配置）
+func (v *SignValidator) withBody(body []byte) (*SignValidator, map[string]interface{}, error) {
+	c := *v
+	c.config.RequiredKeys = nil
+
+	if len(v.paths) > 0 {
+		params, err := v.extractPaths(body)
+		if err != nil {
+			return nil, nil, err
+		}
+		return &c, params, nil
+	}
+
+	if v.config.CanonicalBody {
+		var err error
+		if body, err = canonicalizeJSON(body); err != nil {
+			return nil, nil, fmt.Errorf("请求体不是有效的 JSON: %w", err)
+		}
+	}
+	c.config.TimestampKey = ""
+	c.config.NonceStore = nil
+	c.config.SequenceStore = nil
+	c.request = &canonicalRequest{canonical: string(body)}
+	return &c, nil, nil
+}
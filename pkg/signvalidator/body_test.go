@@ -0,0 +1,146 @@
+package signvalidator
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestGenerateBodySignature(t *testing.T) {
+	body := []byte(`{"b":2,"a":"x"}`)
+	v := NewSignValidator(Config{Secret: "secret", Algorithm: HMAC_SHA256, SecretJoin: SecretJoinNone})
+
+	signature, err := v.GenerateBodySignature(body)
+	if err != nil {
+		t.Fatalf("生成签名失败: %v", err)
+	}
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write(body)
+	if want := hex.EncodeToString(mac.Sum(nil)); signature != want {
+		t.Errorf("应对原始请求体计算 HMAC，期望 %s，实际 %s", want, signature)
+	}
+
+	valid, err := v.ValidateBody(body, signature)
+	if err != nil || !valid {
+		t.Errorf("签名验证应通过，valid=%v err=%v", valid, err)
+	}
+	if valid, _ := v.ValidateBody([]byte(`{"a":"x","b":2}`), signature); valid {
+		t.Error("未开启 CanonicalBody 时请求体字节不同不应通过")
+	}
+}
+
+func TestGenerateBodySignature_SecretJoin(t *testing.T) {
+	v := NewSignValidator(Config{Secret: "secret"})
+	signature, err := v.GenerateBodySignature([]byte("payload"))
+	if err != nil {
+		t.Fatalf("生成签名失败: %v", err)
+	}
+	sum := sha256.Sum256([]byte("payload&key=secret"))
+	if want := hex.EncodeToString(sum[:]); signature != want {
+		t.Errorf("应按 SecretJoin 追加密钥，期望 %s，实际 %s", want, signature)
+	}
+}
+
+func TestGenerateBodySignature_CanonicalBody(t *testing.T) {
+	v := NewSignValidator(Config{Secret: "secret", Algorithm: HMAC_SHA256, CanonicalBody: true})
+
+	signature, err := v.GenerateBodySignature([]byte(`{"b": 2.50, "a": "x"}`))
+	if err != nil {
+		t.Fatalf("生成签名失败: %v", err)
+	}
+	valid, err := v.ValidateBody([]byte("{\"a\":\"x\",\n \"b\":2.5}"), signature)
+	if err != nil || !valid {
+		t.Errorf("规范化后相同的 JSON 应通过，valid=%v err=%v", valid, err)
+	}
+
+	for _, body := range []string{"", "{", `{"a":1} {"b":2}`} {
+		if _, err := v.GenerateBodySignature([]byte(body)); err == nil {
+			t.Errorf("无效的 JSON %q 应返回错误", body)
+		}
+	}
+}
+
+func TestValidateRequestBody(t *testing.T) {
+	skipFIPS(t)
+	v := NewSignValidator(Config{
+		Secret:            "secret",
+		Algorithm:         HMAC_SHA256,
+		AlgorithmKey:      "sign_type",
+		AllowedAlgorithms: []SignAlgorithm{HMAC_SHA256, MD5},
+		RequiredKeys:      []string{"timestamp"},
+	})
+	body := `{"order":"1"}`
+	signature, err := NewSignValidator(Config{Secret: "secret", Algorithm: MD5}).GenerateBodySignature([]byte(body))
+	if err != nil {
+		t.Fatalf("生成签名失败: %v", err)
+	}
+
+	r := httptest.NewRequest("POST", "/orders?sign_type=MD5", strings.NewReader(body))
+	valid, err := v.ValidateRequestBody(r, signature)
+	if err != nil || !valid {
+		t.Errorf("应按查询参数协商算法并验证通过，valid=%v err=%v", valid, err)
+	}
+
+	restored, _ := io.ReadAll(r.Body)
+	if string(restored) != body {
+		t.Errorf("请求体应被恢复，实际 %q", restored)
+	}
+}
+
+func TestGenerateBodySignatureContext(t *testing.T) {
+	type tenantKey struct{}
+	v := NewSignValidator(Config{
+		Algorithm: HMAC_SHA256,
+		SecretProvider: func(ctx context.Context, params map[string]interface{}) (string, error) {
+			return "secret-" + ctx.Value(tenantKey{}).(string), nil
+		},
+	})
+	ctx := context.WithValue(context.Background(), tenantKey{}, "a")
+	signature, err := v.GenerateBodySignatureContext(ctx, []byte(`{"ok":true}`))
+	if err != nil {
+		t.Fatalf("生成签名失败: %v", err)
+	}
+
+	expected := NewSignValidator(Config{Secret: "secret-a", Algorithm: HMAC_SHA256})
+	if valid, err := expected.ValidateBody([]byte(`{"ok":true}`), signature); err != nil || !valid {
+		t.Errorf("应使用 SecretProvider 按 ctx 返回的密钥签名: valid=%v, err=%v", valid, err)
+	}
+}
+
+func TestValidateBody_ReplayConfig(t *testing.T) {
+	body := []byte(`{"amount":"100"}`)
+	for name, config := range map[string]Config{
+		"TimestampKey":  {Secret: "secret", TimestampKey: "timestamp"},
+		"NonceStore":    {Secret: "secret", NonceStore: NewMemoryNonceStore(0)},
+		"SequenceStore": {Secret: "secret", SequenceStore: NewMemorySequenceStore()},
+	} {
+		v := NewSignValidator(config)
+		signature, err := v.GenerateBodySignature(body)
+		if err != nil {
+			t.Fatalf("%s: 生成签名失败: %v", name, err)
+		}
+		if valid, err := v.ValidateBody(body, signature); !errors.Is(err, errBodyReplay) || valid {
+			t.Errorf("%s: 原始请求体签名无法检查重放时应返回错误: valid=%v, err=%v", name, valid, err)
+		}
+		r := httptest.NewRequest("POST", "/", strings.NewReader(string(body)))
+		if valid, err := v.ValidateRequestBody(r, signature); !errors.Is(err, errBodyReplay) || valid {
+			t.Errorf("%s: ValidateRequestBody 应返回错误: valid=%v, err=%v", name, valid, err)
+		}
+	}
+
+	v := NewSignValidator(Config{Secret: "secret", TimestampKey: "ts", SignedPaths: []string{"$.amount", "$.ts"}})
+	body = []byte(`{"amount":"100","ts":"` + v.formatTimestamp(v.now()) + `"}`)
+	signature, err := v.GenerateBodySignature(body)
+	if err != nil {
+		t.Fatalf("生成签名失败: %v", err)
+	}
+	if valid, err := v.ValidateBody(body, signature); err != nil || !valid {
+		t.Errorf("配置了 SignedPaths 时应按提取的参数检查时间戳: valid=%v, err=%v", valid, err)
+	}
+}
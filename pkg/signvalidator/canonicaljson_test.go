@@ -0,0 +1,56 @@
+package signvalidator
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCanonicalJSON(t *testing.T) {
+	testCases := []struct {
+		input    interface{}
+		expected string
+	}{
+		// RFC 8785 3.2.2 示例中的数字
+		{[]interface{}{333333333.33333329, 1e30, 4.50, 2e-3, 0.000000000000000000000000001}, `[333333333.3333333,1e+30,4.5,0.002,1e-27]`},
+		{map[string]interface{}{"literals": []interface{}{nil, true, false}, "string": "€$\u000f\nA'B\"\\\\\"/"},
+			`{"literals":[null,true,false],"string":"€$\u000f\nA'B\"\\\\\"/"}`},
+		// HTML 字符不转义
+		{map[string]string{"a": "<b>&</b>"}, `{"a":"<b>&</b>"}`},
+		// 按 UTF-16 码元排序：U+1F600（代理对 D83D）排在 U+FB33 之前
+		{map[string]int{"\ufb33": 1, "\U0001f600": 2, "a": 3}, "{\"a\":3,\"\U0001f600\":2,\"\ufb33\":1}"},
+		{struct {
+			B int     `json:"b"`
+			A float64 `json:"a"`
+		}{B: 1, A: -0.0}, `{"a":0,"b":1}`},
+		{9007199254740993, `9007199254740992`},
+	}
+
+	for _, tc := range testCases {
+		result, err := CanonicalJSON(tc.input)
+		if err != nil {
+			t.Fatalf("序列化失败: %v", err)
+		}
+		if string(result) != tc.expected {
+			t.Errorf("CanonicalJSON(%v) = %s, 期望 %s", tc.input, result, tc.expected)
+		}
+	}
+
+	if _, err := CanonicalJSON(math.Inf(1)); err == nil {
+		t.Errorf("无穷大应该返回错误")
+	}
+}
+
+func TestSignValidator_CanonicalJSON(t *testing.T) {
+	params := map[string]interface{}{"items": map[string]interface{}{"b": "<x>", "a": 1.50}}
+
+	validator := NewSignValidator(Config{Algorithm: SHA256, CanonicalJSON: true})
+	if s, _ := validator.buildStringToSign(params); s != `items={"a":1.5,"b":"<x>"}` {
+		t.Errorf("待签名字符串 = %s", s)
+	}
+
+	// 默认使用 json.Marshal，HTML 字符被转义
+	validator = NewSignValidator(Config{Algorithm: SHA256})
+	if s, _ := validator.buildStringToSign(params); s != `items={"a":1.5,"b":"\u003cx\u003e"}` {
+		t.Errorf("待签名字符串 = %s", s)
+	}
+}
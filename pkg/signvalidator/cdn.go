@@ -0,0 +1,153 @@
+package signvalidator
+
+import (
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CDNAuth 表示 CDN 链接鉴权方式（md5(uri-timestamp-rand-uid-key) 形式）的预设
+type CDNAuth string
+
+const (
+	// CDNAliyunA 阿里云 CDN 鉴权方式 A：?auth_key=timestamp-rand-uid-md5hash
+	CDNAliyunA CDNAuth = "aliyun-a"
+	// CDNTencentA 腾讯云 CDN 鉴权方式 TypeA：?sign=timestamp-rand-uid-md5hash
+	CDNTencentA CDNAuth = "tencent-a"
+	// CDNKS3 金山云 CDN 鉴权方式 A：?auth_key=timestamp-rand-uid-md5hash
+	CDNKS3 CDNAuth = "ks3"
+)
+
+// defaultCDNTTL CDN 鉴权链接的默认有效时长，与各厂商控制台的默认值一致
+const defaultCDNTTL = 30 * time.Minute
+
+// CDNConfig CDN 链接鉴权配置
+type CDNConfig struct {
+	// Auth 鉴权方式预设
+	Auth CDNAuth
+	// Key CDN 控制台配置的鉴权密钥
+	Key string
+	// ParamName 鉴权参数名，默认为预设的参数名（阿里云、金山云为 auth_key，腾讯云为 sign）
+	ParamName string
+	// UID 用户 ID，默认为 "0"
+	UID string
+	// TTL 鉴权链接的有效时长，需与 CDN 控制台的配置一致，默认为 30 分钟
+	TTL time.Duration
+	// Clock 读取当前时间的时钟，默认为 SystemClock
+	Clock Clock
+}
+
+// CDNSigner 生成与验证 CDN 鉴权链接
+type CDNSigner struct {
+	config CDNConfig
+}
+
+// NewCDNSigner 创建 CDN 鉴权签名器，预设不支持或未设置 Key 时返回错误
+func NewCDNSigner(config CDNConfig) (*CDNSigner, error) {
+	switch config.Auth {
+	case CDNAliyunA, CDNKS3:
+		if config.ParamName == "" {
+			config.ParamName = "auth_key"
+		}
+	case CDNTencentA:
+		if config.ParamName == "" {
+			config.ParamName = "sign"
+		}
+	default:
+		return nil, fmt.Errorf("不支持的 CDN 鉴权方式: %s", config.Auth)
+	}
+
+	switch {
+	case config.Key == "":
+		return nil, errors.New("CDN 鉴权密钥不能为空")
+	case strings.Contains(config.UID, "-"):
+		return nil, errors.New("CDN 鉴权用户 ID 不能包含 \"-\"")
+	case config.TTL < 0:
+		return nil, errors.New("CDN 鉴权链接的有效时长不能为负数")
+	}
+	if config.UID == "" {
+		config.UID = "0"
+	}
+	if config.TTL == 0 {
+		config.TTL = defaultCDNTTL
+	}
+	return &CDNSigner{config: config}, nil
+}
+
+// Sign 为 rawURL 添加鉴权参数，timestamp 为当前时间，rand 为 32 位随机十六进制字符串，已有的鉴权参数会被替换
+func (s *CDNSigner) Sign(rawURL string) (string, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", fmt.Errorf("解析链接失败: %w", err)
+	}
+	random := make([]byte, 16)
+	if _, err := rand.Read(random); err != nil {
+		return "", fmt.Errorf("生成随机数失败: %w", err)
+	}
+
+	timestamp := strconv.FormatInt(s.now().Unix(), 10)
+	nonce := hex.EncodeToString(random)
+	hash := s.hash(cdnPath(u), timestamp, nonce, s.config.UID)
+
+	query := u.Query()
+	query.Set(s.config.ParamName, strings.Join([]string{timestamp, nonce, s.config.UID, hash}, "-"))
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+// Verify 验证 rawURL 中的鉴权参数，签名有效但超过 TTL 时返回 ErrURLExpired，
+// 缺少鉴权参数时返回 *MissingKeysError，参数格式错误时返回错误
+func (s *CDNSigner) Verify(rawURL string) (bool, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false, fmt.Errorf("解析链接失败: %w", err)
+	}
+	value := u.Query().Get(s.config.ParamName)
+	if value == "" {
+		return false, &MissingKeysError{Keys: []string{s.config.ParamName}}
+	}
+
+	parts := strings.Split(value, "-")
+	if len(parts) != 4 {
+		return false, fmt.Errorf("CDN 鉴权参数格式错误: %s", value)
+	}
+	timestamp, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return false, fmt.Errorf("CDN 鉴权时间戳格式错误: %s", parts[0])
+	}
+
+	expected := s.hash(cdnPath(u), parts[0], parts[1], parts[2])
+	if !hmac.Equal([]byte(expected), []byte(strings.ToLower(parts[3]))) {
+		return false, nil
+	}
+	if s.now().After(time.Unix(timestamp, 0).Add(s.config.TTL)) {
+		return false, ErrURLExpired
+	}
+	return true, nil
+}
+
+// hash 计算 md5("uri-timestamp-rand-uid-key") 的十六进制小写结果
+func (s *CDNSigner) hash(uri, timestamp, nonce, uid string) string {
+	sum := md5.Sum([]byte(strings.Join([]string{uri, timestamp, nonce, uid, s.config.Key}, "-")))
+	return hex.EncodeToString(sum[:])
+}
+
+// now 返回当前时间
+func (s *CDNSigner) now() time.Time {
+	return clockOrSystem(s.config.Clock).Now()
+}
+
+// cdnPath 返回参与鉴权的 URI（不含查询参数的编码路径）
+func cdnPath(u *url.URL) string {
+	if path := u.EscapedPath(); path != "" {
+		return path
+	}
+	return "/"
+}
@@ -0,0 +1,98 @@
+package signvalidator
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCDNSigner_AliyunVector(t *testing.T) {
+	// 阿里云 CDN 鉴权方式 A 文档示例
+	s, err := NewCDNSigner(CDNConfig{
+		Auth:  CDNAliyunA,
+		Key:   "aliyuncdnexp1234",
+		Clock: NewFakeClock(time.Unix(1444435200, 0)),
+	})
+	if err != nil {
+		t.Fatalf("创建 CDN 签名器失败: %v", err)
+	}
+	rawURL := "http://cdn.example.com/video/standard/1K.html?auth_key=1444435200-0-0-80cd3862d699b7118eed99103f2a3a4f"
+	if valid, err := s.Verify(rawURL); err != nil || !valid {
+		t.Errorf("文档示例验证应通过，valid=%v err=%v", valid, err)
+	}
+}
+
+func TestCDNSigner(t *testing.T) {
+	clock := NewFakeClock(time.Unix(1700000000, 0))
+	for _, tc := range []struct {
+		auth  CDNAuth
+		param string
+	}{
+		{CDNAliyunA, "auth_key"},
+		{CDNTencentA, "sign"},
+		{CDNKS3, "auth_key"},
+	} {
+		s, err := NewCDNSigner(CDNConfig{Auth: tc.auth, Key: "secret", UID: "42", Clock: clock})
+		if err != nil {
+			t.Fatalf("%s: 创建 CDN 签名器失败: %v", tc.auth, err)
+		}
+		signed, err := s.Sign("https://cdn.example.com/files/报告.pdf?v=1")
+		if err != nil {
+			t.Fatalf("%s: 生成鉴权链接失败: %v", tc.auth, err)
+		}
+		u, _ := url.Parse(signed)
+		parts := strings.Split(u.Query().Get(tc.param), "-")
+		if len(parts) != 4 || parts[0] != "1700000000" || parts[2] != "42" {
+			t.Errorf("%s: 鉴权参数 %s 格式不正确: %s", tc.auth, tc.param, signed)
+		}
+		if valid, err := s.Verify(signed); err != nil || !valid {
+			t.Errorf("%s: 鉴权链接验证应通过，valid=%v err=%v", tc.auth, valid, err)
+		}
+
+		other := *u
+		other.Path = "/files/other.pdf"
+		if valid, _ := s.Verify(other.String()); valid {
+			t.Errorf("%s: 路径不同时验证不应通过", tc.auth)
+		}
+	}
+}
+
+func TestCDNSigner_Expired(t *testing.T) {
+	clock := NewFakeClock(time.Unix(1700000000, 0))
+	s, _ := NewCDNSigner(CDNConfig{Auth: CDNTencentA, Key: "secret", TTL: time.Minute, Clock: clock})
+	signed, _ := s.Sign("https://cdn.example.com/a.mp4")
+
+	clock.Advance(time.Minute)
+	if valid, err := s.Verify(signed); err != nil || !valid {
+		t.Errorf("有效期内验证应通过，valid=%v err=%v", valid, err)
+	}
+	clock.Advance(time.Second)
+	if valid, err := s.Verify(signed); !errors.Is(err, ErrURLExpired) || valid {
+		t.Errorf("超过有效期应返回 ErrURLExpired，valid=%v err=%v", valid, err)
+	}
+}
+
+func TestCDNSigner_Invalid(t *testing.T) {
+	for _, config := range []CDNConfig{
+		{Auth: "unknown", Key: "secret"},
+		{Auth: CDNAliyunA},
+		{Auth: CDNAliyunA, Key: "secret", UID: "a-b"},
+		{Auth: CDNAliyunA, Key: "secret", TTL: -time.Second},
+	} {
+		if _, err := NewCDNSigner(config); err == nil {
+			t.Errorf("%+v: 应返回错误", config)
+		}
+	}
+
+	s, _ := NewCDNSigner(CDNConfig{Auth: CDNAliyunA, Key: "secret"})
+	if _, err := s.Verify("https://cdn.example.com/a.mp4"); !errors.Is(err, ErrMissingKeys) {
+		t.Errorf("缺少鉴权参数应返回 ErrMissingKeys，实际 %v", err)
+	}
+	for _, value := range []string{"1-2-3", "x-0-0-abc"} {
+		if _, err := s.Verify("https://cdn.example.com/a.mp4?auth_key=" + value); err == nil {
+			t.Errorf("%s: 格式错误应返回错误", value)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package signvalidator
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestFakeClock(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	clock := NewFakeClock(start)
+	if !clock.Now().Equal(start) {
+		t.Errorf("当前时间 = %v, 期望 %v", clock.Now(), start)
+	}
+	clock.Advance(time.Minute)
+	if !clock.Now().Equal(start.Add(time.Minute)) {
+		t.Errorf("前进后的时间 = %v, 期望 %v", clock.Now(), start.Add(time.Minute))
+	}
+	clock.Set(start)
+	if !clock.Now().Equal(start) {
+		t.Errorf("设置后的时间 = %v, 期望 %v", clock.Now(), start)
+	}
+}
+
+func TestConfigClock(t *testing.T) {
+	clock := NewFakeClock(time.Unix(1700000000, 0))
+	v := NewSignValidator(Config{
+		Secret:       "secret",
+		TimestampKey: "timestamp",
+		Clock:        clock,
+	})
+
+	params, err := v.SignRequest(map[string]interface{}{"a": "1"})
+	if err != nil {
+		t.Fatalf("签名失败: %v", err)
+	}
+	if params["timestamp"] != "1700000000" {
+		t.Errorf("时间戳 = %v, 应使用 Clock", params["timestamp"])
+	}
+
+	clock.Advance(5 * time.Minute)
+	if valid, err := v.ValidateWithSignInParams(params); err != nil || !valid {
+		t.Errorf("边界时间验证应通过，valid=%v err=%v", valid, err)
+	}
+	clock.Advance(time.Second)
+	if _, err := v.ValidateWithSignInParams(params); !errors.Is(err, ErrTimestampExpired) {
+		t.Errorf("超出 MaxSkew 应返回 ErrTimestampExpired，实际 %v", err)
+	}
+}
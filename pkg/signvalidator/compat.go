@@ -0,0 +1,170 @@
+package signvalidator
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Compat 表示兼容其他语言签名实现的预设
+type Compat string
+
+const (
+	// CompatPHP 与 PHP ksort + http_build_query 一致：参数值按 urlencode 编码（空格为 "+"），
+	// 数组展开为 a[b]=1 形式，布尔值为 1/0，null 参数被跳过，浮点数使用最短表示
+	CompatPHP Compat = "php"
+	// CompatJava 与 Java TreeMap + URLEncoder.encode 一致：参数名按 String.compareTo（UTF-16 码元）排序，
+	// 参数值按 URLEncoder 编码（空格为 "+"，"*" 不编码），浮点数按 Double.toString 格式化
+	CompatJava Compat = "java"
+)
+
+// applyCompat 为预设中未单独设置的选项填充预设值
+func (v *SignValidator) applyCompat() error {
+	switch v.config.Compat {
+	case "":
+	case CompatPHP:
+		if v.config.URLEncode == URLEncodeNone {
+			v.config.URLEncode = URLEncodeAll
+		}
+		if v.config.BoolFormat == "" {
+			v.config.BoolFormat = BoolNumeric
+		}
+		if v.config.NilMode == "" {
+			v.config.NilMode = NilSkip
+		}
+		if v.config.FloatFormat == nil {
+			v.config.FloatFormat = &FloatFormat{Shortest: true}
+		}
+	case CompatJava:
+		if v.config.URLEncode == URLEncodeNone {
+			v.config.URLEncode = URLEncodeAll
+		}
+		if v.config.SortFunc == nil {
+			v.config.SortFunc = lessUTF16
+		}
+	default:
+		return fmt.Errorf("不支持的兼容预设: %s", v.config.Compat)
+	}
+	return nil
+}
+
+// escape 按兼容预设编码参数名或参数值，默认按 RFC 3986 编码
+func (v *SignValidator) escape(s string) string {
+	switch v.config.Compat {
+	case CompatPHP:
+		return formEncode(s, "-_.")
+	case CompatJava:
+		return formEncode(s, "-_.*")
+	default:
+		return percentEncode(s)
+	}
+}
+
+// formEncode 按 application/x-www-form-urlencoded 编码字符串：空格编码为 "+"，
+// 字母、数字与 safe 中的字符保留，其余字节编码为 %XX
+func formEncode(s string, safe string) string {
+	const hexDigits = "0123456789ABCDEF"
+	var builder strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || c < 0x80 && strings.IndexByte(safe, c) >= 0:
+			builder.WriteByte(c)
+		case c == ' ':
+			builder.WriteByte('+')
+		default:
+			builder.WriteByte('%')
+			builder.WriteByte(hexDigits[c>>4])
+			builder.WriteByte(hexDigits[c&0x0f])
+		}
+	}
+	return builder.String()
+}
+
+// isPHPArray 判断参数值是否按 PHP 数组展开（map、slice、array，不含 []byte）
+func isPHPArray(value interface{}) bool {
+	if _, ok := value.([]byte); ok {
+		return false
+	}
+	switch reflect.ValueOf(value).Kind() {
+	case reflect.Map, reflect.Slice, reflect.Array:
+		return true
+	}
+	return false
+}
+
+// flattenPHP 将数组参数展开为 prefix[key]=value 形式，map 的键按字节序排序，列表按下标顺序
+func (v *SignValidator) flattenPHP(pairs []queryPair, prefix string, value interface{}) ([]queryPair, error) {
+	if value == nil {
+		if v.config.NilMode == NilSkip {
+			return pairs, nil
+		}
+	} else if isPHPArray(value) {
+		rv := reflect.ValueOf(value)
+		if rv.Kind() == reflect.Map {
+			keys := make([]string, 0, rv.Len())
+			items := make(map[string]interface{}, rv.Len())
+			for _, key := range rv.MapKeys() {
+				name := fmt.Sprint(key.Interface())
+				keys = append(keys, name)
+				items[name] = rv.MapIndex(key).Interface()
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				var err error
+				if pairs, err = v.flattenPHP(pairs, prefix+"["+key+"]", items[key]); err != nil {
+					return nil, err
+				}
+			}
+			return pairs, nil
+		}
+		for i := 0; i < rv.Len(); i++ {
+			var err error
+			if pairs, err = v.flattenPHP(pairs, prefix+"["+strconv.Itoa(i)+"]", rv.Index(i).Interface()); err != nil {
+				return nil, err
+			}
+		}
+		return pairs, nil
+	}
+
+	s, err := v.formatValue(prefix, value)
+	if err != nil {
+		return nil, err
+	}
+	if s == "" && v.config.SkipEmptyValues {
+		return pairs, nil
+	}
+	return append(pairs, queryPair{key: prefix, value: s}), nil
+}
+
+// formatJavaDouble 按 Java Double.toString / Float.toString 规则格式化浮点数
+func formatJavaDouble(f float64, bitSize int) string {
+	switch {
+	case math.IsNaN(f):
+		return "NaN"
+	case math.IsInf(f, 1):
+		return "Infinity"
+	case math.IsInf(f, -1):
+		return "-Infinity"
+	}
+
+	if abs := math.Abs(f); abs == 0 || abs >= 1e-3 && abs < 1e7 {
+		s := strconv.FormatFloat(f, 'f', -1, bitSize)
+		if !strings.Contains(s, ".") {
+			s += ".0"
+		}
+		return s
+	}
+
+	// 科学计数法：1.0E10、1.5E-5
+	s := strconv.FormatFloat(f, 'e', -1, bitSize)
+	mantissa, exponent, _ := strings.Cut(s, "e")
+	if !strings.Contains(mantissa, ".") {
+		mantissa += ".0"
+	}
+	exp, _ := strconv.Atoi(exponent)
+	return mantissa + "E" + strconv.Itoa(exp)
+}
@@ -0,0 +1,87 @@
+package signvalidator
+
+import "testing"
+
+func TestSignValidator_CompatPHP(t *testing.T) {
+	skipFIPS(t)
+	// 对应 PHP: ksort($p); http_build_query($p) . '&key=testSecret'
+	params := map[string]interface{}{
+		"x": "a b~*",
+		"a": map[string]interface{}{"c": []interface{}{1, 2}, "b": 1},
+		"n": nil,
+		"t": true,
+		"f": false,
+		"e": "",
+		"p": 1.5,
+		"z": []interface{}{},
+	}
+
+	validator, err := New(Config{Secret: "testSecret", Algorithm: MD5, Compat: CompatPHP})
+	if err != nil {
+		t.Fatalf("创建签名验证器失败: %v", err)
+	}
+	expected := "a%5Bb%5D=1&a%5Bc%5D%5B0%5D=1&a%5Bc%5D%5B1%5D=2&e=&f=0&p=1.5&t=1&x=a+b%7E%2A&key=testSecret"
+	if s, _ := validator.buildStringToSign(params); s != expected {
+		t.Errorf("待签名字符串 = %s, 期望 %s", s, expected)
+	}
+
+	// 单独设置的选项优先于预设值
+	validator, err = New(Config{Algorithm: MD5, Compat: CompatPHP, BoolFormat: BoolLower, URLEncode: URLEncodeValues})
+	if err != nil {
+		t.Fatalf("创建签名验证器失败: %v", err)
+	}
+	if s, _ := validator.buildStringToSign(map[string]interface{}{"t": true, "a": []string{"x y"}}); s != "a[0]=x+y&t=true" {
+		t.Errorf("待签名字符串 = %s", s)
+	}
+
+	if _, err := New(Config{Compat: "ruby"}); err == nil {
+		t.Errorf("不支持的兼容预设应该返回错误")
+	}
+}
+
+func TestSignValidator_CompatJava(t *testing.T) {
+	skipFIPS(t)
+	// 对应 Java: new TreeMap<>(params)，逐项 URLEncoder.encode(value, "UTF-8")
+	params := map[string]interface{}{
+		"q":          "a b*~'中",
+		"amount":     100.0,
+		"rate":       1e-5,
+		"ok":         true,
+		"\ufb33":     "x",
+		"\U0001f600": "y",
+	}
+
+	validator, err := New(Config{Secret: "testSecret", Algorithm: MD5, Compat: CompatJava})
+	if err != nil {
+		t.Fatalf("创建签名验证器失败: %v", err)
+	}
+	expected := "amount=100.0&ok=true&q=a+b*%7E%27%E4%B8%AD&rate=1.0E-5&%F0%9F%98%80=y&%EF%AC%B3=x&key=testSecret"
+	if s, _ := validator.buildStringToSign(params); s != expected {
+		t.Errorf("待签名字符串 = %s, 期望 %s", s, expected)
+	}
+}
+
+func TestFormatJavaDouble(t *testing.T) {
+	testCases := []struct {
+		input    float64
+		expected string
+	}{
+		{0, "0.0"},
+		{1, "1.0"},
+		{99.99, "99.99"},
+		{0.001, "0.001"},
+		{0.0001, "1.0E-4"},
+		{1234567.5, "1234567.5"},
+		{1e7, "1.0E7"},
+		{-1.5e10, "-1.5E10"},
+	}
+
+	for _, tc := range testCases {
+		if result := formatJavaDouble(tc.input, 64); result != tc.expected {
+			t.Errorf("formatJavaDouble(%v) = %s, 期望 %s", tc.input, result, tc.expected)
+		}
+	}
+	if result := formatJavaDouble(float64(float32(0.1)), 32); result != "0.1" {
+		t.Errorf("formatJavaDouble(float32(0.1)) = %s, 期望 0.1", result)
+	}
+}
@@ -0,0 +1,216 @@
+package signvalidator
+
+import (
+	"crypto/hkdf"
+	"crypto/pbkdf2"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+
+	"github.com/huangchunlong818/sign-chao/pkg/signvalidator/internal/scrypt"
+)
+
+var (
+	errHKDFSecret    = errors.New("HKDF 派生密钥需要配置主密钥")
+	errStretchSecret = errors.New("口令拉伸需要配置 Secret")
+)
+
+// StretchMethod 口令拉伸算法
+type StretchMethod string
+
+const (
+	// StretchPBKDF2 PBKDF2-HMAC，与 PHP hash_pbkdf2 兼容
+	StretchPBKDF2 StretchMethod = "pbkdf2"
+	// StretchScrypt scrypt
+	StretchScrypt StretchMethod = "scrypt"
+)
+
+// SecretStretch 将低熵口令 Secret 拉伸为签名密钥的配置。
+// 拉伸结果以小写十六进制字符串代替 Secret 参与签名（与 PHP hash_pbkdf2 默认输出一致），创建验证器时计算一次
+type SecretStretch struct {
+	// Method 拉伸算法，默认为 StretchPBKDF2
+	Method StretchMethod
+	// Hash PBKDF2 摘要算法，默认为 SHA256
+	Hash SignAlgorithm
+	// Salt 盐值
+	Salt string
+	// Iterations PBKDF2 迭代次数，默认为 600000
+	Iterations int
+	// N scrypt CPU/内存开销参数，必须为 2 的幂，默认为 32768
+	N int
+	// R scrypt 块大小参数，默认为 8
+	R int
+	// P scrypt 并行度参数，默认为 1
+	P int
+	// KeyLength 密钥长度（字节），默认为 32
+	KeyLength int
+}
+
+// HKDF 使用 HKDF 从主密钥派生各上下文独立的签名密钥。
+// 派生结果以小写十六进制字符串代替 Secret 参与签名，即 Secret = hex(HKDF(Hash, Secret, Salt, Info+上下文, KeyLength))
+type HKDF struct {
+	// Hash 摘要算法，默认为 SHA256
+	Hash SignAlgorithm
+	// Salt 盐值，可为空
+	Salt string
+	// Info 上下文信息（如接口版本 "api-v2"）
+	Info string
+	// InfoKey 上下文参数名（如 "app_id"），设置后该参数的值追加到 Info 之后，参数缺失时返回错误
+	InfoKey string
+	// KeyLength 派生密钥长度（字节），默认为 32
+	KeyLength int
+}
+
+// hashFunc 返回派生密钥使用的摘要算法，仅支持不带密钥的密码学哈希算法
+func (v *SignValidator) hashFunc(algorithm SignAlgorithm) (func() hash.Hash, error) {
+	if _, err := newDigest(algorithm); err != nil {
+		return nil, err
+	}
+	if AlgorithmStrength(algorithm) == StrengthInsecure {
+		return nil, fmt.Errorf("%w: %s", ErrInsecureAlgorithm, algorithm)
+	}
+	if err := v.checkAlgorithm(algorithm); err != nil {
+		return nil, err
+	}
+	return func() hash.Hash {
+		h, _ := newDigest(algorithm)
+		return h
+	}, nil
+}
+
+// stretchSecret 按 Stretch 配置拉伸 Secret、Secrets、Keys 与 PreviousSecrets
+func (v *SignValidator) stretchSecret() error {
+	if v.config.Stretch == nil {
+		return nil
+	}
+	if v.config.Secret == "" && len(v.config.Secrets) == 0 {
+		return errStretchSecret
+	}
+
+	var err error
+	if v.config.Secret != "" {
+		if v.config.Secret, err = v.stretch(v.config.Secret); err != nil {
+			return err
+		}
+	}
+	for i := range v.config.Secrets {
+		if v.config.Secrets[i].Value, err = v.stretch(v.config.Secrets[i].Value); err != nil {
+			return err
+		}
+	}
+	for kid, secret := range v.config.Keys {
+		if v.config.Keys[kid], err = v.stretch(secret); err != nil {
+			return err
+		}
+	}
+	for i := range v.config.PreviousSecrets {
+		if v.config.PreviousSecrets[i].Secret, err = v.stretch(v.config.PreviousSecrets[i].Secret); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// stretch 按 Stretch 配置拉伸 secret，返回十六进制编码的签名密钥
+func (v *SignValidator) stretch(secret string) (string, error) {
+	config := v.config.Stretch
+
+	keyLength := config.KeyLength
+	if keyLength <= 0 {
+		keyLength = 32
+	}
+
+	var key []byte
+	var err error
+	switch config.Method {
+	case StretchPBKDF2, "":
+		algorithm := config.Hash
+		if algorithm == "" {
+			algorithm = SHA256
+		}
+		iterations := config.Iterations
+		if iterations <= 0 {
+			iterations = 600000
+		}
+		var h func() hash.Hash
+		if h, err = v.hashFunc(algorithm); err != nil {
+			return "", fmt.Errorf("PBKDF2 摘要算法: %w", err)
+		}
+		key, err = pbkdf2.Key(h, secret, []byte(config.Salt), iterations, keyLength)
+	case StretchScrypt:
+		if v.fips() {
+			return "", fmt.Errorf("%w: scrypt", ErrFIPSNotApproved)
+		}
+		n, r, p := config.N, config.R, config.P
+		if n == 0 {
+			n = 32768
+		}
+		if r == 0 {
+			r = 8
+		}
+		if p == 0 {
+			p = 1
+		}
+		key, err = scrypt.Key([]byte(secret), []byte(config.Salt), n, r, p, keyLength)
+	default:
+		return "", fmt.Errorf("不支持的口令拉伸算法: %s", config.Method)
+	}
+	if err != nil {
+		return "", fmt.Errorf("口令拉伸失败: %w", err)
+	}
+	return hex.EncodeToString(key), nil
+}
+
+// checkHKDF 检查 HKDF 配置并填充默认值
+func (v *SignValidator) checkHKDF() error {
+	if v.config.HKDF == nil {
+		return nil
+	}
+	if v.config.Secret == "" && v.config.SecretProvider == nil {
+		return errHKDFSecret
+	}
+
+	config := *v.config.HKDF
+	if config.Hash == "" {
+		config.Hash = SHA256
+	}
+	if config.KeyLength <= 0 {
+		config.KeyLength = 32
+	}
+	if _, err := v.hashFunc(config.Hash); err != nil {
+		return fmt.Errorf("HKDF 摘要算法: %w", err)
+	}
+	v.config.HKDF = &config
+	return nil
+}
+
+// withContextSecret 返回使用本次请求上下文派生密钥的验证器副本，未配置 HKDF 时返回自身
+func (v *SignValidator) withContextSecret(params map[string]interface{}) (*SignValidator, error) {
+	config := v.config.HKDF
+	if config == nil {
+		return v, nil
+	}
+
+	info := config.Info
+	if config.InfoKey != "" {
+		value, exists := v.lookup(params, config.InfoKey)
+		if !exists {
+			return nil, fmt.Errorf("HKDF 派生密钥需要 %s 参数", config.InfoKey)
+		}
+		info += convertToString(value)
+	}
+
+	h, err := v.hashFunc(config.Hash)
+	if err != nil {
+		return nil, err
+	}
+	key, err := hkdf.Key(h, []byte(v.config.Secret), []byte(config.Salt), info, config.KeyLength)
+	if err != nil {
+		return nil, fmt.Errorf("HKDF 派生密钥失败: %w", err)
+	}
+
+	c := *v
+	c.config.Secret = hex.EncodeToString(key)
+	return &c, nil
+}
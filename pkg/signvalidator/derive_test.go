@@ -0,0 +1,160 @@
+package signvalidator
+
+import "testing"
+
+func TestHKDF_ContextSecret_CaseInsensitive(t *testing.T) {
+	validator := NewSignValidator(Config{
+		Secret:              "masterSecret",
+		Algorithm:           HMAC_SHA256,
+		HKDF:                &HKDF{Salt: "sign-chao", Info: "api-v2:", InfoKey: "app_id"},
+		CaseInsensitiveKeys: true,
+	})
+
+	params := map[string]interface{}{"APP_ID": "app001", "id": 123}
+	signature, err := validator.GenerateSignature(params)
+	if err != nil {
+		t.Fatalf("大小写不同的 InfoKey 参数应参与密钥派生: %v", err)
+	}
+
+	// 与精确匹配 app_id 时派生的密钥相同
+	derived := NewSignValidator(Config{
+		Secret:    "6f408faa92c6f78d19b66617585bf9edb7c23ec90382a8dc6ec09e71acd7fbe6",
+		Algorithm: HMAC_SHA256,
+	})
+	if expected, _ := derived.GenerateSignature(params); signature != expected {
+		t.Errorf("派生密钥签名 = %s, 期望 %s", signature, expected)
+	}
+	if valid, err := validator.Validate(params, signature); err != nil || !valid {
+		t.Errorf("签名验证失败: valid=%v, err=%v", valid, err)
+	}
+}
+
+func TestHKDF_ContextSecret(t *testing.T) {
+	validator := NewSignValidator(Config{
+		Secret:    "masterSecret",
+		Algorithm: HMAC_SHA256,
+		HKDF: &HKDF{
+			Salt:    "sign-chao",
+			Info:    "api-v2:",
+			InfoKey: "app_id",
+		},
+	})
+
+	params := map[string]interface{}{
+		"app_id": "app001",
+		"id":     123,
+	}
+
+	signature, err := validator.GenerateSignature(params)
+	if err != nil {
+		t.Fatalf("生成签名失败: %v", err)
+	}
+
+	// 对端直接使用派生密钥作为 Secret 时签名一致
+	derived := NewSignValidator(Config{
+		Secret:    "6f408faa92c6f78d19b66617585bf9edb7c23ec90382a8dc6ec09e71acd7fbe6",
+		Algorithm: HMAC_SHA256,
+	})
+	expected, err := derived.GenerateSignature(params)
+	if err != nil {
+		t.Fatalf("生成签名失败: %v", err)
+	}
+	if signature != expected {
+		t.Errorf("派生密钥签名 = %s, 期望 %s", signature, expected)
+	}
+
+	valid, err := validator.Validate(params, signature)
+	if err != nil {
+		t.Fatalf("验证签名失败: %v", err)
+	}
+	if !valid {
+		t.Errorf("派生密钥签名验证失败")
+	}
+
+	// 不同上下文的签名互不通用
+	other := map[string]interface{}{
+		"app_id": "app002",
+		"id":     123,
+	}
+	otherSignature, err := validator.GenerateSignature(other)
+	if err != nil {
+		t.Fatalf("生成签名失败: %v", err)
+	}
+	if otherSignature == signature {
+		t.Errorf("不同上下文的签名不应相同")
+	}
+
+	// 缺少上下文参数
+	if _, err := validator.GenerateSignature(map[string]interface{}{"id": 123}); err == nil {
+		t.Errorf("缺少上下文参数应该返回错误")
+	}
+}
+
+func TestHKDF_InvalidConfig(t *testing.T) {
+	skipFIPS(t)
+	if _, err := New(Config{Algorithm: HMAC_SHA256, HKDF: &HKDF{Info: "api-v2"}}); err == nil {
+		t.Errorf("未配置主密钥应该返回错误")
+	}
+	if _, err := New(Config{Secret: "masterSecret", Algorithm: HMAC_SHA256, HKDF: &HKDF{Hash: HMAC_SHA256}}); err == nil {
+		t.Errorf("HKDF 摘要算法不支持 HMAC")
+	}
+	if _, err := New(Config{Secret: "masterSecret", Algorithm: HMAC_SHA256, HKDF: &HKDF{Hash: SM3, Info: "api-v2"}}); err != nil {
+		t.Errorf("使用 SM3 派生密钥不应返回错误: %v", err)
+	}
+}
+
+func TestStretch_Secret(t *testing.T) {
+	skipFIPS(t)
+	params := map[string]interface{}{
+		"id":   123,
+		"name": "test",
+	}
+
+	testCases := []struct {
+		name    string
+		stretch *SecretStretch
+		secret  string
+	}{
+		// PHP: hash_pbkdf2("sha256", "passphrase", "salt", 1000)
+		{"pbkdf2", &SecretStretch{Salt: "salt", Iterations: 1000}, "250a335432ada0626d4eadebe23ee97a732fca73f81c60578c59ed69c32b85f3"},
+		// PHP: hash_pbkdf2("sha1", "passphrase", "salt", 1000)
+		{"pbkdf2-sha1", &SecretStretch{Hash: SHA1, Salt: "salt", Iterations: 1000, KeyLength: 20}, "32b4087e7ab9263e9604948af8a630deba72978a"},
+		{"scrypt", &SecretStretch{Method: StretchScrypt, Salt: "salt", N: 1024}, "12b89583aaa583a69c7137cfae83af82c201675df13172714ebef5f73029d39c"},
+	}
+
+	for _, tc := range testCases {
+		validator, err := New(Config{
+			Secret:    "passphrase",
+			Algorithm: HMAC_SHA256,
+			Stretch:   tc.stretch,
+		})
+		if err != nil {
+			t.Fatalf("%s 创建验证器失败: %v", tc.name, err)
+		}
+
+		signature, err := validator.GenerateSignature(params)
+		if err != nil {
+			t.Fatalf("生成签名失败: %v", err)
+		}
+
+		expected, err := NewSignValidator(Config{Secret: tc.secret, Algorithm: HMAC_SHA256}).GenerateSignature(params)
+		if err != nil {
+			t.Fatalf("生成签名失败: %v", err)
+		}
+		if signature != expected {
+			t.Errorf("%s 签名 = %s, 期望 %s", tc.name, signature, expected)
+		}
+	}
+}
+
+func TestStretch_InvalidConfig(t *testing.T) {
+	if _, err := New(Config{Algorithm: HMAC_SHA256, Stretch: &SecretStretch{Iterations: 1000}}); err == nil {
+		t.Errorf("未配置口令应该返回错误")
+	}
+	if _, err := New(Config{Secret: "passphrase", Stretch: &SecretStretch{Method: StretchScrypt, N: 1000}}); err == nil {
+		t.Errorf("scrypt N 不是 2 的幂应该返回错误")
+	}
+	if _, err := New(Config{Secret: "passphrase", Stretch: &SecretStretch{Method: "bcrypt"}}); err == nil {
+		t.Errorf("不支持的拉伸算法应该返回错误")
+	}
+}
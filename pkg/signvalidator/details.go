@@ -0,0 +1,51 @@
+package signvalidator
+
+// SignatureDetails 签名过程的详细信息，用于排查与合作方签名不一致的问题
+type SignatureDetails struct {
+	// Signature 生成的签名
+	Signature string
+	// Algorithm 实际使用的签名算法（AlgorithmKey 协商后的结果）
+	Algorithm SignAlgorithm
+	// StringToSign 待签名字符串，包含追加的密钥，请勿直接记录到日志
+	StringToSign string
+	// Keys 参与拼接的参数名，按拼接顺序排列
+	Keys []string
+	// Pairs 按拼接顺序排列的参数名与转换后的参数值（URL 编码前），多值参数按 MultiValue 展开
+	Pairs []SignaturePair
+}
+
+// SignaturePair 参与拼接的一个参数
+type SignaturePair struct {
+	Key   string
+	Value string
+}
+
+// GenerateSignatureWithDetails 生成签名，并返回待签名字符串、排序后的参数名及转换后的参数值，
+// 无需在包内添加打印即可与合作方逐项对比。使用 Template、HTTP 请求或原始请求体签名时
+// Keys 与 Pairs 仅包含 {params} 中拼接的参数或为空
+func (v *SignValidator) GenerateSignatureWithDetails(params map[string]interface{}) (*SignatureDetails, error) {
+	if v.err != nil {
+		return nil, v.err
+	}
+
+	details := &SignatureDetails{}
+	c := *v
+	c.trace = details
+	signature, err := c.GenerateSignature(params)
+	if err != nil {
+		return nil, err
+	}
+	details.Signature = signature
+	return details, nil
+}
+
+// record 记录参与拼接的参数
+func (d *SignatureDetails) record(keys []string, values map[string][]queryPair) {
+	d.Keys = append([]string(nil), keys...)
+	d.Pairs = d.Pairs[:0]
+	for _, k := range keys {
+		for _, pair := range values[k] {
+			d.Pairs = append(d.Pairs, SignaturePair{Key: pair.key, Value: pair.value})
+		}
+	}
+}
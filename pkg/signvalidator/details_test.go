@@ -0,0 +1,66 @@
+package signvalidator
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGenerateSignatureWithDetails(t *testing.T) {
+	v := NewSignValidator(Config{
+		Secret:     "secret",
+		Algorithm:  HMAC_SHA256,
+		URLEncode:  URLEncodeValues,
+		MultiValue: MultiValueRepeat,
+		IgnoreKeys: []string{"debug"},
+	})
+	params := map[string]interface{}{
+		"b":     2.5,
+		"a":     "x y",
+		"tags":  []string{"t1", "t2"},
+		"debug": true,
+		"sign":  "old",
+	}
+
+	details, err := v.GenerateSignatureWithDetails(params)
+	if err != nil {
+		t.Fatalf("生成签名失败: %v", err)
+	}
+
+	signature, _ := v.GenerateSignature(params)
+	if details.Signature != signature {
+		t.Errorf("签名应与 GenerateSignature 一致，期望 %s，实际 %s", signature, details.Signature)
+	}
+	if details.Algorithm != HMAC_SHA256 {
+		t.Errorf("算法 = %s, 期望 %s", details.Algorithm, HMAC_SHA256)
+	}
+	if want := "a=x%20y&b=2.500000&tags=t1&tags=t2&key=secret"; details.StringToSign != want {
+		t.Errorf("待签名字符串 = %s, 期望 %s", details.StringToSign, want)
+	}
+	if want := []string{"a", "b", "tags"}; !reflect.DeepEqual(details.Keys, want) {
+		t.Errorf("参数名 = %v, 期望 %v", details.Keys, want)
+	}
+	wantPairs := []SignaturePair{{"a", "x y"}, {"b", "2.500000"}, {"tags", "t1"}, {"tags", "t2"}}
+	if !reflect.DeepEqual(details.Pairs, wantPairs) {
+		t.Errorf("参数 = %v, 期望 %v", details.Pairs, wantPairs)
+	}
+}
+
+func TestGenerateSignatureWithDetails_Negotiated(t *testing.T) {
+	skipFIPS(t)
+	v := NewSignValidator(Config{
+		Secret:            "secret",
+		AlgorithmKey:      "sign_type",
+		AllowedAlgorithms: []SignAlgorithm{SHA256, MD5},
+	})
+	details, err := v.GenerateSignatureWithDetails(map[string]interface{}{"sign_type": "MD5"})
+	if err != nil {
+		t.Fatalf("生成签名失败: %v", err)
+	}
+	if details.Algorithm != MD5 {
+		t.Errorf("应返回协商后的算法，实际 %s", details.Algorithm)
+	}
+
+	if _, err := NewSignValidator(Config{Secret: "secret", RequiredKeys: []string{"a"}}).GenerateSignatureWithDetails(nil); err == nil {
+		t.Error("生成签名失败时应返回错误")
+	}
+}
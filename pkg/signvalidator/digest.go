@@ -0,0 +1,157 @@
+package signvalidator
+
+import (
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hash"
+	"net/http"
+	"strings"
+)
+
+// DigestAlgorithm 请求体摘要请求头的摘要算法（RFC 9530 的算法名称）
+type DigestAlgorithm string
+
+const (
+	// DigestSHA256 SHA-256 摘要
+	DigestSHA256 DigestAlgorithm = "sha-256"
+	// DigestSHA512 SHA-512 摘要
+	DigestSHA512 DigestAlgorithm = "sha-512"
+)
+
+// ErrDigestMismatch 请求体与摘要请求头不一致
+var ErrDigestMismatch = errors.New("请求体摘要不匹配")
+
+// ContentDigest 请求体摘要请求头配置，默认为 RFC 9530 的 Content-Digest：
+// 生成形如 "sha-256=:<Base64>:" 的值；Header 为 "Digest" 时使用 RFC 3230 的 "SHA-256=<Base64>" 格式
+type ContentDigest struct {
+	// Algorithm 生成摘要使用的算法，默认为 DigestSHA256；验证时接受 DigestSHA256 与 DigestSHA512
+	Algorithm DigestAlgorithm
+	// Header 摘要请求头，默认为 "Content-Digest"
+	Header string
+	// Key 摘要请求头的值参与参数签名时的参数名，默认为 "content_digest"
+	Key string
+}
+
+// checkContentDigest 检查请求体摘要配置
+func (v *SignValidator) checkContentDigest() error {
+	d := v.config.ContentDigest
+	if d == nil {
+		return nil
+	}
+	switch d.Algorithm {
+	case "", DigestSHA256, DigestSHA512:
+	default:
+		return fmt.Errorf("不支持的请求体摘要算法: %s", d.Algorithm)
+	}
+	return nil
+}
+
+// header 返回摘要请求头名称
+func (d *ContentDigest) header() string {
+	if d.Header == "" {
+		return "Content-Digest"
+	}
+	return d.Header
+}
+
+// key 返回摘要参与参数签名时的参数名
+func (d *ContentDigest) key() string {
+	if d.Key == "" {
+		return "content_digest"
+	}
+	return d.Key
+}
+
+// legacy 判断是否使用 RFC 3230 的 Digest 请求头格式
+func (d *ContentDigest) legacy() bool {
+	return http.CanonicalHeaderKey(d.header()) == "Digest"
+}
+
+// Generate 计算请求体摘要，返回摘要请求头的值
+func (d *ContentDigest) Generate(body []byte) (string, error) {
+	algorithm := d.Algorithm
+	if algorithm == "" {
+		algorithm = DigestSHA256
+	}
+	h := newContentDigest(algorithm)
+	if h == nil {
+		return "", fmt.Errorf("不支持的请求体摘要算法: %s", algorithm)
+	}
+	h.Write(body)
+	digest := base64.StdEncoding.EncodeToString(h.Sum(nil))
+	if d.legacy() {
+		return strings.ToUpper(string(algorithm)) + "=" + digest, nil
+	}
+	return string(algorithm) + "=:" + digest + ":", nil
+}
+
+// Verify 验证摘要请求头的值与请求体一致：值中的每个 sha-256 与 sha-512 摘要都需要匹配，
+// 其他算法被忽略，没有可验证的摘要时返回错误；同时接受 RFC 9530 与 RFC 3230 格式
+func (d *ContentDigest) Verify(header string, body []byte) error {
+	checked := false
+	for _, member := range strings.Split(header, ",") {
+		name, value, ok := strings.Cut(strings.TrimSpace(member), "=")
+		if !ok {
+			return fmt.Errorf("%w: 摘要请求头格式无效", ErrDigestMismatch)
+		}
+		h := newContentDigest(DigestAlgorithm(strings.ToLower(name)))
+		if h == nil {
+			continue
+		}
+		// RFC 9530 的值为 :Base64: 形式的字节序列，可能带有 ";" 开头的参数
+		value, _, _ = strings.Cut(value, ";")
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && value[0] == ':' && value[len(value)-1] == ':' {
+			value = value[1 : len(value)-1]
+		}
+		expected, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return fmt.Errorf("%w: 摘要不是有效的 Base64", ErrDigestMismatch)
+		}
+		h.Write(body)
+		if subtle.ConstantTimeCompare(h.Sum(nil), expected) != 1 {
+			return ErrDigestMismatch
+		}
+		checked = true
+	}
+	if !checked {
+		return fmt.Errorf("%w: 摘要请求头中没有支持的算法", ErrDigestMismatch)
+	}
+	return nil
+}
+
+// newContentDigest 返回摘要算法的哈希函数，不支持时返回 nil
+func newContentDigest(algorithm DigestAlgorithm) hash.Hash {
+	switch algorithm {
+	case DigestSHA256:
+		return sha256.New()
+	case DigestSHA512:
+		return sha512.New()
+	}
+	return nil
+}
+
+// verifyContentDigest 验证请求的摘要请求头与请求体一致并返回其值，未配置 ContentDigest 时返回空字符串；
+// 请求缺少摘要请求头时返回 *MissingKeysError，读取后恢复请求体
+func (v *SignValidator) verifyContentDigest(r *http.Request) (string, error) {
+	d := v.config.ContentDigest
+	if d == nil {
+		return "", nil
+	}
+	header := r.Header.Get(d.header())
+	if header == "" {
+		return "", &MissingKeysError{Keys: []string{d.header()}}
+	}
+	body, err := readBody(r, v.config.MaxBodyBytes)
+	if err != nil {
+		return "", err
+	}
+	if err := d.Verify(header, body); err != nil {
+		return "", err
+	}
+	return header, nil
+}
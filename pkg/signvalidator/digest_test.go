@@ -0,0 +1,120 @@
+package signvalidator
+
+import (
+	"errors"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestContentDigest_Generate(t *testing.T) {
+	body := []byte(`{"hello": "world"}`)
+	tests := []struct {
+		digest *ContentDigest
+		want   string
+	}{
+		{&ContentDigest{}, "sha-256=:X48E9qOokqqrvdts8nOJRJN3OWDUoyWxBf7kbu9DBPE=:"},
+		{&ContentDigest{Algorithm: DigestSHA512}, "sha-512=:WZDPaVn/7XgHaAy8pmojAkGWoRx2UFChF41A2svX+TaPm+AbwAgBWnrIiYllu7BNNyealdVLvRwEmTHWXvJwew==:"},
+		{&ContentDigest{Header: "Digest"}, "SHA-256=X48E9qOokqqrvdts8nOJRJN3OWDUoyWxBf7kbu9DBPE="},
+	}
+	for _, tt := range tests {
+		got, err := tt.digest.Generate(body)
+		if err != nil || got != tt.want {
+			t.Errorf("Generate() = %q, %v, 期望 %q", got, err, tt.want)
+		}
+		if err := tt.digest.Verify(got, body); err != nil {
+			t.Errorf("生成的摘要应验证通过: %v", err)
+		}
+	}
+}
+
+func TestContentDigest_Verify(t *testing.T) {
+	d := &ContentDigest{}
+	body := []byte(`{"hello": "world"}`)
+	both := "sha-512=:WZDPaVn/7XgHaAy8pmojAkGWoRx2UFChF41A2svX+TaPm+AbwAgBWnrIiYllu7BNNyealdVLvRwEmTHWXvJwew==:, md5=:deadbeef:, sha-256=:X48E9qOokqqrvdts8nOJRJN3OWDUoyWxBf7kbu9DBPE=:"
+	if err := d.Verify(both, body); err != nil {
+		t.Errorf("多个摘要应验证通过，未知算法应被忽略: %v", err)
+	}
+	if err := d.Verify(both, []byte(`{"hello":"world"}`)); !errors.Is(err, ErrDigestMismatch) {
+		t.Errorf("请求体不一致时应返回 ErrDigestMismatch: %v", err)
+	}
+	for _, header := range []string{"md5=:deadbeef:", "sha-256", "sha-256=:not base64:"} {
+		if err := d.Verify(header, body); !errors.Is(err, ErrDigestMismatch) {
+			t.Errorf("Verify(%q) 应返回 ErrDigestMismatch: %v", header, err)
+		}
+	}
+
+	v := NewSignValidator(Config{Secret: "s", ContentDigest: &ContentDigest{Algorithm: "md5"}})
+	if _, err := v.GenerateSignature(map[string]interface{}{"a": "1"}); err == nil {
+		t.Error("不支持的摘要算法应返回错误")
+	}
+}
+
+func TestContentDigest_Params(t *testing.T) {
+	v := NewSignValidator(Config{Secret: "testSecret", Algorithm: HMAC_SHA256, ContentDigest: &ContentDigest{}})
+
+	r := httptest.NewRequest("POST", "/orders", strings.NewReader(`{"amount":100}`))
+	r.Header.Set("Content-Type", "application/json")
+	if err := v.SignHTTPRequest(r); err != nil {
+		t.Fatalf("签名请求失败: %v", err)
+	}
+	digest := r.Header.Get("Content-Digest")
+	if !strings.HasPrefix(digest, "sha-256=:") || r.URL.Query().Has("content_digest") {
+		t.Errorf("应生成摘要请求头且不写入查询参数: %q %s", digest, r.URL.RawQuery)
+	}
+	params, valid, err := v.ValidateRequestParams(r, "")
+	if err != nil || !valid || params["content_digest"] != digest {
+		t.Errorf("签名后的请求应验证通过: valid=%v, err=%v, params=%v", valid, err, params)
+	}
+
+	// 参数相同但字节不同的请求体
+	tampered := httptest.NewRequest("POST", "/orders?"+r.URL.RawQuery, strings.NewReader(`{"amount": 100}`))
+	tampered.Header = r.Header.Clone()
+	if _, _, err := v.ValidateRequestParams(tampered, ""); !errors.Is(err, ErrDigestMismatch) {
+		t.Errorf("请求体被修改时应返回 ErrDigestMismatch: %v", err)
+	}
+	// 摘要与修改后的请求体一致，但签名不再匹配
+	recomputed, _ := (&ContentDigest{}).Generate([]byte(`{"amount": 100}`))
+	tampered.Header.Set("Content-Digest", recomputed)
+	if _, valid, err := v.ValidateRequestParams(tampered, ""); err != nil || valid {
+		t.Errorf("替换摘要请求头后签名应验证失败: valid=%v, err=%v", valid, err)
+	}
+
+	tampered.Header.Del("Content-Digest")
+	var missing *MissingKeysError
+	if _, _, err := v.ValidateRequestParams(tampered, ""); !errors.As(err, &missing) {
+		t.Errorf("缺少摘要请求头时应返回 MissingKeysError: %v", err)
+	}
+}
+
+func TestContentDigest_Canonical(t *testing.T) {
+	var reason EventReason
+	v := NewSignValidator(Config{
+		Secret:               "testSecret",
+		Algorithm:            HMAC_SHA256,
+		SignatureHeader:      "X-Signature",
+		CanonicalRequest:     &CanonicalRequest{SignedHeaders: []string{"Content-Digest"}},
+		ContentDigest:        &ContentDigest{Algorithm: DigestSHA512, Header: "Digest"},
+		OnVerificationFailed: func(event SecurityEvent) { reason = event.Reason },
+	})
+
+	r := httptest.NewRequest("PUT", "/files/1", strings.NewReader("raw bytes"))
+	if err := v.SignHTTPRequest(r); err != nil {
+		t.Fatalf("签名请求失败: %v", err)
+	}
+	if !strings.HasPrefix(r.Header.Get("Digest"), "SHA-512=") {
+		t.Errorf("应生成 Digest 请求头: %v", r.Header)
+	}
+	if result, err := v.VerifyHTTPRequest(r); err != nil || !result.Valid {
+		t.Errorf("签名后的请求应验证通过: %+v, err=%v", result, err)
+	}
+	if body, _ := io.ReadAll(r.Body); string(body) != "raw bytes" {
+		t.Errorf("请求体 = %q, 应被恢复", body)
+	}
+
+	r.Body = io.NopCloser(strings.NewReader("raw bytez"))
+	if _, err := v.VerifyHTTPRequest(r); !errors.Is(err, ErrDigestMismatch) || reason != ReasonDigestMismatch {
+		t.Errorf("请求体被修改时应返回 ErrDigestMismatch 并上报事件: %v, %s", err, reason)
+	}
+}
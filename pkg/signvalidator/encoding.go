@@ -0,0 +1,136 @@
+package signvalidator
+
+import (
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// SignatureEncoding 表示签名结果的编码方式
+type SignatureEncoding string
+
+const (
+	// EncodingHex 十六进制（默认），大小写由 UpperCase 决定
+	EncodingHex SignatureEncoding = "hex"
+	// EncodingBase64 标准 Base64（带填充）
+	EncodingBase64 SignatureEncoding = "base64"
+	// EncodingBase64URL URL 安全的 Base64（不带填充，与 JWS 相同）
+	EncodingBase64URL SignatureEncoding = "base64url"
+	// EncodingBase64NoPad 标准 Base64（不带填充）
+	EncodingBase64NoPad SignatureEncoding = "base64-nopad"
+	// EncodingBase32 RFC 4648 Base32（大写，不带填充），适合人工输入的校验码
+	EncodingBase32 SignatureEncoding = "base32"
+	// EncodingBase58 Bitcoin 字母表的 Base58，不含 0、O、I、l 等易混淆字符
+	EncodingBase58 SignatureEncoding = "base58"
+	// EncodingBase62 仅含数字与大小写字母的 Base62，适合短链接
+	EncodingBase62 SignatureEncoding = "base62"
+)
+
+const (
+	base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+	base62Alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+)
+
+// errBaseN 签名字符串含有字母表之外的字符
+var errBaseN = errors.New("签名含有无效字符")
+
+// checkEncoding 检查签名编码方式
+func (v *SignValidator) checkEncoding() error {
+	switch v.config.Encoding {
+	case "", EncodingHex, EncodingBase64, EncodingBase64URL, EncodingBase64NoPad,
+		EncodingBase32, EncodingBase58, EncodingBase62:
+		return nil
+	}
+	return fmt.Errorf("不支持的签名编码方式: %s", v.config.Encoding)
+}
+
+// encode 按编码方式将签名结果编码为字符串
+func (e SignatureEncoding) encode(data []byte) string {
+	switch e {
+	case EncodingBase64:
+		return base64.StdEncoding.EncodeToString(data)
+	case EncodingBase64URL:
+		return base64.RawURLEncoding.EncodeToString(data)
+	case EncodingBase64NoPad:
+		return base64.RawStdEncoding.EncodeToString(data)
+	case EncodingBase32:
+		return base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(data)
+	case EncodingBase58:
+		return encodeBaseN(base58Alphabet, data)
+	case EncodingBase62:
+		return encodeBaseN(base62Alphabet, data)
+	}
+	return hex.EncodeToString(data)
+}
+
+// decode 按编码方式将签名字符串解码为字节
+func (e SignatureEncoding) decode(s string) ([]byte, error) {
+	switch e {
+	case EncodingBase64:
+		return base64.StdEncoding.DecodeString(s)
+	case EncodingBase64URL:
+		return base64.RawURLEncoding.DecodeString(s)
+	case EncodingBase64NoPad:
+		return base64.RawStdEncoding.DecodeString(s)
+	case EncodingBase32:
+		return base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(s)
+	case EncodingBase58:
+		return decodeBaseN(base58Alphabet, s)
+	case EncodingBase62:
+		return decodeBaseN(base62Alphabet, s)
+	}
+	return hex.DecodeString(s)
+}
+
+// isHex 判断编码方式是否为十六进制
+func (e SignatureEncoding) isHex() bool {
+	return e == "" || e == EncodingHex
+}
+
+// encodeBaseN 将字节按大端整数转换为 alphabet 进制，每个前导零字节编码为 alphabet[0]，与 Bitcoin Base58 相同
+func encodeBaseN(alphabet string, data []byte) string {
+	zeros := 0
+	for zeros < len(data) && data[zeros] == 0 {
+		zeros++
+	}
+
+	n := new(big.Int).SetBytes(data)
+	base := big.NewInt(int64(len(alphabet)))
+	mod := new(big.Int)
+	var digits []byte
+	for n.Sign() > 0 {
+		n.DivMod(n, base, mod)
+		digits = append(digits, alphabet[mod.Int64()])
+	}
+	for i := 0; i < zeros; i++ {
+		digits = append(digits, alphabet[0])
+	}
+	for i, j := 0, len(digits)-1; i < j; i, j = i+1, j-1 {
+		digits[i], digits[j] = digits[j], digits[i]
+	}
+	return string(digits)
+}
+
+// decodeBaseN 为 encodeBaseN 的逆过程
+func decodeBaseN(alphabet, s string) ([]byte, error) {
+	zeros := 0
+	for zeros < len(s) && s[zeros] == alphabet[0] {
+		zeros++
+	}
+
+	n := new(big.Int)
+	base := big.NewInt(int64(len(alphabet)))
+	for i := zeros; i < len(s); i++ {
+		digit := strings.IndexByte(alphabet, s[i])
+		if digit < 0 {
+			return nil, errBaseN
+		}
+		n.Mul(n, base)
+		n.Add(n, big.NewInt(int64(digit)))
+	}
+	return append(make([]byte, zeros), n.Bytes()...), nil
+}
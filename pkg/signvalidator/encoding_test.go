@@ -0,0 +1,108 @@
+package signvalidator
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+func TestSignatureEncoding(t *testing.T) {
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte("payload"))
+	sum := mac.Sum(nil)
+
+	tests := map[SignatureEncoding]string{
+		"":                  hex.EncodeToString(sum),
+		EncodingHex:         hex.EncodeToString(sum),
+		EncodingBase64:      base64.StdEncoding.EncodeToString(sum),
+		EncodingBase64URL:   base64.RawURLEncoding.EncodeToString(sum),
+		EncodingBase64NoPad: base64.RawStdEncoding.EncodeToString(sum),
+	}
+	for encoding, want := range tests {
+		v := NewSignValidator(Config{
+			Secret:     "secret",
+			Algorithm:  HMAC_SHA256,
+			SecretJoin: SecretJoinNone,
+			Encoding:   encoding,
+			UpperCase:  true,
+		})
+		if encoding.isHex() {
+			want = strings.ToUpper(want)
+		}
+
+		signature, err := v.GenerateBodySignature([]byte("payload"))
+		if err != nil {
+			t.Fatalf("%s: 生成签名失败: %v", encoding, err)
+		}
+		if signature != want {
+			t.Errorf("%s: 签名 = %s, 期望 %s", encoding, signature, want)
+		}
+		if valid, err := v.ValidateBody([]byte("payload"), signature); err != nil || !valid {
+			t.Errorf("%s: 签名验证应通过，valid=%v err=%v", encoding, valid, err)
+		}
+	}
+}
+
+func TestSignatureEncoding_Asymmetric(t *testing.T) {
+	privateKeyPEM, publicKeyPEM, err := GenerateKeyPair(ED25519)
+	if err != nil {
+		t.Fatalf("生成密钥对失败: %v", err)
+	}
+	params := map[string]interface{}{"id": 1}
+
+	for _, encoding := range []SignatureEncoding{EncodingBase64, EncodingBase64URL, EncodingBase64NoPad, EncodingBase32, EncodingBase58, EncodingBase62} {
+		signature, err := NewSignValidator(Config{Algorithm: ED25519, PrivateKeyPEM: privateKeyPEM, Encoding: encoding}).GenerateSignature(params)
+		if err != nil {
+			t.Fatalf("%s: 生成签名失败: %v", encoding, err)
+		}
+		if _, err := encoding.decode(signature); err != nil {
+			t.Errorf("%s: 签名不是有效的编码: %s", encoding, signature)
+		}
+
+		verifier := NewSignValidator(Config{Algorithm: ED25519, PublicKeyPEM: publicKeyPEM, Encoding: encoding})
+		if valid, err := verifier.Validate(params, signature); err != nil || !valid {
+			t.Errorf("%s: 签名验证应通过，valid=%v err=%v", encoding, valid, err)
+		}
+		if valid, err := verifier.Validate(params, "!"+signature); err != nil || valid {
+			t.Errorf("%s: 无法解码的签名应视为无效，valid=%v err=%v", encoding, valid, err)
+		}
+	}
+}
+
+func TestSignatureEncoding_BaseN(t *testing.T) {
+	tests := []struct {
+		encoding SignatureEncoding
+		data     []byte
+		want     string
+	}{
+		{EncodingBase32, []byte("Hello World!"), "JBSWY3DPEBLW64TMMQQQ"},
+		{EncodingBase58, []byte("Hello World!"), "2NEpo7TZRRrLZSi2U"},
+		{EncodingBase58, []byte{0, 0, 1}, "112"},
+		{EncodingBase62, []byte("Hello World!"), "T8dgcjRGkZ3aysdN"},
+		{EncodingBase62, []byte{0, 0, 1}, "001"},
+		{EncodingBase62, nil, ""},
+	}
+	for _, tt := range tests {
+		if got := tt.encoding.encode(tt.data); got != tt.want {
+			t.Errorf("%s(%x) = %s, 期望 %s", tt.encoding, tt.data, got, tt.want)
+		}
+		decoded, err := tt.encoding.decode(tt.want)
+		if err != nil || string(decoded) != string(tt.data) {
+			t.Errorf("%s 解码 %s = %x, 期望 %x, err=%v", tt.encoding, tt.want, decoded, tt.data, err)
+		}
+	}
+
+	if _, err := EncodingBase58.decode("0OIl"); err == nil {
+		t.Error("Base58 字母表之外的字符应返回错误")
+	}
+}
+
+func TestSignatureEncoding_Unsupported(t *testing.T) {
+	v := NewSignValidator(Config{Secret: "secret", Encoding: "base85"})
+	if _, err := v.GenerateSignature(map[string]interface{}{"a": 1}); err == nil {
+		t.Error("不支持的签名编码方式应返回错误")
+	}
+}
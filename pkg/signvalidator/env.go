@@ -0,0 +1,131 @@
+package signvalidator
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// maxSecretFileSize 密钥文件的最大字节数
+const maxSecretFileSize = 64 << 10
+
+// LoadSecretFile 读取密钥文件（如 Docker、Kubernetes 挂载的 secret），去除末尾的换行符；
+// 文件为空、超过 64 KiB 或密钥中包含换行符时返回错误（PEM 密钥请使用 LoadKeyFile）
+func LoadSecretFile(path string) (string, error) {
+	secret, err := LoadKeyFile(path)
+	if err != nil {
+		return "", err
+	}
+	if strings.ContainsAny(secret, "\r\n") {
+		return "", fmt.Errorf("密钥文件 %s 包含多行内容", path)
+	}
+	return secret, nil
+}
+
+// LoadKeyFile 读取 PEM 等多行密钥文件，去除末尾的换行符；文件为空或超过 64 KiB 时返回错误
+func LoadKeyFile(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("读取密钥文件失败: %w", err)
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, maxSecretFileSize+1))
+	if err != nil {
+		return "", fmt.Errorf("读取密钥文件失败: %w", err)
+	}
+	if len(data) > maxSecretFileSize {
+		return "", fmt.Errorf("密钥文件 %s 超过 %d 字节", path, maxSecretFileSize)
+	}
+	key := strings.TrimRight(string(data), "\r\n")
+	if key == "" {
+		return "", fmt.Errorf("密钥文件 %s 为空", path)
+	}
+	return key, nil
+}
+
+// ConfigFromEnv 读取以 prefix 开头的环境变量填充 base 的密钥与常用选项并返回，未设置的环境变量不修改 base：
+//
+//	{prefix}SECRET            密钥
+//	{prefix}SECRET_FILE       密钥文件路径，与 {prefix}SECRET 只能设置一个
+//	{prefix}PRIVATE_KEY_FILE  PEM 私钥文件路径
+//	{prefix}PUBLIC_KEY_FILE   PEM 公钥文件路径
+//	{prefix}ALGORITHM         签名算法名称，按 ParseAlgorithm 解析
+//	{prefix}SIGNATURE_KEY     签名参数名
+//
+// 已设置但值为空的环境变量视为配置错误；错误信息中不包含密钥内容
+func ConfigFromEnv(prefix string, base Config) (Config, error) {
+	config := base
+	env := func(name string) (string, bool, error) {
+		value, exists := os.LookupEnv(prefix + name)
+		if exists && strings.TrimSpace(value) == "" {
+			return "", false, fmt.Errorf("环境变量 %s%s 为空", prefix, name)
+		}
+		return value, exists, nil
+	}
+
+	secret, hasSecret, err := env("SECRET")
+	if err != nil {
+		return Config{}, err
+	}
+	secretFile, hasSecretFile, err := env("SECRET_FILE")
+	if err != nil {
+		return Config{}, err
+	}
+	switch {
+	case hasSecret && hasSecretFile:
+		return Config{}, fmt.Errorf("环境变量 %sSECRET 与 %sSECRET_FILE 只能设置一个", prefix, prefix)
+	case hasSecret:
+		config.Secret = secret
+	case hasSecretFile:
+		if config.Secret, err = LoadSecretFile(secretFile); err != nil {
+			return Config{}, err
+		}
+	}
+
+	for _, key := range []struct {
+		name   string
+		target *string
+	}{
+		{"PRIVATE_KEY_FILE", &config.PrivateKeyPEM},
+		{"PUBLIC_KEY_FILE", &config.PublicKeyPEM},
+	} {
+		path, exists, err := env(key.name)
+		if err != nil {
+			return Config{}, err
+		}
+		if exists {
+			if *key.target, err = LoadKeyFile(path); err != nil {
+				return Config{}, err
+			}
+		}
+	}
+
+	if name, exists, err := env("ALGORITHM"); err != nil {
+		return Config{}, err
+	} else if exists {
+		if config.Algorithm, err = ParseAlgorithm(name); err != nil {
+			return Config{}, fmt.Errorf("环境变量 %sALGORITHM: %w", prefix, err)
+		}
+	}
+	if signatureKey, exists, err := env("SIGNATURE_KEY"); err != nil {
+		return Config{}, err
+	} else if exists {
+		config.SignatureKey = signatureKey
+	}
+	return config, nil
+}
+
+// NewFromEnv 按 ConfigFromEnv 读取以 prefix 开头的环境变量创建验证器，
+// 未配置密钥（{prefix}SECRET、{prefix}SECRET_FILE 或 PEM 密钥文件）时返回错误
+func NewFromEnv(prefix string) (*SignValidator, error) {
+	config, err := ConfigFromEnv(prefix, Config{})
+	if err != nil {
+		return nil, err
+	}
+	if config.Secret == "" && config.PrivateKeyPEM == "" && config.PublicKeyPEM == "" {
+		return nil, fmt.Errorf("环境变量 %sSECRET 或 %sSECRET_FILE 未设置", prefix, prefix)
+	}
+	return New(config)
+}
@@ -0,0 +1,112 @@
+package signvalidator
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadSecretFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "secret")
+	os.WriteFile(path, []byte("s3cr3t\r\n"), 0o600)
+	if secret, err := LoadSecretFile(path); err != nil || secret != "s3cr3t" {
+		t.Errorf("密钥 = %q, err=%v, 期望 s3cr3t", secret, err)
+	}
+
+	for name, content := range map[string]string{
+		"empty":     "\n",
+		"multiline": "a\nb\n",
+		"large":     strings.Repeat("a", maxSecretFileSize+1),
+	} {
+		path := filepath.Join(dir, name)
+		os.WriteFile(path, []byte(content), 0o600)
+		if _, err := LoadSecretFile(path); err == nil {
+			t.Errorf("%s: 应返回错误", name)
+		}
+	}
+	if _, err := LoadSecretFile(filepath.Join(dir, "missing")); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("文件不存在时应返回 os.ErrNotExist，实际 %v", err)
+	}
+}
+
+func TestConfigFromEnv(t *testing.T) {
+	dir := t.TempDir()
+	secretFile := filepath.Join(dir, "secret")
+	os.WriteFile(secretFile, []byte("from-file\n"), 0o600)
+
+	t.Setenv("APP_SIGN_SECRET_FILE", secretFile)
+	t.Setenv("APP_SIGN_ALGORITHM", "HmacSHA256")
+	t.Setenv("APP_SIGN_SIGNATURE_KEY", "signature")
+
+	config, err := ConfigFromEnv("APP_SIGN_", Config{TimestampKey: "ts", SignatureKey: "sign"})
+	if err != nil {
+		t.Fatalf("读取环境变量失败: %v", err)
+	}
+	if config.Secret != "from-file" || config.Algorithm != HMAC_SHA256 || config.SignatureKey != "signature" || config.TimestampKey != "ts" {
+		t.Errorf("配置不正确: %+v", config)
+	}
+
+	v, err := NewFromEnv("APP_SIGN_")
+	if err != nil {
+		t.Fatalf("创建验证器失败: %v", err)
+	}
+	params := map[string]interface{}{"a": "1"}
+	signature, _ := v.GenerateSignature(params)
+	expected, _ := NewSignValidator(Config{Secret: "from-file", Algorithm: HMAC_SHA256}).GenerateSignature(params)
+	if signature != expected {
+		t.Errorf("签名 = %s, 期望 %s", signature, expected)
+	}
+}
+
+func TestConfigFromEnv_KeyFiles(t *testing.T) {
+	privateKeyPEM, publicKeyPEM, err := GenerateKeyPair(ED25519)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "private.pem"), []byte(privateKeyPEM), 0o600)
+	os.WriteFile(filepath.Join(dir, "public.pem"), []byte(publicKeyPEM), 0o600)
+
+	t.Setenv("SIGN_ALGORITHM", "EdDSA")
+	t.Setenv("SIGN_PRIVATE_KEY_FILE", filepath.Join(dir, "private.pem"))
+	signer, err := NewFromEnv("SIGN_")
+	if err != nil {
+		t.Fatalf("创建签名器失败: %v", err)
+	}
+	signature, err := signer.GenerateSignature(map[string]interface{}{"a": "1"})
+	if err != nil {
+		t.Fatalf("签名失败: %v", err)
+	}
+
+	os.Unsetenv("SIGN_PRIVATE_KEY_FILE")
+	t.Setenv("SIGN_PUBLIC_KEY_FILE", filepath.Join(dir, "public.pem"))
+	verifier, err := NewFromEnv("SIGN_")
+	if err != nil {
+		t.Fatalf("创建验证器失败: %v", err)
+	}
+	if valid, err := verifier.Validate(map[string]interface{}{"a": "1"}, signature); err != nil || !valid {
+		t.Errorf("公钥验证应通过，valid=%v err=%v", valid, err)
+	}
+}
+
+func TestConfigFromEnv_Invalid(t *testing.T) {
+	for _, env := range []map[string]string{
+		{"X_SECRET": "a", "X_SECRET_FILE": "/tmp/a"},
+		{"X_SECRET": " "},
+		{"X_SECRET": "a", "X_ALGORITHM": "unknown"},
+		{"X_SECRET_FILE": filepath.Join(t.TempDir(), "missing")},
+		{"X_ALGORITHM": "sha256"},
+	} {
+		t.Run("", func(t *testing.T) {
+			for k, value := range env {
+				t.Setenv(k, value)
+			}
+			if _, err := NewFromEnv("X_"); err == nil {
+				t.Errorf("%v: 应返回错误", env)
+			}
+		})
+	}
+}
@@ -0,0 +1,155 @@
+// Package etcd 使用 etcd v3 实现 signvalidator.NonceStore：通过 JSON 网关（/v3/lease/grant、/v3/kv/txn）
+// 以事务原子地写入不存在的键并绑定租约，随机数随租约过期，适用于没有 Redis 的环境。
+package etcd
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config etcd 配置
+type Config struct {
+	// Endpoint etcd 地址，如 "http://127.0.0.1:2379"
+	Endpoint string
+	// KeyPrefix 键前缀，默认为 "/nonce/"
+	KeyPrefix string
+	// Client HTTP 客户端，默认为 http.DefaultClient，可配置 TLS 客户端证书
+	Client *http.Client
+	// Timeout 单次调用超时时间，默认为 2 秒
+	Timeout time.Duration
+	// OnError 调用失败时的回调，可用于记录日志；调用失败时 Seen 返回 true 以拒绝请求
+	OnError func(error)
+}
+
+// APIError etcd 返回的错误
+type APIError struct {
+	// StatusCode HTTP 状态码
+	StatusCode int
+	// Message 错误信息
+	Message string `json:"message"`
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("etcd 错误（HTTP %d）: %s", e.StatusCode, e.Message)
+}
+
+// NonceStore 基于 etcd 的 signvalidator.NonceStore
+type NonceStore struct {
+	config Config
+}
+
+// NewNonceStore 检查配置并创建 NonceStore
+func NewNonceStore(config Config) (*NonceStore, error) {
+	if config.Endpoint == "" {
+		return nil, errors.New("etcd 地址为空")
+	}
+	if config.KeyPrefix == "" {
+		config.KeyPrefix = "/nonce/"
+	}
+	if config.Client == nil {
+		config.Client = http.DefaultClient
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = 2 * time.Second
+	}
+	config.Endpoint = strings.TrimRight(config.Endpoint, "/")
+	return &NonceStore{config: config}, nil
+}
+
+// Seen 实现 signvalidator.NonceStore：申请 TTL 租约，并在键的创建版本为 0（不存在）时写入绑定该租约的键
+func (s *NonceStore) Seen(nonce string, ttl time.Duration) bool {
+	created, err := s.create(s.config.KeyPrefix+nonce, ttl)
+	if err != nil {
+		if s.config.OnError != nil {
+			s.config.OnError(err)
+		}
+		return true
+	}
+	return !created
+}
+
+// create 仅在键不存在时写入，键已存在时返回 false
+func (s *NonceStore) create(key string, ttl time.Duration) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.config.Timeout)
+	defer cancel()
+
+	seconds := int64((ttl + time.Second - 1) / time.Second)
+	if seconds < 1 {
+		seconds = 1
+	}
+	var lease struct {
+		ID string `json:"ID"`
+	}
+	if err := s.call(ctx, "/v3/lease/grant", map[string]interface{}{"TTL": strconv.FormatInt(seconds, 10)}, &lease); err != nil {
+		return false, err
+	}
+	if lease.ID == "" {
+		return false, errors.New("etcd 未返回租约")
+	}
+
+	encodedKey := base64.StdEncoding.EncodeToString([]byte(key))
+	txn := map[string]interface{}{
+		"compare": []map[string]interface{}{{
+			"key":             encodedKey,
+			"result":          "EQUAL",
+			"target":          "CREATE",
+			"create_revision": "0",
+		}},
+		"success": []map[string]interface{}{{
+			"request_put": map[string]interface{}{
+				"key":   encodedKey,
+				"value": base64.StdEncoding.EncodeToString([]byte("1")),
+				"lease": lease.ID,
+			},
+		}},
+	}
+	var result struct {
+		Succeeded bool `json:"succeeded"`
+	}
+	if err := s.call(ctx, "/v3/kv/txn", txn, &result); err != nil {
+		return false, err
+	}
+	return result.Succeeded, nil
+}
+
+// call 调用 JSON 网关接口
+func (s *NonceStore) call(ctx context.Context, path string, body, output interface{}) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.config.Endpoint+path, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.config.Client.Do(req)
+	if err != nil {
+		return fmt.Errorf("调用 etcd 失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	data, err = io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return fmt.Errorf("读取 etcd 响应失败: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		apiErr := &APIError{StatusCode: resp.StatusCode}
+		json.Unmarshal(data, apiErr)
+		return apiErr
+	}
+	if err := json.Unmarshal(data, output); err != nil {
+		return fmt.Errorf("解析 etcd 响应失败: %w", err)
+	}
+	return nil
+}
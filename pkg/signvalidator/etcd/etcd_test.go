@@ -0,0 +1,138 @@
+package etcd
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/huangchunlong818/sign-chao/pkg/signvalidator"
+)
+
+// fakeEtcd 模拟 etcd JSON 网关的租约与事务接口
+type fakeEtcd struct {
+	mu     sync.Mutex
+	leases map[string]string
+	keys   map[string]string
+	nextID int
+}
+
+func (f *fakeEtcd) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch r.URL.Path {
+	case "/v3/lease/grant":
+		var input struct {
+			TTL string `json:"TTL"`
+		}
+		json.NewDecoder(r.Body).Decode(&input)
+		f.nextID++
+		id := strconv.Itoa(f.nextID)
+		f.leases[id] = input.TTL
+		json.NewEncoder(w).Encode(map[string]string{"ID": id, "TTL": input.TTL})
+	case "/v3/kv/txn":
+		var input struct {
+			Compare []struct {
+				Key            string `json:"key"`
+				Target         string `json:"target"`
+				CreateRevision string `json:"create_revision"`
+			} `json:"compare"`
+			Success []struct {
+				RequestPut struct {
+					Key   string `json:"key"`
+					Lease string `json:"lease"`
+				} `json:"request_put"`
+			} `json:"success"`
+		}
+		json.NewDecoder(r.Body).Decode(&input)
+		if len(input.Compare) != 1 || input.Compare[0].Target != "CREATE" || input.Compare[0].CreateRevision != "0" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"message":"invalid txn"}`))
+			return
+		}
+		key, _ := base64.StdEncoding.DecodeString(input.Compare[0].Key)
+		if _, exists := f.keys[string(key)]; exists {
+			// JSON 网关省略值为 false 的字段
+			w.Write([]byte(`{"header":{}}`))
+			return
+		}
+		f.keys[string(key)] = input.Success[0].RequestPut.Lease
+		w.Write([]byte(`{"header":{},"succeeded":true}`))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message":"Not Found"}`))
+	}
+}
+
+func newFakeEtcd(t *testing.T) (*fakeEtcd, *httptest.Server) {
+	t.Helper()
+	f := &fakeEtcd{leases: map[string]string{}, keys: map[string]string{}}
+	server := httptest.NewServer(f)
+	t.Cleanup(server.Close)
+	return f, server
+}
+
+func TestNonceStore(t *testing.T) {
+	f, server := newFakeEtcd(t)
+	store, err := NewNonceStore(Config{Endpoint: server.URL + "/"})
+	if err != nil {
+		t.Fatalf("创建 NonceStore 失败: %v", err)
+	}
+
+	var _ signvalidator.NonceStore = store
+	if store.Seen("n1", 90*time.Second) {
+		t.Error("首次出现的随机数不应被视为已使用")
+	}
+	if !store.Seen("n1", 90*time.Second) {
+		t.Error("重复的随机数应被视为已使用")
+	}
+
+	lease, exists := f.keys["/nonce/n1"]
+	if !exists {
+		t.Fatalf("键应写入 KeyPrefix 之下: %v", f.keys)
+	}
+	if f.leases[lease] != "90" {
+		t.Errorf("租约 TTL = %s, 期望 90", f.leases[lease])
+	}
+}
+
+func TestNonceStore_Validator(t *testing.T) {
+	_, server := newFakeEtcd(t)
+	store, _ := NewNonceStore(Config{Endpoint: server.URL})
+
+	v := signvalidator.NewSignValidator(signvalidator.Config{Secret: "secret", NonceStore: store})
+	signed, err := v.SignRequest(map[string]interface{}{"a": 1})
+	if err != nil {
+		t.Fatalf("签名请求失败: %v", err)
+	}
+	if valid, err := v.ValidateWithSignInParams(signed); err != nil || !valid {
+		t.Errorf("首次验证应通过，valid=%v err=%v", valid, err)
+	}
+	if _, err := v.ValidateWithSignInParams(signed); !errors.Is(err, signvalidator.ErrNonceReused) {
+		t.Errorf("重放的请求应返回 ErrNonceReused，实际 %v", err)
+	}
+}
+
+func TestNonceStore_Error(t *testing.T) {
+	_, server := newFakeEtcd(t)
+	var reported error
+	store, _ := NewNonceStore(Config{Endpoint: server.URL + "/missing", OnError: func(err error) { reported = err }})
+
+	if !store.Seen("n1", time.Minute) {
+		t.Error("etcd 调用失败时应拒绝请求")
+	}
+	var apiErr *APIError
+	if !errors.As(reported, &apiErr) || apiErr.StatusCode != http.StatusNotFound {
+		t.Errorf("应报告 APIError，实际 %v", reported)
+	}
+
+	if _, err := NewNonceStore(Config{}); err == nil {
+		t.Error("地址为空时应返回错误")
+	}
+}
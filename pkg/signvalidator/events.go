@@ -0,0 +1,118 @@
+package signvalidator
+
+import (
+	"errors"
+	"net/http"
+	"time"
+)
+
+// EventReason 安全事件的原因
+type EventReason string
+
+const (
+	// ReasonInvalidSignature 签名不匹配
+	ReasonInvalidSignature EventReason = "invalid_signature"
+	// ReasonMalformed 签名或参数格式错误、算法不被允许等
+	ReasonMalformed EventReason = "malformed"
+	// ReasonMissingKeys 缺少必需的参数
+	ReasonMissingKeys EventReason = "missing_keys"
+	// ReasonTooManyFailures 失败次数达到 FailureLimit 上限
+	ReasonTooManyFailures EventReason = "too_many_failures"
+	// ReasonNonceReused 随机数重复使用
+	ReasonNonceReused EventReason = "nonce_reused"
+	// ReasonSignatureReused 一次性签名重复提交
+	ReasonSignatureReused EventReason = "signature_reused"
+	// ReasonSequenceNotIncreasing 序号未递增
+	ReasonSequenceNotIncreasing EventReason = "sequence_not_increasing"
+	// ReasonTimestampExpired 时间戳超出允许的偏差
+	ReasonTimestampExpired EventReason = "timestamp_expired"
+	// ReasonURLExpired 预签名链接已过期
+	ReasonURLExpired EventReason = "url_expired"
+	// ReasonTokenExpired 签名令牌已过期
+	ReasonTokenExpired EventReason = "token_expired"
+	// ReasonDigestMismatch 请求体与摘要请求头不一致
+	ReasonDigestMismatch EventReason = "digest_mismatch"
+)
+
+// SecurityEvent 验证失败时传给安全事件回调的上下文，可转发到 SIEM 等系统
+type SecurityEvent struct {
+	// Reason 失败原因
+	Reason EventReason
+	// Err 验证返回的错误，签名不匹配时为空
+	Err error
+	// KeyID 请求中 KeyIDKey 参数（或 KeyIDHeader 请求头）的值，可能为空
+	KeyID string
+	// RemoteAddr 请求的来源地址，仅在验证 HTTP 请求时设置
+	RemoteAddr string
+	// Time 事件发生的时间
+	Time time.Time
+}
+
+// eventReason 根据验证返回的错误判断失败原因
+func eventReason(err error) EventReason {
+	switch {
+	case err == nil:
+		return ReasonInvalidSignature
+	case errors.Is(err, ErrTimestampExpired):
+		return ReasonTimestampExpired
+	case errors.Is(err, ErrURLExpired):
+		return ReasonURLExpired
+	case errors.Is(err, ErrTokenExpired):
+		return ReasonTokenExpired
+	case errors.Is(err, ErrNonceReused):
+		return ReasonNonceReused
+	case errors.Is(err, ErrSignatureReused):
+		return ReasonSignatureReused
+	case errors.Is(err, ErrSequenceNotIncreasing):
+		return ReasonSequenceNotIncreasing
+	case errors.Is(err, ErrTooManyFailures):
+		return ReasonTooManyFailures
+	case errors.Is(err, ErrMissingKeys):
+		return ReasonMissingKeys
+	case errors.Is(err, ErrDigestMismatch):
+		return ReasonDigestMismatch
+	default:
+		return ReasonMalformed
+	}
+}
+
+// hasEventHooks 判断是否配置了安全事件回调
+func (v *SignValidator) hasEventHooks() bool {
+	return v.config.OnVerificationFailed != nil || v.config.OnReplayDetected != nil || v.config.OnExpired != nil
+}
+
+// withRemoteAddr 返回记录请求来源地址的验证器副本，未配置安全事件回调时返回 v
+func (v *SignValidator) withRemoteAddr(r *http.Request) *SignValidator {
+	if !v.hasEventHooks() {
+		return v
+	}
+	c := *v
+	c.remoteAddr = r.RemoteAddr
+	return &c
+}
+
+// report 按失败原因调用 OnExpired、OnReplayDetected 或 OnVerificationFailed
+func (v *SignValidator) report(params map[string]interface{}, err error) {
+	if !v.hasEventHooks() {
+		return
+	}
+
+	event := SecurityEvent{
+		Reason:     eventReason(err),
+		Err:        err,
+		RemoteAddr: v.remoteAddr,
+		Time:       v.now(),
+	}
+	event.KeyID, _ = v.keyID(params)
+
+	hook := v.config.OnVerificationFailed
+	switch event.Reason {
+	case ReasonTimestampExpired, ReasonURLExpired, ReasonTokenExpired:
+		hook = v.config.OnExpired
+	case ReasonNonceReused, ReasonSignatureReused, ReasonSequenceNotIncreasing:
+		hook = v.config.OnReplayDetected
+	}
+	if hook != nil {
+		hook(event)
+	}
+}
@@ -0,0 +1,117 @@
+package signvalidator
+
+import (
+	"errors"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// eventRecorder 记录各回调收到的安全事件
+type eventRecorder struct {
+	failed, replayed, expired []SecurityEvent
+}
+
+func (r *eventRecorder) config(config Config) Config {
+	config.OnVerificationFailed = func(e SecurityEvent) { r.failed = append(r.failed, e) }
+	config.OnReplayDetected = func(e SecurityEvent) { r.replayed = append(r.replayed, e) }
+	config.OnExpired = func(e SecurityEvent) { r.expired = append(r.expired, e) }
+	return config
+}
+
+func TestSecurityEvents(t *testing.T) {
+	clock := NewFakeClock(time.Unix(1700000000, 0))
+	var events eventRecorder
+	v := NewSignValidator(events.config(Config{
+		Secret:       "secret",
+		Clock:        clock,
+		TimestampKey: "timestamp",
+		NonceStore:   NewMemoryNonceStore(0),
+	}))
+
+	params, _ := v.SignRequest(map[string]interface{}{"kid": "k1", "order_id": "1001"})
+	signature := params["sign"].(string)
+
+	if valid, _ := v.Validate(params, "bad"); valid {
+		t.Fatal("无效签名验证应失败")
+	}
+	if len(events.failed) != 1 || events.failed[0].Reason != ReasonInvalidSignature || events.failed[0].KeyID != "k1" {
+		t.Fatalf("签名不匹配应调用 OnVerificationFailed，实际 %+v", events.failed)
+	}
+	if !events.failed[0].Time.Equal(clock.Now()) {
+		t.Errorf("事件时间 = %v, 期望 %v", events.failed[0].Time, clock.Now())
+	}
+
+	if valid, err := v.Validate(params, signature); err != nil || !valid {
+		t.Fatalf("首次验证应通过，valid=%v err=%v", valid, err)
+	}
+	v.Validate(params, signature)
+	if len(events.replayed) != 1 || events.replayed[0].Reason != ReasonNonceReused || !errors.Is(events.replayed[0].Err, ErrNonceReused) {
+		t.Errorf("重放应调用 OnReplayDetected，实际 %+v", events.replayed)
+	}
+
+	clock.Advance(time.Hour)
+	v.Validate(params, signature)
+	if len(events.expired) != 1 || events.expired[0].Reason != ReasonTimestampExpired {
+		t.Errorf("时间戳过期应调用 OnExpired，实际 %+v", events.expired)
+	}
+
+	v.Validate(map[string]interface{}{"order_id": "1001"}, signature)
+	if len(events.failed) != 2 || events.failed[1].Reason != ReasonMissingKeys {
+		t.Errorf("缺少参数应调用 OnVerificationFailed，实际 %+v", events.failed)
+	}
+	if len(events.replayed) != 1 || len(events.expired) != 1 {
+		t.Errorf("每次失败只应调用一个回调: %+v", events)
+	}
+}
+
+func TestSecurityEvents_RemoteAddr(t *testing.T) {
+	var events eventRecorder
+	v := NewSignValidator(events.config(Config{Secret: "secret", Algorithm: HMAC_SHA256}))
+
+	r := httptest.NewRequest("POST", "/orders?kid=k2", strings.NewReader(`{"a":1}`))
+	r.RemoteAddr = "203.0.113.7:52000"
+	v.ValidateRequestBody(r, "bad")
+
+	form := httptest.NewRequest("POST", "/orders", strings.NewReader(url.Values{"a": {"1"}, "sign": {"bad"}}.Encode()))
+	form.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	form.RemoteAddr = "198.51.100.1:1234"
+	v.ValidateForm(form)
+
+	if len(events.failed) != 2 {
+		t.Fatalf("应调用两次 OnVerificationFailed，实际 %+v", events.failed)
+	}
+	if events.failed[0].RemoteAddr != "203.0.113.7:52000" || events.failed[0].KeyID != "k2" {
+		t.Errorf("请求体验证事件 = %+v", events.failed[0])
+	}
+	if events.failed[1].RemoteAddr != "198.51.100.1:1234" {
+		t.Errorf("表单验证事件 = %+v", events.failed[1])
+	}
+}
+
+func TestSecurityEvents_Expired(t *testing.T) {
+	clock := NewFakeClock(time.Unix(1700000000, 0))
+	var events eventRecorder
+	v := NewSignValidator(events.config(Config{Secret: "secret", Algorithm: HMAC_SHA256, Clock: clock}))
+
+	signedURL, _ := v.SignURL("GET", "https://example.com/a", time.Minute)
+	token, _ := NewTokenSigner(v).Sign(map[string]interface{}{"kid": "k3"}, time.Minute)
+	clock.Advance(time.Minute)
+
+	v.VerifySignedURL("GET", signedURL)
+	NewTokenSigner(v).Verify(token)
+	if len(events.expired) != 2 || events.expired[0].Reason != ReasonURLExpired || events.expired[1].Reason != ReasonTokenExpired {
+		t.Fatalf("链接与令牌过期应调用 OnExpired，实际 %+v", events.expired)
+	}
+	if events.expired[1].KeyID != "k3" {
+		t.Errorf("令牌过期事件的 KeyID = %q, 期望 k3", events.expired[1].KeyID)
+	}
+
+	// 诊断不触发回调
+	v.ExplainMismatch(map[string]interface{}{"a": "1"}, "bad")
+	if len(events.failed) != 0 {
+		t.Errorf("ExplainMismatch 不应触发回调，实际 %+v", events.failed)
+	}
+}
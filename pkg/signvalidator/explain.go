@@ -0,0 +1,221 @@
+package signvalidator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Mismatch 签名验证失败的诊断结果
+type Mismatch struct {
+	// Valid 签名是否实际有效
+	Valid bool
+	// Details 按当前配置生成签名的详细信息，仅持有公钥时 Details.Signature 为空
+	Details *SignatureDetails
+	// Causes 可能的原因，每条说明对方与当前配置的一处差异及对应的配置项；为空时未找到可能的原因，
+	// 请确认双方密钥一致并逐项对比 Details.StringToSign
+	Causes []string
+}
+
+// mismatchVariant 诊断时尝试的配置或参数差异
+type mismatchVariant struct {
+	cause string
+	apply func(c *SignValidator, params map[string]interface{}) map[string]interface{}
+}
+
+// diagnosisAlgorithms 诊断时尝试的常见摘要与 HMAC 算法
+var diagnosisAlgorithms = []SignAlgorithm{MD5, SHA1, SHA256, HMAC_MD5, HMAC_SHA1, HMAC_SHA256, SM3, HMAC_SM3}
+
+// ExplainMismatch 诊断签名验证失败的原因：返回期望的签名与待签名字符串，并逐项尝试常见差异
+// （签名大小写与截断、多余参数、浮点数格式、参数名大小写、空值、URL 编码、密钥拼接方式、签名编码与算法），
+// 列出能使签名验证通过的差异
+func (v *SignValidator) ExplainMismatch(params map[string]interface{}, signature string) (*Mismatch, error) {
+	if v.err != nil {
+		return nil, v.err
+	}
+
+	// 诊断时不记录随机数、签名、序号与失败次数，也不触发安全事件回调
+	quiet := *v
+	quiet.config.NonceStore = nil
+	quiet.config.SignatureStore = nil
+	quiet.config.SequenceStore = nil
+	quiet.config.OnVerificationFailed = nil
+	quiet.config.OnReplayDetected = nil
+	quiet.config.OnExpired = nil
+	quiet.failures = nil
+	v = &quiet
+
+	valid, err := v.Validate(params, signature)
+	if err != nil {
+		return nil, err
+	}
+
+	details := &SignatureDetails{}
+	c := *v
+	c.trace = details
+	if details.Signature, err = c.GenerateSignature(params); err != nil {
+		// 仅持有公钥时无法生成签名，但待签名字符串已经构建
+		if !isAsymmetric(details.Algorithm) || details.StringToSign == "" {
+			return nil, err
+		}
+	}
+
+	m := &Mismatch{Valid: valid, Details: details}
+	if valid {
+		return m, nil
+	}
+
+	if expected := details.Signature; expected != "" {
+		switch {
+		case strings.EqualFold(expected, signature):
+			m.Causes = append(m.Causes, "签名大小写不一致（检查 UpperCase）")
+		case len(signature) < len(expected) && strings.HasPrefix(expected, signature):
+			m.Causes = append(m.Causes, fmt.Sprintf("签名被截断为 %d 个字符（设置 TruncateLength）", len(signature)))
+		case strings.HasSuffix(signature, expected):
+			m.Causes = append(m.Causes, fmt.Sprintf("签名带有前缀 %q（设置 SignaturePrefix）", strings.TrimSuffix(signature, expected)))
+		}
+	}
+
+	for _, variant := range v.mismatchVariants(params) {
+		c := *v
+		variantParams := variant.apply(&c, params)
+		if ok, err := c.Validate(variantParams, signature); err == nil && ok {
+			m.Causes = append(m.Causes, variant.cause)
+		}
+	}
+	return m, nil
+}
+
+// mismatchVariants 返回诊断时依次尝试的差异
+func (v *SignValidator) mismatchVariants(params map[string]interface{}) []mismatchVariant {
+	var variants []mismatchVariant
+
+	// 多余参数：对方未对某个参数签名
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		if !v.isExcludedKey(k, nil) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		key := k
+		variants = append(variants, mismatchVariant{
+			cause: fmt.Sprintf("参数 %s 未参与对方的签名（可能是多余参数，可加入 IgnoreKeys）", key),
+			apply: func(_ *SignValidator, params map[string]interface{}) map[string]interface{} {
+				without := make(map[string]interface{}, len(params))
+				for k, value := range params {
+					if k != key {
+						without[k] = value
+					}
+				}
+				return without
+			},
+		})
+	}
+
+	// 浮点数格式
+	if hasFloat(params) && v.config.ValueEncoder == nil {
+		for _, format := range []struct {
+			cause  string
+			format *FloatFormat
+		}{
+			{"浮点数格式不一致，对方使用最短表示（设置 FloatFormat: &FloatFormat{Shortest: true}）", &FloatFormat{Shortest: true}},
+			{"浮点数格式不一致，对方保留两位小数（设置 FloatFormat: &FloatFormat{Precision: 2}）", &FloatFormat{Precision: 2}},
+		} {
+			format := format
+			variants = append(variants, configVariant(format.cause, func(c *Config) { c.FloatFormat = format.format }))
+		}
+	}
+
+	// 参数名大小写与排序
+	if v.config.KeyCase != KeyLower {
+		variants = append(variants, configVariant("参数名大小写不一致，对方将参数名转换为小写（设置 KeyCase: KeyLower）",
+			func(c *Config) { c.KeyCase = KeyLower }))
+	}
+	if v.config.SortFunc == nil {
+		variants = append(variants, configVariant("参数排序不一致，对方忽略大小写排序（设置 SortFunc: SortCaseInsensitive）",
+			func(c *Config) { c.SortFunc = SortCaseInsensitive }))
+	}
+
+	// 参数值处理
+	if hasEmptyValue(params) {
+		if v.config.SkipEmptyValues {
+			variants = append(variants, configVariant("对方未跳过空值参数（关闭 SkipEmptyValues）", func(c *Config) { c.SkipEmptyValues = false }))
+		} else {
+			variants = append(variants, configVariant("对方跳过了空值参数（设置 SkipEmptyValues: true）", func(c *Config) { c.SkipEmptyValues = true }))
+		}
+	}
+	if !v.config.TrimValues {
+		variants = append(variants, configVariant("对方去除了参数值首尾的空白（设置 TrimValues: true）", func(c *Config) { c.TrimValues = true }))
+	}
+	for _, mode := range []URLEncodeMode{URLEncodeNone, URLEncodeValues, URLEncodeAll} {
+		if mode != v.config.URLEncode {
+			mode := mode
+			variants = append(variants, configVariant(fmt.Sprintf("URL 编码方式不一致（设置 URLEncode: %q）", mode),
+				func(c *Config) { c.URLEncode = mode }))
+		}
+	}
+
+	// 密钥拼接方式、签名编码与算法
+	if !isAsymmetric(v.config.Algorithm) {
+		for _, join := range []SecretJoinMode{SecretJoinAmpersand, SecretJoinRaw, SecretJoinNone} {
+			current := v.config.SecretJoin
+			if current == "" {
+				current = SecretJoinAmpersand
+			}
+			if join != current {
+				join := join
+				variants = append(variants, configVariant(fmt.Sprintf("密钥拼接方式不一致（设置 SecretJoin: %q）", join),
+					func(c *Config) { c.SecretJoin = join }))
+			}
+		}
+		for _, algorithm := range diagnosisAlgorithms {
+			if algorithm != v.config.Algorithm {
+				algorithm := algorithm
+				variants = append(variants, configVariant(fmt.Sprintf("签名算法不一致，对方可能使用 %s", algorithm),
+					func(c *Config) { c.Algorithm = algorithm; c.AcceptAlgorithms = nil }))
+			}
+		}
+	}
+	for _, encoding := range []SignatureEncoding{EncodingHex, EncodingBase64, EncodingBase64URL} {
+		if encoding != v.config.Encoding && !(encoding.isHex() && v.config.Encoding.isHex()) {
+			encoding := encoding
+			variants = append(variants, configVariant(fmt.Sprintf("签名编码方式不一致（设置 Encoding: %q）", encoding),
+				func(c *Config) { c.Encoding = encoding }))
+		}
+	}
+	return variants
+}
+
+// configVariant 返回修改配置、参数不变的差异
+func configVariant(cause string, modify func(c *Config)) mismatchVariant {
+	return mismatchVariant{
+		cause: cause,
+		apply: func(c *SignValidator, params map[string]interface{}) map[string]interface{} {
+			modify(&c.config)
+			return params
+		},
+	}
+}
+
+// hasFloat 判断参数中是否有浮点数
+func hasFloat(params map[string]interface{}) bool {
+	for _, value := range params {
+		switch value.(type) {
+		case float32, float64:
+			return true
+		}
+	}
+	return false
+}
+
+// hasEmptyValue 判断参数中是否有 nil 或空字符串
+func hasEmptyValue(params map[string]interface{}) bool {
+	for _, value := range params {
+		if value == nil || value == "" {
+			return true
+		}
+	}
+	return false
+}
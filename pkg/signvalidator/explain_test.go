@@ -0,0 +1,107 @@
+package signvalidator
+
+import (
+	"strings"
+	"testing"
+)
+
+// hasCause 判断诊断结果中是否有包含 substr 的原因
+func hasCause(m *Mismatch, substr string) bool {
+	for _, cause := range m.Causes {
+		if strings.Contains(cause, substr) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestExplainMismatch(t *testing.T) {
+	skipFIPS(t)
+	params := map[string]interface{}{
+		"amount":   9.9,
+		"order_id": "1001",
+		"Trace":    "t1",
+		"remark":   "",
+	}
+	v := NewSignValidator(Config{Secret: "secret", Algorithm: HMAC_SHA256})
+
+	tests := []struct {
+		name    string
+		partner Config
+		params  map[string]interface{}
+		cause   string
+	}{
+		{"大小写", Config{Secret: "secret", Algorithm: HMAC_SHA256, UpperCase: true}, params, "UpperCase"},
+		{"截断", Config{Secret: "secret", Algorithm: HMAC_SHA256, TruncateLength: 16}, params, "TruncateLength"},
+		{"前缀", Config{Secret: "secret", Algorithm: HMAC_SHA256, SignaturePrefix: "sha256="}, params, "SignaturePrefix"},
+		{"浮点数", Config{Secret: "secret", Algorithm: HMAC_SHA256, FloatFormat: &FloatFormat{Shortest: true}}, params, "Shortest"},
+		{"参数名小写", Config{Secret: "secret", Algorithm: HMAC_SHA256, KeyCase: KeyLower}, params, "KeyLower"},
+		{"空值", Config{Secret: "secret", Algorithm: HMAC_SHA256, SkipEmptyValues: true}, params, "SkipEmptyValues"},
+		{"URL 编码", Config{Secret: "secret", Algorithm: HMAC_SHA256, URLEncode: URLEncodeAll}, map[string]interface{}{"q": "a b"}, "URLEncode"},
+		{"密钥拼接", Config{Secret: "secret", Algorithm: HMAC_SHA256, SecretJoin: SecretJoinNone}, params, "SecretJoin"},
+		{"算法", Config{Secret: "secret", Algorithm: MD5}, params, string(MD5)},
+		{"编码", Config{Secret: "secret", Algorithm: HMAC_SHA256, Encoding: EncodingBase64}, params, "Encoding"},
+		{"多余参数", Config{Secret: "secret", Algorithm: HMAC_SHA256, IgnoreKeys: []string{"Trace"}}, params, "参数 Trace"},
+	}
+	for _, tt := range tests {
+		signature, err := NewSignValidator(tt.partner).GenerateSignature(tt.params)
+		if err != nil {
+			t.Fatalf("%s: 生成签名失败: %v", tt.name, err)
+		}
+		m, err := v.ExplainMismatch(tt.params, signature)
+		if err != nil {
+			t.Fatalf("%s: 诊断失败: %v", tt.name, err)
+		}
+		if m.Valid {
+			t.Errorf("%s: 签名不应有效", tt.name)
+		}
+		if m.Details.Signature == "" || m.Details.StringToSign == "" {
+			t.Errorf("%s: 应返回期望的签名与待签名字符串", tt.name)
+		}
+		if !hasCause(m, tt.cause) {
+			t.Errorf("%s: 原因应包含 %q，实际 %v", tt.name, tt.cause, m.Causes)
+		}
+	}
+}
+
+func TestExplainMismatch_Valid(t *testing.T) {
+	v := NewSignValidator(Config{Secret: "secret"})
+	params := map[string]interface{}{"a": 1}
+	signature, _ := v.GenerateSignature(params)
+
+	m, err := v.ExplainMismatch(params, signature)
+	if err != nil {
+		t.Fatalf("诊断失败: %v", err)
+	}
+	if !m.Valid || len(m.Causes) != 0 {
+		t.Errorf("有效签名不应有原因，valid=%v causes=%v", m.Valid, m.Causes)
+	}
+
+	m, _ = v.ExplainMismatch(params, "0000")
+	if m.Valid || len(m.Causes) != 0 {
+		t.Errorf("无关的签名不应找到原因，causes=%v", m.Causes)
+	}
+}
+
+func TestExplainMismatch_PublicKeyOnly(t *testing.T) {
+	privateKeyPEM, publicKeyPEM, err := GenerateKeyPair(ED25519)
+	if err != nil {
+		t.Fatalf("生成密钥对失败: %v", err)
+	}
+	params := map[string]interface{}{"a": 1, "extra": "x"}
+	signature, err := NewSignValidator(Config{Algorithm: ED25519, PrivateKeyPEM: privateKeyPEM, IgnoreKeys: []string{"extra"}}).GenerateSignature(params)
+	if err != nil {
+		t.Fatalf("生成签名失败: %v", err)
+	}
+
+	m, err := NewSignValidator(Config{Algorithm: ED25519, PublicKeyPEM: publicKeyPEM}).ExplainMismatch(params, signature)
+	if err != nil {
+		t.Fatalf("仅持有公钥时也应能诊断: %v", err)
+	}
+	if m.Details.Signature != "" || m.Details.StringToSign != "a=1&extra=x" {
+		t.Errorf("应返回待签名字符串且签名为空，实际 %+v", m.Details)
+	}
+	if !hasCause(m, "参数 extra") {
+		t.Errorf("原因应包含多余参数，实际 %v", m.Causes)
+	}
+}
@@ -0,0 +1,138 @@
+package signvalidator
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrTooManyFailures 调用方在统计窗口内签名验证失败次数过多，请求被提前拒绝
+var ErrTooManyFailures = errors.New("签名验证失败次数过多")
+
+// defaultFailureWindow 默认的失败次数统计窗口
+const defaultFailureWindow = 10 * time.Minute
+
+// defaultFailureCapacity 默认最多跟踪的调用方数量
+const defaultFailureCapacity = 100000
+
+// FailureLimit 签名验证失败次数限制，用于阻止针对短密钥的暴力尝试
+type FailureLimit struct {
+	// Key 区分调用方的参数名（如 "app_id"），每个取值单独计数；为空时所有请求共用一个计数
+	Key string
+	// MaxFailures 统计窗口内允许的签名无效次数，达到后窗口结束前的请求直接返回 ErrTooManyFailures
+	MaxFailures int
+	// Window 统计窗口，从该调用方第一次失败开始计算，默认为 10 分钟
+	Window time.Duration
+	// Capacity 最多跟踪的调用方数量，超出时清理已过期的记录，仍然超出时不再跟踪新的调用方，默认为 100000
+	Capacity int
+	// OnLimit 调用方的失败次数达到 MaxFailures 时调用（每个窗口一次），key 为 Key 参数的值
+	OnLimit func(key string)
+}
+
+// failureTracker 记录各调用方在统计窗口内的失败次数，在验证器的副本之间共享
+type failureTracker struct {
+	mu      sync.Mutex
+	entries map[string]*failureEntry
+}
+
+// failureEntry 调用方的失败次数及统计窗口的开始时间
+type failureEntry struct {
+	count int
+	start time.Time
+}
+
+// checkFailureLimit 检查 FailureLimit 配置并创建失败次数记录
+func (v *SignValidator) checkFailureLimit() error {
+	if v.config.FailureLimit == nil {
+		return nil
+	}
+
+	config := *v.config.FailureLimit
+	switch {
+	case config.MaxFailures <= 0:
+		return errors.New("FailureLimit 的 MaxFailures 必须大于 0")
+	case config.Window < 0:
+		return errors.New("FailureLimit 的 Window 不能为负数")
+	}
+	if config.Window == 0 {
+		config.Window = defaultFailureWindow
+	}
+	if config.Capacity <= 0 {
+		config.Capacity = defaultFailureCapacity
+	}
+	v.config.FailureLimit = &config
+	v.failures = &failureTracker{entries: make(map[string]*failureEntry)}
+	return nil
+}
+
+// checkFailures 调用方在统计窗口内失败次数已达到上限时返回 ErrTooManyFailures，未配置 FailureLimit 时不检查
+func (v *SignValidator) checkFailures(params map[string]interface{}) error {
+	if v.failures == nil {
+		return nil
+	}
+	limit := v.config.FailureLimit
+	key := v.failureKey(params)
+
+	v.failures.mu.Lock()
+	entry, exists := v.failures.entries[key]
+	blocked := exists && entry.count >= limit.MaxFailures && v.now().Sub(entry.start) < limit.Window
+	v.failures.mu.Unlock()
+
+	if blocked {
+		return fmt.Errorf("%w: %s", ErrTooManyFailures, key)
+	}
+	return nil
+}
+
+// recordFailure 记录一次签名无效，失败次数达到上限时调用 OnLimit
+func (v *SignValidator) recordFailure(params map[string]interface{}) {
+	if v.failures == nil {
+		return
+	}
+	limit := v.config.FailureLimit
+	key := v.failureKey(params)
+	now := v.now()
+
+	v.failures.mu.Lock()
+	entry, exists := v.failures.entries[key]
+	if !exists || now.Sub(entry.start) >= limit.Window {
+		if !exists && len(v.failures.entries) >= limit.Capacity {
+			v.failures.purge(now, limit.Window)
+			if len(v.failures.entries) >= limit.Capacity {
+				v.failures.mu.Unlock()
+				return
+			}
+		}
+		entry = &failureEntry{start: now}
+		v.failures.entries[key] = entry
+	}
+	entry.count++
+	reached := entry.count == limit.MaxFailures
+	v.failures.mu.Unlock()
+
+	if reached && limit.OnLimit != nil {
+		limit.OnLimit(key)
+	}
+}
+
+// failureKey 返回 FailureLimit.Key 参数的值，未配置 Key 或参数缺失时为空字符串
+func (v *SignValidator) failureKey(params map[string]interface{}) string {
+	if v.config.FailureLimit.Key == "" {
+		return ""
+	}
+	value, exists := v.lookup(params, v.config.FailureLimit.Key)
+	if !exists || value == nil {
+		return ""
+	}
+	return convertToString(value)
+}
+
+// purge 删除统计窗口已结束的记录
+func (t *failureTracker) purge(now time.Time, window time.Duration) {
+	for key, entry := range t.entries {
+		if now.Sub(entry.start) >= window {
+			delete(t.entries, key)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package signvalidator
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestValidate_FailureLimit(t *testing.T) {
+	clock := NewFakeClock(time.Unix(1700000000, 0))
+	var limited []string
+	v := NewSignValidator(Config{
+		Secret: "secret",
+		Clock:  clock,
+		FailureLimit: &FailureLimit{
+			Key:         "app_id",
+			MaxFailures: 3,
+			Window:      time.Minute,
+			OnLimit:     func(key string) { limited = append(limited, key) },
+		},
+	})
+	params := map[string]interface{}{"app_id": "a", "order_id": "1001"}
+	signature, _ := v.GenerateSignature(params)
+
+	for i := 0; i < 3; i++ {
+		if valid, err := v.Validate(params, "bad"); err != nil || valid {
+			t.Fatalf("第 %d 次无效签名验证应失败且不返回错误，valid=%v err=%v", i+1, valid, err)
+		}
+	}
+	if len(limited) != 1 || limited[0] != "a" {
+		t.Errorf("达到上限时应调用一次 OnLimit，实际 %v", limited)
+	}
+
+	// 达到上限后正确的签名也被提前拒绝
+	if valid, err := v.Validate(params, signature); !errors.Is(err, ErrTooManyFailures) || valid {
+		t.Errorf("达到上限后应返回 ErrTooManyFailures，valid=%v err=%v", valid, err)
+	}
+
+	// 其他调用方不受影响
+	other := map[string]interface{}{"app_id": "b", "order_id": "1001"}
+	otherSignature, _ := v.GenerateSignature(other)
+	if valid, err := v.Validate(other, otherSignature); err != nil || !valid {
+		t.Errorf("其他调用方验证应通过，valid=%v err=%v", valid, err)
+	}
+
+	// 窗口结束后恢复
+	clock.Advance(time.Minute)
+	if valid, err := v.Validate(params, signature); err != nil || !valid {
+		t.Errorf("窗口结束后验证应通过，valid=%v err=%v", valid, err)
+	}
+	if _, err := v.ValidateStrings(map[string]string{"app_id": "a", "order_id": "1001"}, "bad"); err != nil {
+		t.Errorf("窗口结束后第一次失败不应被拒绝: %v", err)
+	}
+}
+
+func TestValidate_FailureLimitSharedCopies(t *testing.T) {
+	v := NewSignValidator(Config{Secret: "secret", Algorithm: HMAC_SHA256, FailureLimit: &FailureLimit{MaxFailures: 2}})
+	v.ValidateBody([]byte(`{"a":1}`), "bad")
+	v.Validate(map[string]interface{}{"a": "1"}, "bad")
+
+	body := []byte(`{"a":1}`)
+	signature, _ := v.GenerateBodySignature(body)
+	if _, err := v.ValidateBody(body, signature); !errors.Is(err, ErrTooManyFailures) {
+		t.Errorf("不同验证方式的失败次数应合并计算，实际 %v", err)
+	}
+}
+
+func TestFailureLimit_Invalid(t *testing.T) {
+	for _, limit := range []*FailureLimit{
+		{},
+		{MaxFailures: 1, Window: -time.Second},
+	} {
+		if _, err := New(Config{Secret: "secret", FailureLimit: limit}); err == nil {
+			t.Errorf("%+v: 应返回错误", limit)
+		}
+	}
+}
+
+func TestFailureLimit_Capacity(t *testing.T) {
+	clock := NewFakeClock(time.Unix(1700000000, 0))
+	v := NewSignValidator(Config{
+		Secret:       "secret",
+		Clock:        clock,
+		FailureLimit: &FailureLimit{Key: "app_id", MaxFailures: 1, Window: time.Minute, Capacity: 2},
+	})
+	for _, appID := range []string{"a", "b", "c"} {
+		v.Validate(map[string]interface{}{"app_id": appID}, "bad")
+	}
+	if len(v.failures.entries) != 2 {
+		t.Errorf("跟踪的调用方数量 = %d, 期望 2", len(v.failures.entries))
+	}
+
+	clock.Advance(time.Minute)
+	v.Validate(map[string]interface{}{"app_id": "c"}, "bad")
+	if _, exists := v.failures.entries["c"]; !exists || len(v.failures.entries) != 1 {
+		t.Errorf("超出容量时应清理过期记录，实际 %v", v.failures.entries)
+	}
+}
@@ -0,0 +1,265 @@
+package signvalidator
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// defaultSecretFileInterval 默认的密钥文件检查间隔
+const defaultSecretFileInterval = 5 * time.Second
+
+// FileSecretConfig 密钥文件配置
+type FileSecretConfig struct {
+	// Path 密钥文件路径，内容为密钥标识到密钥的映射；扩展名为 .yaml 或 .yml 时按 YAML（仅支持 "kid: secret" 形式的单层映射）解析，
+	// 否则按 JSON 对象解析
+	Path string
+	// Interval 轮询文件内容是否变化的间隔，默认为 5 秒
+	Interval time.Duration
+	// OnError 重新加载失败时调用，此时继续使用原有的密钥
+	OnError func(err error)
+}
+
+// FileSecretSource 从文件加载密钥环，并按 Interval 轮询文件内容的 SHA-256 摘要（不使用 fsnotify/inotify 以避免外部依赖），
+// 内容变化时重新加载并原子地替换密钥环，轮换密钥无需重启进程；比较内容而不是修改时间与大小，
+// 修改时间精度不足或大小不变的改写同样能被检测到，文件被整体替换（如 Kubernetes Secret 挂载的符号链接切换）时同样生效
+type FileSecretSource struct {
+	config FileSecretConfig
+	keys   atomic.Pointer[map[string]string]
+
+	mu  sync.Mutex
+	sum [sha256.Size]byte
+
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// NewFileSecretSource 加载密钥文件并开始检查文件变化，首次加载失败时返回错误
+func NewFileSecretSource(config FileSecretConfig) (*FileSecretSource, error) {
+	switch {
+	case config.Path == "":
+		return nil, errors.New("密钥文件路径不能为空")
+	case config.Interval < 0:
+		return nil, errors.New("密钥文件检查间隔不能为负数")
+	}
+	if config.Interval == 0 {
+		config.Interval = defaultSecretFileInterval
+	}
+
+	s := &FileSecretSource{
+		config: config,
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	if err := s.Reload(); err != nil {
+		return nil, err
+	}
+	go s.watch()
+	return s, nil
+}
+
+// Secret 返回密钥标识对应的密钥
+func (s *FileSecretSource) Secret(kid string) (string, bool) {
+	secret, exists := (*s.keys.Load())[kid]
+	return secret, exists
+}
+
+// Len 返回当前密钥环中的密钥数量
+func (s *FileSecretSource) Len() int {
+	return len(*s.keys.Load())
+}
+
+// SecretProvider 返回按 keyIDKey 参数从当前密钥环选择密钥的 SecretProvider，
+// 参数名的大小写规则与调用方验证器的 CaseInsensitiveKeys 一致；参数缺失时返回 *MissingKeysError，密钥标识不在文件中时返回 *UnknownKeyIDError
+func (s *FileSecretSource) SecretProvider(keyIDKey string) SecretProvider {
+	return func(ctx context.Context, params map[string]interface{}) (string, error) {
+		value, exists := lookupContext(ctx, params, keyIDKey)
+		if !exists || value == nil || value == "" {
+			return "", &MissingKeysError{Keys: []string{keyIDKey}}
+		}
+		kid := convertToString(value)
+		secret, exists := s.Secret(kid)
+		if !exists {
+			return "", &UnknownKeyIDError{KeyID: kid}
+		}
+		return secret, nil
+	}
+}
+
+// Reload 立即重新加载密钥文件，失败时继续使用原有的密钥
+func (s *FileSecretSource) Reload() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := os.ReadFile(s.config.Path)
+	if err != nil {
+		return fmt.Errorf("读取密钥文件失败: %w", err)
+	}
+	return s.loadLocked(data)
+}
+
+// Close 停止检查文件变化
+func (s *FileSecretSource) Close() error {
+	s.closeOnce.Do(func() {
+		close(s.stop)
+		<-s.done
+	})
+	return nil
+}
+
+// watch 定期检查文件内容，变化时重新加载
+func (s *FileSecretSource) watch() {
+	defer close(s.done)
+	ticker := time.NewTicker(s.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+			if err := s.reloadIfChanged(); err != nil && s.config.OnError != nil {
+				s.config.OnError(err)
+			}
+		}
+	}
+}
+
+// reloadIfChanged 文件内容的摘要变化时重新加载
+func (s *FileSecretSource) reloadIfChanged() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := os.ReadFile(s.config.Path)
+	if err != nil {
+		return fmt.Errorf("读取密钥文件失败: %w", err)
+	}
+	if sha256.Sum256(data) == s.sum {
+		return nil
+	}
+	return s.loadLocked(data)
+}
+
+// loadLocked 解析密钥文件内容并替换密钥环，调用方需持有 s.mu
+func (s *FileSecretSource) loadLocked(data []byte) error {
+	var (
+		keys map[string]string
+		err  error
+	)
+	switch strings.ToLower(filepath.Ext(s.config.Path)) {
+	case ".yaml", ".yml":
+		keys, err = parseSecretYAML(data)
+	default:
+		keys, err = parseSecretJSON(data)
+	}
+	if err != nil {
+		return fmt.Errorf("解析密钥文件 %s 失败: %w", s.config.Path, err)
+	}
+	if len(keys) == 0 {
+		return fmt.Errorf("密钥文件 %s 中没有密钥", s.config.Path)
+	}
+	for kid, secret := range keys {
+		if secret == "" {
+			return fmt.Errorf("密钥文件 %s 中密钥标识 %s 的密钥为空", s.config.Path, kid)
+		}
+	}
+
+	s.keys.Store(&keys)
+	s.sum = sha256.Sum256(data)
+	return nil
+}
+
+// parseSecretJSON 解析 {"kid": "secret"} 形式的 JSON 对象
+func parseSecretJSON(data []byte) (map[string]string, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	var keys map[string]string
+	if err := decoder.Decode(&keys); err != nil {
+		return nil, err
+	}
+	if decoder.More() {
+		return nil, errors.New("JSON 对象之后存在多余内容")
+	}
+	return keys, nil
+}
+
+// parseSecretYAML 解析 "kid: secret" 形式的单层 YAML 映射，支持注释与单双引号
+func parseSecretYAML(data []byte) (map[string]string, error) {
+	keys := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || text == "---" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		if strings.HasPrefix(scanner.Text(), " ") || strings.HasPrefix(scanner.Text(), "\t") {
+			return nil, fmt.Errorf("第 %d 行: 仅支持单层映射", line)
+		}
+
+		key, value, ok := strings.Cut(text, ":")
+		if !ok {
+			return nil, fmt.Errorf("第 %d 行: 缺少 \":\"", line)
+		}
+		kid, err := unquoteYAML(strings.TrimSpace(key))
+		if err != nil {
+			return nil, fmt.Errorf("第 %d 行: %w", line, err)
+		}
+		secret, err := unquoteYAML(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("第 %d 行: %w", line, err)
+		}
+		if kid == "" {
+			return nil, fmt.Errorf("第 %d 行: 密钥标识为空", line)
+		}
+		if _, exists := keys[kid]; exists {
+			return nil, fmt.Errorf("第 %d 行: 密钥标识重复: %s", line, kid)
+		}
+		keys[kid] = secret
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// unquoteYAML 去除 YAML 标量的引号，未加引号时去除行尾注释；锚点、别名、块标量与流式集合等不支持的语法返回错误，
+// 避免将其字面文本当作密钥
+func unquoteYAML(s string) (string, error) {
+	switch {
+	case s != "" && strings.ContainsRune("|>&*{[", rune(s[0])):
+		return "", fmt.Errorf("不支持的 YAML 语法: %s", s)
+	case strings.HasPrefix(s, `"`):
+		end := strings.LastIndex(s, `"`)
+		if end == 0 {
+			return "", fmt.Errorf("引号未闭合: %s", s)
+		}
+		if rest := strings.TrimSpace(s[end+1:]); rest != "" && !strings.HasPrefix(rest, "#") {
+			return "", fmt.Errorf("引号之后存在多余内容: %s", s)
+		}
+		return strconv.Unquote(s[:end+1])
+	case strings.HasPrefix(s, "'"):
+		end := strings.LastIndex(s, "'")
+		if end == 0 {
+			return "", fmt.Errorf("引号未闭合: %s", s)
+		}
+		if rest := strings.TrimSpace(s[end+1:]); rest != "" && !strings.HasPrefix(rest, "#") {
+			return "", fmt.Errorf("引号之后存在多余内容: %s", s)
+		}
+		return strings.ReplaceAll(s[1:end], "''", "'"), nil
+	}
+	if i := strings.Index(s, " #"); i >= 0 {
+		s = strings.TrimSpace(s[:i])
+	}
+	return s, nil
+}
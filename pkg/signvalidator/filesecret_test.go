@@ -0,0 +1,183 @@
+package signvalidator
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeSecretFile 写入密钥文件，并将修改时间设置为 modTime 以确保变化可被检测
+func writeSecretFile(t *testing.T, path, content string, modTime time.Time) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFileSecretSource(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "secrets.json")
+	start := time.Unix(1700000000, 0)
+	writeSecretFile(t, path, `{"k1": "secret-1"}`, start)
+
+	source, err := NewFileSecretSource(FileSecretConfig{Path: path, Interval: time.Hour})
+	if err != nil {
+		t.Fatalf("加载密钥文件失败: %v", err)
+	}
+	defer source.Close()
+
+	v := NewSignValidator(Config{Algorithm: HMAC_SHA256, SecretProvider: source.SecretProvider("kid")})
+	params := map[string]interface{}{"kid": "k2", "order_id": "1001"}
+	signature, _ := NewSignValidator(Config{Algorithm: HMAC_SHA256, Secret: "secret-2"}).GenerateSignature(params)
+	if _, err := v.Validate(params, signature); !errors.Is(err, ErrUnknownKeyID) {
+		t.Errorf("文件中没有的密钥标识应返回 ErrUnknownKeyID，实际 %v", err)
+	}
+
+	writeSecretFile(t, path, `{"k1": "secret-1", "k2": "secret-2"}`, start.Add(time.Second))
+	if err := source.reloadIfChanged(); err != nil {
+		t.Fatalf("重新加载失败: %v", err)
+	}
+	if valid, err := v.Validate(params, signature); err != nil || !valid {
+		t.Errorf("重新加载后验证应通过，valid=%v err=%v", valid, err)
+	}
+
+	// 无效内容不替换原有密钥
+	writeSecretFile(t, path, `{"k1": `, start.Add(2*time.Second))
+	if err := source.reloadIfChanged(); err == nil {
+		t.Error("无效的密钥文件应返回错误")
+	}
+	if source.Len() != 2 {
+		t.Errorf("加载失败后密钥数量 = %d, 应保持 2", source.Len())
+	}
+
+	// 修改时间与大小都不变的改写同样应被检测到
+	writeSecretFile(t, path, `{"k1": "secret-1", "k2": "secret-3"}`, start.Add(time.Second))
+	if err := source.reloadIfChanged(); err != nil {
+		t.Fatalf("重新加载失败: %v", err)
+	}
+	if secret, _ := source.Secret("k2"); secret != "secret-3" {
+		t.Errorf("修改时间与大小不变时应按内容重新加载，k2 = %q", secret)
+	}
+
+	if _, err := v.Validate(map[string]interface{}{"order_id": "1001"}, signature); !errors.Is(err, ErrMissingKeys) {
+		t.Errorf("缺少密钥标识应返回 ErrMissingKeys，实际 %v", err)
+	}
+}
+
+func TestFileSecretSource_CaseInsensitiveKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "secrets.json")
+	writeSecretFile(t, path, `{"k1": "secret-1"}`, time.Unix(1700000000, 0))
+	source, err := NewFileSecretSource(FileSecretConfig{Path: path, Interval: time.Hour})
+	if err != nil {
+		t.Fatalf("加载密钥文件失败: %v", err)
+	}
+	defer source.Close()
+
+	params := map[string]interface{}{"KID": "k1", "order_id": "1001"}
+	signature, _ := NewSignValidator(Config{Algorithm: HMAC_SHA256, Secret: "secret-1"}).GenerateSignature(params)
+	v := NewSignValidator(Config{Algorithm: HMAC_SHA256, SecretProvider: source.SecretProvider("kid"), CaseInsensitiveKeys: true})
+	if valid, err := v.Validate(params, signature); err != nil || !valid {
+		t.Errorf("开启 CaseInsensitiveKeys 后应按大小写不同的密钥标识参数查找密钥: valid=%v, err=%v", valid, err)
+	}
+
+	strict := NewSignValidator(Config{Algorithm: HMAC_SHA256, SecretProvider: source.SecretProvider("kid")})
+	if _, err := strict.Validate(params, signature); !errors.Is(err, ErrMissingKeys) {
+		t.Errorf("未开启 CaseInsensitiveKeys 时应返回 ErrMissingKeys，实际 %v", err)
+	}
+}
+
+func TestFileSecretSource_Watch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "secrets.json")
+	start := time.Unix(1700000000, 0)
+	writeSecretFile(t, path, `{"k1": "secret-1"}`, start)
+
+	errs := make(chan error, 10)
+	source, err := NewFileSecretSource(FileSecretConfig{
+		Path:     path,
+		Interval: 5 * time.Millisecond,
+		OnError:  func(err error) { errs <- err },
+	})
+	if err != nil {
+		t.Fatalf("加载密钥文件失败: %v", err)
+	}
+	defer source.Close()
+
+	// 整体替换文件
+	next := path + ".tmp"
+	writeSecretFile(t, next, `{"k1": "rotated"}`, start.Add(time.Second))
+	if err := os.Rename(next, path); err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		if secret, _ := source.Secret("k1"); secret == "rotated" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("文件变化后应自动重新加载")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	os.Remove(path)
+	select {
+	case <-errs:
+	case <-time.After(2 * time.Second):
+		t.Error("文件被删除时应调用 OnError")
+	}
+	if secret, _ := source.Secret("k1"); secret != "rotated" {
+		t.Error("加载失败时应继续使用原有的密钥")
+	}
+}
+
+func TestFileSecretSource_YAML(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "secrets.yaml")
+	writeSecretFile(t, path, `---
+# 当前密钥
+"2024-06": "s3cr3t: with colon"
+2024-01: 'it''s old' # 旧密钥
+plain: abc # 注释
+quoted: "*not-an-alias"
+`, time.Now())
+
+	source, err := NewFileSecretSource(FileSecretConfig{Path: path})
+	if err != nil {
+		t.Fatalf("加载 YAML 密钥文件失败: %v", err)
+	}
+	defer source.Close()
+	for kid, expected := range map[string]string{"2024-06": "s3cr3t: with colon", "2024-01": "it's old", "plain": "abc", "quoted": "*not-an-alias"} {
+		if secret, _ := source.Secret(kid); secret != expected {
+			t.Errorf("%s 的密钥 = %q, 期望 %q", kid, secret, expected)
+		}
+	}
+}
+
+func TestFileSecretSource_Invalid(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"empty.json":     `{}`,
+		"blank.json":     `{"a": ""}`,
+		"nested.yaml":    "a:\n  b: c\n",
+		"duplicate.yaml": "a: 1\na: 2\n",
+		"noColon.yml":    "abc\n",
+		"block.yaml":     "a: |\n  secret\n",
+		"folded.yaml":    "a: >-\n  secret\n",
+		"anchor.yaml":    "a: &base secret\n",
+		"alias.yaml":     "a: secret\nb: *base\n",
+		"flowMap.yaml":   "a: {b: c}\n",
+		"flowList.yaml":  "a: [b, c]\n",
+	} {
+		path := filepath.Join(dir, name)
+		writeSecretFile(t, path, content, time.Now())
+		if _, err := NewFileSecretSource(FileSecretConfig{Path: path}); err == nil {
+			t.Errorf("%s: 应返回错误", name)
+		}
+	}
+	if _, err := NewFileSecretSource(FileSecretConfig{Path: filepath.Join(dir, "missing.json")}); err == nil {
+		t.Error("文件不存在时应返回错误")
+	}
+}
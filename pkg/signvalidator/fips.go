@@ -0,0 +1,38 @@
+package signvalidator
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrFIPSNotApproved FIPS 模式下使用了未经 FIPS 批准的算法
+var ErrFIPSNotApproved = errors.New("FIPS 模式下不允许使用该算法")
+
+// FIPSBuild 是否为使用 fips 构建标签编译的版本，为 true 时始终启用 FIPS 模式
+const FIPSBuild = fipsBuild
+
+// IsFIPSApproved 判断签名算法是否为 FIPS 批准的算法（FIPS 180-4、FIPS 186-5、FIPS 198-1、FIPS 202 及 SP 800-38B）
+func IsFIPSApproved(algorithm SignAlgorithm) bool {
+	switch algorithm {
+	case SHA256, SHA3_256, SHA3_512,
+		HMAC_SHA256, HMAC_SHA3_256, HMAC_SHA3_512,
+		RSA_SHA256, RSA_PSS_SHA256, RSA_PSS_SHA512,
+		ECDSA_P256_SHA256, ECDSA_P384_SHA384, ED25519,
+		CMAC_AES128, CMAC_AES256:
+		return true
+	}
+	return false
+}
+
+// fips 判断是否启用 FIPS 模式
+func (v *SignValidator) fips() bool {
+	return FIPSBuild || v.config.FIPS
+}
+
+// checkFIPS FIPS 模式下检查算法是否经过批准
+func (v *SignValidator) checkFIPS(algorithm SignAlgorithm) error {
+	if v.fips() && !IsFIPSApproved(algorithm) {
+		return fmt.Errorf("%w: %s", ErrFIPSNotApproved, algorithm)
+	}
+	return nil
+}
@@ -0,0 +1,5 @@
+//go:build fips
+
+package signvalidator
+
+const fipsBuild = true
@@ -0,0 +1,75 @@
+package signvalidator
+
+import (
+	"errors"
+	"testing"
+)
+
+// skipFIPS fips 构建下跳过依赖未经批准算法的测试
+func skipFIPS(t *testing.T) {
+	t.Helper()
+	if FIPSBuild {
+		t.Skip("fips 构建下不允许使用未经 FIPS 批准的算法")
+	}
+}
+
+func TestFIPS_RejectNotApproved(t *testing.T) {
+	for _, algorithm := range []SignAlgorithm{MD5, SHA1, HMAC_MD5, HMAC_SHA1, SM3, HMAC_SM3, BLAKE3, CHACHA20_POLY1305} {
+		if _, err := New(Config{Secret: "testSecret", Algorithm: algorithm, FIPS: true}); !errors.Is(err, ErrFIPSNotApproved) {
+			t.Errorf("%s 错误 = %v, 期望 ErrFIPSNotApproved", algorithm, err)
+		}
+	}
+
+	// 非批准算法同样不能作为摘要轮次、候选算法或 KDF 摘要算法
+	testCases := []Config{
+		{Secret: "testSecret", Algorithm: SHA256, Rounds: []DigestRound{{Algorithm: MD5}}},
+		{Secret: "testSecret", Algorithm: HMAC_SHA256, AcceptAlgorithms: []SignAlgorithm{HMAC_SHA256, HMAC_SHA1}},
+		{Secret: "testSecret", Algorithm: HMAC_SHA256, HKDF: &HKDF{Hash: SM3}},
+		{Secret: "testSecret", Algorithm: HMAC_SHA256, Stretch: &SecretStretch{Method: StretchScrypt}},
+	}
+	for i, config := range testCases {
+		config.FIPS = true
+		if _, err := New(config); !errors.Is(err, ErrFIPSNotApproved) {
+			t.Errorf("用例 %d 错误 = %v, 期望 ErrFIPSNotApproved", i, err)
+		}
+	}
+}
+
+func TestFIPS_Approved(t *testing.T) {
+	validator, err := New(Config{
+		Secret:            "testSecret",
+		Algorithm:         HMAC_SHA256,
+		AlgorithmKey:      "sign_type",
+		AllowedAlgorithms: []SignAlgorithm{HMAC_SHA256, HMAC_SHA3_256},
+		FIPS:              true,
+	})
+	if err != nil {
+		t.Fatalf("创建验证器失败: %v", err)
+	}
+
+	params := map[string]interface{}{"id": 123, "sign_type": "hmac_sha3_256"}
+	signature, err := validator.GenerateSignature(params)
+	if err != nil {
+		t.Fatalf("生成签名失败: %v", err)
+	}
+	valid, err := validator.Validate(params, signature)
+	if err != nil {
+		t.Fatalf("验证签名失败: %v", err)
+	}
+	if !valid {
+		t.Errorf("签名验证失败")
+	}
+}
+
+func TestIsFIPSApproved(t *testing.T) {
+	for _, algorithm := range []SignAlgorithm{SHA256, HMAC_SHA256, RSA_PSS_SHA256, ECDSA_P256_SHA256, ED25519, CMAC_AES128} {
+		if !IsFIPSApproved(algorithm) {
+			t.Errorf("%s 应该是 FIPS 批准的算法", algorithm)
+		}
+	}
+	for _, algorithm := range []SignAlgorithm{MD5, SHA1, HMAC_SHA1, SM2, BLAKE2B_256, CRC32C} {
+		if IsFIPSApproved(algorithm) {
+			t.Errorf("%s 不应该是 FIPS 批准的算法", algorithm)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package signvalidator
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+)
+
+// errNotForm 请求体不是 application/x-www-form-urlencoded 表单
+var errNotForm = errors.New("请求体不是 application/x-www-form-urlencoded 表单")
+
+// FormParams 读取 application/x-www-form-urlencoded 请求体并转换为签名参数（规则与 GenerateSignatureValues 相同），
+// 读取后恢复请求体，后续处理仍可调用 r.ParseForm；请求体已被 r.ParseForm 读取时使用 r.PostForm。
+// 请求体超过 DefaultMaxBodyBytes 时返回 ErrBodyTooLarge
+func FormParams(r *http.Request) (map[string]interface{}, error) {
+	return formParams(r, DefaultMaxBodyBytes)
+}
+
+// formParams 同 FormParams，请求体最多读取 limit 字节
+func formParams(r *http.Request, limit int64) (map[string]interface{}, error) {
+	values, err := readForm(r, limit)
+	if err != nil {
+		return nil, err
+	}
+	return multiValueParams(values), nil
+}
+
+// GenerateFormSignature 对 HTTP 请求的表单请求体生成签名
+func (v *SignValidator) GenerateFormSignature(r *http.Request) (string, error) {
+	if v.err != nil {
+		return "", v.err
+	}
+	params, err := formParams(r, v.config.MaxBodyBytes)
+	if err != nil {
+		return "", err
+	}
+	return v.GenerateSignature(params)
+}
+
+// ValidateForm 读取表单请求体并验证其中签名参数（SignatureKey）的签名，读取后恢复请求体
+func (v *SignValidator) ValidateForm(r *http.Request) (bool, error) {
+	if v.err != nil {
+		return false, v.err
+	}
+	params, err := formParams(r, v.config.MaxBodyBytes)
+	if err != nil {
+		return false, err
+	}
+	return v.withIncoming(r).ValidateWithSignInParams(params)
+}
+
+// readForm 读取并解析表单请求体，请求体最多读取 limit 字节
+func readForm(r *http.Request, limit int64) (url.Values, error) {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mediaType != "application/x-www-form-urlencoded" {
+		return nil, errNotForm
+	}
+
+	body, err := readBody(r, limit)
+	if err != nil {
+		return nil, err
+	}
+	if len(body) == 0 && r.PostForm != nil {
+		return r.PostForm, nil
+	}
+
+	values, err := url.ParseQuery(string(body))
+	if err != nil {
+		return nil, fmt.Errorf("解析表单失败: %w", err)
+	}
+	return values, nil
+}
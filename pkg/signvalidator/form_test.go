@@ -0,0 +1,83 @@
+package signvalidator
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func newFormRequest(body string) *http.Request {
+	r := httptest.NewRequest("POST", "/notify", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+	return r
+}
+
+func TestFormParams(t *testing.T) {
+	r := newFormRequest("a=1&b=x+y&c=1&c=2&d=")
+	params, err := FormParams(r)
+	if err != nil {
+		t.Fatalf("解析表单失败: %v", err)
+	}
+	if params["a"] != "1" || params["b"] != "x y" || params["d"] != "" {
+		t.Errorf("参数不正确: %v", params)
+	}
+	if c, ok := params["c"].([]string); !ok || len(c) != 2 {
+		t.Errorf("重复参数应为 []string，实际 %#v", params["c"])
+	}
+
+	// 请求体应被恢复，后续处理可继续解析表单
+	if err := r.ParseForm(); err != nil {
+		t.Fatalf("恢复后解析表单失败: %v", err)
+	}
+	if r.PostForm.Get("b") != "x y" {
+		t.Errorf("请求体未被恢复，PostForm=%v", r.PostForm)
+	}
+
+	// 请求体已被 ParseForm 读取时使用 PostForm
+	params, err = FormParams(r)
+	if err != nil || params["a"] != "1" {
+		t.Errorf("应使用已解析的 PostForm，params=%v err=%v", params, err)
+	}
+}
+
+func TestFormParams_NotForm(t *testing.T) {
+	r := httptest.NewRequest("POST", "/notify", strings.NewReader(`{"a":1}`))
+	r.Header.Set("Content-Type", "application/json")
+	if _, err := FormParams(r); !errors.Is(err, errNotForm) {
+		t.Errorf("非表单请求应返回 errNotForm，实际 %v", err)
+	}
+
+	r = newFormRequest("a=%zz")
+	if _, err := FormParams(r); err == nil {
+		t.Error("无效的表单应返回错误")
+	}
+}
+
+func TestValidateForm(t *testing.T) {
+	v := NewSignValidator(Config{Secret: "secret", Algorithm: HMAC_SHA256})
+	values := url.Values{"order_id": {"1001"}, "amount": {"9.90"}, "tag": {"a", "b"}}
+
+	r := newFormRequest(values.Encode())
+	signature, err := v.GenerateFormSignature(r)
+	if err != nil {
+		t.Fatalf("生成签名失败: %v", err)
+	}
+	want, _ := v.GenerateSignatureValues(values)
+	if signature != want {
+		t.Errorf("表单签名应与 GenerateSignatureValues 一致，期望 %s，实际 %s", want, signature)
+	}
+
+	values.Set("sign", signature)
+	valid, err := v.ValidateForm(newFormRequest(values.Encode()))
+	if err != nil || !valid {
+		t.Errorf("签名验证应通过，valid=%v err=%v", valid, err)
+	}
+
+	values.Set("amount", "0.01")
+	if valid, _ := v.ValidateForm(newFormRequest(values.Encode())); valid {
+		t.Error("参数修改后签名验证不应通过")
+	}
+}
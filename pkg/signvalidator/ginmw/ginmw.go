@@ -0,0 +1,80 @@
+// Package ginmw 提供验证请求签名的 gin 中间件：验证通过的参数与密钥标识保存在 gin.Context 中，
+// 验证失败时以可配置的状态码与 JSON 响应中止请求。该包是独立的 Go 模块，核心包不依赖 gin。
+package ginmw
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/huangchunlong818/sign-chao/pkg/signvalidator"
+	"github.com/huangchunlong818/sign-chao/pkg/signvalidator/httpmw"
+)
+
+const (
+	// ParamsKey gin.Context 中保存验证通过的参数（map[string]interface{}）的键
+	ParamsKey = "signvalidator.params"
+	// KeyIDKey gin.Context 中保存请求密钥标识（string）的键
+	KeyIDKey = "signvalidator.key_id"
+)
+
+// Config 中间件配置
+type Config struct {
+	// Validator 签名验证器
+	Validator *signvalidator.SignValidator
+	// SignatureHeader 签名请求头（如 "X-Signature"），为空时从参数中的签名参数（SignatureKey）读取
+	SignatureHeader string
+	// AbortStatus 验证失败时的状态码，默认为 401；请求体超过 MaxBodyBytes（signvalidator.ErrBodyTooLarge）时始终为 413
+	AbortStatus int
+	// ErrorBody 返回验证失败时的 JSON 响应体，默认为 {"code":401,"message":"签名无效"}；
+	// 签名不匹配时 err 为 httpmw.ErrInvalidSignature，其他情况为验证或读取参数返回的错误
+	ErrorBody func(err error) interface{}
+}
+
+// New 检查配置并返回 gin 中间件，验证通过的请求继续交给后续处理器，请求体保持可读
+func New(config Config) (gin.HandlerFunc, error) {
+	if config.Validator == nil {
+		return nil, errors.New("签名验证器为空")
+	}
+	if config.AbortStatus == 0 {
+		config.AbortStatus = http.StatusUnauthorized
+	}
+	if config.ErrorBody == nil {
+		config.ErrorBody = func(err error) interface{} {
+			if errors.Is(err, signvalidator.ErrBodyTooLarge) {
+				return gin.H{"code": http.StatusRequestEntityTooLarge, "message": "请求体过大"}
+			}
+			return gin.H{"code": config.AbortStatus, "message": httpmw.ErrInvalidSignature.Error()}
+		}
+	}
+
+	return func(c *gin.Context) {
+		params, err := httpmw.Verify(c.Request, config.Validator, config.SignatureHeader)
+		if err != nil {
+			status := config.AbortStatus
+			if errors.Is(err, signvalidator.ErrBodyTooLarge) {
+				status = http.StatusRequestEntityTooLarge
+			}
+			c.AbortWithStatusJSON(status, config.ErrorBody(err))
+			return
+		}
+		c.Set(ParamsKey, params)
+		c.Set(KeyIDKey, config.Validator.KeyID(params, c.Request))
+		c.Next()
+	}, nil
+}
+
+// Params 返回中间件保存在 gin.Context 中的验证通过的参数
+func Params(c *gin.Context) (map[string]interface{}, bool) {
+	value, exists := c.Get(ParamsKey)
+	if !exists {
+		return nil, false
+	}
+	params, ok := value.(map[string]interface{})
+	return params, ok
+}
+
+// KeyID 返回中间件保存在 gin.Context 中的密钥标识，请求未携带密钥标识时为空字符串
+func KeyID(c *gin.Context) string {
+	return c.GetString(KeyIDKey)
+}
@@ -0,0 +1,123 @@
+package ginmw
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/huangchunlong818/sign-chao/pkg/signvalidator"
+	"github.com/huangchunlong818/sign-chao/pkg/signvalidator/httpmw"
+)
+
+func init() {
+	gin.SetMode(gin.TestMode)
+}
+
+func newEngine(t *testing.T, config Config, handler gin.HandlerFunc) *gin.Engine {
+	t.Helper()
+	middleware, err := New(config)
+	if err != nil {
+		t.Fatalf("创建中间件失败: %v", err)
+	}
+	engine := gin.New()
+	engine.Use(middleware)
+	engine.Any("/orders", handler)
+	return engine
+}
+
+func TestMiddleware(t *testing.T) {
+	keys := map[string]string{"k1": "secret-1", "k2": "secret-2"}
+	validator, err := signvalidator.New(signvalidator.Config{Algorithm: signvalidator.HMAC_SHA256, Keys: keys, KeyID: "k2"})
+	if err != nil {
+		t.Fatalf("创建签名验证器失败: %v", err)
+	}
+	signed, err := validator.SignRequest(map[string]interface{}{"app_id": "a", "amount": "100"})
+	if err != nil {
+		t.Fatalf("签名请求失败: %v", err)
+	}
+
+	var params map[string]interface{}
+	var kid string
+	engine := newEngine(t, Config{Validator: validator}, func(c *gin.Context) {
+		params, _ = Params(c)
+		kid = KeyID(c)
+		c.Status(http.StatusNoContent)
+	})
+
+	body, _ := json.Marshal(signed)
+	r := httptest.NewRequest("POST", "/orders", strings.NewReader(string(body)))
+	r.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, r)
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("签名有效时状态码 = %d, 期望 204: %s", w.Code, w.Body.String())
+	}
+	if params["amount"] != "100" || kid != "k2" {
+		t.Errorf("上下文中的参数 = %v, 密钥标识 = %s", params, kid)
+	}
+
+	signed["amount"] = "999"
+	body, _ = json.Marshal(signed)
+	r = httptest.NewRequest("POST", "/orders", strings.NewReader(string(body)))
+	r.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	engine.ServeHTTP(w, r)
+	if w.Code != http.StatusUnauthorized || w.Body.String() != `{"code":401,"message":"签名无效"}` {
+		t.Errorf("签名无效时应返回默认响应: %d %s", w.Code, w.Body.String())
+	}
+}
+
+func TestMiddleware_ErrorBody(t *testing.T) {
+	validator, err := signvalidator.New(signvalidator.Config{Secret: "testSecret", Algorithm: signvalidator.HMAC_SHA256})
+	if err != nil {
+		t.Fatalf("创建签名验证器失败: %v", err)
+	}
+	called := false
+	engine := newEngine(t, Config{
+		Validator:       validator,
+		SignatureHeader: "X-Signature",
+		AbortStatus:     http.StatusForbidden,
+		ErrorBody: func(err error) interface{} {
+			return gin.H{"errcode": 40001, "invalid": errors.Is(err, httpmw.ErrInvalidSignature)}
+		},
+	}, func(c *gin.Context) { called = true })
+
+	r := httptest.NewRequest("GET", "/orders?a=1", nil)
+	r.Header.Set("X-Signature", "bad")
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, r)
+	if called {
+		t.Error("签名无效时不应调用后续处理器")
+	}
+	if w.Code != http.StatusForbidden || w.Body.String() != `{"errcode":40001,"invalid":true}` {
+		t.Errorf("应返回自定义响应: %d %s", w.Code, w.Body.String())
+	}
+}
+
+func TestNew_NilValidator(t *testing.T) {
+	if _, err := New(Config{}); err == nil {
+		t.Error("验证器为空时应返回错误")
+	}
+}
+
+func TestMiddleware_BodyTooLarge(t *testing.T) {
+	validator, err := signvalidator.New(signvalidator.Config{Secret: "testSecret", Algorithm: signvalidator.HMAC_SHA256, MaxBodyBytes: 8})
+	if err != nil {
+		t.Fatalf("创建签名验证器失败: %v", err)
+	}
+	engine := newEngine(t, Config{Validator: validator}, func(c *gin.Context) {
+		t.Error("请求体过大时不应调用后续处理器")
+	})
+
+	r := httptest.NewRequest("POST", "/orders", strings.NewReader(`{"amount":"100"}`))
+	r.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, r)
+	if w.Code != http.StatusRequestEntityTooLarge || w.Body.String() != `{"code":413,"message":"请求体过大"}` {
+		t.Errorf("请求体过大时应返回 413: %d %s", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,59 @@
+package grpcmw
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+)
+
+// sign 按服务端相同的规则组成参数，经 SignRequestDetached 补充时间戳、随机数与密钥标识后生成签名，
+// 返回写入签名与补充参数的上下文
+func (c *Config) sign(ctx context.Context, method string, req interface{}) (context.Context, error) {
+	params, err := c.params(nil, method, req)
+	if err != nil {
+		return nil, c.fail(ctx, codes.Internal, msgSign, method, err)
+	}
+	signed, signature, err := c.Validator.SignRequestDetached(params)
+	if err != nil {
+		return nil, c.fail(ctx, codes.Internal, msgSign, method, err)
+	}
+
+	pairs := []string{c.SignatureMetadata, signature}
+	for key, name := range c.Metadata {
+		if value, exists := signed[name]; exists {
+			pairs = append(pairs, key, fmt.Sprint(value))
+		}
+	}
+	return metadata.AppendToOutgoingContext(ctx, pairs...), nil
+}
+
+// UnaryClientInterceptor 返回为一元调用自动签名的客户端拦截器，签名参数与 UnaryServerInterceptor 一致
+func UnaryClientInterceptor(config Config) (grpc.UnaryClientInterceptor, error) {
+	if err := config.setDefaults(); err != nil {
+		return nil, err
+	}
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		ctx, err := config.sign(ctx, method, req)
+		if err != nil {
+			return err
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}, nil
+}
+
+// StreamClientInterceptor 返回在建立流时自动签名的客户端拦截器，签名参数与 StreamServerInterceptor 一致（不包含消息内容）
+func StreamClientInterceptor(config Config) (grpc.StreamClientInterceptor, error) {
+	if err := config.setDefaults(); err != nil {
+		return nil, err
+	}
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		ctx, err := config.sign(ctx, method, nil)
+		if err != nil {
+			return nil, err
+		}
+		return streamer(ctx, desc, cc, method, opts...)
+	}, nil
+}
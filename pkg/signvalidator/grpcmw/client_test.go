@@ -0,0 +1,153 @@
+package grpcmw
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/huangchunlong818/sign-chao/pkg/signvalidator"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// echoServer 回显请求的一元方法与返回一条消息的服务端流方法
+type echoServer struct{}
+
+var echoDesc = grpc.ServiceDesc{
+	ServiceName: "test.Echo",
+	HandlerType: (*interface{})(nil),
+	Methods: []grpc.MethodDesc{{
+		MethodName: "Echo",
+		Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+			in := new(structpb.Struct)
+			if err := dec(in); err != nil {
+				return nil, err
+			}
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) { return req, nil }
+			return interceptor(ctx, in, &grpc.UnaryServerInfo{Server: srv, FullMethod: "/test.Echo/Echo"}, handler)
+		},
+	}},
+	Streams: []grpc.StreamDesc{{
+		StreamName:    "Watch",
+		ServerStreams: true,
+		Handler: func(srv interface{}, stream grpc.ServerStream) error {
+			in := new(structpb.Struct)
+			if err := stream.RecvMsg(in); err != nil {
+				return err
+			}
+			return stream.SendMsg(in)
+		},
+	}},
+}
+
+func dialEcho(t *testing.T, server, client Config) *grpc.ClientConn {
+	t.Helper()
+	unaryServer, err := UnaryServerInterceptor(server)
+	if err != nil {
+		t.Fatalf("创建拦截器失败: %v", err)
+	}
+	streamServer, err := StreamServerInterceptor(server)
+	if err != nil {
+		t.Fatalf("创建拦截器失败: %v", err)
+	}
+	unaryClient, err := UnaryClientInterceptor(client)
+	if err != nil {
+		t.Fatalf("创建拦截器失败: %v", err)
+	}
+	streamClient, err := StreamClientInterceptor(client)
+	if err != nil {
+		t.Fatalf("创建拦截器失败: %v", err)
+	}
+
+	listener := bufconn.Listen(1 << 20)
+	s := grpc.NewServer(grpc.UnaryInterceptor(unaryServer), grpc.StreamInterceptor(streamServer))
+	s.RegisterService(&echoDesc, echoServer{})
+	go s.Serve(listener)
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(unaryClient),
+		grpc.WithStreamInterceptor(streamClient),
+	)
+	if err != nil {
+		t.Fatalf("连接失败: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func TestClientInterceptors(t *testing.T) {
+	keys := map[string]string{"k1": "secret-1", "k2": "secret-2"}
+	newKeyRing := func(kid string) *signvalidator.SignValidator {
+		validator, err := signvalidator.New(signvalidator.Config{
+			Algorithm:    signvalidator.HMAC_SHA256,
+			Keys:         keys,
+			KeyID:        kid,
+			TimestampKey: "timestamp",
+			NonceStore:   signvalidator.NewMemoryNonceStore(0),
+		})
+		if err != nil {
+			t.Fatalf("创建签名验证器失败: %v", err)
+		}
+		return validator
+	}
+	conn := dialEcho(t, Config{Validator: newKeyRing("k2"), MethodKey: "method"}, Config{Validator: newKeyRing("k1"), MethodKey: "method"})
+
+	in, _ := structpb.NewStruct(map[string]interface{}{"order_id": "1001", "amount": 9.9})
+	out := new(structpb.Struct)
+	for i := 0; i < 2; i++ {
+		if err := conn.Invoke(context.Background(), "/test.Echo/Echo", in, out); err != nil {
+			t.Fatalf("第 %d 次一元调用失败: %v", i+1, err)
+		}
+	}
+	if out.Fields["order_id"].GetStringValue() != "1001" {
+		t.Errorf("一元调用响应不正确: %v", out)
+	}
+
+	stream, err := conn.NewStream(context.Background(), &echoDesc.Streams[0], "/test.Echo/Watch")
+	if err != nil {
+		t.Fatalf("建立流失败: %v", err)
+	}
+	if err := stream.SendMsg(in); err != nil {
+		t.Fatalf("发送消息失败: %v", err)
+	}
+	stream.CloseSend()
+	if err := stream.RecvMsg(out); err != nil {
+		t.Fatalf("流调用失败: %v", err)
+	}
+	if err := stream.RecvMsg(out); err != io.EOF {
+		t.Errorf("流应正常结束，实际 %v", err)
+	}
+}
+
+func TestClientInterceptors_WrongSecret(t *testing.T) {
+	newValidator := func(secret string) *signvalidator.SignValidator {
+		validator, err := signvalidator.New(signvalidator.Config{Secret: secret, Algorithm: signvalidator.HMAC_SHA256})
+		if err != nil {
+			t.Fatalf("创建签名验证器失败: %v", err)
+		}
+		return validator
+	}
+	conn := dialEcho(t, Config{Validator: newValidator("server")}, Config{Validator: newValidator("client")})
+
+	in, _ := structpb.NewStruct(map[string]interface{}{"order_id": "1001"})
+	err := conn.Invoke(context.Background(), "/test.Echo/Echo", in, new(structpb.Struct))
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("密钥不一致时状态码 = %v, 期望 Unauthenticated", status.Code(err))
+	}
+
+	stream, err := conn.NewStream(context.Background(), &echoDesc.Streams[0], "/test.Echo/Watch")
+	if err == nil {
+		err = stream.RecvMsg(new(structpb.Struct))
+	}
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("密钥不一致时流的状态码 = %v, 期望 Unauthenticated", status.Code(err))
+	}
+}
@@ -0,0 +1,168 @@
+// Package grpcmw 提供验证调用签名的 gRPC 服务端拦截器与自动签名的客户端拦截器：签名、时间戳与随机数放在元数据中，
+// 请求消息与完整方法名参与签名，签名无效的调用以 codes.Unauthenticated 拒绝。
+// 该包是独立的 Go 模块，核心包不依赖 gRPC。
+package grpcmw
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/huangchunlong818/sign-chao/pkg/signvalidator"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// msgVerification 返回给调用方的验证失败信息，详细原因交给 Config.OnError
+	msgVerification = "签名验证失败"
+	// msgSign 客户端拦截器生成签名失败时的信息
+	msgSign = "生成签名失败"
+)
+
+// Config 拦截器配置
+type Config struct {
+	// Validator 签名验证器，客户端拦截器使用其 SignRequestDetached 生成签名
+	Validator *signvalidator.SignValidator
+	// SignatureMetadata 签名所在的元数据键，默认为 "x-signature"
+	SignatureMetadata string
+	// Metadata 参与签名的元数据键与参数名的映射，默认为 {"x-timestamp": "timestamp", "x-nonce": "nonce", "x-key-id": "kid"}，
+	// 参数名应与 Validator 的 TimestampKey、NonceKey、KeyIDKey 一致；服务端元数据缺失时不加入参数，
+	// 客户端将 SignRequest 补充的同名参数写入对应元数据
+	Metadata map[string]string
+	// MethodKey 完整方法名（如 "/order.v1.OrderService/Create"）参与签名的参数名，默认为 "grpc_method"，
+	// 使截获的签名不能用于调用其他方法；请求消息中已有同名字段时返回错误
+	MethodKey string
+	// SkipMethod 为 true 时签名不绑定方法名（不推荐，截获的签名可在时间窗口内用于其他方法）
+	SkipMethod bool
+	// OnError 验证或签名失败时的回调，err 为详细原因，可用于记录日志；返回给对端的状态只包含固定的失败信息
+	OnError func(ctx context.Context, method string, err error)
+	// Params 将请求消息转换为参数，默认为 ProtoParams
+	Params func(req interface{}) (map[string]interface{}, error)
+}
+
+// paramsKey 上下文中保存验证通过的参数的键
+type paramsKey struct{}
+
+// setDefaults 检查配置并填充默认值
+func (c *Config) setDefaults() error {
+	if c.Validator == nil {
+		return errors.New("签名验证器为空")
+	}
+	if c.SignatureMetadata == "" {
+		c.SignatureMetadata = "x-signature"
+	}
+	if c.Metadata == nil {
+		c.Metadata = map[string]string{"x-timestamp": "timestamp", "x-nonce": "nonce", "x-key-id": "kid"}
+	}
+	if c.MethodKey == "" {
+		c.MethodKey = "grpc_method"
+	}
+	if c.Params == nil {
+		c.Params = ProtoParams
+	}
+	return nil
+}
+
+// ProtoParams 使用 protojson（字段名取 proto 定义中的名称）将 protobuf 消息转换为参数，
+// 数字保留为 json.Number，64 位整数按 protojson 规则为字符串
+func ProtoParams(req interface{}) (map[string]interface{}, error) {
+	message, ok := req.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("请求不是 protobuf 消息: %T", req)
+	}
+	data, err := protojson.MarshalOptions{UseProtoNames: true}.Marshal(message)
+	if err != nil {
+		return nil, fmt.Errorf("转换请求消息失败: %w", err)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var params map[string]interface{}
+	if err := decoder.Decode(&params); err != nil {
+		return nil, fmt.Errorf("转换请求消息失败: %w", err)
+	}
+	if params == nil {
+		params = map[string]interface{}{}
+	}
+	return params, nil
+}
+
+// params 由请求消息、元数据与方法名组成签名参数，req 为 nil 时（流式调用）不包含消息
+func (c *Config) params(md metadata.MD, method string, req interface{}) (map[string]interface{}, error) {
+	params := map[string]interface{}{}
+	if req != nil {
+		var err error
+		if params, err = c.Params(req); err != nil {
+			return nil, err
+		}
+	}
+	for key, name := range c.Metadata {
+		if values := md.Get(key); len(values) > 0 {
+			params[name] = values[0]
+		}
+	}
+	if !c.SkipMethod {
+		if _, exists := params[c.MethodKey]; exists {
+			return nil, fmt.Errorf("请求参数中已有方法名参数 %s", c.MethodKey)
+		}
+		params[c.MethodKey] = method
+	}
+	return params, nil
+}
+
+// fail 调用 OnError 并返回只包含固定信息 message 的状态错误
+func (c *Config) fail(ctx context.Context, code codes.Code, message, method string, err error) error {
+	if c.OnError != nil {
+		c.OnError(ctx, method, err)
+	}
+	return status.Error(code, message)
+}
+
+// verify 验证调用签名，返回验证通过的参数；失败时返回只包含固定信息的 gRPC 状态错误
+func (c *Config) verify(ctx context.Context, method string, req interface{}) (map[string]interface{}, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	signatures := md.Get(c.SignatureMetadata)
+	if len(signatures) == 0 || signatures[0] == "" {
+		return nil, c.fail(ctx, codes.Unauthenticated, msgVerification, method, fmt.Errorf("缺少签名元数据 %s", c.SignatureMetadata))
+	}
+
+	params, err := c.params(md, method, req)
+	if err != nil {
+		return nil, c.fail(ctx, codes.Internal, msgVerification, method, err)
+	}
+	valid, err := c.Validator.ValidateContext(ctx, params, signatures[0])
+	if err != nil {
+		return nil, c.fail(ctx, codes.Unauthenticated, msgVerification, method, err)
+	}
+	if !valid {
+		return nil, c.fail(ctx, codes.Unauthenticated, msgVerification, method, errors.New("签名无效"))
+	}
+	return params, nil
+}
+
+// UnaryServerInterceptor 返回验证调用签名的一元拦截器，验证通过的参数可通过 Params 获取
+func UnaryServerInterceptor(config Config) (grpc.UnaryServerInterceptor, error) {
+	if err := config.setDefaults(); err != nil {
+		return nil, err
+	}
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		params, err := config.verify(ctx, info.FullMethod, req)
+		if err != nil {
+			return nil, err
+		}
+		return handler(context.WithValue(ctx, paramsKey{}, params), req)
+	}, nil
+}
+
+// Params 返回拦截器保存在上下文中的验证通过的参数
+func Params(ctx context.Context) (map[string]interface{}, bool) {
+	params, ok := ctx.Value(paramsKey{}).(map[string]interface{})
+	return params, ok
+}
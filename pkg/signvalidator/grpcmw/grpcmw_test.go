@@ -0,0 +1,168 @@
+package grpcmw
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/huangchunlong818/sign-chao/pkg/signvalidator"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func newValidator(t *testing.T, clock signvalidator.Clock) *signvalidator.SignValidator {
+	t.Helper()
+	validator, err := signvalidator.New(signvalidator.Config{
+		Secret:       "testSecret",
+		Algorithm:    signvalidator.HMAC_SHA256,
+		TimestampKey: "timestamp",
+		NonceStore:   signvalidator.NewMemoryNonceStore(0),
+		Clock:        clock,
+	})
+	if err != nil {
+		t.Fatalf("创建签名验证器失败: %v", err)
+	}
+	return validator
+}
+
+func TestProtoParams(t *testing.T) {
+	message, err := structpb.NewStruct(map[string]interface{}{"order_id": "1001", "amount": 9.9, "tags": []interface{}{"a"}})
+	if err != nil {
+		t.Fatalf("创建消息失败: %v", err)
+	}
+	params, err := ProtoParams(message)
+	if err != nil {
+		t.Fatalf("转换请求消息失败: %v", err)
+	}
+	if params["order_id"] != "1001" || params["amount"] != json.Number("9.9") {
+		t.Errorf("参数不正确: %v", params)
+	}
+
+	if params, err := ProtoParams(wrapperspb.Int64(1)); err == nil {
+		t.Errorf("非对象消息应返回错误: %v", params)
+	}
+	if _, err := ProtoParams("text"); err == nil {
+		t.Error("非 protobuf 消息应返回错误")
+	}
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	clock := signvalidator.NewFakeClock(time.Unix(1700000000, 0))
+	validator := newValidator(t, clock)
+	interceptor, err := UnaryServerInterceptor(Config{Validator: validator, MethodKey: "method"})
+	if err != nil {
+		t.Fatalf("创建拦截器失败: %v", err)
+	}
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/order.v1.OrderService/Create"}
+	message, _ := structpb.NewStruct(map[string]interface{}{"order_id": "1001"})
+	signature, err := validator.GenerateSignature(map[string]interface{}{
+		"order_id":  "1001",
+		"timestamp": "1700000000",
+		"nonce":     "n1",
+		"method":    info.FullMethod,
+	})
+	if err != nil {
+		t.Fatalf("生成签名失败: %v", err)
+	}
+
+	var got map[string]interface{}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		got, _ = Params(ctx)
+		return "ok", nil
+	}
+	call := func(md metadata.MD, info *grpc.UnaryServerInfo) error {
+		_, err := interceptor(metadata.NewIncomingContext(context.Background(), md), message, info, handler)
+		return err
+	}
+
+	md := metadata.Pairs("x-signature", signature, "x-timestamp", "1700000000", "x-nonce", "n1")
+	if err := call(md, info); err != nil {
+		t.Fatalf("签名有效时应通过: %v", err)
+	}
+	if got["order_id"] != "1001" || got["nonce"] != "n1" {
+		t.Errorf("上下文中的参数不正确: %v", got)
+	}
+
+	for name, md := range map[string]metadata.MD{
+		"随机数重复": md,
+		"缺少签名":  metadata.Pairs("x-timestamp", "1700000000", "x-nonce", "n2"),
+		"签名无效":  metadata.Pairs("x-signature", signature, "x-timestamp", "1700000000", "x-nonce", "n3"),
+	} {
+		if err := call(md, info); status.Code(err) != codes.Unauthenticated {
+			t.Errorf("%s: 状态码 = %v, 期望 Unauthenticated", name, status.Code(err))
+		}
+	}
+
+	md = metadata.Pairs("x-signature", signature, "x-timestamp", "1700000000", "x-nonce", "n1")
+	if err := call(md, &grpc.UnaryServerInfo{FullMethod: "/order.v1.OrderService/Delete"}); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("签名应绑定方法名，实际 %v", err)
+	}
+}
+
+func TestUnaryServerInterceptor_NilValidator(t *testing.T) {
+	if _, err := UnaryServerInterceptor(Config{}); err == nil {
+		t.Error("验证器为空时应返回错误")
+	}
+}
+
+func TestUnaryServerInterceptor_MethodBinding(t *testing.T) {
+	clock := signvalidator.NewFakeClock(time.Unix(1700000000, 0))
+	validator := newValidator(t, clock)
+	var details []error
+	interceptor, err := UnaryServerInterceptor(Config{
+		Validator: validator,
+		OnError:   func(ctx context.Context, method string, err error) { details = append(details, err) },
+	})
+	if err != nil {
+		t.Fatalf("创建拦截器失败: %v", err)
+	}
+
+	message, _ := structpb.NewStruct(map[string]interface{}{"order_id": "1001"})
+	signature, err := validator.GenerateSignature(map[string]interface{}{
+		"order_id":    "1001",
+		"timestamp":   "1700000000",
+		"nonce":       "n1",
+		"grpc_method": "/order.v1.OrderService/Create",
+	})
+	if err != nil {
+		t.Fatalf("生成签名失败: %v", err)
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+	call := func(method string, req interface{}) error {
+		md := metadata.Pairs("x-signature", signature, "x-timestamp", "1700000000", "x-nonce", "n1")
+		_, err := interceptor(metadata.NewIncomingContext(context.Background(), md), req, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+		return err
+	}
+
+	err = call("/order.v1.OrderService/Delete", message)
+	if status.Code(err) != codes.Unauthenticated || status.Convert(err).Message() != msgVerification {
+		t.Errorf("默认应绑定方法名并只返回固定信息: %v", err)
+	}
+	if len(details) != 1 {
+		t.Errorf("OnError 应收到详细原因: %v", details)
+	}
+	if err := call("/order.v1.OrderService/Create", message); err != nil {
+		t.Errorf("方法名一致时应通过: %v", err)
+	}
+
+	conflict, _ := structpb.NewStruct(map[string]interface{}{"grpc_method": "x"})
+	if err := call("/order.v1.OrderService/Create", conflict); status.Code(err) != codes.Internal || status.Convert(err).Message() != msgVerification {
+		t.Errorf("请求消息中已有方法名参数时应返回错误: %v", err)
+	}
+
+	unbound, err := UnaryServerInterceptor(Config{Validator: newValidator(t, clock), SkipMethod: true})
+	if err != nil {
+		t.Fatalf("创建拦截器失败: %v", err)
+	}
+	signature, _ = validator.GenerateSignature(map[string]interface{}{"order_id": "1001", "timestamp": "1700000000", "nonce": "n2"})
+	md := metadata.Pairs("x-signature", signature, "x-timestamp", "1700000000", "x-nonce", "n2")
+	if _, err := unbound(metadata.NewIncomingContext(context.Background(), md), message, &grpc.UnaryServerInfo{FullMethod: "/any"}, handler); err != nil {
+		t.Errorf("SkipMethod 时签名不应包含方法名: %v", err)
+	}
+}
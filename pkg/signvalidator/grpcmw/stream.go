@@ -0,0 +1,33 @@
+package grpcmw
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// StreamServerInterceptor 返回在建立流时验证签名的流拦截器。流的消息在建立之后才发送，
+// 签名参数只包含元数据中的时间戳、随机数等参数与方法名，不包含消息内容
+func StreamServerInterceptor(config Config) (grpc.StreamServerInterceptor, error) {
+	if err := config.setDefaults(); err != nil {
+		return nil, err
+	}
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		params, err := config.verify(stream.Context(), info.FullMethod, nil)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &serverStream{ServerStream: stream, ctx: context.WithValue(stream.Context(), paramsKey{}, params)})
+	}, nil
+}
+
+// serverStream 替换上下文的 grpc.ServerStream，使处理器可通过 Params 获取验证通过的参数
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context 返回包含验证通过的参数的上下文
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,61 @@
+package grpcmw
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/huangchunlong818/sign-chao/pkg/signvalidator"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// fakeServerStream 只提供上下文的 grpc.ServerStream
+type fakeServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *fakeServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestStreamServerInterceptor(t *testing.T) {
+	validator := newValidator(t, signvalidator.NewFakeClock(time.Unix(1700000000, 0)))
+	interceptor, err := StreamServerInterceptor(Config{Validator: validator, MethodKey: "method"})
+	if err != nil {
+		t.Fatalf("创建拦截器失败: %v", err)
+	}
+
+	info := &grpc.StreamServerInfo{FullMethod: "/order.v1.OrderService/Watch", IsServerStream: true}
+	signature, err := validator.GenerateSignature(map[string]interface{}{
+		"timestamp": "1700000000",
+		"nonce":     "n1",
+		"method":    info.FullMethod,
+	})
+	if err != nil {
+		t.Fatalf("生成签名失败: %v", err)
+	}
+
+	var got map[string]interface{}
+	handler := func(srv interface{}, stream grpc.ServerStream) error {
+		got, _ = Params(stream.Context())
+		return nil
+	}
+	call := func(md metadata.MD) error {
+		stream := &fakeServerStream{ctx: metadata.NewIncomingContext(context.Background(), md)}
+		return interceptor(nil, stream, info, handler)
+	}
+
+	if err := call(metadata.Pairs("x-signature", signature, "x-timestamp", "1700000000", "x-nonce", "n1")); err != nil {
+		t.Fatalf("签名有效时应建立流: %v", err)
+	}
+	if got["method"] != info.FullMethod {
+		t.Errorf("流上下文中的参数不正确: %v", got)
+	}
+	if err := call(metadata.Pairs("x-signature", signature, "x-timestamp", "1700000000", "x-nonce", "n2")); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("签名无效时状态码 = %v, 期望 Unauthenticated", status.Code(err))
+	}
+}
@@ -0,0 +1,101 @@
+// Package httpmw 提供验证请求签名的 net/http 中间件：从查询参数与表单、multipart、JSON 请求体中读取参数，
+// 签名无效时返回 401，请求体超过 MaxBodyBytes 时返回 413，验证通过的参数保存在请求上下文中。
+package httpmw
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/huangchunlong818/sign-chao/pkg/signvalidator"
+)
+
+// ErrInvalidSignature 签名与参数不匹配
+var ErrInvalidSignature = errors.New("签名无效")
+
+// defaultBody 默认的失败响应体
+const defaultBody = `{"code":401,"message":"签名无效"}`
+
+// tooLargeBody 请求体超过 MaxBodyBytes 时的默认响应体
+const tooLargeBody = `{"code":413,"message":"请求体过大"}`
+
+// Config 中间件配置
+type Config struct {
+	// Validator 签名验证器，NewRouter 中用于未匹配任何路由的请求
+	Validator *signvalidator.SignValidator
+	// SignatureHeader 签名请求头（如 "X-Signature"），为空时从参数中的签名参数（SignatureKey）读取
+	SignatureHeader string
+	// StatusCode 验证失败时的状态码，默认为 401
+	StatusCode int
+	// Body 验证失败时的响应体，默认为 {"code":401,"message":"签名无效"}
+	Body []byte
+	// ContentType 验证失败时的 Content-Type，默认为 "application/json; charset=utf-8"
+	ContentType string
+	// ErrorHandler 自定义验证失败的响应，设置后忽略 StatusCode、Body 与 ContentType；签名不匹配时 err 为 ErrInvalidSignature，
+	// 请求体超过 MaxBodyBytes 时为 signvalidator.ErrBodyTooLarge（默认返回 413），其他情况为验证或读取参数返回的错误
+	ErrorHandler func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// paramsKey 请求上下文中保存验证通过的参数的键
+type paramsKey struct{}
+
+// New 检查配置并返回中间件，验证通过的请求交给下一个处理器，请求体保持可读
+func New(config Config) (func(http.Handler) http.Handler, error) {
+	if config.Validator == nil {
+		return nil, errors.New("签名验证器为空")
+	}
+	return NewRouter(config)
+}
+
+// setDefaults 填充失败响应的默认值
+func (c *Config) setDefaults() {
+	if c.StatusCode == 0 {
+		c.StatusCode = http.StatusUnauthorized
+	}
+	if c.Body == nil {
+		c.Body = []byte(defaultBody)
+	}
+	if c.ContentType == "" {
+		c.ContentType = "application/json; charset=utf-8"
+	}
+	if c.ErrorHandler == nil {
+		statusCode, body, contentType := c.StatusCode, c.Body, c.ContentType
+		c.ErrorHandler = func(w http.ResponseWriter, r *http.Request, err error) {
+			w.Header().Set("Content-Type", contentType)
+			if errors.Is(err, signvalidator.ErrBodyTooLarge) {
+				w.WriteHeader(http.StatusRequestEntityTooLarge)
+				w.Write([]byte(tooLargeBody))
+				return
+			}
+			w.WriteHeader(statusCode)
+			w.Write(body)
+		}
+	}
+}
+
+// Verify 读取请求参数并验证签名，返回验证通过的参数；签名取自 signatureHeader 请求头，为空时取自参数中的签名参数。
+// 签名不匹配时返回 ErrInvalidSignature，供其他框架的中间件复用
+func Verify(r *http.Request, v *signvalidator.SignValidator, signatureHeader string) (map[string]interface{}, error) {
+	var signature string
+	if signatureHeader != "" {
+		if signature = r.Header.Get(signatureHeader); signature == "" {
+			return nil, fmt.Errorf("%w: 缺少 %s 请求头", ErrInvalidSignature, signatureHeader)
+		}
+	}
+
+	params, valid, err := v.ValidateRequestParams(r, signature)
+	if err != nil {
+		return nil, err
+	}
+	if !valid {
+		return nil, ErrInvalidSignature
+	}
+	return params, nil
+}
+
+// Params 返回中间件保存在请求上下文中的验证通过的参数
+func Params(ctx context.Context) (map[string]interface{}, bool) {
+	params, ok := ctx.Value(paramsKey{}).(map[string]interface{})
+	return params, ok
+}
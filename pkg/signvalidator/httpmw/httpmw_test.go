@@ -0,0 +1,158 @@
+package httpmw
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/huangchunlong818/sign-chao/pkg/signvalidator"
+)
+
+func newValidator(t *testing.T) *signvalidator.SignValidator {
+	t.Helper()
+	validator, err := signvalidator.New(signvalidator.Config{Secret: "testSecret", Algorithm: signvalidator.HMAC_SHA256})
+	if err != nil {
+		t.Fatalf("创建签名验证器失败: %v", err)
+	}
+	return validator
+}
+
+func sign(t *testing.T, validator *signvalidator.SignValidator, params map[string]interface{}) string {
+	t.Helper()
+	signature, err := validator.GenerateSignature(params)
+	if err != nil {
+		t.Fatalf("生成签名失败: %v", err)
+	}
+	return signature
+}
+
+func TestMiddleware(t *testing.T) {
+	validator := newValidator(t)
+	middleware, err := New(Config{Validator: validator})
+	if err != nil {
+		t.Fatalf("创建中间件失败: %v", err)
+	}
+
+	var got map[string]interface{}
+	var body string
+	handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, _ = Params(r.Context())
+		data, _ := io.ReadAll(r.Body)
+		body = string(data)
+	}))
+
+	signature := sign(t, validator, map[string]interface{}{"app_id": "a", "amount": "100"})
+	r := httptest.NewRequest("POST", "/orders?app_id=a&sign="+signature, strings.NewReader(`{"amount":"100"}`))
+	r.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	if w.Code != http.StatusOK {
+		t.Fatalf("签名有效时状态码 = %d, 期望 200", w.Code)
+	}
+	if got["amount"] != "100" || got["app_id"] != "a" {
+		t.Errorf("上下文中的参数不正确: %v", got)
+	}
+	if body != `{"amount":"100"}` {
+		t.Errorf("后续处理器读取的请求体 = %q", body)
+	}
+
+	r = httptest.NewRequest("POST", "/orders?app_id=a&sign="+signature, strings.NewReader(`{"amount":"999"}`))
+	r.Header.Set("Content-Type", "application/json")
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("签名无效时状态码 = %d, 期望 401", w.Code)
+	}
+	if w.Body.String() != defaultBody || w.Header().Get("Content-Type") != "application/json; charset=utf-8" {
+		t.Errorf("默认失败响应不正确: %q %q", w.Header().Get("Content-Type"), w.Body.String())
+	}
+}
+
+func TestMiddleware_SignatureHeader(t *testing.T) {
+	validator := newValidator(t)
+	middleware, err := New(Config{
+		Validator:       validator,
+		SignatureHeader: "X-Signature",
+		StatusCode:      http.StatusForbidden,
+		Body:            []byte("forbidden"),
+		ContentType:     "text/plain",
+	})
+	if err != nil {
+		t.Fatalf("创建中间件失败: %v", err)
+	}
+	handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	r := httptest.NewRequest("POST", "/orders?a=1", strings.NewReader("b=2"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	r.Header.Set("X-Signature", sign(t, validator, map[string]interface{}{"a": "1", "b": "2"}))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	if w.Code != http.StatusOK {
+		t.Errorf("签名请求头有效时状态码 = %d, 期望 200", w.Code)
+	}
+
+	r = httptest.NewRequest("GET", "/orders?a=1", nil)
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	if w.Code != http.StatusForbidden || w.Body.String() != "forbidden" || w.Header().Get("Content-Type") != "text/plain" {
+		t.Errorf("缺少签名请求头时应返回自定义响应: %d %q", w.Code, w.Body.String())
+	}
+}
+
+func TestMiddleware_ErrorHandler(t *testing.T) {
+	var handled error
+	middleware, err := New(Config{
+		Validator: newValidator(t),
+		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+			handled = err
+			w.WriteHeader(http.StatusTeapot)
+		},
+	})
+	if err != nil {
+		t.Fatalf("创建中间件失败: %v", err)
+	}
+	handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/?a=1&sign=bad", nil))
+	if w.Code != http.StatusTeapot || !errors.Is(handled, ErrInvalidSignature) {
+		t.Errorf("签名不匹配时应调用 ErrorHandler 并传入 ErrInvalidSignature: %d %v", w.Code, handled)
+	}
+
+	r := httptest.NewRequest("POST", "/", strings.NewReader("hello"))
+	r.Header.Set("Content-Type", "text/plain")
+	handler.ServeHTTP(httptest.NewRecorder(), r)
+	if handled == nil || errors.Is(handled, ErrInvalidSignature) {
+		t.Errorf("读取参数失败时应传入原始错误: %v", handled)
+	}
+}
+
+func TestNew_NilValidator(t *testing.T) {
+	if _, err := New(Config{}); err == nil {
+		t.Error("验证器为空时应返回错误")
+	}
+}
+
+func TestMiddleware_BodyTooLarge(t *testing.T) {
+	validator, err := signvalidator.New(signvalidator.Config{Secret: "testSecret", Algorithm: signvalidator.HMAC_SHA256, MaxBodyBytes: 8})
+	if err != nil {
+		t.Fatalf("创建签名验证器失败: %v", err)
+	}
+	middleware, err := New(Config{Validator: validator})
+	if err != nil {
+		t.Fatalf("创建中间件失败: %v", err)
+	}
+	called := false
+	handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { called = true }))
+
+	r := httptest.NewRequest("POST", "/orders", strings.NewReader(`{"amount":"100"}`))
+	r.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	if w.Code != http.StatusRequestEntityTooLarge || w.Body.String() != tooLargeBody || called {
+		t.Errorf("请求体过大时应返回 413: %d %q", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,134 @@
+package httpmw
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/huangchunlong818/sign-chao/pkg/signvalidator"
+)
+
+// ResponseConfig 响应签名配置
+type ResponseConfig struct {
+	// Validator 签名使用的验证器，配置了 SecretProvider 时以请求的上下文（包含 Params）查找密钥
+	Validator *signvalidator.SignValidator
+	// Select 按请求选择验证器（如 TenantRegistry.ValidatorFor(Params)），设置后优先于 Validator；返回错误时交给 ErrorHandler
+	Select func(r *http.Request) (*signvalidator.SignValidator, error)
+	// Header 签名响应头，默认为 "X-Signature"
+	Header string
+	// JSON 为 true 时签名写入 JSON 响应对象中验证器 SignatureKey 对应的字段（与 VerifyHTTPResponse 读取的字段一致），
+	// 签名参数为对象的其余字段；响应不是 JSON 对象时仍写入 Header。为 false 时对原始响应体签名（规则同 GenerateBodySignature）并写入 Header
+	JSON bool
+	// ErrorHandler 签名失败时的响应，默认返回 500
+	ErrorHandler func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+// SignResponses 返回为响应签名的中间件：缓存处理器写入的响应体，计算签名后写入响应头或 JSON 字段再发送，
+// 使调用方可以验证响应（见 signvalidator.VerifyHTTPResponse）；不允许响应体的状态码（1xx、204、304）不签名。缓存期间不支持 http.Flusher，不适用于流式响应
+func SignResponses(config ResponseConfig) (func(http.Handler) http.Handler, error) {
+	if config.Validator == nil && config.Select == nil {
+		return nil, errors.New("签名验证器为空")
+	}
+	if config.Header == "" {
+		config.Header = "X-Signature"
+	}
+	if config.ErrorHandler == nil {
+		config.ErrorHandler = func(w http.ResponseWriter, r *http.Request, err error) {
+			http.Error(w, "响应签名失败", http.StatusInternalServerError)
+		}
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			buffered := &bufferedResponse{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(buffered, r)
+			if buffered.status < 200 || buffered.status == http.StatusNoContent || buffered.status == http.StatusNotModified {
+				w.WriteHeader(buffered.status)
+				return
+			}
+
+			body, err := config.sign(r, w.Header(), buffered.body.Bytes())
+			if err != nil {
+				config.ErrorHandler(w, r, err)
+				return
+			}
+			w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+			w.WriteHeader(buffered.status)
+			w.Write(body)
+		})
+	}, nil
+}
+
+// sign 计算响应签名并写入响应头，JSON 模式下返回写入签名字段后的响应体
+func (c *ResponseConfig) sign(r *http.Request, header http.Header, body []byte) ([]byte, error) {
+	v := c.Validator
+	if c.Select != nil {
+		var err error
+		if v, err = c.Select(r); err != nil {
+			return nil, err
+		}
+		if v == nil {
+			return nil, errors.New("签名验证器为空")
+		}
+	}
+
+	if c.JSON && isJSON(header.Get("Content-Type")) {
+		decoder := json.NewDecoder(bytes.NewReader(body))
+		decoder.UseNumber()
+		var object map[string]interface{}
+		if decoder.Decode(&object) == nil && object != nil {
+			delete(object, v.SignatureKey())
+			signature, err := v.GenerateSignatureContext(r.Context(), object)
+			if err != nil {
+				return nil, err
+			}
+			object[v.SignatureKey()] = signature
+
+			var buf bytes.Buffer
+			encoder := json.NewEncoder(&buf)
+			encoder.SetEscapeHTML(false)
+			if err := encoder.Encode(object); err != nil {
+				return nil, err
+			}
+			return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+		}
+	}
+
+	signature, err := v.GenerateBodySignatureContext(r.Context(), body)
+	if err != nil {
+		return nil, err
+	}
+	header.Set(c.Header, signature)
+	return body, nil
+}
+
+// isJSON 判断 Content-Type 是否为 JSON
+func isJSON(contentType string) bool {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+// bufferedResponse 缓存状态码与响应体的 http.ResponseWriter，响应头直接写入底层的 ResponseWriter
+type bufferedResponse struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	body        bytes.Buffer
+}
+
+// WriteHeader 记录状态码，签名后再写入
+func (b *bufferedResponse) WriteHeader(status int) {
+	if !b.wroteHeader {
+		b.status, b.wroteHeader = status, true
+	}
+}
+
+// Write 缓存响应体
+func (b *bufferedResponse) Write(data []byte) (int, error) {
+	b.wroteHeader = true
+	return b.body.Write(data)
+}
@@ -0,0 +1,180 @@
+package httpmw
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/huangchunlong818/sign-chao/pkg/signvalidator"
+)
+
+func TestSignResponses(t *testing.T) {
+	validator := newValidator(t)
+	middleware, err := SignResponses(ResponseConfig{Validator: validator})
+	if err != nil {
+		t.Fatalf("创建中间件失败: %v", err)
+	}
+	handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		io.WriteString(w, `{"code":0,`)
+		io.WriteString(w, `"data":{"id":1}}`)
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/orders/1", nil))
+	if w.Code != http.StatusCreated || w.Body.String() != `{"code":0,"data":{"id":1}}` {
+		t.Errorf("响应 = %d %s", w.Code, w.Body.String())
+	}
+	if valid, err := validator.ValidateBody(w.Body.Bytes(), w.Header().Get("X-Signature")); err != nil || !valid {
+		t.Errorf("响应签名应验证通过: valid=%v, err=%v", valid, err)
+	}
+}
+
+func TestSignResponses_JSON(t *testing.T) {
+	validator := newValidator(t)
+	middleware, err := SignResponses(ResponseConfig{Validator: validator, JSON: true})
+	if err != nil {
+		t.Fatalf("创建中间件失败: %v", err)
+	}
+	handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		io.WriteString(w, `{"code":0,"amount":100.50,"msg":"<ok>"}`)
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+	if w.Header().Get("X-Signature") != "" {
+		t.Error("JSON 模式下不应写入签名响应头")
+	}
+	decoder := json.NewDecoder(w.Body)
+	decoder.UseNumber()
+	var object map[string]interface{}
+	if err := decoder.Decode(&object); err != nil {
+		t.Fatalf("解析响应失败: %v", err)
+	}
+	if object["amount"] != json.Number("100.50") || object["msg"] != "<ok>" {
+		t.Errorf("响应字段应保持不变: %v", object)
+	}
+	if valid, err := validator.ValidateWithSignInParams(object); err != nil || !valid {
+		t.Errorf("签名字段应验证通过: valid=%v, err=%v", valid, err)
+	}
+
+	plain := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "plain text")
+	}))
+	w = httptest.NewRecorder()
+	plain.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+	if w.Body.String() != "plain text" || w.Header().Get("X-Signature") == "" {
+		t.Errorf("非 JSON 响应应签名响应头: %q %v", w.Body.String(), w.Header())
+	}
+}
+
+func TestSignResponses_Tenant(t *testing.T) {
+	registry := signvalidator.NewTenantRegistry("app_id")
+	for tenant, secret := range map[string]string{"app_1": "secret-1", "app_2": "secret-2"} {
+		if err := registry.Register(tenant, signvalidator.Config{Secret: secret, Algorithm: signvalidator.HMAC_SHA256}); err != nil {
+			t.Fatalf("注册租户失败: %v", err)
+		}
+	}
+	middleware, err := SignResponses(ResponseConfig{
+		Select: func(r *http.Request) (*signvalidator.SignValidator, error) {
+			params, _ := Params(r.Context())
+			return registry.ValidatorFor(params)
+		},
+	})
+	if err != nil {
+		t.Fatalf("创建中间件失败: %v", err)
+	}
+	handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "ok")
+	}))
+
+	r := httptest.NewRequest("GET", "/", nil)
+	r = r.WithContext(context.WithValue(r.Context(), paramsKey{}, map[string]interface{}{"app_id": "app_2"}))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+	tenant, _ := registry.Validator("app_2")
+	if valid, err := tenant.ValidateBody([]byte("ok"), w.Header().Get("X-Signature")); err != nil || !valid {
+		t.Errorf("应使用租户的密钥签名: valid=%v, err=%v", valid, err)
+	}
+
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+	if w.Code != http.StatusInternalServerError || w.Body.String() == "ok" {
+		t.Errorf("无法选择租户时应返回 500: %d %q", w.Code, w.Body.String())
+	}
+}
+
+func TestSignResponses_NoBody(t *testing.T) {
+	middleware, err := SignResponses(ResponseConfig{Validator: newValidator(t)})
+	if err != nil {
+		t.Fatalf("创建中间件失败: %v", err)
+	}
+	w := httptest.NewRecorder()
+	middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})).ServeHTTP(w, httptest.NewRequest("DELETE", "/", nil))
+	if w.Code != http.StatusNoContent || w.Header().Get("X-Signature") != "" {
+		t.Errorf("204 响应不应签名: %d %v", w.Code, w.Header())
+	}
+
+	if _, err := SignResponses(ResponseConfig{}); err == nil {
+		t.Error("未配置验证器时应返回错误")
+	}
+}
+
+func TestSignResponses_VerifyHTTPResponse(t *testing.T) {
+	validator := newValidator(t)
+	for _, inJSON := range []bool{false, true} {
+		middleware, err := SignResponses(ResponseConfig{Validator: validator, JSON: inJSON})
+		if err != nil {
+			t.Fatalf("创建中间件失败: %v", err)
+		}
+		w := httptest.NewRecorder()
+		middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			io.WriteString(w, `{"code":0,"data":{"id":1}}`)
+		})).ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+
+		if valid, err := validator.VerifyHTTPResponse(w.Result()); err != nil || !valid {
+			t.Errorf("JSON=%v 时客户端应验证通过: valid=%v, err=%v", inJSON, valid, err)
+		}
+	}
+}
+
+func TestSignResponses_JSONRoundTrip(t *testing.T) {
+	validator, err := signvalidator.New(signvalidator.Config{Secret: "testSecret", Algorithm: signvalidator.HMAC_SHA256, SignatureKey: "signature"})
+	if err != nil {
+		t.Fatalf("创建签名验证器失败: %v", err)
+	}
+	middleware, err := SignResponses(ResponseConfig{Validator: validator, JSON: true})
+	if err != nil {
+		t.Fatalf("创建中间件失败: %v", err)
+	}
+	server := httptest.NewServer(middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"code":0,"sign":"data"}`)
+	})))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	if err != nil {
+		t.Fatalf("请求失败: %v", err)
+	}
+	defer resp.Body.Close()
+	if valid, err := validator.VerifyHTTPResponse(resp); err != nil || !valid {
+		t.Fatalf("客户端应按自定义的 SignatureKey 验证通过: valid=%v, err=%v", valid, err)
+	}
+
+	var object map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&object); err != nil {
+		t.Fatalf("解析响应失败: %v", err)
+	}
+	if object["signature"] == nil || object["sign"] != "data" {
+		t.Errorf("签名应写入 SignatureKey 字段且不影响其他字段: %v", object)
+	}
+}
@@ -0,0 +1,81 @@
+package httpmw
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/huangchunlong818/sign-chao/pkg/signvalidator"
+)
+
+// ErrNoRoute 请求未匹配任何路由且未配置默认的 Validator
+var ErrNoRoute = errors.New("请求路径未配置签名规则")
+
+// Route 单个路由的签名规则
+type Route struct {
+	// Pattern http.ServeMux 格式的路由模式，如 "POST /orders/{id}"、"/v2/"，按 ServeMux 的优先级匹配
+	Pattern string
+	// Validator 该路由使用的签名验证器，可配置不同的 SignatureKey、算法或密钥；为 nil 时该路由不验证签名
+	Validator *signvalidator.SignValidator
+	// SignatureHeader 签名请求头，为空时使用 Config.SignatureHeader
+	SignatureHeader string
+}
+
+// NewRouter 返回按路由选择签名规则的中间件，使同一个路由器（ServeMux、chi 等）下的接口使用不同的签名规则。
+// 未匹配任何路由的请求使用 Config.Validator，其为 nil 时以 ErrNoRoute 拒绝请求；路由模式无效或重复时返回错误
+func NewRouter(config Config, routes ...Route) (func(http.Handler) http.Handler, error) {
+	mux := http.NewServeMux()
+	rules := make(map[string]Route, len(routes))
+	for _, route := range routes {
+		if err := register(mux, route.Pattern); err != nil {
+			return nil, err
+		}
+		if route.SignatureHeader == "" {
+			route.SignatureHeader = config.SignatureHeader
+		}
+		rules[route.Pattern] = route
+	}
+	config.setDefaults()
+
+	fallback := Route{Validator: config.Validator, SignatureHeader: config.SignatureHeader}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			route := fallback
+			if len(rules) > 0 {
+				if _, pattern := mux.Handler(r); pattern != "" {
+					if matched, exists := rules[pattern]; exists {
+						route = matched
+					}
+				}
+			}
+
+			if route.Validator == nil {
+				if route.Pattern == "" {
+					config.ErrorHandler(w, r, ErrNoRoute)
+					return
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			params, err := Verify(r, route.Validator, route.SignatureHeader)
+			if err != nil {
+				config.ErrorHandler(w, r, err)
+				return
+			}
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), paramsKey{}, params)))
+		})
+	}, nil
+}
+
+// register 将路由模式注册到用于匹配的 ServeMux，将 ServeMux 对无效或重复模式的 panic 转为错误
+func register(mux *http.ServeMux, pattern string) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("路由模式 %q 无效: %v", pattern, recovered)
+		}
+	}()
+	mux.Handle(pattern, http.NotFoundHandler())
+	return nil
+}
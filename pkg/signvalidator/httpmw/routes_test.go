@@ -0,0 +1,87 @@
+package httpmw
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/huangchunlong818/sign-chao/pkg/signvalidator"
+)
+
+func TestNewRouter(t *testing.T) {
+	v1 := newValidator(t)
+	v2, err := signvalidator.New(signvalidator.Config{Secret: "v2Secret", Algorithm: signvalidator.SHA256, SignatureKey: "signature"})
+	if err != nil {
+		t.Fatalf("创建签名验证器失败: %v", err)
+	}
+
+	var handled error
+	middleware, err := NewRouter(Config{
+		Validator: v1,
+		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) {
+			handled = err
+			w.WriteHeader(http.StatusUnauthorized)
+		},
+	},
+		Route{Pattern: "/v2/", Validator: v2},
+		Route{Pattern: "POST /callbacks/{channel}", Validator: v2, SignatureHeader: "X-Signature"},
+		Route{Pattern: "GET /healthz"},
+	)
+	if err != nil {
+		t.Fatalf("创建中间件失败: %v", err)
+	}
+	handler := middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	serve := func(r *http.Request) int {
+		handled = nil
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w.Code
+	}
+
+	params := map[string]interface{}{"a": "1"}
+	if code := serve(httptest.NewRequest("GET", "/v1/orders?a=1&sign="+sign(t, v1, params), nil)); code != http.StatusOK {
+		t.Errorf("未匹配路由时应使用默认验证器: %d %v", code, handled)
+	}
+	if code := serve(httptest.NewRequest("GET", "/v2/orders?a=1&signature="+sign(t, v2, params), nil)); code != http.StatusOK {
+		t.Errorf("/v2/ 应使用 v2 验证器与 signature 参数: %d %v", code, handled)
+	}
+	if code := serve(httptest.NewRequest("GET", "/v2/orders?a=1&sign="+sign(t, v1, params), nil)); code != http.StatusUnauthorized {
+		t.Errorf("/v2/ 使用 v1 的规则签名时应验证失败: %d", code)
+	}
+
+	r := httptest.NewRequest("POST", "/callbacks/wechat?a=1", nil)
+	r.Header.Set("X-Signature", sign(t, v2, params))
+	if code := serve(r); code != http.StatusOK {
+		t.Errorf("回调路由应从请求头读取签名: %d %v", code, handled)
+	}
+	if code := serve(httptest.NewRequest("GET", "/healthz", nil)); code != http.StatusOK || handled != nil {
+		t.Errorf("未配置验证器的路由不应验证签名: %d %v", code, handled)
+	}
+}
+
+func TestNewRouter_NoDefault(t *testing.T) {
+	var handled error
+	middleware, err := NewRouter(Config{
+		ErrorHandler: func(w http.ResponseWriter, r *http.Request, err error) { handled = err },
+	}, Route{Pattern: "/api/", Validator: newValidator(t)})
+	if err != nil {
+		t.Fatalf("创建中间件失败: %v", err)
+	}
+	middleware(http.NotFoundHandler()).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/other", nil))
+	if !errors.Is(handled, ErrNoRoute) {
+		t.Errorf("未匹配路由且无默认验证器时应返回 ErrNoRoute，实际 %v", handled)
+	}
+}
+
+func TestNewRouter_InvalidPattern(t *testing.T) {
+	for _, routes := range [][]Route{
+		{{Pattern: ""}},
+		{{Pattern: "/a"}, {Pattern: "/a"}},
+		{{Pattern: "GET /{id"}},
+	} {
+		if _, err := NewRouter(Config{}, routes...); err == nil {
+			t.Errorf("%v: 应返回错误", routes)
+		}
+	}
+}
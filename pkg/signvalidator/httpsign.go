@@ -0,0 +1,162 @@
+package signvalidator
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+)
+
+// SignHTTPRequest 就地为 HTTP 请求签名：与 SignRequest 相同地补充时间戳、随机数与密钥标识，写入查询参数，
+// 签名写入 Authorization 请求头（配置了 Authorization 时，需要 KeyID）或 SignatureHeader 请求头，均未配置时为 SignatureKey 查询参数。配置了 CanonicalRequest 时按 GenerateRequestSignature 签名，
+// 否则签名参数为 RequestParams 合并的查询参数与请求体参数；配置了 ContentDigest 时生成摘要请求头，参数签名模式下其值同时参与签名。请求体读取后恢复并设置 GetBody，请求中已有的同名参数保持不变
+func (v *SignValidator) SignHTTPRequest(r *http.Request) error {
+	if v.err != nil {
+		return v.err
+	}
+	return v.signHTTPRequest(r, v.config.SignatureHeader)
+}
+
+// HTTPVerification VerifyHTTPRequest 的验证结果
+type HTTPVerification struct {
+	// Valid 签名是否有效
+	Valid bool
+	// Algorithm 验证通过的签名算法
+	Algorithm SignAlgorithm
+	// SecretName 验证通过的密钥名称，规则同 ValidateSecrets
+	SecretName string
+	// KeyID 请求的密钥标识（KeyIDKey 查询参数或 KeyIDHeader 请求头），可能为空
+	KeyID string
+	// BodyHash 请求体摘要（CanonicalRequest.BodyHash 算法，默认 SHA256）的十六进制字符串
+	BodyHash string
+	// Canonical 待签名的规范化请求，可用于排查签名不一致
+	Canonical string
+}
+
+// VerifyHTTPRequest 验证 HTTP 请求的签名，签名覆盖方法、路径、查询参数、SignedHeaders 指定的请求头与请求体摘要（规则同 ValidateRequest），
+// 签名依次按 SignatureSources 与 Authorization 请求头查找，均未找到时取自 SignatureHeader 请求头或 SignatureKey 查询参数。读取请求后总是返回验证结果，
+// 签名不匹配时 Valid 为 false 且错误为 nil；配置了 ContentDigest 时摘要请求头缺失或与请求体不一致返回错误。读取后恢复请求体
+func (v *SignValidator) VerifyHTTPRequest(r *http.Request) (*HTTPVerification, error) {
+	if v.err != nil {
+		return nil, v.err
+	}
+	c, params, err := v.withIncoming(r).withRequest(r)
+	if err != nil {
+		return nil, err
+	}
+	signature, err := c.applySources(r, params)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &HTTPVerification{
+		KeyID:     c.KeyID(params, r),
+		BodyHash:  c.request.bodyHash,
+		Canonical: c.request.canonical,
+	}
+	switch {
+	case signature != "":
+	case v.config.SignatureHeader != "":
+		signature = r.Header.Get(v.config.SignatureHeader)
+	default:
+		value, _ := v.lookup(params, v.config.SignatureKey)
+		signature, _ = value.(string)
+	}
+	if signature == "" {
+		err := &MissingKeysError{Keys: []string{v.signatureSource()}}
+		c.report(params, err)
+		return result, err
+	}
+	if _, err := c.verifyContentDigest(r); err != nil {
+		c.report(params, err)
+		return result, err
+	}
+
+	algorithm, name, valid, err := c.validateAlgorithms(params, signature)
+	if !valid {
+		c.report(params, err)
+	}
+	result.Valid = valid
+	if valid {
+		result.Algorithm, result.SecretName = algorithm, name
+	}
+	return result, err
+}
+
+// signatureSource 返回 HTTP 请求中签名所在的请求头或查询参数名称
+func (v *SignValidator) signatureSource() string {
+	if v.config.Authorization != nil {
+		return v.config.Authorization.header()
+	}
+	if v.config.SignatureHeader != "" {
+		return v.config.SignatureHeader
+	}
+	return v.config.SignatureKey
+}
+
+// signHTTPRequest 为请求补充参数并写入签名，signatureHeader 为空时签名写入 SignatureKey 查询参数
+func (v *SignValidator) signHTTPRequest(r *http.Request, signatureHeader string) error {
+	body, err := readBody(r, v.config.MaxBodyBytes)
+	if err != nil {
+		return err
+	}
+	if r.Body != nil {
+		r.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(body)), nil
+		}
+	}
+
+	var digest string
+	if d := v.config.ContentDigest; d != nil {
+		if digest, err = d.Generate(body); err != nil {
+			return err
+		}
+		r.Header.Set(d.header(), digest)
+	}
+
+	query := r.URL.Query()
+	var params map[string]interface{}
+	if v.config.CanonicalRequest != nil {
+		params = queryParams(query)
+	} else if params, err = requestParams(r, v.config.MaxBodyBytes); err != nil {
+		return err
+	}
+	signed, err := v.stamp(params)
+	if err != nil {
+		return err
+	}
+	for key, value := range signed {
+		if _, exists := params[key]; !exists {
+			query.Set(key, convertToString(value))
+		}
+	}
+	r.URL.RawQuery = query.Encode()
+	if digest != "" && v.config.CanonicalRequest == nil {
+		signed[v.config.ContentDigest.key()] = digest
+	}
+
+	var signature string
+	if v.config.CanonicalRequest != nil {
+		signature, err = v.GenerateRequestSignature(r)
+	} else {
+		signature, err = v.GenerateSignatureContext(r.Context(), signed)
+	}
+	if err != nil {
+		return err
+	}
+
+	if v.config.Authorization != nil {
+		if v.config.KeyID == "" {
+			return errors.New("Authorization 请求头需要配置 KeyID")
+		}
+		r.Header.Set(v.config.Authorization.header(), v.config.Authorization.Format(v.config.KeyID, signature))
+		return nil
+	}
+	if signatureHeader != "" {
+		r.Header.Set(signatureHeader, signature)
+		return nil
+	}
+	query.Set(v.config.SignatureKey, signature)
+	r.URL.RawQuery = query.Encode()
+	return nil
+}
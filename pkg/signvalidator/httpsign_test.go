@@ -0,0 +1,143 @@
+package signvalidator
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSignHTTPRequest(t *testing.T) {
+	clock := NewFakeClock(time.Unix(1700000000, 0))
+	v := NewSignValidator(Config{
+		Secret:       "testSecret",
+		Algorithm:    HMAC_SHA256,
+		TimestampKey: "timestamp",
+		NonceStore:   NewMemoryNonceStore(0),
+		Clock:        clock,
+	})
+
+	r := httptest.NewRequest("POST", "/orders?app_id=a", strings.NewReader("amount=100"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if err := v.SignHTTPRequest(r); err != nil {
+		t.Fatalf("签名请求失败: %v", err)
+	}
+	query := r.URL.Query()
+	if query.Get("timestamp") != "1700000000" || len(query.Get("nonce")) != 32 || query.Get("sign") == "" {
+		t.Errorf("应补充时间戳、随机数与签名参数: %s", r.URL.RawQuery)
+	}
+	if _, valid, err := v.ValidateRequestParams(r, ""); err != nil || !valid {
+		t.Errorf("签名后的请求应验证通过: valid=%v, err=%v", valid, err)
+	}
+
+	if body, _ := io.ReadAll(r.Body); string(body) != "amount=100" {
+		t.Errorf("请求体 = %q, 应被恢复", body)
+	}
+	if r.GetBody == nil {
+		t.Error("应设置 GetBody")
+	} else if body, _ := r.GetBody(); body != nil {
+		if data, _ := io.ReadAll(body); string(data) != "amount=100" {
+			t.Errorf("GetBody 返回的请求体 = %q", data)
+		}
+	}
+}
+
+func TestSignHTTPRequest_Header(t *testing.T) {
+	v := NewSignValidator(Config{
+		Secret:           "testSecret",
+		Algorithm:        HMAC_SHA256,
+		SignatureHeader:  "X-Signature",
+		CanonicalRequest: &CanonicalRequest{SignedHeaders: []string{"Host"}},
+	})
+
+	r := httptest.NewRequest("PUT", "http://api.example.com/files/1?nonce=fixed", strings.NewReader("raw bytes"))
+	if err := v.SignHTTPRequest(r); err != nil {
+		t.Fatalf("签名请求失败: %v", err)
+	}
+	if r.URL.Query().Has("sign") || r.Header.Get("X-Signature") == "" {
+		t.Errorf("签名应写入请求头: %s %v", r.URL.RawQuery, r.Header)
+	}
+	if r.URL.Query().Get("nonce") != "fixed" || !r.URL.Query().Has("timestamp") {
+		t.Errorf("应保留已有随机数并补充时间戳: %s", r.URL.RawQuery)
+	}
+	if valid, err := v.ValidateRequest(r, r.Header.Get("X-Signature")); err != nil || !valid {
+		t.Errorf("规范化请求签名应验证通过: valid=%v, err=%v", valid, err)
+	}
+}
+
+func TestVerifyHTTPRequest(t *testing.T) {
+	v := NewSignValidator(Config{
+		Secrets:          []Secret{{Name: "partner-a", Value: "secret-a"}, {Name: "partner-b", Value: "secret-b"}},
+		Algorithm:        HMAC_SHA256,
+		SignatureHeader:  "X-Signature",
+		KeyIDHeader:      "X-Key-Id",
+		CanonicalRequest: &CanonicalRequest{SignedHeaders: []string{"Host", "Content-Type"}},
+	})
+	signer := NewSignValidator(Config{
+		Secret:           "secret-b",
+		Algorithm:        HMAC_SHA256,
+		SignatureHeader:  "X-Signature",
+		CanonicalRequest: &CanonicalRequest{SignedHeaders: []string{"Host", "Content-Type"}},
+	})
+
+	newRequest := func(body string) *http.Request {
+		r := httptest.NewRequest("POST", "http://api.example.com/orders?b=2&a=1", strings.NewReader(body))
+		r.Header.Set("Content-Type", "application/json")
+		r.Header.Set("X-Key-Id", "partner-b")
+		return r
+	}
+	r := newRequest(`{"amount":100}`)
+	if err := signer.SignHTTPRequest(r); err != nil {
+		t.Fatalf("签名请求失败: %v", err)
+	}
+
+	result, err := v.VerifyHTTPRequest(r)
+	if err != nil || !result.Valid {
+		t.Fatalf("签名应验证通过: %+v, err=%v", result, err)
+	}
+	bodyHash := sha256.Sum256([]byte(`{"amount":100}`))
+	if result.BodyHash != hex.EncodeToString(bodyHash[:]) {
+		t.Errorf("请求体摘要 = %s", result.BodyHash)
+	}
+	if result.SecretName != "partner-b" || result.KeyID != "partner-b" || result.Algorithm != HMAC_SHA256 {
+		t.Errorf("验证结果不正确: %+v", result)
+	}
+	if !strings.HasPrefix(result.Canonical, "POST\n/orders\n") {
+		t.Errorf("规范化请求 = %q", result.Canonical)
+	}
+
+	tampered := newRequest(`{"amount":999}`)
+	tampered.URL.RawQuery = r.URL.RawQuery
+	tampered.Header.Set("X-Signature", r.Header.Get("X-Signature"))
+	if result, err := v.VerifyHTTPRequest(tampered); err != nil || result.Valid {
+		t.Errorf("请求体被篡改时应验证失败: %+v, err=%v", result, err)
+	}
+
+	var missing *MissingKeysError
+	if _, err := v.VerifyHTTPRequest(newRequest("")); !errors.As(err, &missing) || missing.Keys[0] != "X-Signature" {
+		t.Errorf("缺少签名请求头时应返回 *MissingKeysError，实际 %v", err)
+	}
+}
+
+func TestVerifyHTTPRequest_CaseInsensitiveKeys(t *testing.T) {
+	v := NewSignValidator(Config{Secret: "testSecret", Algorithm: HMAC_SHA256, CanonicalRequest: &CanonicalRequest{}, CaseInsensitiveKeys: true})
+	r := httptest.NewRequest("GET", "/orders?app_id=a", nil)
+	if err := v.SignHTTPRequest(r); err != nil {
+		t.Fatalf("签名请求失败: %v", err)
+	}
+
+	// 大小写不同的签名参数同样作为签名读取
+	query := r.URL.Query()
+	query.Set("SIGN", query.Get("sign"))
+	query.Del("sign")
+	r.URL.RawQuery = query.Encode()
+	result, err := v.VerifyHTTPRequest(r)
+	if err != nil || !result.Valid {
+		t.Errorf("签名验证失败: result=%+v, err=%v", result, err)
+	}
+}
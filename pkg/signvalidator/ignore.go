@@ -0,0 +1,62 @@
+package signvalidator
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// ignorePattern IgnoreKeys 中的通配符或正则表达式
+type ignorePattern struct {
+	glob string
+	re   *regexp.Regexp
+}
+
+// compileIgnoreKeys 解析 IgnoreKeys 中的通配符（包含 * ? [）与 "re:" 前缀的正则表达式
+func (v *SignValidator) compileIgnoreKeys() error {
+	for _, key := range v.config.IgnoreKeys {
+		switch {
+		case strings.HasPrefix(key, "re:"):
+			expr := key[len("re:"):]
+			if v.config.CaseInsensitiveKeys {
+				expr = "(?i)" + expr
+			}
+			re, err := regexp.Compile(expr)
+			if err != nil {
+				return fmt.Errorf("忽略参数正则表达式 %s 无效: %w", key, err)
+			}
+			v.ignorePatterns = append(v.ignorePatterns, ignorePattern{re: re})
+		case strings.ContainsAny(key, "*?["):
+			glob := key
+			if v.config.CaseInsensitiveKeys {
+				glob = strings.ToLower(glob)
+			}
+			if _, err := path.Match(glob, ""); err != nil {
+				return fmt.Errorf("忽略参数通配符 %s 无效: %w", key, err)
+			}
+			v.ignorePatterns = append(v.ignorePatterns, ignorePattern{glob: glob})
+		}
+	}
+	return nil
+}
+
+// ignoreKey 判断参数名是否匹配 IgnoreKeys 中的通配符或正则表达式
+func (v *SignValidator) ignoreKey(key string) bool {
+	for _, pattern := range v.ignorePatterns {
+		if pattern.re != nil {
+			if pattern.re.MatchString(key) {
+				return true
+			}
+			continue
+		}
+		name := key
+		if v.config.CaseInsensitiveKeys {
+			name = strings.ToLower(name)
+		}
+		if matched, _ := path.Match(pattern.glob, name); matched {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,68 @@
+package signvalidator
+
+import "testing"
+
+func TestSignValidator_IgnorePatterns(t *testing.T) {
+	skipFIPS(t)
+	params := map[string]interface{}{
+		"id":          1,
+		"debug_trace": "x",
+		"debug_level": 2,
+		"_t1":         3,
+		"_tx":         4,
+		"X-Trace-Id":  "abc",
+	}
+
+	validator, err := New(Config{Algorithm: MD5, IgnoreKeys: []string{"debug_*", `re:^_t\d+$`, "X-Trace-Id"}})
+	if err != nil {
+		t.Fatalf("创建签名验证器失败: %v", err)
+	}
+	if s, _ := validator.buildStringToSign(params); s != "_tx=4&id=1" {
+		t.Errorf("待签名字符串 = %s", s)
+	}
+
+	// 忽略大小写时通配符与正则表达式同样忽略大小写
+	validator, err = New(Config{Algorithm: MD5, CaseInsensitiveKeys: true, IgnoreKeys: []string{"DEBUG_*", "re:^x-trace"}})
+	if err != nil {
+		t.Fatalf("创建签名验证器失败: %v", err)
+	}
+	if s, _ := validator.buildStringToSign(params); s != "_t1=3&_tx=4&id=1" {
+		t.Errorf("待签名字符串 = %s", s)
+	}
+
+	for _, pattern := range []string{"re:(", "debug_[", "[a-"} {
+		if _, err := New(Config{Algorithm: MD5, IgnoreKeys: []string{pattern}}); err == nil {
+			t.Errorf("%q 无效的模式应该返回错误", pattern)
+		}
+	}
+}
+
+func TestSignValidator_IncludeKeys(t *testing.T) {
+	skipFIPS(t)
+	params := map[string]interface{}{"app_id": "demo", "amount": 100, "timestamp": 1700000000, "extra": "x", "sign": "xxx"}
+
+	validator := NewSignValidator(Config{Secret: "testSecret", Algorithm: MD5, IncludeKeys: []string{"app_id", "amount", "timestamp", "sign"}})
+	if s, _ := validator.buildStringToSign(params); s != "amount=100&app_id=demo&timestamp=1700000000&key=testSecret" {
+		t.Errorf("待签名字符串 = %s", s)
+	}
+
+	// 新增的参数不影响签名
+	signature, err := validator.GenerateSignature(params)
+	if err != nil {
+		t.Fatalf("生成签名失败: %v", err)
+	}
+	params["new_field"] = "y"
+	valid, err := validator.Validate(params, signature)
+	if err != nil {
+		t.Fatalf("验证签名失败: %v", err)
+	}
+	if !valid {
+		t.Errorf("签名验证失败")
+	}
+
+	// IgnoreKeys 优先于 IncludeKeys
+	validator = NewSignValidator(Config{Algorithm: MD5, IncludeKeys: []string{"app_id", "amount"}, IgnoreKeys: []string{"amount"}})
+	if s, _ := validator.buildStringToSign(params); s != "app_id=demo" {
+		t.Errorf("待签名字符串 = %s", s)
+	}
+}
@@ -0,0 +1,163 @@
+// Package blake2b 实现 RFC 7693 定义的 BLAKE2b 哈希算法，支持带密钥模式
+package blake2b
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash"
+	"math/bits"
+)
+
+const (
+	// BlockSize BLAKE2b 分组长度（字节）
+	BlockSize = 128
+	// Size BLAKE2b-512 摘要长度（字节）
+	Size = 64
+	// Size256 BLAKE2b-256 摘要长度（字节）
+	Size256 = 32
+	// MaxKeySize 密钥最大长度（字节）
+	MaxKeySize = 64
+)
+
+var iv = [8]uint64{
+	0x6a09e667f3bcc908, 0xbb67ae8584caa73b, 0x3c6ef372fe94f82b, 0xa54ff53a5f1d36f1,
+	0x510e527fade682d1, 0x9b05688c2b3e6c1f, 0x1f83d9abfb41bd6b, 0x5be0cd19137e2179,
+}
+
+var sigma = [12][16]byte{
+	{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15},
+	{14, 10, 4, 8, 9, 15, 13, 6, 1, 12, 0, 2, 11, 7, 5, 3},
+	{11, 8, 12, 0, 5, 2, 15, 13, 10, 14, 3, 6, 7, 1, 9, 4},
+	{7, 9, 3, 1, 13, 12, 11, 14, 2, 6, 5, 10, 4, 0, 15, 8},
+	{9, 0, 5, 7, 2, 4, 10, 15, 14, 1, 11, 12, 6, 8, 3, 13},
+	{2, 12, 6, 10, 0, 11, 8, 3, 4, 13, 7, 5, 15, 14, 1, 9},
+	{12, 5, 1, 15, 14, 13, 4, 10, 0, 7, 6, 3, 9, 2, 8, 11},
+	{13, 11, 7, 14, 12, 1, 3, 9, 5, 0, 15, 4, 8, 6, 2, 10},
+	{6, 15, 14, 9, 11, 3, 0, 8, 12, 2, 13, 7, 1, 4, 10, 5},
+	{10, 2, 8, 4, 7, 6, 1, 5, 15, 11, 9, 14, 3, 12, 13, 0},
+	{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15},
+	{14, 10, 4, 8, 9, 15, 13, 6, 1, 12, 0, 2, 11, 7, 5, 3},
+}
+
+type digest struct {
+	h      [8]uint64
+	t      [2]uint64
+	x      [BlockSize]byte
+	nx     int
+	size   int
+	key    [BlockSize]byte
+	keyLen int
+}
+
+// New 创建指定摘要长度的 BLAKE2b 哈希实例，key 非空时为带密钥模式
+func New(size int, key []byte) (hash.Hash, error) {
+	if size < 1 || size > Size {
+		return nil, errors.New("blake2b: 摘要长度无效")
+	}
+	if len(key) > MaxKeySize {
+		return nil, errors.New("blake2b: 密钥长度不能超过 64 字节")
+	}
+	d := &digest{size: size, keyLen: len(key)}
+	copy(d.key[:], key)
+	d.Reset()
+	return d, nil
+}
+
+// New256 创建 BLAKE2b-256 哈希实例
+func New256(key []byte) (hash.Hash, error) { return New(Size256, key) }
+
+// New512 创建 BLAKE2b-512 哈希实例
+func New512(key []byte) (hash.Hash, error) { return New(Size, key) }
+
+func (d *digest) Reset() {
+	d.h = iv
+	d.h[0] ^= uint64(d.size) | uint64(d.keyLen)<<8 | 1<<16 | 1<<24
+	d.t = [2]uint64{}
+	d.nx = 0
+	// 带密钥模式下，密钥填充为一个完整分组作为首个输入
+	if d.keyLen > 0 {
+		d.x = d.key
+		d.nx = BlockSize
+	}
+}
+
+func (d *digest) Size() int { return d.size }
+
+func (d *digest) BlockSize() int { return BlockSize }
+
+func (d *digest) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 {
+		// 最后一个分组需要在 Sum 时带终止标志压缩，因此缓冲区满且仍有输入时才压缩
+		if d.nx == BlockSize {
+			d.compress(false)
+			d.nx = 0
+		}
+		c := copy(d.x[d.nx:], p)
+		d.nx += c
+		p = p[c:]
+	}
+	return n, nil
+}
+
+func (d *digest) Sum(in []byte) []byte {
+	c := *d
+	for i := c.nx; i < BlockSize; i++ {
+		c.x[i] = 0
+	}
+	c.compress(true)
+
+	var out [Size]byte
+	for i, v := range c.h {
+		binary.LittleEndian.PutUint64(out[i*8:], v)
+	}
+	return append(in, out[:c.size]...)
+}
+
+func (d *digest) compress(last bool) {
+	d.t[0] += uint64(d.nx)
+	if d.t[0] < uint64(d.nx) {
+		d.t[1]++
+	}
+
+	var m [16]uint64
+	for i := range m {
+		m[i] = binary.LittleEndian.Uint64(d.x[i*8:])
+	}
+
+	var v [16]uint64
+	copy(v[:8], d.h[:])
+	copy(v[8:], iv[:])
+	v[12] ^= d.t[0]
+	v[13] ^= d.t[1]
+	if last {
+		v[14] = ^v[14]
+	}
+
+	for i := 0; i < 12; i++ {
+		s := &sigma[i]
+		g(&v, 0, 4, 8, 12, m[s[0]], m[s[1]])
+		g(&v, 1, 5, 9, 13, m[s[2]], m[s[3]])
+		g(&v, 2, 6, 10, 14, m[s[4]], m[s[5]])
+		g(&v, 3, 7, 11, 15, m[s[6]], m[s[7]])
+		g(&v, 0, 5, 10, 15, m[s[8]], m[s[9]])
+		g(&v, 1, 6, 11, 12, m[s[10]], m[s[11]])
+		g(&v, 2, 7, 8, 13, m[s[12]], m[s[13]])
+		g(&v, 3, 4, 9, 14, m[s[14]], m[s[15]])
+	}
+
+	for i := 0; i < 8; i++ {
+		d.h[i] ^= v[i] ^ v[i+8]
+	}
+}
+
+func g(v *[16]uint64, a, b, c, d int, x, y uint64) {
+	v[a] = v[a] + v[b] + x
+	v[d] = bits.RotateLeft64(v[d]^v[a], -32)
+	v[c] = v[c] + v[d]
+	v[b] = bits.RotateLeft64(v[b]^v[c], -24)
+	v[a] = v[a] + v[b] + y
+	v[d] = bits.RotateLeft64(v[d]^v[a], -16)
+	v[c] = v[c] + v[d]
+	v[b] = bits.RotateLeft64(v[b]^v[c], -63)
+}
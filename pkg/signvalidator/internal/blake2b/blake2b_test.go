@@ -0,0 +1,52 @@
+package blake2b
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+func TestSum(t *testing.T) {
+	testCases := []struct {
+		size     int
+		key      string
+		input    string
+		expected string
+	}{
+		// RFC 7693 附录 A
+		{Size, "", "abc", "ba80a53f981c4d0d6a2797b69f12f6e94c212f14685ac4b74b12bb6fdbffa2d17d87c5392aab792dc252d5de4533cc9518d38aa8dbf1925ab92386edd4009923"},
+		{Size256, "", "abc", "bddd813c634239723171ef3fee98579b94964e3bb1cb3e427262c8c068d52319"},
+		// 恰好一个分组
+		{Size, "", strings.Repeat("y", 128), "71a796ac8d94e4f83af8d4698529be647007fef7effd29fdbd6f121c952611452aab9034e15ade1e9931923e857bac166e9f90979354684d11e956fc2248a294"},
+		// 带密钥模式
+		{Size256, "secret", "The quick brown fox jumps over the lazy dog", "12662d1fd4d81c598f7c105d5844a9aeb50b7f7b3f4ca2249e6dc9fbe4a6bea1"},
+		{Size, "secret", "", "865aca2ba0b9b941352e4680e14f543d1af37f7a3479304262a5da8c97468d9fe22636bae941d9c7b83b93efc36e82177606c72a1c00af48bb182c69d1f1abc3"},
+		{Size, strings.Repeat("k", 64), strings.Repeat("x", 300), "38f35d4c78d1d347f9f2b21d1d339ac9ebfeba4ddc6fa2d02bd6b669d4b50b29580ee2051e0ad8256947b2b2e47472b909c12757e022634d5d30c65c583aaa35"},
+	}
+
+	for _, tc := range testCases {
+		h, err := New(tc.size, []byte(tc.key))
+		if err != nil {
+			t.Fatalf("创建哈希实例失败: %v", err)
+		}
+
+		// 分段写入以覆盖缓冲逻辑
+		for i := 0; i < len(tc.input); i += 50 {
+			end := i + 50
+			if end > len(tc.input) {
+				end = len(tc.input)
+			}
+			h.Write([]byte(tc.input[i:end]))
+		}
+
+		if result := hex.EncodeToString(h.Sum(nil)); result != tc.expected {
+			t.Errorf("BLAKE2b-%d(key=%q, %d 字节) = %s, 期望 %s", tc.size*8, tc.key, len(tc.input), result, tc.expected)
+		}
+	}
+}
+
+func TestNew_InvalidKey(t *testing.T) {
+	if _, err := New(Size, make([]byte, 65)); err == nil {
+		t.Errorf("密钥超过 64 字节时应该返回错误")
+	}
+}
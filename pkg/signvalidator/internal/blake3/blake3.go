@@ -0,0 +1,254 @@
+// Package blake3 实现 BLAKE3 哈希算法的哈希与带密钥模式（基于官方参考实现）
+package blake3
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash"
+	"math/bits"
+)
+
+const (
+	// Size 默认摘要长度（字节）
+	Size = 32
+	// KeySize 带密钥模式的密钥长度（字节）
+	KeySize = 32
+	// BlockSize 分组长度（字节）
+	BlockSize = 64
+
+	chunkLen = 1024
+
+	flagChunkStart = 1 << 0
+	flagChunkEnd   = 1 << 1
+	flagParent     = 1 << 2
+	flagRoot       = 1 << 3
+	flagKeyedHash  = 1 << 4
+)
+
+var iv = [8]uint32{
+	0x6a09e667, 0xbb67ae85, 0x3c6ef372, 0xa54ff53a,
+	0x510e527f, 0x9b05688c, 0x1f83d9ab, 0x5be0cd19,
+}
+
+var msgPermutation = [16]int{2, 6, 3, 10, 7, 0, 4, 13, 1, 11, 12, 5, 9, 14, 15, 8}
+
+func g(state *[16]uint32, a, b, c, d int, mx, my uint32) {
+	state[a] = state[a] + state[b] + mx
+	state[d] = bits.RotateLeft32(state[d]^state[a], -16)
+	state[c] = state[c] + state[d]
+	state[b] = bits.RotateLeft32(state[b]^state[c], -12)
+	state[a] = state[a] + state[b] + my
+	state[d] = bits.RotateLeft32(state[d]^state[a], -8)
+	state[c] = state[c] + state[d]
+	state[b] = bits.RotateLeft32(state[b]^state[c], -7)
+}
+
+func round(state *[16]uint32, m *[16]uint32) {
+	g(state, 0, 4, 8, 12, m[0], m[1])
+	g(state, 1, 5, 9, 13, m[2], m[3])
+	g(state, 2, 6, 10, 14, m[4], m[5])
+	g(state, 3, 7, 11, 15, m[6], m[7])
+	g(state, 0, 5, 10, 15, m[8], m[9])
+	g(state, 1, 6, 11, 12, m[10], m[11])
+	g(state, 2, 7, 8, 13, m[12], m[13])
+	g(state, 3, 4, 9, 14, m[14], m[15])
+}
+
+func compress(cv *[8]uint32, block *[16]uint32, counter uint64, blockLen, flags uint32) [16]uint32 {
+	state := [16]uint32{
+		cv[0], cv[1], cv[2], cv[3], cv[4], cv[5], cv[6], cv[7],
+		iv[0], iv[1], iv[2], iv[3],
+		uint32(counter), uint32(counter >> 32), blockLen, flags,
+	}
+	m := *block
+	for i := 0; i < 7; i++ {
+		round(&state, &m)
+		if i < 6 {
+			var permuted [16]uint32
+			for j := range permuted {
+				permuted[j] = m[msgPermutation[j]]
+			}
+			m = permuted
+		}
+	}
+	for i := 0; i < 8; i++ {
+		state[i] ^= state[i+8]
+		state[i+8] ^= cv[i]
+	}
+	return state
+}
+
+func first8(words [16]uint32) [8]uint32 {
+	var out [8]uint32
+	copy(out[:], words[:8])
+	return out
+}
+
+func wordsFromBytes(b []byte) [16]uint32 {
+	var words [16]uint32
+	for i := range words {
+		words[i] = binary.LittleEndian.Uint32(b[i*4:])
+	}
+	return words
+}
+
+type output struct {
+	inputCV  [8]uint32
+	block    [16]uint32
+	counter  uint64
+	blockLen uint32
+	flags    uint32
+}
+
+func (o *output) chainingValue() [8]uint32 {
+	return first8(compress(&o.inputCV, &o.block, o.counter, o.blockLen, o.flags))
+}
+
+func (o *output) rootBytes(out []byte) {
+	var counter uint64
+	for len(out) > 0 {
+		words := compress(&o.inputCV, &o.block, counter, o.blockLen, o.flags|flagRoot)
+		var buf [64]byte
+		for i, w := range words {
+			binary.LittleEndian.PutUint32(buf[i*4:], w)
+		}
+		n := copy(out, buf[:])
+		out = out[n:]
+		counter++
+	}
+}
+
+type chunkState struct {
+	cv               [8]uint32
+	chunkCounter     uint64
+	block            [BlockSize]byte
+	blockLen         int
+	blocksCompressed int
+	flags            uint32
+}
+
+func newChunkState(key [8]uint32, chunkCounter uint64, flags uint32) chunkState {
+	return chunkState{cv: key, chunkCounter: chunkCounter, flags: flags}
+}
+
+func (c *chunkState) len() int {
+	return BlockSize*c.blocksCompressed + c.blockLen
+}
+
+func (c *chunkState) startFlag() uint32 {
+	if c.blocksCompressed == 0 {
+		return flagChunkStart
+	}
+	return 0
+}
+
+func (c *chunkState) update(input []byte) {
+	for len(input) > 0 {
+		// 当前分组已满且仍有输入时压缩，最后一个分组留给 output 处理
+		if c.blockLen == BlockSize {
+			words := wordsFromBytes(c.block[:])
+			c.cv = first8(compress(&c.cv, &words, c.chunkCounter, BlockSize, c.flags|c.startFlag()))
+			c.blocksCompressed++
+			c.block = [BlockSize]byte{}
+			c.blockLen = 0
+		}
+		n := copy(c.block[c.blockLen:], input)
+		c.blockLen += n
+		input = input[n:]
+	}
+}
+
+func (c *chunkState) output() output {
+	return output{
+		inputCV:  c.cv,
+		block:    wordsFromBytes(c.block[:]),
+		counter:  c.chunkCounter,
+		blockLen: uint32(c.blockLen),
+		flags:    c.flags | c.startFlag() | flagChunkEnd,
+	}
+}
+
+func parentOutput(left, right [8]uint32, key [8]uint32, flags uint32) output {
+	var block [16]uint32
+	copy(block[:8], left[:])
+	copy(block[8:], right[:])
+	return output{inputCV: key, block: block, blockLen: BlockSize, flags: flagParent | flags}
+}
+
+type hasher struct {
+	key     [8]uint32
+	chunk   chunkState
+	cvStack [][8]uint32
+	flags   uint32
+}
+
+// New 创建 BLAKE3 哈希实例
+func New() hash.Hash {
+	return newHasher(iv, 0)
+}
+
+// NewKeyed 创建带密钥模式的 BLAKE3 实例，密钥必须为 32 字节
+func NewKeyed(key []byte) (hash.Hash, error) {
+	if len(key) != KeySize {
+		return nil, errors.New("blake3: 密钥长度必须为 32 字节")
+	}
+	var words [8]uint32
+	for i := range words {
+		words[i] = binary.LittleEndian.Uint32(key[i*4:])
+	}
+	return newHasher(words, flagKeyedHash), nil
+}
+
+func newHasher(key [8]uint32, flags uint32) *hasher {
+	return &hasher{key: key, chunk: newChunkState(key, 0, flags), flags: flags}
+}
+
+func (h *hasher) addChunkChainingValue(cv [8]uint32, totalChunks uint64) {
+	// 每个完整子树合并为父节点，栈中保留的节点数等于 totalChunks 二进制中 1 的个数
+	for totalChunks&1 == 0 {
+		top := h.cvStack[len(h.cvStack)-1]
+		h.cvStack = h.cvStack[:len(h.cvStack)-1]
+		parent := parentOutput(top, cv, h.key, h.flags)
+		cv = parent.chainingValue()
+		totalChunks >>= 1
+	}
+	h.cvStack = append(h.cvStack, cv)
+}
+
+func (h *hasher) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 {
+		if h.chunk.len() == chunkLen {
+			out := h.chunk.output()
+			totalChunks := h.chunk.chunkCounter + 1
+			h.addChunkChainingValue(out.chainingValue(), totalChunks)
+			h.chunk = newChunkState(h.key, totalChunks, h.flags)
+		}
+		want := chunkLen - h.chunk.len()
+		if want > len(p) {
+			want = len(p)
+		}
+		h.chunk.update(p[:want])
+		p = p[want:]
+	}
+	return n, nil
+}
+
+func (h *hasher) Sum(in []byte) []byte {
+	out := h.chunk.output()
+	for i := len(h.cvStack) - 1; i >= 0; i-- {
+		out = parentOutput(h.cvStack[i], out.chainingValue(), h.key, h.flags)
+	}
+	var sum [Size]byte
+	out.rootBytes(sum[:])
+	return append(in, sum[:]...)
+}
+
+func (h *hasher) Reset() {
+	h.chunk = newChunkState(h.key, 0, h.flags)
+	h.cvStack = h.cvStack[:0]
+}
+
+func (h *hasher) Size() int { return Size }
+
+func (h *hasher) BlockSize() int { return BlockSize }
@@ -0,0 +1,74 @@
+package blake3
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+// 官方测试向量：输入为 i % 251 的字节序列，密钥为 "whats the Elvish word for friend"
+func TestVectors(t *testing.T) {
+	testCases := []struct {
+		length int
+		hash   string
+		keyed  string
+	}{
+		{0, "af1349b9f5f9a1a6a0404dea36dcc9499bcb25c9adc112b7cc9a93cae41f3262", "92b2b75604ed3c761f9d6f62392c8a9227ad0ea3f09573e783f1498a4ed60d26"},
+		{1, "2d3adedff11b61f14c886e35afa036736dcd87a74d27b5c1510225d0f592e213", "6d7878dfff2f485635d39013278ae14f1454b8c0a3a2d34bc1ab38228a80c95b"},
+		{64, "4eed7141ea4a5cd4b788606bd23f46e212af9cacebacdc7d1f4c6dc7f2511b98", "ba8ced36f327700d213f120b1a207a3b8c04330528586f414d09f2f7d9ccb7e6"},
+		{65, "de1e5fa0be70df6d2be8fffd0e99ceaa8eb6e8c93a63f2d8d1c30ecb6b263dee", "c0a4edefa2d2accb9277c371ac12fcdbb52988a86edc54f0716e1591b4326e72"},
+		{1023, "10108970eeda3eb932baac1428c7a2163b0e924c9a9e25b35bba72b28f70bd11", "c951ecdf03288d0fcc96ee3413563d8a6d3589547f2c2fb36d9786470f1b9d6e"},
+		{1024, "42214739f095a406f3fc83deb889744ac00df831c10daa55189b5d121c855af7", "75c46f6f3d9eb4f55ecaaee480db732e6c2105546f1e675003687c31719c7ba4"},
+		{1025, "d00278ae47eb27b34faecf67b4fe263f82d5412916c1ffd97c8cb7fb814b8444", "357dc55de0c7e382c900fd6e320acc04146be01db6a8ce7210b7189bd664ea69"},
+		{2049, "5f4d72f40d7a5f82b15ca2b2e44b1de3c2ef86c426c95c1af0b6879522563030", "9f29700902f7c86e514ddc4df1e3049f258b2472b6dd5267f61bf13983b78dd5"},
+		{3073, "7124b49501012f81cc7f11ca069ec9226cecb8a2c850cfe644e327d22d3e1cd3", "68dede9bef00ba89e43f31a6825f4cf433389fedae75c04ee9f0cf16a427c95a"},
+		{5120, "9cadc15fed8b5d854562b26a9536d9707cadeda9b143978f319ab34230535833", "2c493e48e9b9bf31e0553a22b23503c0a3388f035cece68eb438d22fa1943e20"},
+		{8193, "bab6c09cb8ce8cf459261398d2e7aef35700bf488116ceb94a36d0f5f1b7bc3b", "954a2a75420c8d6547e3ba5b98d963e6fa6491addc8c023189cc519821b4a1f5"},
+	}
+
+	input := make([]byte, 8193)
+	for i := range input {
+		input[i] = byte(i % 251)
+	}
+	key := []byte("whats the Elvish word for friend")
+
+	for _, tc := range testCases {
+		h := New()
+		// 分段写入以覆盖分组与块边界
+		for i := 0; i < tc.length; i += 100 {
+			end := i + 100
+			if end > tc.length {
+				end = tc.length
+			}
+			h.Write(input[i:end])
+		}
+		if result := hex.EncodeToString(h.Sum(nil)); result != tc.hash {
+			t.Errorf("BLAKE3(%d 字节) = %s, 期望 %s", tc.length, result, tc.hash)
+		}
+
+		k, err := NewKeyed(key)
+		if err != nil {
+			t.Fatalf("创建带密钥实例失败: %v", err)
+		}
+		k.Write(input[:tc.length])
+		if result := hex.EncodeToString(k.Sum(nil)); result != tc.keyed {
+			t.Errorf("带密钥 BLAKE3(%d 字节) = %s, 期望 %s", tc.length, result, tc.keyed)
+		}
+	}
+}
+
+func TestSumDoesNotChangeState(t *testing.T) {
+	h := New()
+	h.Write([]byte("ab"))
+	h.Sum(nil)
+	h.Write([]byte("c"))
+
+	if result := hex.EncodeToString(h.Sum(nil)); result != "6437b3ac38465133ffb63b75273a8db548c558465d79db03fd359c6cd5bd9d85" {
+		t.Errorf("BLAKE3(abc) = %s", result)
+	}
+}
+
+func TestNewKeyed_InvalidKey(t *testing.T) {
+	if _, err := NewKeyed([]byte("short")); err == nil {
+		t.Errorf("密钥不是 32 字节时应该返回错误")
+	}
+}
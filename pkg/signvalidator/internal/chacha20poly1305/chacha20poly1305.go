@@ -0,0 +1,243 @@
+// Package chacha20poly1305 实现 RFC 8439 定义的 ChaCha20-Poly1305 认证标签计算。
+// 签名场景下明文为空，待签名字符串作为附加数据（AAD），标签即为消息认证码。
+package chacha20poly1305
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/bits"
+)
+
+const (
+	// KeySize 密钥长度（字节）
+	KeySize = 32
+	// NonceSize nonce 长度（字节）
+	NonceSize = 12
+	// TagSize 认证标签长度（字节）
+	TagSize = 16
+)
+
+// Tag 计算明文为空、附加数据为 aad 时的 AEAD 认证标签，
+// 与 ChaCha20-Poly1305 的 Seal(nil, nonce, nil, aad) 结果一致
+func Tag(key, nonce, aad []byte) ([]byte, error) {
+	return tag(key, nonce, aad, nil)
+}
+
+func tag(key, nonce, aad, ciphertext []byte) ([]byte, error) {
+	if len(key) != KeySize {
+		return nil, errors.New("chacha20poly1305: 密钥长度必须为 32 字节")
+	}
+	if len(nonce) != NonceSize {
+		return nil, errors.New("chacha20poly1305: nonce 长度必须为 12 字节")
+	}
+
+	// 一次性 Poly1305 密钥取计数器为 0 的密钥流前 32 字节
+	block := chacha20Block(key, nonce, 0)
+
+	p := newPoly1305(block[:32])
+	p.write(aad)
+	p.write(make([]byte, pad16(len(aad))))
+	p.write(ciphertext)
+	p.write(make([]byte, pad16(len(ciphertext))))
+
+	var lengths [16]byte
+	binary.LittleEndian.PutUint64(lengths[:8], uint64(len(aad)))
+	binary.LittleEndian.PutUint64(lengths[8:], uint64(len(ciphertext)))
+	p.write(lengths[:])
+
+	return p.sum(), nil
+}
+
+func pad16(n int) int {
+	if n%16 == 0 {
+		return 0
+	}
+	return 16 - n%16
+}
+
+func quarterRound(a, b, c, d uint32) (uint32, uint32, uint32, uint32) {
+	a += b
+	d = bits.RotateLeft32(d^a, 16)
+	c += d
+	b = bits.RotateLeft32(b^c, 12)
+	a += b
+	d = bits.RotateLeft32(d^a, 8)
+	c += d
+	b = bits.RotateLeft32(b^c, 7)
+	return a, b, c, d
+}
+
+// chacha20Block 计算一个 64 字节的 ChaCha20 密钥流分组
+func chacha20Block(key, nonce []byte, counter uint32) [64]byte {
+	var state [16]uint32
+	state[0], state[1], state[2], state[3] = 0x61707865, 0x3320646e, 0x79622d32, 0x6b206574
+	for i := 0; i < 8; i++ {
+		state[4+i] = binary.LittleEndian.Uint32(key[i*4:])
+	}
+	state[12] = counter
+	for i := 0; i < 3; i++ {
+		state[13+i] = binary.LittleEndian.Uint32(nonce[i*4:])
+	}
+
+	x := state
+	for i := 0; i < 10; i++ {
+		x[0], x[4], x[8], x[12] = quarterRound(x[0], x[4], x[8], x[12])
+		x[1], x[5], x[9], x[13] = quarterRound(x[1], x[5], x[9], x[13])
+		x[2], x[6], x[10], x[14] = quarterRound(x[2], x[6], x[10], x[14])
+		x[3], x[7], x[11], x[15] = quarterRound(x[3], x[7], x[11], x[15])
+		x[0], x[5], x[10], x[15] = quarterRound(x[0], x[5], x[10], x[15])
+		x[1], x[6], x[11], x[12] = quarterRound(x[1], x[6], x[11], x[12])
+		x[2], x[7], x[8], x[13] = quarterRound(x[2], x[7], x[8], x[13])
+		x[3], x[4], x[9], x[14] = quarterRound(x[3], x[4], x[9], x[14])
+	}
+
+	var out [64]byte
+	for i := range x {
+		binary.LittleEndian.PutUint32(out[i*4:], x[i]+state[i])
+	}
+	return out
+}
+
+// poly1305 以 5 个 26 位分量表示累加器的 Poly1305 实现
+type poly1305 struct {
+	r   [5]uint32
+	s   [4]uint32
+	h   [5]uint32
+	buf [16]byte
+	n   int
+}
+
+func newPoly1305(key []byte) *poly1305 {
+	p := &poly1305{}
+	p.r[0] = binary.LittleEndian.Uint32(key[0:]) & 0x3ffffff
+	p.r[1] = (binary.LittleEndian.Uint32(key[3:]) >> 2) & 0x3ffff03
+	p.r[2] = (binary.LittleEndian.Uint32(key[6:]) >> 4) & 0x3ffc0ff
+	p.r[3] = (binary.LittleEndian.Uint32(key[9:]) >> 6) & 0x3f03fff
+	p.r[4] = (binary.LittleEndian.Uint32(key[12:]) >> 8) & 0x00fffff
+	for i := 0; i < 4; i++ {
+		p.s[i] = binary.LittleEndian.Uint32(key[16+i*4:])
+	}
+	return p
+}
+
+func (p *poly1305) write(data []byte) {
+	if p.n > 0 {
+		c := copy(p.buf[p.n:], data)
+		p.n += c
+		data = data[c:]
+		if p.n < 16 {
+			return
+		}
+		p.block(p.buf[:], 1<<24)
+		p.n = 0
+	}
+	for len(data) >= 16 {
+		p.block(data[:16], 1<<24)
+		data = data[16:]
+	}
+	p.n = copy(p.buf[:], data)
+}
+
+func (p *poly1305) block(m []byte, hibit uint32) {
+	const mask = 0x3ffffff
+	r0, r1, r2, r3, r4 := uint64(p.r[0]), uint64(p.r[1]), uint64(p.r[2]), uint64(p.r[3]), uint64(p.r[4])
+	s1, s2, s3, s4 := r1*5, r2*5, r3*5, r4*5
+
+	h0 := uint64(p.h[0] + binary.LittleEndian.Uint32(m[0:])&mask)
+	h1 := uint64(p.h[1] + (binary.LittleEndian.Uint32(m[3:])>>2)&mask)
+	h2 := uint64(p.h[2] + (binary.LittleEndian.Uint32(m[6:])>>4)&mask)
+	h3 := uint64(p.h[3] + (binary.LittleEndian.Uint32(m[9:])>>6)&mask)
+	h4 := uint64(p.h[4] + (binary.LittleEndian.Uint32(m[12:])>>8 | hibit))
+
+	d0 := h0*r0 + h1*s4 + h2*s3 + h3*s2 + h4*s1
+	d1 := h0*r1 + h1*r0 + h2*s4 + h3*s3 + h4*s2
+	d2 := h0*r2 + h1*r1 + h2*r0 + h3*s4 + h4*s3
+	d3 := h0*r3 + h1*r2 + h2*r1 + h3*r0 + h4*s4
+	d4 := h0*r4 + h1*r3 + h2*r2 + h3*r1 + h4*r0
+
+	c := d0 >> 26
+	p.h[0] = uint32(d0) & mask
+	d1 += c
+	c = d1 >> 26
+	p.h[1] = uint32(d1) & mask
+	d2 += c
+	c = d2 >> 26
+	p.h[2] = uint32(d2) & mask
+	d3 += c
+	c = d3 >> 26
+	p.h[3] = uint32(d3) & mask
+	d4 += c
+	c = d4 >> 26
+	p.h[4] = uint32(d4) & mask
+	p.h[0] += uint32(c) * 5
+	p.h[1] += p.h[0] >> 26
+	p.h[0] &= mask
+}
+
+func (p *poly1305) sum() []byte {
+	const mask = 0x3ffffff
+	if p.n > 0 {
+		var last [16]byte
+		copy(last[:], p.buf[:p.n])
+		last[p.n] = 1
+		p.block(last[:], 0)
+	}
+
+	h0, h1, h2, h3, h4 := p.h[0], p.h[1], p.h[2], p.h[3], p.h[4]
+
+	// 完全进位
+	c := h1 >> 26
+	h1 &= mask
+	h2 += c
+	c = h2 >> 26
+	h2 &= mask
+	h3 += c
+	c = h3 >> 26
+	h3 &= mask
+	h4 += c
+	c = h4 >> 26
+	h4 &= mask
+	h0 += c * 5
+	c = h0 >> 26
+	h0 &= mask
+	h1 += c
+
+	// 计算 h - p，并在 h >= p 时选择该结果
+	g0 := h0 + 5
+	c = g0 >> 26
+	g0 &= mask
+	g1 := h1 + c
+	c = g1 >> 26
+	g1 &= mask
+	g2 := h2 + c
+	c = g2 >> 26
+	g2 &= mask
+	g3 := h3 + c
+	c = g3 >> 26
+	g3 &= mask
+	g4 := h4 + c - (1 << 26)
+
+	sel := (g4 >> 31) - 1
+	h0 = h0&^sel | g0&sel
+	h1 = h1&^sel | g1&sel
+	h2 = h2&^sel | g2&sel
+	h3 = h3&^sel | g3&sel
+	h4 = h4&^sel | g4&sel
+
+	// h = h mod 2^128，再加上 s
+	w0 := h0 | h1<<26
+	w1 := h1>>6 | h2<<20
+	w2 := h2>>12 | h3<<14
+	w3 := h3>>18 | h4<<8
+
+	out := make([]byte, TagSize)
+	f := uint64(w0) + uint64(p.s[0])
+	binary.LittleEndian.PutUint32(out[0:], uint32(f))
+	f = uint64(w1) + uint64(p.s[1]) + f>>32
+	binary.LittleEndian.PutUint32(out[4:], uint32(f))
+	f = uint64(w2) + uint64(p.s[2]) + f>>32
+	binary.LittleEndian.PutUint32(out[8:], uint32(f))
+	f = uint64(w3) + uint64(p.s[3]) + f>>32
+	binary.LittleEndian.PutUint32(out[12:], uint32(f))
+	return out
+}
@@ -0,0 +1,91 @@
+package chacha20poly1305
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+func mustHex(s string) []byte {
+	b, err := hex.DecodeString(strings.ReplaceAll(s, " ", ""))
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// RFC 8439 第 2.3.2 节
+func TestChaCha20Block(t *testing.T) {
+	key := mustHex("000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f")
+	nonce := mustHex("000000090000004a00000000")
+
+	block := chacha20Block(key, nonce, 1)
+	expected := "10f1e7e4d13b5915500fdd1fa32071c4c7d1f4c733c068030422aa9ac3d46c4e" +
+		"d2826446079faa0914c2d705d98b02a2b5129cd1de164eb9cbd083e8a2503c4e"
+	if result := hex.EncodeToString(block[:]); result != expected {
+		t.Errorf("chacha20Block = %s, 期望 %s", result, expected)
+	}
+}
+
+// RFC 8439 第 2.5.2 节
+func TestPoly1305(t *testing.T) {
+	key := mustHex("85d6be7857556d337f4452fe42d506a80103808afb0db2fd4abff6af4149f51b")
+
+	p := newPoly1305(key)
+	p.write([]byte("Cryptographic Forum "))
+	p.write([]byte("Research Group"))
+
+	if result := hex.EncodeToString(p.sum()); result != "a8061dc1305136c6c22b8baf0c0127a9" {
+		t.Errorf("poly1305 = %s", result)
+	}
+}
+
+// RFC 8439 第 2.8.2 节
+func TestAEADTag(t *testing.T) {
+	key := mustHex("808182838485868788898a8b8c8d8e8f909192939495969798999a9b9c9d9e9f")
+	nonce := mustHex("070000004041424344454647")
+	aad := mustHex("50515253c0c1c2c3c4c5c6c7")
+	ciphertext := mustHex("d31a8d34648e60db7b86afbc53ef7ec2a4aded51296e08fea9e2b5a736ee62d6" +
+		"3dbea45e8ca9671282fafb69da92728b1a71de0a9e060b2905d6a5b67ecd3b36" +
+		"92ddbd7f2d778b8c9803aee328091b58fab324e4fad675945585808b4831d7bc" +
+		"3ff4def08e4b7a9de576d26586cec64b6116")
+
+	result, err := tag(key, nonce, aad, ciphertext)
+	if err != nil {
+		t.Fatalf("计算标签失败: %v", err)
+	}
+	if hex.EncodeToString(result) != "1ae10b594f09e26a7e902ecbd0600691" {
+		t.Errorf("tag = %x", result)
+	}
+}
+
+// 与 golang.org/x/crypto/chacha20poly1305 的 Seal(nil, nonce, nil, aad) 结果对照
+func TestTag(t *testing.T) {
+	key := []byte("0123456789abcdef0123456789abcdef")
+	nonce := mustHex("000000090000004a00000000")
+
+	testCases := []struct {
+		aad      string
+		expected string
+	}{
+		{"", "d310d49a8d3a7db110a62decc47ea56d"},
+		{"a", "3028123dc3c388f429fb318a93bd5598"},
+		{"0123456789abcdef", "0bac9b3e66640dc13be5d552531f8294"},
+		{"0123456789abcdef0", "490335e9994b47853ac3805ae65724a6"},
+		{strings.Repeat("x", 100), "2e2bb287321150e51ca22b8bff886c43"},
+	}
+
+	for _, tc := range testCases {
+		result, err := Tag(key, nonce, []byte(tc.aad))
+		if err != nil {
+			t.Fatalf("计算标签失败: %v", err)
+		}
+		if hex.EncodeToString(result) != tc.expected {
+			t.Errorf("Tag(%d 字节) = %x, 期望 %s", len(tc.aad), result, tc.expected)
+		}
+	}
+
+	if _, err := Tag(key[:16], nonce, nil); err == nil {
+		t.Errorf("密钥长度无效时应该返回错误")
+	}
+}
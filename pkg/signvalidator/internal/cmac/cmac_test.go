@@ -0,0 +1,40 @@
+package cmac
+
+import (
+	"crypto/aes"
+	"encoding/hex"
+	"testing"
+)
+
+// RFC 4493 第 4 节测试向量
+func TestAESCMAC(t *testing.T) {
+	key, _ := hex.DecodeString("2b7e151628aed2a6abf7158809cf4f3c")
+	message, _ := hex.DecodeString("6bc1bee22e409f96e93d7e117393172aae2d8a571e03ac9c9eb76fac45af8e5130c81c46a35ce411e5fbc1191a0a52eff69f2445df4f9b17ad2b417be66c3710")
+
+	testCases := []struct {
+		length   int
+		expected string
+	}{
+		{0, "bb1d6929e95937287fa37d129b756746"},
+		{16, "070a16b46b4d4144f79bdd9dd04a287c"},
+		{40, "dfa66747de9ae63030ca32611497c827"},
+		{64, "51f0bebf7e3b9d92fc49741779363cfe"},
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		t.Fatalf("创建分组密码失败: %v", err)
+	}
+
+	for _, tc := range testCases {
+		h, err := New(block)
+		if err != nil {
+			t.Fatalf("创建 CMAC 失败: %v", err)
+		}
+
+		h.Write(message[:tc.length])
+		if result := hex.EncodeToString(h.Sum(nil)); result != tc.expected {
+			t.Errorf("AES-CMAC(%d 字节) = %s, 期望 %s", tc.length, result, tc.expected)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+// Package nfc 实现 Unicode 规范化形式 C（NFC，规范分解后再规范组合）
+package nfc
+
+import "unicode/utf8"
+
+// 韩文音节的算法分解参数
+const (
+	sBase  = 0xAC00
+	lBase  = 0x1100
+	vBase  = 0x1161
+	tBase  = 0x11A7
+	lCount = 19
+	vCount = 21
+	tCount = 28
+	nCount = vCount * tCount
+	sCount = lCount * nCount
+)
+
+// String 返回 s 的 NFC 规范化形式，s 不是有效的 UTF-8 时原样返回
+func String(s string) string {
+	ascii := true
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			ascii = false
+			break
+		}
+	}
+	if ascii || !utf8.ValidString(s) {
+		return s
+	}
+
+	runes := make([]rune, 0, len(s))
+	for _, r := range s {
+		runes = decompose(runes, r)
+	}
+	reorder(runes)
+	return string(compose(runes))
+}
+
+// decompose 将 r 的完全规范分解追加到 dst
+func decompose(dst []rune, r rune) []rune {
+	if sIndex := r - sBase; sIndex >= 0 && sIndex < sCount {
+		dst = append(dst, lBase+sIndex/nCount, vBase+sIndex%nCount/tCount)
+		if t := sIndex % tCount; t != 0 {
+			dst = append(dst, tBase+t)
+		}
+		return dst
+	}
+	parts, ok := decompositions[r]
+	if !ok {
+		return append(dst, r)
+	}
+	for _, part := range parts {
+		dst = decompose(dst, part)
+	}
+	return dst
+}
+
+// reorder 按规范组合类对连续的非起始字符进行稳定排序
+func reorder(runes []rune) {
+	for i := 1; i < len(runes); i++ {
+		cc := combiningClasses[runes[i]]
+		if cc == 0 {
+			continue
+		}
+		for j := i; j > 0; j-- {
+			prev := combiningClasses[runes[j-1]]
+			if prev == 0 || prev <= cc {
+				break
+			}
+			runes[j-1], runes[j] = runes[j], runes[j-1]
+		}
+	}
+}
+
+// compose 对规范排序后的字符序列进行规范组合
+func compose(runes []rune) []rune {
+	if len(runes) == 0 {
+		return runes
+	}
+
+	starterIndex := 0
+	lastClass := int(combiningClasses[runes[0]])
+	if lastClass != 0 {
+		// 以非起始字符开头时，其后的字符不能与之组合
+		lastClass = 256
+	}
+
+	out := runes[:1]
+	for _, r := range runes[1:] {
+		cc := int(combiningClasses[r])
+		if composite, ok := composePair(out[starterIndex], r); ok && (lastClass < cc || lastClass == 0) {
+			out[starterIndex] = composite
+			continue
+		}
+		if cc == 0 {
+			starterIndex = len(out)
+		}
+		lastClass = cc
+		out = append(out, r)
+	}
+	return out
+}
+
+// composePair 返回两个字符的主组合字符
+func composePair(first, second rune) (rune, bool) {
+	if lIndex := first - lBase; lIndex >= 0 && lIndex < lCount {
+		if vIndex := second - vBase; vIndex >= 0 && vIndex < vCount {
+			return sBase + (lIndex*vCount+vIndex)*tCount, true
+		}
+	}
+	if sIndex := first - sBase; sIndex >= 0 && sIndex < sCount && sIndex%tCount == 0 {
+		if tIndex := second - tBase; tIndex > 0 && tIndex < tCount {
+			return first + tIndex, true
+		}
+	}
+	composite, ok := compositions[uint64(first)<<21|uint64(second)]
+	return composite, ok
+}
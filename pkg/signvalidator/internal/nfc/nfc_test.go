@@ -0,0 +1,40 @@
+package nfc
+
+import "testing"
+
+func TestString(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"abc", "abc"},
+		// iOS 输入法常见的 NFD 形式
+		{"e\u0301", "\u00e9"},
+		{"Cafe\u0301", "Caf\u00e9"},
+		{"\u00e9", "\u00e9"},
+		// 规范排序后组合：点在下方（220）先于抑扬符（230）
+		{"a\u0302\u0323", "\u1ead"},
+		{"d\u0307\u0323", "\u1e0d\u0307"},
+		// 组合排除字符与单字符分解
+		{"\u0915\u093c", "\u0915\u093c"},
+		{"\u2126", "\u03a9"},
+		{"A\u030a", "\u00c5"},
+		// 韩文音节
+		{"\u1100\u1161", "\uac00"},
+		{"\u1100\u1161\u11a8", "\uac01"},
+		{"\uac00\u11a8", "\uac01"},
+		{"\u1112\u1161\u11ab\u1100\u1173\u11af", "\ud55c\uae00"},
+		// 以非起始字符开头
+		{"\u0301e", "\u0301e"},
+		// 中文不受影响
+		{"中文签名", "中文签名"},
+		// 无效的 UTF-8 原样返回
+		{"e\u0301\xff", "e\u0301\xff"},
+	}
+
+	for _, tc := range testCases {
+		if result := String(tc.input); result != tc.expected {
+			t.Errorf("String(%+q) = %+q, 期望 %+q", tc.input, result, tc.expected)
+		}
+	}
+}
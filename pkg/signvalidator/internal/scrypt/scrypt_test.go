@@ -0,0 +1,38 @@
+package scrypt
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func TestKey(t *testing.T) {
+	// RFC 7914 第 12 节测试向量
+	testCases := []struct {
+		password string
+		salt     string
+		n, r, p  int
+		expected string
+	}{
+		{"", "", 16, 1, 1, "77d6576238657b203b19ca42c18a0497f16b4844e3074ae8dfdffa3fede21442fcd0069ded0948f8326a753a0fc81f17e8d3e0fb2e0d3628cf35e20c38d18906"},
+		{"password", "NaCl", 1024, 8, 16, "fdbabe1c9d3472007856e7190d01e9fe7c6ad7cbc8237830e77376634b3731622eaf30d92e22a3886ff109279d9830dac727afb94a83ee6d8360cbdfa2cc0640"},
+	}
+
+	for _, tc := range testCases {
+		key, err := Key([]byte(tc.password), []byte(tc.salt), tc.n, tc.r, tc.p, 64)
+		if err != nil {
+			t.Fatalf("派生密钥失败: %v", err)
+		}
+		if result := hex.EncodeToString(key); result != tc.expected {
+			t.Errorf("scrypt(%q, %q, %d, %d, %d) = %s, 期望 %s", tc.password, tc.salt, tc.n, tc.r, tc.p, result, tc.expected)
+		}
+	}
+}
+
+func TestKey_InvalidParams(t *testing.T) {
+	if _, err := Key([]byte("password"), nil, 1000, 8, 1, 32); err == nil {
+		t.Errorf("N 不是 2 的幂应该返回错误")
+	}
+	if _, err := Key([]byte("password"), nil, 1024, 0, 1, 32); err == nil {
+		t.Errorf("r 为 0 应该返回错误")
+	}
+}
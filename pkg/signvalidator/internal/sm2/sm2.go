@@ -0,0 +1,274 @@
+// Package sm2 实现 GB/T 32918-2016 定义的 SM2 椭圆曲线数字签名算法（SM2withSM3）。
+//
+// 曲线运算基于 elliptic.CurveParams 的通用 math/big 实现，标量乘法与模 n 运算均不是常数时间的：
+// 验证只涉及公开数据，可以放心使用；签名时的耗时与随机数 k 及私钥相关，攻击者能够大量测量签名耗时（如共享主机、
+// 对外提供签名接口的服务端）时可能通过计时侧信道恢复私钥。这类场景应使用 KMS 或 HSM 签名（如 aliyunkms、pkcs11），
+// 本地私钥签名仅适用于调用方自身（如客户端）签名请求等无法被外部反复计时的场景
+package sm2
+
+import (
+	"crypto"
+	"crypto/elliptic"
+	"encoding/asn1"
+	"errors"
+	"io"
+	"math/big"
+	"sync"
+
+	"github.com/huangchunlong818/sign-chao/pkg/signvalidator/internal/sm3"
+)
+
+// DefaultUID 默认用户标识，GM/T 0009 规定的缺省值
+var DefaultUID = []byte("1234567812345678")
+
+var (
+	initOnce sync.Once
+	sm2P256  *elliptic.CurveParams
+)
+
+func initP256() {
+	sm2P256 = &elliptic.CurveParams{Name: "SM2-P-256"}
+	sm2P256.P, _ = new(big.Int).SetString("FFFFFFFEFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF00000000FFFFFFFFFFFFFFFF", 16)
+	sm2P256.N, _ = new(big.Int).SetString("FFFFFFFEFFFFFFFFFFFFFFFFFFFFFFFF7203DF6B21C6052B53BBF40939D54123", 16)
+	sm2P256.B, _ = new(big.Int).SetString("28E9FA9E9D9F5E344D5A9E4BCF6509A7F39789F515AB8F92DDBCBD414D940E93", 16)
+	sm2P256.Gx, _ = new(big.Int).SetString("32C4AE2C1F1981195F9904466A39C9948FE30BBFF2660BE1715A4589334C74C7", 16)
+	sm2P256.Gy, _ = new(big.Int).SetString("BC3736A2F4F6779C59BDCEE36B692153D0A9877CC62A474002DF32E52139F0A0", 16)
+	sm2P256.BitSize = 256
+}
+
+// P256 返回 SM2 推荐曲线
+func P256() elliptic.Curve {
+	initOnce.Do(initP256)
+	return sm2P256
+}
+
+// PublicKey SM2 公钥
+type PublicKey struct {
+	X, Y *big.Int
+}
+
+// PrivateKey SM2 私钥
+type PrivateKey struct {
+	PublicKey
+	D *big.Int
+}
+
+type signature struct {
+	R, S *big.Int
+}
+
+// GenerateKey 生成新的 SM2 密钥对
+func GenerateKey(rand io.Reader) (*PrivateKey, error) {
+	params := P256().Params()
+	// 私钥取值范围为 [1, n-2]
+	max := new(big.Int).Sub(params.N, big.NewInt(2))
+	for {
+		k := make([]byte, 32)
+		if _, err := io.ReadFull(rand, k); err != nil {
+			return nil, err
+		}
+		d := new(big.Int).SetBytes(k)
+		if d.Sign() > 0 && d.Cmp(max) <= 0 {
+			return newPrivateKey(d), nil
+		}
+	}
+}
+
+// NewPrivateKey 根据 32 字节的私钥标量创建私钥
+func NewPrivateKey(key []byte) (*PrivateKey, error) {
+	if len(key) != 32 {
+		return nil, errors.New("sm2: 私钥长度必须为 32 字节")
+	}
+	d := new(big.Int).SetBytes(key)
+	max := new(big.Int).Sub(P256().Params().N, big.NewInt(2))
+	if d.Sign() <= 0 || d.Cmp(max) > 0 {
+		return nil, errors.New("sm2: 私钥超出取值范围")
+	}
+	return newPrivateKey(d), nil
+}
+
+func newPrivateKey(d *big.Int) *PrivateKey {
+	priv := &PrivateKey{D: d}
+	priv.X, priv.Y = P256().ScalarBaseMult(d.FillBytes(make([]byte, 32)))
+	return priv
+}
+
+// NewPublicKey 根据未压缩格式（04||X||Y）或不带前缀的 64 字节坐标创建公钥
+func NewPublicKey(key []byte) (*PublicKey, error) {
+	switch {
+	case len(key) == 65 && key[0] == 4:
+		key = key[1:]
+	case len(key) == 64:
+	default:
+		return nil, errors.New("sm2: 公钥格式无效")
+	}
+	pub := &PublicKey{
+		X: new(big.Int).SetBytes(key[:32]),
+		Y: new(big.Int).SetBytes(key[32:]),
+	}
+	if !P256().IsOnCurve(pub.X, pub.Y) {
+		return nil, errors.New("sm2: 公钥不在曲线上")
+	}
+	return pub, nil
+}
+
+// Bytes 返回未压缩格式（04||X||Y）的公钥
+func (pub *PublicKey) Bytes() []byte {
+	out := make([]byte, 65)
+	out[0] = 4
+	pub.X.FillBytes(out[1:33])
+	pub.Y.FillBytes(out[33:])
+	return out
+}
+
+// Bytes 返回 32 字节的私钥标量
+func (priv *PrivateKey) Bytes() []byte {
+	return priv.D.FillBytes(make([]byte, 32))
+}
+
+// Public 实现 crypto.Signer 接口
+func (priv *PrivateKey) Public() crypto.PublicKey {
+	return &priv.PublicKey
+}
+
+// Sign 实现 crypto.Signer 接口，使用默认用户标识签名并返回 ASN.1 DER 编码结果。
+// SM2 需要对原文计算 Z 值，因此 msg 为原始消息而非摘要，opts 被忽略。
+func (priv *PrivateKey) Sign(rand io.Reader, msg []byte, opts crypto.SignerOpts) ([]byte, error) {
+	r, s, err := Sign(rand, priv, DefaultUID, msg)
+	if err != nil {
+		return nil, err
+	}
+	return asn1.Marshal(signature{r, s})
+}
+
+// VerifyASN1 使用默认用户标识验证 ASN.1 DER 编码的签名
+func VerifyASN1(pub *PublicKey, msg, sig []byte) bool {
+	var parsed signature
+	rest, err := asn1.Unmarshal(sig, &parsed)
+	if err != nil || len(rest) != 0 {
+		return false
+	}
+	return Verify(pub, DefaultUID, msg, parsed.R, parsed.S)
+}
+
+// Sign 使用私钥对消息签名
+func Sign(rand io.Reader, priv *PrivateKey, uid, msg []byte) (r, s *big.Int, err error) {
+	return signDigest(rand, priv, hashToInt(&priv.PublicKey, uid, msg))
+}
+
+// SignDigest 对已计算的摘要 SM3(Z || M) 签名并返回 ASN.1 DER 编码结果
+func SignDigest(rand io.Reader, priv *PrivateKey, digest []byte) ([]byte, error) {
+	r, s, err := signDigest(rand, priv, new(big.Int).SetBytes(digest))
+	if err != nil {
+		return nil, err
+	}
+	return asn1.Marshal(signature{r, s})
+}
+
+// signDigest 使用随机数 k 对摘要 e 签名
+func signDigest(rand io.Reader, priv *PrivateKey, e *big.Int) (r, s *big.Int, err error) {
+	n := P256().Params().N
+	for {
+		k, err := randScalar(rand, n)
+		if err != nil {
+			return nil, nil, err
+		}
+		r, s, ok := signWithK(priv, e, k)
+		if ok {
+			return r, s, nil
+		}
+	}
+}
+
+// signWithK 使用给定随机数计算签名，结果无效时返回 false；ScalarBaseMult 与 ModInverse 不是常数时间的，见包文档
+func signWithK(priv *PrivateKey, e, k *big.Int) (r, s *big.Int, ok bool) {
+	curve := P256()
+	n := curve.Params().N
+
+	x1, _ := curve.ScalarBaseMult(k.FillBytes(make([]byte, 32)))
+	r = new(big.Int).Add(e, x1)
+	r.Mod(r, n)
+	if r.Sign() == 0 || new(big.Int).Add(r, k).Cmp(n) == 0 {
+		return nil, nil, false
+	}
+
+	// s = (1 + d)^-1 * (k - r*d) mod n
+	dPlus1Inv := new(big.Int).Add(priv.D, big.NewInt(1))
+	dPlus1Inv.ModInverse(dPlus1Inv, n)
+	s = new(big.Int).Mul(r, priv.D)
+	s.Sub(k, s)
+	s.Mul(s, dPlus1Inv)
+	s.Mod(s, n)
+	if s.Sign() == 0 {
+		return nil, nil, false
+	}
+	return r, s, true
+}
+
+// Verify 验证消息签名
+func Verify(pub *PublicKey, uid, msg []byte, r, s *big.Int) bool {
+	if pub == nil || pub.X == nil || pub.Y == nil || r == nil || s == nil {
+		return false
+	}
+	curve := P256()
+	n := curve.Params().N
+	if r.Sign() <= 0 || r.Cmp(n) >= 0 || s.Sign() <= 0 || s.Cmp(n) >= 0 {
+		return false
+	}
+
+	t := new(big.Int).Add(r, s)
+	t.Mod(t, n)
+	if t.Sign() == 0 {
+		return false
+	}
+
+	x1, y1 := curve.ScalarBaseMult(s.FillBytes(make([]byte, 32)))
+	x2, y2 := curve.ScalarMult(pub.X, pub.Y, t.FillBytes(make([]byte, 32)))
+	x, _ := curve.Add(x1, y1, x2, y2)
+
+	e := hashToInt(pub, uid, msg)
+	expected := new(big.Int).Add(e, x)
+	expected.Mod(expected, n)
+	return expected.Cmp(r) == 0
+}
+
+// Digest 计算 SM2 签名使用的摘要 SM3(Z || M)，用于只接受摘要的远程签名服务
+func Digest(pub *PublicKey, uid, msg []byte) []byte {
+	h := sm3.New()
+	h.Write(computeZ(pub, uid))
+	h.Write(msg)
+	return h.Sum(nil)
+}
+
+// hashToInt 计算 e = SM3(Z || M)
+func hashToInt(pub *PublicKey, uid, msg []byte) *big.Int {
+	return new(big.Int).SetBytes(Digest(pub, uid, msg))
+}
+
+// computeZ 计算 Z = SM3(ENTL || ID || a || b || xG || yG || xA || yA)
+func computeZ(pub *PublicKey, uid []byte) []byte {
+	params := P256().Params()
+	a := new(big.Int).Sub(params.P, big.NewInt(3))
+	entl := len(uid) * 8
+
+	h := sm3.New()
+	h.Write([]byte{byte(entl >> 8), byte(entl)})
+	h.Write(uid)
+	for _, x := range []*big.Int{a, params.B, params.Gx, params.Gy, pub.X, pub.Y} {
+		h.Write(x.FillBytes(make([]byte, 32)))
+	}
+	return h.Sum(nil)
+}
+
+func randScalar(rand io.Reader, n *big.Int) (*big.Int, error) {
+	buf := make([]byte, 32)
+	for {
+		if _, err := io.ReadFull(rand, buf); err != nil {
+			return nil, err
+		}
+		k := new(big.Int).SetBytes(buf)
+		if k.Sign() > 0 && k.Cmp(n) < 0 {
+			return k, nil
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package sm2
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"math/big"
+	"testing"
+)
+
+func hexInt(s string) *big.Int {
+	n, _ := new(big.Int).SetString(s, 16)
+	return n
+}
+
+// GB/T 32918.5-2017 附录 A.2 推荐曲线签名示例
+func TestSignWithK(t *testing.T) {
+	d, _ := hex.DecodeString("3945208F7B2144B13F36E38AC6D39F95889393692860B51A42FB81EF4DF7C5B8")
+	priv, err := NewPrivateKey(d)
+	if err != nil {
+		t.Fatalf("创建私钥失败: %v", err)
+	}
+
+	if priv.X.Cmp(hexInt("09F9DF311E5421A150DD7D161E4BC5C672179FAD1833FC076BB08FF356F35020")) != 0 ||
+		priv.Y.Cmp(hexInt("CCEA490CE26775A52DC6EA718CC1AA600AED05FBF35E084A6632F6072DA9AD13")) != 0 {
+		t.Fatalf("公钥推导结果不正确: (%X, %X)", priv.X, priv.Y)
+	}
+
+	msg := []byte("message digest")
+	e := hashToInt(&priv.PublicKey, DefaultUID, msg)
+	k := hexInt("59276E27D506861A16680F3AD9C02DCCEF3CC1FA3CDBE4CE6D54B80DEAC1BC21")
+
+	r, s, ok := signWithK(priv, e, k)
+	if !ok {
+		t.Fatalf("签名失败")
+	}
+	if r.Cmp(hexInt("F5A03B0648D2C4630EEAC513E1BB81A15944DA3827D5B74143AC7EACEEE720B3")) != 0 {
+		t.Errorf("r = %X", r)
+	}
+	if s.Cmp(hexInt("B1B6AA29DF212FD8763182BC0D421CA1BB9038FD1F7F42D4840B69C485BBC1AA")) != 0 {
+		t.Errorf("s = %X", s)
+	}
+
+	if !Verify(&priv.PublicKey, DefaultUID, msg, r, s) {
+		t.Errorf("标准示例签名验证失败")
+	}
+}
+
+func TestSignVerifyASN1(t *testing.T) {
+	priv, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("生成密钥失败: %v", err)
+	}
+
+	msg := []byte("amount=100&id=123")
+	sig, err := priv.Sign(rand.Reader, msg, nil)
+	if err != nil {
+		t.Fatalf("签名失败: %v", err)
+	}
+
+	if !VerifyASN1(&priv.PublicKey, msg, sig) {
+		t.Errorf("签名验证失败")
+	}
+
+	if VerifyASN1(&priv.PublicKey, []byte("amount=200&id=123"), sig) {
+		t.Errorf("篡改消息后验证应该失败，但通过了")
+	}
+
+	pub, err := NewPublicKey(priv.PublicKey.Bytes())
+	if err != nil {
+		t.Fatalf("解析公钥失败: %v", err)
+	}
+	if !VerifyASN1(pub, msg, sig) {
+		t.Errorf("使用解析后的公钥验证失败")
+	}
+}
+
+func TestSignDigest(t *testing.T) {
+	priv, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("生成密钥失败: %v", err)
+	}
+
+	msg := []byte("message digest")
+	sig, err := SignDigest(rand.Reader, priv, Digest(&priv.PublicKey, DefaultUID, msg))
+	if err != nil {
+		t.Fatalf("签名失败: %v", err)
+	}
+	if !VerifyASN1(&priv.PublicKey, msg, sig) {
+		t.Errorf("对摘要的签名验证失败")
+	}
+}
@@ -0,0 +1,122 @@
+package sm2
+
+import (
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+)
+
+var (
+	// oidPublicKeyECDSA id-ecPublicKey
+	oidPublicKeyECDSA = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+	// oidNamedCurveSM2 SM2 推荐曲线
+	oidNamedCurveSM2 = asn1.ObjectIdentifier{1, 2, 156, 10197, 1, 301}
+)
+
+type pkcs8 struct {
+	Version    int
+	Algo       pkix.AlgorithmIdentifier
+	PrivateKey []byte
+}
+
+type ecPrivateKey struct {
+	Version       int
+	PrivateKey    []byte
+	NamedCurveOID asn1.ObjectIdentifier `asn1:"optional,explicit,tag:0"`
+	PublicKey     asn1.BitString        `asn1:"optional,explicit,tag:1"`
+}
+
+type publicKeyInfo struct {
+	Algorithm pkix.AlgorithmIdentifier
+	PublicKey asn1.BitString
+}
+
+// ParsePKCS8PrivateKey 解析 PKCS#8 DER 编码的 SM2 私钥
+func ParsePKCS8PrivateKey(der []byte) (*PrivateKey, error) {
+	var key pkcs8
+	if _, err := asn1.Unmarshal(der, &key); err != nil {
+		return nil, err
+	}
+	if !key.Algo.Algorithm.Equal(oidPublicKeyECDSA) || !isSM2Curve(key.Algo.Parameters.FullBytes) {
+		return nil, errors.New("sm2: 不是 SM2 私钥")
+	}
+	return ParseECPrivateKey(key.PrivateKey)
+}
+
+// ParseECPrivateKey 解析 SEC 1 DER 编码的 SM2 私钥
+func ParseECPrivateKey(der []byte) (*PrivateKey, error) {
+	var key ecPrivateKey
+	if _, err := asn1.Unmarshal(der, &key); err != nil {
+		return nil, err
+	}
+	if len(key.NamedCurveOID) > 0 && !key.NamedCurveOID.Equal(oidNamedCurveSM2) {
+		return nil, errors.New("sm2: 不是 SM2 私钥")
+	}
+	if len(key.PrivateKey) > 32 {
+		return nil, errors.New("sm2: 私钥长度无效")
+	}
+	// 标量可能省略了前导零
+	padded := make([]byte, 32)
+	copy(padded[32-len(key.PrivateKey):], key.PrivateKey)
+	return NewPrivateKey(padded)
+}
+
+// ParsePKIXPublicKey 解析 PKIX DER 编码的 SM2 公钥
+func ParsePKIXPublicKey(der []byte) (*PublicKey, error) {
+	var info publicKeyInfo
+	if _, err := asn1.Unmarshal(der, &info); err != nil {
+		return nil, err
+	}
+	if !info.Algorithm.Algorithm.Equal(oidPublicKeyECDSA) || !isSM2Curve(info.Algorithm.Parameters.FullBytes) {
+		return nil, errors.New("sm2: 不是 SM2 公钥")
+	}
+	return NewPublicKey(info.PublicKey.RightAlign())
+}
+
+// MarshalPKCS8PrivateKey 将 SM2 私钥编码为 PKCS#8 DER
+func MarshalPKCS8PrivateKey(priv *PrivateKey) ([]byte, error) {
+	pub := priv.PublicKey.Bytes()
+	inner, err := asn1.Marshal(ecPrivateKey{
+		Version:    1,
+		PrivateKey: priv.Bytes(),
+		PublicKey:  asn1.BitString{Bytes: pub, BitLength: len(pub) * 8},
+	})
+	if err != nil {
+		return nil, err
+	}
+	params, err := asn1.Marshal(oidNamedCurveSM2)
+	if err != nil {
+		return nil, err
+	}
+	return asn1.Marshal(pkcs8{
+		Algo: pkix.AlgorithmIdentifier{
+			Algorithm:  oidPublicKeyECDSA,
+			Parameters: asn1.RawValue{FullBytes: params},
+		},
+		PrivateKey: inner,
+	})
+}
+
+// MarshalPKIXPublicKey 将 SM2 公钥编码为 PKIX DER
+func MarshalPKIXPublicKey(pub *PublicKey) ([]byte, error) {
+	params, err := asn1.Marshal(oidNamedCurveSM2)
+	if err != nil {
+		return nil, err
+	}
+	data := pub.Bytes()
+	return asn1.Marshal(publicKeyInfo{
+		Algorithm: pkix.AlgorithmIdentifier{
+			Algorithm:  oidPublicKeyECDSA,
+			Parameters: asn1.RawValue{FullBytes: params},
+		},
+		PublicKey: asn1.BitString{Bytes: data, BitLength: len(data) * 8},
+	})
+}
+
+func isSM2Curve(params []byte) bool {
+	var oid asn1.ObjectIdentifier
+	if _, err := asn1.Unmarshal(params, &oid); err != nil {
+		return false
+	}
+	return oid.Equal(oidNamedCurveSM2)
+}
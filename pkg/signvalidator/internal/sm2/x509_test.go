@@ -0,0 +1,48 @@
+package sm2
+
+import (
+	"crypto/rand"
+	"testing"
+)
+
+func TestPKCS8RoundTrip(t *testing.T) {
+	priv, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("生成密钥失败: %v", err)
+	}
+
+	der, err := MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		t.Fatalf("编码私钥失败: %v", err)
+	}
+
+	parsed, err := ParsePKCS8PrivateKey(der)
+	if err != nil {
+		t.Fatalf("解析私钥失败: %v", err)
+	}
+
+	if parsed.D.Cmp(priv.D) != 0 || parsed.X.Cmp(priv.X) != 0 {
+		t.Errorf("解析后的私钥与原私钥不一致")
+	}
+}
+
+func TestPKIXRoundTrip(t *testing.T) {
+	priv, err := GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("生成密钥失败: %v", err)
+	}
+
+	der, err := MarshalPKIXPublicKey(&priv.PublicKey)
+	if err != nil {
+		t.Fatalf("编码公钥失败: %v", err)
+	}
+
+	parsed, err := ParsePKIXPublicKey(der)
+	if err != nil {
+		t.Fatalf("解析公钥失败: %v", err)
+	}
+
+	if parsed.X.Cmp(priv.X) != 0 || parsed.Y.Cmp(priv.Y) != 0 {
+		t.Errorf("解析后的公钥与原公钥不一致")
+	}
+}
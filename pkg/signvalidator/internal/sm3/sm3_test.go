@@ -0,0 +1,44 @@
+package sm3
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+// GB/T 32905-2016 附录 A 示例
+func TestSum(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"abc", "66c7f0f462eeedd9d1f2d46bdc10e4e24167c4875cf2f7a2297da02b8f4ba8e0"},
+		{strings.Repeat("abcd", 16), "debe9ff92275b8a138604889c18e5a4d6fdb70e5387e5765293dcba39c0c5732"},
+		{"", "1ab21d8355cfa17f8e61194831e81a8f22bec8c728fefb747ed035eb5082aa2b"},
+	}
+
+	for _, tc := range testCases {
+		sum := Sum([]byte(tc.input))
+		if result := hex.EncodeToString(sum[:]); result != tc.expected {
+			t.Errorf("Sum(%q) = %s, 期望 %s", tc.input, result, tc.expected)
+		}
+	}
+}
+
+func TestStreamingWrite(t *testing.T) {
+	data := []byte(strings.Repeat("abcd", 40))
+	expected := Sum(data)
+
+	h := New()
+	for i := 0; i < len(data); i += 7 {
+		end := i + 7
+		if end > len(data) {
+			end = len(data)
+		}
+		h.Write(data[i:end])
+	}
+
+	if result := h.Sum(nil); hex.EncodeToString(result) != hex.EncodeToString(expected[:]) {
+		t.Errorf("分段写入结果 %x 与一次写入结果 %x 不一致", result, expected)
+	}
+}
@@ -0,0 +1,132 @@
+// Package xxhash 实现 xxHash64 非密码学哈希算法（种子为 0）
+package xxhash
+
+import (
+	"encoding/binary"
+	"hash"
+	"math/bits"
+)
+
+// Size xxHash64 摘要长度（字节）
+const Size = 8
+
+const (
+	prime1 uint64 = 11400714785074694791
+	prime2 uint64 = 14029467366897019727
+	prime3 uint64 = 1609587929392839161
+	prime4 uint64 = 9650029242287828579
+	prime5 uint64 = 2870177450012600261
+)
+
+type digest struct {
+	v1, v2, v3, v4 uint64
+	total          uint64
+	mem            [32]byte
+	n              int
+}
+
+// New 创建 xxHash64 哈希实例，Sum 以大端序输出
+func New() hash.Hash64 {
+	d := new(digest)
+	d.Reset()
+	return d
+}
+
+func (d *digest) Reset() {
+	// 使用变量运算以获得 uint64 回绕结果
+	p1, p2 := prime1, prime2
+	d.v1 = p1 + p2
+	d.v2 = p2
+	d.v3 = 0
+	d.v4 = -p1
+	d.total = 0
+	d.n = 0
+}
+
+func (d *digest) Size() int { return Size }
+
+func (d *digest) BlockSize() int { return 32 }
+
+func round(acc, input uint64) uint64 {
+	acc += input * prime2
+	acc = bits.RotateLeft64(acc, 31)
+	return acc * prime1
+}
+
+func mergeRound(acc, val uint64) uint64 {
+	val = round(0, val)
+	acc ^= val
+	return acc*prime1 + prime4
+}
+
+func (d *digest) Write(p []byte) (int, error) {
+	n := len(p)
+	d.total += uint64(n)
+
+	if d.n+len(p) < 32 {
+		d.n += copy(d.mem[d.n:], p)
+		return n, nil
+	}
+
+	if d.n > 0 {
+		c := copy(d.mem[d.n:], p)
+		d.processStripe(d.mem[:])
+		p = p[c:]
+		d.n = 0
+	}
+
+	for len(p) >= 32 {
+		d.processStripe(p[:32])
+		p = p[32:]
+	}
+	d.n = copy(d.mem[:], p)
+	return n, nil
+}
+
+func (d *digest) processStripe(b []byte) {
+	d.v1 = round(d.v1, binary.LittleEndian.Uint64(b[0:]))
+	d.v2 = round(d.v2, binary.LittleEndian.Uint64(b[8:]))
+	d.v3 = round(d.v3, binary.LittleEndian.Uint64(b[16:]))
+	d.v4 = round(d.v4, binary.LittleEndian.Uint64(b[24:]))
+}
+
+func (d *digest) Sum64() uint64 {
+	var h uint64
+	if d.total >= 32 {
+		h = bits.RotateLeft64(d.v1, 1) + bits.RotateLeft64(d.v2, 7) +
+			bits.RotateLeft64(d.v3, 12) + bits.RotateLeft64(d.v4, 18)
+		h = mergeRound(h, d.v1)
+		h = mergeRound(h, d.v2)
+		h = mergeRound(h, d.v3)
+		h = mergeRound(h, d.v4)
+	} else {
+		h = d.v3 + prime5
+	}
+	h += d.total
+
+	b := d.mem[:d.n]
+	for ; len(b) >= 8; b = b[8:] {
+		h ^= round(0, binary.LittleEndian.Uint64(b))
+		h = bits.RotateLeft64(h, 27)*prime1 + prime4
+	}
+	if len(b) >= 4 {
+		h ^= uint64(binary.LittleEndian.Uint32(b)) * prime1
+		h = bits.RotateLeft64(h, 23)*prime2 + prime3
+		b = b[4:]
+	}
+	for ; len(b) > 0; b = b[1:] {
+		h ^= uint64(b[0]) * prime5
+		h = bits.RotateLeft64(h, 11) * prime1
+	}
+
+	h ^= h >> 33
+	h *= prime2
+	h ^= h >> 29
+	h *= prime3
+	h ^= h >> 32
+	return h
+}
+
+func (d *digest) Sum(in []byte) []byte {
+	return binary.BigEndian.AppendUint64(in, d.Sum64())
+}
@@ -0,0 +1,33 @@
+package xxhash
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestSum64(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected uint64
+	}{
+		{"", 0xef46db3751d8e999},
+		{"a", 0xd24ec4f1a98c6e5b},
+		{"abc", 0x44bc2cf5ad770999},
+		{"Nobody inspects the spammish repetition", 0xfbcea83c8a378bf1},
+	}
+
+	for _, tc := range testCases {
+		h := New()
+		// 逐字节写入以覆盖缓冲逻辑
+		for i := 0; i < len(tc.input); i++ {
+			h.Write([]byte{tc.input[i]})
+		}
+		if result := h.Sum64(); result != tc.expected {
+			t.Errorf("xxHash64(%q) = %016x, 期望 %016x", tc.input, result, tc.expected)
+		}
+
+		if result := fmt.Sprintf("%x", h.Sum(nil)); result != fmt.Sprintf("%016x", tc.expected) {
+			t.Errorf("Sum(%q) = %s, 期望大端序 %016x", tc.input, result, tc.expected)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package signvalidator
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// errJSONPath JSON 路径语法错误
+var errJSONPath = errors.New("JSON 路径语法错误")
+
+// jsonPath 解析后的 JSON 路径
+type jsonPath struct {
+	// name 参与签名的参数名，如 "order.id"、"items[0].sku"
+	name  string
+	steps []pathStep
+}
+
+// pathStep JSON 路径中的一级对象成员或数组下标
+type pathStep struct {
+	key     string
+	index   int
+	isIndex bool
+}
+
+// compileSignedPaths 解析 SignedPaths 中的 JSON 路径
+func (v *SignValidator) compileSignedPaths() error {
+	for _, expr := range v.config.SignedPaths {
+		path, err := parseJSONPath(expr)
+		if err != nil {
+			return err
+		}
+		for _, p := range v.paths {
+			if p.name == path.name {
+				return fmt.Errorf("JSON 路径 %s 重复", expr)
+			}
+		}
+		v.paths = append(v.paths, path)
+	}
+	return nil
+}
+
+// parseJSONPath 解析 "$.order.id"、"$.items[0].sku"、"$['a b']" 形式的 JSON 路径，不支持通配符与过滤表达式
+func parseJSONPath(expr string) (jsonPath, error) {
+	rest, ok := strings.CutPrefix(strings.TrimSpace(expr), "$")
+	if !ok || rest == "" {
+		return jsonPath{}, fmt.Errorf("%w: %s", errJSONPath, expr)
+	}
+
+	var path jsonPath
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			end := strings.IndexAny(rest[1:], ".[")
+			if end < 0 {
+				end = len(rest) - 1
+			}
+			key := rest[1 : end+1]
+			if key == "" || key == "*" {
+				return jsonPath{}, fmt.Errorf("%w: %s", errJSONPath, expr)
+			}
+			path.steps = append(path.steps, pathStep{key: key})
+			rest = rest[end+1:]
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return jsonPath{}, fmt.Errorf("%w: %s", errJSONPath, expr)
+			}
+			inner := rest[1:end]
+			if len(inner) >= 2 && (inner[0] == '\'' || inner[0] == '"') && inner[len(inner)-1] == inner[0] {
+				path.steps = append(path.steps, pathStep{key: inner[1 : len(inner)-1]})
+			} else if index, err := strconv.Atoi(inner); err == nil && index >= 0 {
+				path.steps = append(path.steps, pathStep{index: index, isIndex: true})
+			} else {
+				return jsonPath{}, fmt.Errorf("%w: %s", errJSONPath, expr)
+			}
+			rest = rest[end+1:]
+		default:
+			return jsonPath{}, fmt.Errorf("%w: %s", errJSONPath, expr)
+		}
+	}
+
+	var name strings.Builder
+	for i, step := range path.steps {
+		switch {
+		case step.isIndex:
+			name.WriteString("[" + strconv.Itoa(step.index) + "]")
+		case i > 0:
+			name.WriteString("." + step.key)
+		default:
+			name.WriteString(step.key)
+		}
+	}
+	path.name = name.String()
+	return path, nil
+}
+
+// extractPaths 从 JSON 请求体中提取 SignedPaths 对应的值，数字保留原始写法，路径不存在时返回错误
+func (v *SignValidator) extractPaths(body []byte) (map[string]interface{}, error) {
+	document, err := decodeJSON(body)
+	if err != nil {
+		return nil, fmt.Errorf("请求体不是有效的 JSON: %w", err)
+	}
+
+	params := make(map[string]interface{}, len(v.paths))
+	for _, path := range v.paths {
+		value, ok := path.lookup(document)
+		if !ok {
+			return nil, fmt.Errorf("JSON 路径 %s 不存在", path.name)
+		}
+		params[path.name] = value
+	}
+	return params, nil
+}
+
+// lookup 按路径查找值
+func (p jsonPath) lookup(value interface{}) (interface{}, bool) {
+	for _, step := range p.steps {
+		if step.isIndex {
+			array, ok := value.([]interface{})
+			if !ok || step.index >= len(array) {
+				return nil, false
+			}
+			value = array[step.index]
+			continue
+		}
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if value, ok = object[step.key]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
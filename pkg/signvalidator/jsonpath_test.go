@@ -0,0 +1,92 @@
+package signvalidator
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseJSONPath(t *testing.T) {
+	names := map[string]string{
+		"$.items[0].sku": "items[0].sku",
+		"$['a b'].c":     "a b.c",
+	}
+	for expr, want := range names {
+		if path, _ := parseJSONPath(expr); path.name != want {
+			t.Errorf("%s: 参数名应为 %s，实际 %s", expr, want, path.name)
+		}
+	}
+
+	tests := map[string][]pathStep{
+		"$.amount":         {{key: "amount"}},
+		"$.order.id":       {{key: "order"}, {key: "id"}},
+		"$.items[0].sku":   {{key: "items"}, {index: 0, isIndex: true}, {key: "sku"}},
+		"$['a.b'][\"c\"]":  {{key: "a.b"}, {key: "c"}},
+		" $.matrix[1][2] ": {{key: "matrix"}, {index: 1, isIndex: true}, {index: 2, isIndex: true}},
+	}
+	for expr, want := range tests {
+		path, err := parseJSONPath(expr)
+		if err != nil {
+			t.Errorf("%s: 解析失败: %v", expr, err)
+			continue
+		}
+		if !reflect.DeepEqual(path.steps, want) {
+			t.Errorf("%s: 期望 %v，实际 %v", expr, want, path.steps)
+		}
+	}
+
+	for _, expr := range []string{"", "$", "order.id", "$.", "$..a", "$.a[", "$.a[-1]", "$.*", "$.a[x]"} {
+		if _, err := parseJSONPath(expr); !errors.Is(err, errJSONPath) {
+			t.Errorf("%q 应返回 errJSONPath，实际 %v", expr, err)
+		}
+	}
+}
+
+func TestSignedPaths_Config(t *testing.T) {
+	v := NewSignValidator(Config{Secret: "secret", SignedPaths: []string{"$.a", "a"}})
+	if _, err := v.GenerateBodySignature([]byte(`{}`)); !errors.Is(err, errJSONPath) {
+		t.Errorf("无效的 JSON 路径应在创建时报错，实际 %v", err)
+	}
+
+	v = NewSignValidator(Config{Secret: "secret", SignedPaths: []string{"$.a", "$['a']"}})
+	if _, err := v.GenerateBodySignature([]byte(`{"a":1}`)); err == nil {
+		t.Error("重复的 JSON 路径应返回错误")
+	}
+}
+
+func TestGenerateBodySignature_SignedPaths(t *testing.T) {
+	v := NewSignValidator(Config{
+		Secret:      "secret",
+		Algorithm:   HMAC_SHA256,
+		SignedPaths: []string{"$.order.id", "$.amount", "$.items[0].sku"},
+	})
+
+	body := []byte(`{"order":{"id":1001,"status":"new"},"amount":9.90,"items":[{"sku":"A1"}],"trace":"t1"}`)
+	signature, err := v.GenerateBodySignature(body)
+	if err != nil {
+		t.Fatalf("生成签名失败: %v", err)
+	}
+
+	// 数字保留原始写法，仅路径对应的值参与签名
+	want, _ := NewSignValidator(Config{Secret: "secret", Algorithm: HMAC_SHA256}).GenerateSignature(map[string]interface{}{
+		"order.id":     "1001",
+		"amount":       "9.90",
+		"items[0].sku": "A1",
+	})
+	if signature != want {
+		t.Errorf("签名应与提取后的参数一致，期望 %s，实际 %s", want, signature)
+	}
+
+	envelope := []byte(`{"trace":"t2","order":{"status":"paid","id":1001},"items":[{"sku":"A1","qty":2}],"amount":9.90}`)
+	valid, err := v.ValidateBody(envelope, signature)
+	if err != nil || !valid {
+		t.Errorf("未签名的字段变化不应影响验证，valid=%v err=%v", valid, err)
+	}
+
+	if valid, _ := v.ValidateBody([]byte(`{"order":{"id":1002},"amount":9.90,"items":[{"sku":"A1"}]}`), signature); valid {
+		t.Error("签名字段修改后验证不应通过")
+	}
+	if _, err := v.ValidateBody([]byte(`{"order":{"id":1001},"items":[]}`), signature); err == nil {
+		t.Error("路径不存在时应返回错误")
+	}
+}
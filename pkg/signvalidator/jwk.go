@@ -0,0 +1,232 @@
+package signvalidator
+
+import (
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// ErrKeyNotFound 密钥集中没有与 kid 匹配的密钥
+var ErrKeyNotFound = errors.New("未找到匹配的密钥")
+
+// JSONWebKey 从 JWK（RFC 7517）解析出的验证公钥
+type JSONWebKey struct {
+	// KeyID 密钥标识（kid）
+	KeyID string
+	// Algorithm JWK 声明的算法（alg），如 "RS256"，可为空
+	Algorithm string
+	// Use 密钥用途（use），如 "sig"
+	Use string
+	// Key 公钥，类型为 *rsa.PublicKey、*ecdsa.PublicKey 或 ed25519.PublicKey
+	Key crypto.PublicKey
+}
+
+// JSONWebKeySet JWK 密钥集
+type JSONWebKeySet struct {
+	Keys []*JSONWebKey
+}
+
+// rawJWK JWK 的 JSON 表示
+type rawJWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	Crv string `json:"crv"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// ParseJWK 解析单个 JWK 公钥，支持 RSA、EC（P-256、P-384）与 OKP（Ed25519）
+func ParseJWK(data []byte) (*JSONWebKey, error) {
+	var raw rawJWK
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("JWK 格式无效: %w", err)
+	}
+	return raw.parse()
+}
+
+// ParseJWKS 解析 JWK 密钥集，也接受单个 JWK。无法识别的密钥类型（如对称密钥 "oct"）将被跳过
+func ParseJWKS(data []byte) (*JSONWebKeySet, error) {
+	var document struct {
+		Keys []json.RawMessage `json:"keys"`
+	}
+	if err := json.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("JWKS 格式无效: %w", err)
+	}
+
+	// 单个 JWK
+	if document.Keys == nil {
+		key, err := ParseJWK(data)
+		if err != nil {
+			return nil, err
+		}
+		return &JSONWebKeySet{Keys: []*JSONWebKey{key}}, nil
+	}
+
+	set := &JSONWebKeySet{}
+	for _, item := range document.Keys {
+		var raw rawJWK
+		if err := json.Unmarshal(item, &raw); err != nil {
+			return nil, fmt.Errorf("JWK 格式无效: %w", err)
+		}
+		// 跳过加密用途及不支持的密钥，避免一个未知密钥导致整个密钥集不可用
+		if raw.Use == "enc" {
+			continue
+		}
+		key, err := raw.parse()
+		if err != nil {
+			if errors.Is(err, errUnsupportedKeyType) {
+				continue
+			}
+			return nil, err
+		}
+		set.Keys = append(set.Keys, key)
+	}
+	return set, nil
+}
+
+// Key 按 kid 选择密钥；kid 为空且密钥集只有一个密钥时返回该密钥，未找到时返回 ErrKeyNotFound
+func (s *JSONWebKeySet) Key(kid string) (*JSONWebKey, error) {
+	if kid == "" && len(s.Keys) == 1 {
+		return s.Keys[0], nil
+	}
+	for _, key := range s.Keys {
+		if key.KeyID == kid {
+			return key, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: kid=%s", ErrKeyNotFound, kid)
+}
+
+// SignAlgorithm 返回密钥对应的签名算法：优先使用 JWK 声明的 alg，
+// 未声明时按密钥类型推断（EC P-256 为 ECDSA_P256_SHA256，P-384 为 ECDSA_P384_SHA384，Ed25519 为 ED25519，RSA 为 RSA_SHA256）
+func (k *JSONWebKey) SignAlgorithm() (SignAlgorithm, error) {
+	if k.Algorithm != "" {
+		algorithm, err := ParseAlgorithm(k.Algorithm)
+		if err != nil {
+			return "", err
+		}
+		if err := checkKeyType(algorithm, k.Key); err != nil {
+			return "", err
+		}
+		return algorithm, nil
+	}
+
+	switch key := k.Key.(type) {
+	case *rsa.PublicKey:
+		return RSA_SHA256, nil
+	case *ecdsa.PublicKey:
+		switch key.Curve {
+		case elliptic.P256():
+			return ECDSA_P256_SHA256, nil
+		case elliptic.P384():
+			return ECDSA_P384_SHA384, nil
+		}
+	case ed25519.PublicKey:
+		return ED25519, nil
+	}
+	return "", fmt.Errorf("无法推断密钥 %s 的签名算法", k.KeyID)
+}
+
+// NewVerifierFromJWK 使用 JWK 公钥创建签名验证器，签名算法由 SignAlgorithm 确定。
+// JWS 中的 ECDSA 签名为定长 R||S 格式，如需验证此类签名请使用 Config.ECDSAFormat
+func NewVerifierFromJWK(key *JSONWebKey) (Verifier, error) {
+	algorithm, err := key.SignAlgorithm()
+	if err != nil {
+		return nil, err
+	}
+	return NewVerifier(algorithm, key.Key)
+}
+
+var errUnsupportedKeyType = errors.New("不支持的 JWK 密钥类型")
+
+// parse 将 JWK 转换为公钥
+func (raw *rawJWK) parse() (*JSONWebKey, error) {
+	key := &JSONWebKey{KeyID: raw.Kid, Algorithm: raw.Alg, Use: raw.Use}
+
+	switch raw.Kty {
+	case "RSA":
+		n, err := decodeJWKField("n", raw.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decodeJWKField("e", raw.E)
+		if err != nil {
+			return nil, err
+		}
+		exponent := new(big.Int).SetBytes(e)
+		if !exponent.IsInt64() || exponent.Int64() > 1<<31-1 || exponent.Int64() < 3 {
+			return nil, errors.New("JWK RSA 公钥指数无效")
+		}
+		key.Key = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}
+	case "EC":
+		var curve elliptic.Curve
+		var ecdhCurve ecdh.Curve
+		switch raw.Crv {
+		case "P-256":
+			curve, ecdhCurve = elliptic.P256(), ecdh.P256()
+		case "P-384":
+			curve, ecdhCurve = elliptic.P384(), ecdh.P384()
+		default:
+			return nil, fmt.Errorf("%w: EC %s", errUnsupportedKeyType, raw.Crv)
+		}
+		x, err := decodeJWKField("x", raw.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decodeJWKField("y", raw.Y)
+		if err != nil {
+			return nil, err
+		}
+		size := (curve.Params().BitSize + 7) / 8
+		if len(x) != size || len(y) != size {
+			return nil, errors.New("JWK EC 公钥坐标长度无效")
+		}
+		// 通过 ecdh 校验坐标点在曲线上
+		point := append([]byte{4}, append(x, y...)...)
+		if _, err := ecdhCurve.NewPublicKey(point); err != nil {
+			return nil, fmt.Errorf("JWK EC 公钥无效: %w", err)
+		}
+		key.Key = &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+	case "OKP":
+		if raw.Crv != "Ed25519" {
+			return nil, fmt.Errorf("%w: OKP %s", errUnsupportedKeyType, raw.Crv)
+		}
+		x, err := decodeJWKField("x", raw.X)
+		if err != nil {
+			return nil, err
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("JWK Ed25519 公钥长度无效")
+		}
+		key.Key = ed25519.PublicKey(x)
+	default:
+		return nil, fmt.Errorf("%w: %s", errUnsupportedKeyType, raw.Kty)
+	}
+
+	return key, nil
+}
+
+// decodeJWKField 解码 Base64URL 编码的 JWK 字段，兼容带填充的写法
+func decodeJWKField(name, value string) ([]byte, error) {
+	if value == "" {
+		return nil, fmt.Errorf("JWK 缺少 %s 字段", name)
+	}
+	data, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(value, "="))
+	if err != nil {
+		return nil, fmt.Errorf("JWK %s 字段编码无效: %w", name, err)
+	}
+	return data, nil
+}
@@ -0,0 +1,151 @@
+package signvalidator
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+)
+
+// encodeJWKField 以 Base64URL 编码 JWK 字段
+func encodeJWKField(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// rsaJWK 生成 RSA 公钥的 JWK 表示
+func rsaJWK(kid, alg string, key *rsa.PublicKey) string {
+	return fmt.Sprintf(`{"kty":"RSA","kid":%q,"alg":%q,"use":"sig","n":%q,"e":%q}`,
+		kid, alg, encodeJWKField(key.N.Bytes()), encodeJWKField(big.NewInt(int64(key.E)).Bytes()))
+}
+
+func TestParseJWKS(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("生成密钥失败: %v", err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("生成密钥失败: %v", err)
+	}
+	edPublic, edPrivate, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("生成密钥失败: %v", err)
+	}
+
+	ecPublic, err := ecKey.PublicKey.ECDH()
+	if err != nil {
+		t.Fatalf("编码公钥失败: %v", err)
+	}
+	ecPoint := ecPublic.Bytes()
+	document := fmt.Sprintf(`{"keys":[%s,{"kty":"EC","kid":"ec-1","crv":"P-256","x":%q,"y":%q},{"kty":"OKP","kid":"ed-1","crv":"Ed25519","x":%q},{"kty":"oct","kid":"hmac","k":"c2VjcmV0"}]}`,
+		rsaJWK("rsa-1", "PS256", &rsaKey.PublicKey),
+		encodeJWKField(ecPoint[1:33]), encodeJWKField(ecPoint[33:]),
+		encodeJWKField(edPublic))
+
+	set, err := ParseJWKS([]byte(document))
+	if err != nil {
+		t.Fatalf("解析 JWKS 失败: %v", err)
+	}
+	// 对称密钥被跳过
+	if len(set.Keys) != 3 {
+		t.Fatalf("密钥数量 = %d, 期望 3", len(set.Keys))
+	}
+
+	testCases := []struct {
+		kid       string
+		algorithm SignAlgorithm
+		config    Config
+	}{
+		{"rsa-1", RSA_PSS_SHA256, Config{Algorithm: RSA_PSS_SHA256, PrivateKey: rsaKey}},
+		{"ec-1", ECDSA_P256_SHA256, Config{Algorithm: ECDSA_P256_SHA256, PrivateKey: ecKey}},
+		{"ed-1", ED25519, Config{Algorithm: ED25519, PrivateKey: edPrivate}},
+	}
+
+	params := map[string]interface{}{"id": 123, "name": "test"}
+	for _, tc := range testCases {
+		key, err := set.Key(tc.kid)
+		if err != nil {
+			t.Fatalf("选择密钥 %s 失败: %v", tc.kid, err)
+		}
+		algorithm, err := key.SignAlgorithm()
+		if err != nil {
+			t.Fatalf("推断算法失败: %v", err)
+		}
+		if algorithm != tc.algorithm {
+			t.Errorf("%s 算法 = %s, 期望 %s", tc.kid, algorithm, tc.algorithm)
+		}
+
+		signature, err := NewSignValidator(tc.config).GenerateSignature(params)
+		if err != nil {
+			t.Fatalf("生成签名失败: %v", err)
+		}
+		verifier, err := NewVerifierFromJWK(key)
+		if err != nil {
+			t.Fatalf("创建验证器失败: %v", err)
+		}
+		valid, err := verifier.Validate(params, signature)
+		if err != nil {
+			t.Fatalf("验证签名失败: %v", err)
+		}
+		if !valid {
+			t.Errorf("%s 签名验证失败", tc.kid)
+		}
+	}
+
+	if _, err := set.Key("unknown"); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("错误 = %v, 期望 ErrKeyNotFound", err)
+	}
+}
+
+func TestParseJWK_Single(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("生成密钥失败: %v", err)
+	}
+
+	set, err := ParseJWKS([]byte(rsaJWK("", "RS256", &key.PublicKey)))
+	if err != nil {
+		t.Fatalf("解析 JWK 失败: %v", err)
+	}
+	jwk, err := set.Key("")
+	if err != nil {
+		t.Fatalf("选择密钥失败: %v", err)
+	}
+	if !key.PublicKey.Equal(jwk.Key) {
+		t.Errorf("解析的公钥与原公钥不一致")
+	}
+}
+
+func TestParseJWK_Invalid(t *testing.T) {
+	testCases := []string{
+		`not json`,
+		`{"kty":"oct","k":"c2VjcmV0"}`,
+		`{"kty":"RSA","n":"AQAB"}`,
+		`{"kty":"EC","crv":"P-256","x":"AAAA","y":"AAAA"}`,
+		`{"kty":"OKP","crv":"Ed25519","x":"!!!"}`,
+	}
+	for _, data := range testCases {
+		if _, err := ParseJWK([]byte(data)); err == nil {
+			t.Errorf("解析 %s 应该返回错误", data)
+		}
+	}
+
+	// 声明的算法与密钥类型不匹配
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("生成密钥失败: %v", err)
+	}
+	jwk, err := ParseJWK([]byte(rsaJWK("rsa-1", "ES256", &key.PublicKey)))
+	if err != nil {
+		t.Fatalf("解析 JWK 失败: %v", err)
+	}
+	if _, err := jwk.SignAlgorithm(); err == nil {
+		t.Errorf("算法与密钥类型不匹配应该返回错误")
+	}
+}
@@ -0,0 +1,200 @@
+package signvalidator
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// KeyProvider 按密钥标识（kid）提供验证公钥，用于验证定期轮换密钥的对端
+type KeyProvider interface {
+	// PublicKey 返回 kid 对应的公钥，kid 可能为空
+	PublicKey(kid string) (crypto.PublicKey, error)
+}
+
+// JWKSConfig JWKS 密钥提供者配置
+type JWKSConfig struct {
+	// URL JWKS 地址
+	URL string
+	// Client HTTP 客户端，默认为 10 秒超时的客户端
+	Client *http.Client
+	// TTL 密钥缓存时间，默认为 1 小时
+	TTL time.Duration
+	// MinRefreshInterval 遇到未知 kid 时两次刷新的最小间隔，避免伪造的 kid 导致频繁请求，默认为 1 分钟
+	MinRefreshInterval time.Duration
+	// Clock 判断缓存是否过期使用的时钟，默认为 SystemClock
+	Clock Clock
+}
+
+// JWKSProvider 从 JWKS 地址获取并缓存公钥的 KeyProvider。
+// 缓存过期或遇到未知 kid 时重新获取，获取失败时继续使用已缓存的密钥；
+// HTTP 请求不持有锁，同一时间最多只有一个请求，期间其他调用方继续使用已缓存的密钥
+type JWKSProvider struct {
+	config JWKSConfig
+
+	mu        sync.Mutex
+	set       *JSONWebKeySet
+	fetchedAt time.Time
+	fetching  *jwksFetch
+}
+
+// jwksFetch 正在进行的 JWKS 获取，完成后关闭 done
+type jwksFetch struct {
+	done chan struct{}
+	err  error
+}
+
+// maxJWKSSize JWKS 响应的最大字节数
+const maxJWKSSize = 1 << 20
+
+// NewJWKSProvider 创建 JWKS 密钥提供者，首次使用时获取密钥
+func NewJWKSProvider(config JWKSConfig) *JWKSProvider {
+	if config.Client == nil {
+		config.Client = &http.Client{Timeout: 10 * time.Second}
+	}
+	if config.TTL <= 0 {
+		config.TTL = time.Hour
+	}
+	if config.MinRefreshInterval <= 0 {
+		config.MinRefreshInterval = time.Minute
+	}
+	if config.Clock == nil {
+		config.Clock = SystemClock
+	}
+	return &JWKSProvider{config: config}
+}
+
+// PublicKey 返回 kid 对应的公钥
+func (p *JWKSProvider) PublicKey(kid string) (crypto.PublicKey, error) {
+	key, err := p.Key(kid)
+	if err != nil {
+		return nil, err
+	}
+	return key.Key, nil
+}
+
+// Key 返回 kid 对应的 JWK，缓存中没有时（受 MinRefreshInterval 限制）重新获取一次
+func (p *JWKSProvider) Key(kid string) (*JSONWebKey, error) {
+	ctx := context.Background()
+	now := p.config.Clock.Now()
+
+	set, fetchedAt, fetching := p.cached()
+	switch {
+	case set == nil:
+		if err := p.refresh(ctx); err != nil {
+			return nil, err
+		}
+		set, fetchedAt, _ = p.cached()
+	case now.Sub(fetchedAt) >= p.config.TTL && !fetching:
+		// 获取失败时继续使用已缓存的密钥
+		if p.refresh(ctx) == nil {
+			set, fetchedAt, _ = p.cached()
+		}
+	}
+
+	key, err := set.Key(kid)
+	if errors.Is(err, ErrKeyNotFound) && now.Sub(fetchedAt) >= p.config.MinRefreshInterval {
+		// 对端可能已轮换密钥
+		if refreshErr := p.refresh(ctx); refreshErr != nil {
+			return nil, refreshErr
+		}
+		set, _, _ = p.cached()
+		key, err = set.Key(kid)
+	}
+	return key, err
+}
+
+// Refresh 立即重新获取 JWKS，已有获取在进行时等待其结果
+func (p *JWKSProvider) Refresh(ctx context.Context) error {
+	return p.refresh(ctx)
+}
+
+// cached 返回当前缓存的密钥集、获取时间以及是否正在获取
+func (p *JWKSProvider) cached() (*JSONWebKeySet, time.Time, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.set, p.fetchedAt, p.fetching != nil
+}
+
+// refresh 获取 JWKS 并在成功时替换缓存；已有获取在进行时等待其结果而不重复请求
+func (p *JWKSProvider) refresh(ctx context.Context) error {
+	p.mu.Lock()
+	if call := p.fetching; call != nil {
+		p.mu.Unlock()
+		select {
+		case <-call.done:
+			return call.err
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	call := &jwksFetch{done: make(chan struct{})}
+	p.fetching = call
+	// 无论成功与否都记录获取时间，避免对端故障时每次验证都发起请求
+	p.fetchedAt = p.config.Clock.Now()
+	p.mu.Unlock()
+
+	set, err := p.fetch(ctx)
+
+	p.mu.Lock()
+	if err == nil {
+		p.set = set
+	}
+	p.fetching = nil
+	p.mu.Unlock()
+
+	call.err = err
+	close(call.done)
+	return err
+}
+
+// fetch 请求并解析 JWKS，不访问缓存
+func (p *JWKSProvider) fetch(ctx context.Context) (*JSONWebKeySet, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.config.URL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("创建 JWKS 请求失败: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.config.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("获取 JWKS 失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("获取 JWKS 失败: HTTP %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxJWKSSize))
+	if err != nil {
+		return nil, fmt.Errorf("读取 JWKS 失败: %w", err)
+	}
+	return ParseJWKS(data)
+}
+
+// withProvidedKey 返回使用 KeyProvider 中 kid 对应公钥的验证器副本，未配置 KeyProvider 时返回自身
+func (v *SignValidator) withProvidedKey(params map[string]interface{}) (*SignValidator, error) {
+	if v.config.KeyProvider == nil {
+		return v, nil
+	}
+
+	var kid string
+	if value, exists := v.lookup(params, v.config.KeyIDKey); exists {
+		kid = convertToString(value)
+	}
+
+	key, err := v.config.KeyProvider.PublicKey(kid)
+	if err != nil {
+		return nil, err
+	}
+
+	c := *v
+	c.config.PublicKey = key
+	return &c, nil
+}
@@ -0,0 +1,228 @@
+package signvalidator
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+// jwksServer 返回可替换密钥集内容的测试 JWKS 服务
+type jwksServer struct {
+	mu       sync.Mutex
+	document string
+	requests int
+}
+
+func (s *jwksServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests++
+	w.Header().Set("Content-Type", "application/json")
+	w.Write([]byte(s.document))
+}
+
+func (s *jwksServer) count() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests
+}
+
+func (s *jwksServer) set(document string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.document = document
+}
+
+func TestJWKSProvider_Rotation(t *testing.T) {
+	oldKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("生成密钥失败: %v", err)
+	}
+	newKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("生成密钥失败: %v", err)
+	}
+
+	handler := &jwksServer{document: `{"keys":[` + rsaJWK("v1", "RS256", &oldKey.PublicKey) + `]}`}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	clock := NewFakeClock(time.Now())
+	provider := NewJWKSProvider(JWKSConfig{URL: server.URL, TTL: time.Hour, MinRefreshInterval: time.Minute, Clock: clock})
+
+	verifier := NewSignValidator(Config{Algorithm: RSA_SHA256, KeyProvider: provider})
+
+	params := map[string]interface{}{"id": 123, "kid": "v1"}
+	signature, err := NewSignValidator(Config{Algorithm: RSA_SHA256, PrivateKey: oldKey}).GenerateSignature(params)
+	if err != nil {
+		t.Fatalf("生成签名失败: %v", err)
+	}
+	valid, err := verifier.Validate(params, signature)
+	if err != nil {
+		t.Fatalf("验证签名失败: %v", err)
+	}
+	if !valid {
+		t.Errorf("签名验证失败")
+	}
+
+	// 缓存有效期内不重复请求
+	if _, err := verifier.Validate(params, signature); err != nil {
+		t.Fatalf("验证签名失败: %v", err)
+	}
+	if handler.count() != 1 {
+		t.Errorf("请求次数 = %d, 期望 1", handler.count())
+	}
+
+	// 对端发布新密钥后，未知 kid 触发刷新
+	handler.set(`{"keys":[` + rsaJWK("v1", "RS256", &oldKey.PublicKey) + `,` + rsaJWK("v2", "RS256", &newKey.PublicKey) + `]}`)
+	clock.Advance(2 * time.Minute)
+
+	params = map[string]interface{}{"id": 123, "kid": "v2"}
+	signature, err = NewSignValidator(Config{Algorithm: RSA_SHA256, PrivateKey: newKey}).GenerateSignature(params)
+	if err != nil {
+		t.Fatalf("生成签名失败: %v", err)
+	}
+	valid, err = verifier.Validate(params, signature)
+	if err != nil {
+		t.Fatalf("验证签名失败: %v", err)
+	}
+	if !valid {
+		t.Errorf("轮换后的签名验证失败")
+	}
+	if handler.count() != 2 {
+		t.Errorf("请求次数 = %d, 期望 2", handler.count())
+	}
+
+	// 刷新间隔内的未知 kid 不再请求
+	params["kid"] = "v3"
+	if _, err := verifier.Validate(params, signature); !errors.Is(err, ErrKeyNotFound) {
+		t.Errorf("错误 = %v, 期望 ErrKeyNotFound", err)
+	}
+	if handler.count() != 2 {
+		t.Errorf("请求次数 = %d, 期望 2", handler.count())
+	}
+}
+
+func TestJWKSProvider_FetchOutsideLock(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("生成密钥失败: %v", err)
+	}
+
+	handler := &jwksServer{document: `{"keys":[` + rsaJWK("v1", "RS256", &key.PublicKey) + `]}`}
+	started := make(chan struct{}, 1)
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if handler.count() > 0 {
+			started <- struct{}{}
+			<-release
+		}
+		handler.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	clock := NewFakeClock(time.Now())
+	provider := NewJWKSProvider(JWKSConfig{URL: server.URL, Clock: clock})
+	if _, err := provider.PublicKey("v1"); err != nil {
+		t.Fatalf("获取公钥失败: %v", err)
+	}
+
+	// 缓存过期后的刷新阻塞在 HTTP 请求中
+	clock.Advance(2 * time.Hour)
+	refreshed := make(chan error, 1)
+	go func() {
+		_, err := provider.PublicKey("v1")
+		refreshed <- err
+	}()
+	<-started
+
+	// 刷新期间其他调用方直接使用已缓存的密钥
+	cached := make(chan error, 1)
+	go func() {
+		_, err := provider.PublicKey("v1")
+		cached <- err
+	}()
+	select {
+	case err := <-cached:
+		if err != nil {
+			t.Errorf("刷新期间获取公钥失败: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("刷新期间获取公钥被阻塞")
+	}
+
+	close(release)
+	if err := <-refreshed; err != nil {
+		t.Errorf("刷新失败: %v", err)
+	}
+	if handler.count() != 2 {
+		t.Errorf("请求次数 = %d, 期望 2", handler.count())
+	}
+}
+
+// recordingKeyProvider 记录请求的 kid 并返回固定公钥的 KeyProvider
+type recordingKeyProvider struct {
+	key  crypto.PublicKey
+	kids []string
+}
+
+func (p *recordingKeyProvider) PublicKey(kid string) (crypto.PublicKey, error) {
+	p.kids = append(p.kids, kid)
+	return p.key, nil
+}
+
+func TestKeyProvider_CaseInsensitiveKeyID(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("生成密钥失败: %v", err)
+	}
+	provider := &recordingKeyProvider{key: &key.PublicKey}
+	verifier := NewSignValidator(Config{Algorithm: RSA_SHA256, KeyProvider: provider, CaseInsensitiveKeys: true})
+
+	params := map[string]interface{}{"id": 123, "KID": "v1"}
+	signature, err := NewSignValidator(Config{Algorithm: RSA_SHA256, PrivateKey: key}).GenerateSignature(params)
+	if err != nil {
+		t.Fatalf("生成签名失败: %v", err)
+	}
+	if valid, err := verifier.Validate(params, signature); err != nil || !valid {
+		t.Fatalf("签名验证失败: valid=%v, err=%v", valid, err)
+	}
+	if len(provider.kids) != 1 || provider.kids[0] != "v1" {
+		t.Errorf("KeyProvider 收到的 kid = %v, 期望 [v1]", provider.kids)
+	}
+}
+
+func TestJWKSProvider_StaleOnError(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("生成密钥失败: %v", err)
+	}
+
+	handler := &jwksServer{document: `{"keys":[` + rsaJWK("v1", "RS256", &key.PublicKey) + `]}`}
+	server := httptest.NewServer(handler)
+
+	clock := NewFakeClock(time.Now())
+	provider := NewJWKSProvider(JWKSConfig{URL: server.URL, Clock: clock})
+
+	if _, err := provider.PublicKey("v1"); err != nil {
+		t.Fatalf("获取公钥失败: %v", err)
+	}
+
+	// 缓存过期且 JWKS 地址不可用时继续使用已缓存的密钥
+	server.Close()
+	clock.Advance(2 * time.Hour)
+	if _, err := provider.PublicKey("v1"); err != nil {
+		t.Errorf("获取公钥失败: %v", err)
+	}
+
+	// 首次获取失败时返回错误
+	if _, err := NewJWKSProvider(JWKSConfig{URL: server.URL}).PublicKey("v1"); err == nil {
+		t.Errorf("JWKS 地址不可用时应该返回错误")
+	}
+}
@@ -0,0 +1,127 @@
+package signvalidator
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// ErrUnknownKeyID 请求的密钥标识不在密钥环中
+var ErrUnknownKeyID = errors.New("未知的密钥标识")
+
+// UnknownKeyIDError 密钥标识不在 Keys 中时返回的错误
+type UnknownKeyIDError struct {
+	// KeyID 请求中的密钥标识
+	KeyID string
+}
+
+func (e *UnknownKeyIDError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrUnknownKeyID, e.KeyID)
+}
+
+// Unwrap 使 errors.Is(err, ErrUnknownKeyID) 成立
+func (e *UnknownKeyIDError) Unwrap() error {
+	return ErrUnknownKeyID
+}
+
+// checkKeyRing 检查密钥环配置，以 KeyID 对应的密钥作为 Secret，并复制 Keys 以免拉伸密钥时修改调用方的映射
+func (v *SignValidator) checkKeyRing() error {
+	if len(v.config.Keys) == 0 {
+		if v.config.KeyID != "" && v.config.Authorization == nil {
+			return errors.New("KeyID 需要配置密钥环 Keys 或 Authorization")
+		}
+		return nil
+	}
+
+	switch {
+	case v.config.KeyID == "":
+		return errors.New("密钥环需要配置当前密钥标识 KeyID")
+	case v.config.Secret != "":
+		return errors.New("配置了密钥环 Keys 时不能同时设置 Secret")
+	case len(v.config.PreviousSecrets) > 0:
+		return errors.New("配置了密钥环 Keys 时通过密钥标识轮换密钥，不能同时设置 PreviousSecrets")
+	}
+
+	keys := make(map[string]string, len(v.config.Keys))
+	for kid, secret := range v.config.Keys {
+		if secret == "" {
+			return fmt.Errorf("密钥标识 %s 的密钥为空", kid)
+		}
+		keys[kid] = secret
+	}
+	secret, exists := keys[v.config.KeyID]
+	if !exists {
+		return &UnknownKeyIDError{KeyID: v.config.KeyID}
+	}
+	v.config.Keys = keys
+	v.config.Secret = secret
+	return nil
+}
+
+// keyID 返回请求中的密钥标识：KeyIDKey 参数，参数缺失时为 KeyIDHeader 请求头
+func (v *SignValidator) keyID(params map[string]interface{}) (string, bool) {
+	if value, exists := v.lookup(params, v.config.KeyIDKey); exists && value != nil && value != "" {
+		return convertToString(value), true
+	}
+	if v.headerKeyID != "" {
+		return v.headerKeyID, true
+	}
+	return "", false
+}
+
+// KeyID 返回请求使用的密钥标识：参数中 KeyIDKey 的值，缺失时依次为 r 的 KeyIDHeader 请求头与 Authorization 请求头，
+// 均不存在时返回空字符串；供中间件在验证通过后记录调用方使用的密钥标识
+func (v *SignValidator) KeyID(params map[string]interface{}, r *http.Request) string {
+	if value, exists := v.lookup(params, v.config.KeyIDKey); exists && value != nil && value != "" {
+		return convertToString(value)
+	}
+	if r == nil {
+		return ""
+	}
+	if v.config.KeyIDHeader != "" {
+		if kid := r.Header.Get(v.config.KeyIDHeader); kid != "" {
+			return kid
+		}
+	}
+	if auth, err := v.authorization(r); err == nil && auth != nil {
+		return auth.KeyID
+	}
+	return ""
+}
+
+// withKeyIDHeader 返回记录 KeyIDHeader 请求头（配置了密钥环时）或 Authorization 请求头中密钥标识的验证器副本，均不存在时返回 v
+func (v *SignValidator) withKeyIDHeader(r *http.Request) *SignValidator {
+	var kid string
+	if len(v.config.Keys) > 0 && v.config.KeyIDHeader != "" {
+		kid = r.Header.Get(v.config.KeyIDHeader)
+	}
+	if kid == "" {
+		if auth, err := v.authorization(r); err == nil && auth != nil {
+			kid = auth.KeyID
+		}
+	}
+	if kid == "" {
+		return v
+	}
+	c := *v
+	c.headerKeyID = kid
+	return &c
+}
+
+// withKeyRing 返回使用请求的密钥标识所对应密钥的验证器副本，未配置密钥环时返回 v；
+// 缺少密钥标识时返回 *MissingKeysError，密钥标识不在 Keys 中时返回 *UnknownKeyIDError
+func (v *SignValidator) withKeyRing(params map[string]interface{}) (*SignValidator, error) {
+	if len(v.config.Keys) == 0 {
+		return v, nil
+	}
+
+	kid, exists := v.keyID(params)
+	if !exists {
+		return nil, &MissingKeysError{Keys: []string{v.config.KeyIDKey}}
+	}
+	secret, exists := v.config.Keys[kid]
+	if !exists {
+		return nil, &UnknownKeyIDError{KeyID: kid}
+	}
+	return v.withSecret(secret), nil
+}
@@ -0,0 +1,98 @@
+package signvalidator
+
+import (
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestKeyRing(t *testing.T) {
+	keys := map[string]string{"2024-01": "old-secret", "2024-06": "new-secret"}
+	v := NewSignValidator(Config{Algorithm: HMAC_SHA256, Keys: keys, KeyID: "2024-06"})
+
+	signed, err := v.SignRequest(map[string]interface{}{"order_id": "1001"})
+	if err != nil {
+		t.Fatalf("签名请求失败: %v", err)
+	}
+	if signed["kid"] != "2024-06" {
+		t.Errorf("密钥标识 = %v, 期望 2024-06", signed["kid"])
+	}
+	if valid, err := v.ValidateWithSignInParams(signed); err != nil || !valid {
+		t.Errorf("当前密钥的签名验证应通过，valid=%v err=%v", valid, err)
+	}
+
+	// 按 kid 选择旧密钥
+	params := map[string]interface{}{"kid": "2024-01", "order_id": "1001"}
+	signature, _ := NewSignValidator(Config{Algorithm: HMAC_SHA256, Secret: "old-secret"}).GenerateSignature(params)
+	if valid, err := v.Validate(params, signature); err != nil || !valid {
+		t.Errorf("旧密钥标识的签名验证应通过，valid=%v err=%v", valid, err)
+	}
+	params["kid"] = "2024-06"
+	if valid, _ := v.Validate(params, signature); valid {
+		t.Error("密钥标识与签名密钥不一致时验证不应通过")
+	}
+
+	params["kid"] = "2023-01"
+	var unknown *UnknownKeyIDError
+	if _, err := v.Validate(params, signature); !errors.As(err, &unknown) || unknown.KeyID != "2023-01" || !errors.Is(err, ErrUnknownKeyID) {
+		t.Errorf("未知的密钥标识应返回 *UnknownKeyIDError，实际 %v", err)
+	}
+	if _, err := v.Validate(map[string]interface{}{"order_id": "1001"}, signature); !errors.Is(err, ErrMissingKeys) {
+		t.Errorf("缺少密钥标识应返回 ErrMissingKeys，实际 %v", err)
+	}
+	if _, err := v.ValidateStrings(map[string]string{"kid": "2023-01"}, signature); !errors.Is(err, ErrUnknownKeyID) {
+		t.Errorf("字符串参数同样应检查密钥标识，实际 %v", err)
+	}
+}
+
+func TestKeyRing_Header(t *testing.T) {
+	v := NewSignValidator(Config{
+		Algorithm:   HMAC_SHA256,
+		Keys:        map[string]string{"a": "secret-a", "b": "secret-b"},
+		KeyID:       "a",
+		KeyIDHeader: "X-Key-Id",
+	})
+	body := `{"amount":100}`
+	signature, _ := NewSignValidator(Config{Algorithm: HMAC_SHA256, Secret: "secret-b"}).GenerateBodySignature([]byte(body))
+
+	r := httptest.NewRequest("POST", "/orders", strings.NewReader(body))
+	r.Header.Set("X-Key-Id", "b")
+	if valid, err := v.ValidateRequestBody(r, signature); err != nil || !valid {
+		t.Errorf("按请求头选择密钥的验证应通过，valid=%v err=%v", valid, err)
+	}
+
+	r = httptest.NewRequest("POST", "/orders", strings.NewReader(body))
+	if _, err := v.ValidateRequestBody(r, signature); !errors.Is(err, ErrMissingKeys) {
+		t.Errorf("缺少密钥标识请求头应返回 ErrMissingKeys，实际 %v", err)
+	}
+}
+
+func TestKeyRing_Invalid(t *testing.T) {
+	for _, config := range []Config{
+		{KeyID: "a"},
+		{Keys: map[string]string{"a": "secret"}},
+		{Keys: map[string]string{"a": "secret"}, KeyID: "b"},
+		{Keys: map[string]string{"a": ""}, KeyID: "a"},
+		{Keys: map[string]string{"a": "secret"}, KeyID: "a", Secret: "secret"},
+	} {
+		if _, err := New(config); err == nil {
+			t.Errorf("%+v: 应返回错误", config)
+		}
+	}
+}
+
+func TestSignValidator_KeyID(t *testing.T) {
+	v := NewSignValidator(Config{Secret: "testSecret", KeyIDHeader: "X-Key-Id"})
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("X-Key-Id", "from-header")
+	if kid := v.KeyID(map[string]interface{}{"kid": "from-param"}, r); kid != "from-param" {
+		t.Errorf("KeyID = %s, 应优先使用参数", kid)
+	}
+	if kid := v.KeyID(map[string]interface{}{}, r); kid != "from-header" {
+		t.Errorf("KeyID = %s, 参数缺失时应使用请求头", kid)
+	}
+	if kid := v.KeyID(map[string]interface{}{}, nil); kid != "" {
+		t.Errorf("KeyID = %s, 期望为空", kid)
+	}
+}
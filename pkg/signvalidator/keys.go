@@ -0,0 +1,185 @@
+package signvalidator
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/huangchunlong818/sign-chao/pkg/signvalidator/internal/sm2"
+)
+
+// ParsePrivateKey 解析私钥，支持 PEM 格式（PKCS#1、PKCS#8、SEC 1）
+// 以及不带 PEM 头尾的 Base64 DER 格式（常见于支付宝等平台下发的密钥）
+func ParsePrivateKey(key string) (crypto.Signer, error) {
+	der, blockType, err := decodeKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	switch blockType {
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(der)
+	case "EC PRIVATE KEY":
+		if priv, err := sm2.ParseECPrivateKey(der); err == nil {
+			return priv, nil
+		}
+		return x509.ParseECPrivateKey(der)
+	}
+
+	// PKCS#8 以及未知或缺失的块类型依次尝试
+	if priv, err := x509.ParsePKCS8PrivateKey(der); err == nil {
+		signer, ok := priv.(crypto.Signer)
+		if !ok {
+			return nil, fmt.Errorf("不支持的私钥类型: %T", priv)
+		}
+		return signer, nil
+	}
+	if priv, err := sm2.ParsePKCS8PrivateKey(der); err == nil {
+		return priv, nil
+	}
+	if priv, err := x509.ParsePKCS1PrivateKey(der); err == nil {
+		return priv, nil
+	}
+	if priv, err := x509.ParseECPrivateKey(der); err == nil {
+		return priv, nil
+	}
+	return nil, errors.New("无法解析私钥")
+}
+
+// ParsePublicKey 解析公钥，支持 PEM 格式（PKIX、PKCS#1、X.509 证书）
+// 以及不带 PEM 头尾的 Base64 DER 格式
+func ParsePublicKey(key string) (crypto.PublicKey, error) {
+	der, blockType, err := decodeKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	switch blockType {
+	case "RSA PUBLIC KEY":
+		return x509.ParsePKCS1PublicKey(der)
+	case "CERTIFICATE":
+		cert, err := x509.ParseCertificate(der)
+		if err != nil {
+			return nil, err
+		}
+		return cert.PublicKey, nil
+	}
+
+	if pub, err := x509.ParsePKIXPublicKey(der); err == nil {
+		return pub, nil
+	}
+	if pub, err := sm2.ParsePKIXPublicKey(der); err == nil {
+		return pub, nil
+	}
+	if pub, err := x509.ParsePKCS1PublicKey(der); err == nil {
+		return pub, nil
+	}
+	return nil, errors.New("无法解析公钥")
+}
+
+// GenerateKeyPair 为非对称签名算法生成 PEM 编码的密钥对：私钥为 PKCS#8，公钥为 PKIX。
+// RSA_SHA256 与 RSA_PSS_SHA256 使用 2048 位密钥，RSA_PSS_SHA512 使用 3072 位密钥，ECDSA 使用算法对应的曲线
+func GenerateKeyPair(algorithm SignAlgorithm) (privateKeyPEM, publicKeyPEM string, err error) {
+	var key crypto.Signer
+	switch algorithm {
+	case RSA_SHA256, RSA_PSS_SHA256:
+		key, err = rsa.GenerateKey(rand.Reader, 2048)
+	case RSA_PSS_SHA512:
+		key, err = rsa.GenerateKey(rand.Reader, 3072)
+	case ECDSA_P256_SHA256, ECDSA_P384_SHA384:
+		key, err = ecdsa.GenerateKey(ecdsaCurve(algorithm), rand.Reader)
+	case ED25519:
+		_, key, err = ed25519.GenerateKey(rand.Reader)
+	case SM2:
+		key, err = GenerateSM2Key()
+	default:
+		return "", "", fmt.Errorf("签名算法 %s 不是非对称算法", algorithm)
+	}
+	if err != nil {
+		return "", "", fmt.Errorf("生成密钥失败: %w", err)
+	}
+
+	if privateKeyPEM, err = MarshalPrivateKeyPEM(key); err != nil {
+		return "", "", err
+	}
+	if publicKeyPEM, err = MarshalPublicKeyPEM(key.Public()); err != nil {
+		return "", "", err
+	}
+	return privateKeyPEM, publicKeyPEM, nil
+}
+
+// MarshalPrivateKeyPEM 将私钥编码为 PKCS#8 PEM 格式
+func MarshalPrivateKeyPEM(key crypto.Signer) (string, error) {
+	var der []byte
+	var err error
+	if priv, ok := key.(*SM2PrivateKey); ok {
+		der, err = sm2.MarshalPKCS8PrivateKey(priv)
+	} else {
+		der, err = x509.MarshalPKCS8PrivateKey(key)
+	}
+	if err != nil {
+		return "", fmt.Errorf("编码私钥失败: %w", err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})), nil
+}
+
+// MarshalPublicKeyPEM 将公钥编码为 PKIX PEM 格式
+func MarshalPublicKeyPEM(key crypto.PublicKey) (string, error) {
+	var der []byte
+	var err error
+	if pub, ok := key.(*SM2PublicKey); ok {
+		der, err = sm2.MarshalPKIXPublicKey(pub)
+	} else {
+		der, err = x509.MarshalPKIXPublicKey(key)
+	}
+	if err != nil {
+		return "", fmt.Errorf("编码公钥失败: %w", err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})), nil
+}
+
+// LoadPrivateKeyFile 从文件加载私钥
+func LoadPrivateKeyFile(path string) (crypto.Signer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePrivateKey(string(data))
+}
+
+// LoadPublicKeyFile 从文件加载公钥
+func LoadPublicKeyFile(path string) (crypto.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePublicKey(string(data))
+}
+
+// decodeKey 将 PEM 或 Base64 文本解码为 DER，同时返回 PEM 块类型（Base64 格式时为空）
+func decodeKey(key string) ([]byte, string, error) {
+	key = strings.TrimSpace(key)
+	if key == "" {
+		return nil, "", errors.New("密钥为空")
+	}
+
+	if block, _ := pem.Decode([]byte(key)); block != nil {
+		return block.Bytes, block.Type, nil
+	}
+
+	// 去除换行等空白后按 Base64 解码
+	der, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(key), ""))
+	if err != nil {
+		return nil, "", errors.New("密钥既不是 PEM 格式也不是 Base64 格式")
+	}
+	return der, "", nil
+}
@@ -0,0 +1,150 @@
+package signvalidator
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParsePrivateKey_RSA(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("生成密钥失败: %v", err)
+	}
+
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("编码私钥失败: %v", err)
+	}
+
+	testCases := []struct {
+		name  string
+		input string
+	}{
+		{"PKCS#1", string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}))},
+		{"PKCS#8", string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8}))},
+		{"Base64", base64.StdEncoding.EncodeToString(pkcs8)},
+	}
+
+	for _, tc := range testCases {
+		parsed, err := ParsePrivateKey(tc.input)
+		if err != nil {
+			t.Errorf("%s: 解析私钥失败: %v", tc.name, err)
+			continue
+		}
+
+		rsaKey, ok := parsed.(*rsa.PrivateKey)
+		if !ok || !rsaKey.Equal(key) {
+			t.Errorf("%s: 解析后的私钥与原私钥不一致", tc.name)
+		}
+	}
+
+	if _, err := ParsePrivateKey("not a key"); err == nil {
+		t.Errorf("无效私钥应该返回错误")
+	}
+}
+
+func TestParsePublicKey_RSA(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("生成密钥失败: %v", err)
+	}
+
+	pkix, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("编码公钥失败: %v", err)
+	}
+
+	testCases := []struct {
+		name  string
+		input string
+	}{
+		{"PKCS#1", string(pem.EncodeToMemory(&pem.Block{Type: "RSA PUBLIC KEY", Bytes: x509.MarshalPKCS1PublicKey(&key.PublicKey)}))},
+		{"PKIX", string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pkix}))},
+		{"Base64", base64.StdEncoding.EncodeToString(pkix)},
+	}
+
+	for _, tc := range testCases {
+		parsed, err := ParsePublicKey(tc.input)
+		if err != nil {
+			t.Errorf("%s: 解析公钥失败: %v", tc.name, err)
+			continue
+		}
+
+		rsaKey, ok := parsed.(*rsa.PublicKey)
+		if !ok || !rsaKey.Equal(&key.PublicKey) {
+			t.Errorf("%s: 解析后的公钥与原公钥不一致", tc.name)
+		}
+	}
+}
+
+func TestLoadKeyFile(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("生成密钥失败: %v", err)
+	}
+
+	dir := t.TempDir()
+	privatePath := filepath.Join(dir, "private.pem")
+	publicPath := filepath.Join(dir, "public.pem")
+
+	pkix, _ := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	os.WriteFile(privatePath, pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}), 0600)
+	os.WriteFile(publicPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pkix}), 0644)
+
+	if _, err := LoadPrivateKeyFile(privatePath); err != nil {
+		t.Errorf("加载私钥文件失败: %v", err)
+	}
+
+	if _, err := LoadPublicKeyFile(publicPath); err != nil {
+		t.Errorf("加载公钥文件失败: %v", err)
+	}
+
+	if _, err := LoadPrivateKeyFile(filepath.Join(dir, "missing.pem")); err == nil {
+		t.Errorf("文件不存在时应该返回错误")
+	}
+}
+
+func TestGenerateKeyPair(t *testing.T) {
+	params := map[string]interface{}{"id": 123, "name": "test"}
+
+	for _, algorithm := range []SignAlgorithm{RSA_SHA256, RSA_PSS_SHA512, ECDSA_P256_SHA256, ECDSA_P384_SHA384, ED25519, SM2} {
+		if FIPSBuild && !IsFIPSApproved(algorithm) {
+			continue
+		}
+		privateKeyPEM, publicKeyPEM, err := GenerateKeyPair(algorithm)
+		if err != nil {
+			t.Fatalf("%s 生成密钥对失败: %v", algorithm, err)
+		}
+
+		signer, err := New(Config{Algorithm: algorithm, PrivateKeyPEM: privateKeyPEM})
+		if err != nil {
+			t.Fatalf("%s 解析私钥失败: %v", algorithm, err)
+		}
+		verifier, err := New(Config{Algorithm: algorithm, PublicKeyPEM: publicKeyPEM})
+		if err != nil {
+			t.Fatalf("%s 解析公钥失败: %v", algorithm, err)
+		}
+
+		signature, err := signer.GenerateSignature(params)
+		if err != nil {
+			t.Fatalf("%s 生成签名失败: %v", algorithm, err)
+		}
+		valid, err := verifier.Validate(params, signature)
+		if err != nil {
+			t.Fatalf("%s 验证签名失败: %v", algorithm, err)
+		}
+		if !valid {
+			t.Errorf("%s 签名验证失败", algorithm)
+		}
+	}
+
+	if _, _, err := GenerateKeyPair(HMAC_SHA256); err == nil {
+		t.Errorf("对称算法生成密钥对应该返回错误")
+	}
+}
@@ -0,0 +1,163 @@
+// Package memcached 使用 Memcached 实现 signvalidator.NonceStore：通过文本协议的 add 命令原子地记录随机数，
+// 多个服务实例共享同一份记录，适用于没有 Redis 的环境。
+package memcached
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxRelativeExpiry Memcached 相对过期时间的上限，超过时按 Unix 时间戳解释
+const maxRelativeExpiry = 30 * 24 * time.Hour
+
+// Config Memcached 配置
+type Config struct {
+	// Address 服务地址，如 "127.0.0.1:11211"
+	Address string
+	// KeyPrefix 键前缀，默认为 "nonce:"，随机数经 SHA-256 摘要后拼接在前缀之后
+	KeyPrefix string
+	// Timeout 单次调用超时时间（含建立连接），默认为 1 秒
+	Timeout time.Duration
+	// MaxIdle 保留的空闲连接数，默认为 4
+	MaxIdle int
+	// OnError 调用失败时的回调，可用于记录日志；调用失败时 Seen 返回 true 以拒绝请求
+	OnError func(error)
+}
+
+// NonceStore 基于 Memcached 的 signvalidator.NonceStore
+type NonceStore struct {
+	config Config
+	mu     sync.Mutex
+	idle   []*conn
+}
+
+// conn 一条 Memcached 连接
+type conn struct {
+	net.Conn
+	reader *bufio.Reader
+}
+
+// NewNonceStore 检查配置并创建 NonceStore，连接在首次使用时建立
+func NewNonceStore(config Config) (*NonceStore, error) {
+	if config.Address == "" {
+		return nil, errors.New("Memcached 地址为空")
+	}
+	if config.KeyPrefix == "" {
+		config.KeyPrefix = "nonce:"
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = time.Second
+	}
+	if config.MaxIdle <= 0 {
+		config.MaxIdle = 4
+	}
+	return &NonceStore{config: config}, nil
+}
+
+// Seen 实现 signvalidator.NonceStore，使用 add 命令仅在键不存在时写入
+func (s *NonceStore) Seen(nonce string, ttl time.Duration) bool {
+	stored, err := s.add(s.key(nonce), expiry(ttl))
+	if err != nil {
+		if s.config.OnError != nil {
+			s.config.OnError(err)
+		}
+		return true
+	}
+	return !stored
+}
+
+// Close 关闭空闲连接
+func (s *NonceStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, c := range s.idle {
+		c.Close()
+	}
+	s.idle = nil
+	return nil
+}
+
+// key 返回随机数对应的键，摘要使键满足 Memcached 的长度与字符限制
+func (s *NonceStore) key(nonce string) string {
+	sum := sha256.Sum256([]byte(nonce))
+	return s.config.KeyPrefix + hex.EncodeToString(sum[:])
+}
+
+// expiry 将 TTL 转换为 Memcached 的过期时间（秒，至少 1 秒），超过 30 天时使用 Unix 时间戳
+func expiry(ttl time.Duration) int64 {
+	if ttl > maxRelativeExpiry {
+		return time.Now().Add(ttl).Unix()
+	}
+	seconds := int64((ttl + time.Second - 1) / time.Second)
+	if seconds < 1 {
+		seconds = 1
+	}
+	return seconds
+}
+
+// add 执行 add 命令，键已存在时返回 false
+func (s *NonceStore) add(key string, exptime int64) (bool, error) {
+	c, err := s.get()
+	if err != nil {
+		return false, fmt.Errorf("连接 Memcached 失败: %w", err)
+	}
+	c.SetDeadline(time.Now().Add(s.config.Timeout))
+
+	if _, err := fmt.Fprintf(c, "add %s 0 %d 1\r\n1\r\n", key, exptime); err != nil {
+		c.Close()
+		return false, fmt.Errorf("写入 Memcached 失败: %w", err)
+	}
+	line, err := c.reader.ReadString('\n')
+	if err != nil {
+		c.Close()
+		return false, fmt.Errorf("读取 Memcached 响应失败: %w", err)
+	}
+
+	switch reply := strings.TrimRight(line, "\r\n"); reply {
+	case "STORED":
+		s.put(c)
+		return true, nil
+	case "NOT_STORED":
+		s.put(c)
+		return false, nil
+	default:
+		c.Close()
+		return false, fmt.Errorf("Memcached 错误: %s", reply)
+	}
+}
+
+// get 取出空闲连接或建立新连接
+func (s *NonceStore) get() (*conn, error) {
+	s.mu.Lock()
+	if n := len(s.idle); n > 0 {
+		c := s.idle[n-1]
+		s.idle = s.idle[:n-1]
+		s.mu.Unlock()
+		return c, nil
+	}
+	s.mu.Unlock()
+
+	c, err := net.DialTimeout("tcp", s.config.Address, s.config.Timeout)
+	if err != nil {
+		return nil, err
+	}
+	return &conn{Conn: c, reader: bufio.NewReader(c)}, nil
+}
+
+// put 归还连接，空闲连接已满时关闭
+func (s *NonceStore) put(c *conn) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.idle) >= s.config.MaxIdle {
+		c.Close()
+		return
+	}
+	s.idle = append(s.idle, c)
+}
@@ -0,0 +1,153 @@
+package memcached
+
+import (
+	"bufio"
+	"errors"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/huangchunlong818/sign-chao/pkg/signvalidator"
+)
+
+// fakeMemcached 只实现 add 命令的 Memcached 服务
+type fakeMemcached struct {
+	listener net.Listener
+	mu       sync.Mutex
+	keys     map[string]string
+}
+
+func newFakeMemcached(t *testing.T) *fakeMemcached {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("监听失败: %v", err)
+	}
+	f := &fakeMemcached{listener: listener, keys: make(map[string]string)}
+	go f.serve()
+	t.Cleanup(func() { listener.Close() })
+	return f
+}
+
+func (f *fakeMemcached) serve() {
+	for {
+		c, err := f.listener.Accept()
+		if err != nil {
+			return
+		}
+		go f.handle(c)
+	}
+}
+
+func (f *fakeMemcached) handle(c net.Conn) {
+	defer c.Close()
+	reader := bufio.NewReader(c)
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 5 || fields[0] != "add" {
+			c.Write([]byte("ERROR\r\n"))
+			continue
+		}
+		if _, err := reader.ReadString('\n'); err != nil {
+			return
+		}
+
+		f.mu.Lock()
+		_, exists := f.keys[fields[1]]
+		if !exists {
+			f.keys[fields[1]] = fields[3]
+		}
+		f.mu.Unlock()
+		if exists {
+			c.Write([]byte("NOT_STORED\r\n"))
+		} else {
+			c.Write([]byte("STORED\r\n"))
+		}
+	}
+}
+
+func TestNonceStore(t *testing.T) {
+	server := newFakeMemcached(t)
+	store, err := NewNonceStore(Config{Address: server.listener.Addr().String()})
+	if err != nil {
+		t.Fatalf("创建 NonceStore 失败: %v", err)
+	}
+	defer store.Close()
+
+	var _ signvalidator.NonceStore = store
+	if store.Seen("n1", time.Minute) {
+		t.Error("首次出现的随机数不应被视为已使用")
+	}
+	if !store.Seen("n1", time.Minute) {
+		t.Error("重复的随机数应被视为已使用")
+	}
+	if store.Seen("含 空格\r\n的随机数", 1500*time.Millisecond) {
+		t.Error("任意字符的随机数应可以记录")
+	}
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	for key, exptime := range server.keys {
+		if !strings.HasPrefix(key, "nonce:") || len(key) != len("nonce:")+64 {
+			t.Errorf("键格式不正确: %s", key)
+		}
+		if exptime != "60" && exptime != "2" {
+			t.Errorf("过期时间不正确: %s", exptime)
+		}
+	}
+}
+
+func TestNonceStore_Validator(t *testing.T) {
+	server := newFakeMemcached(t)
+	store, _ := NewNonceStore(Config{Address: server.listener.Addr().String()})
+	defer store.Close()
+
+	v := signvalidator.NewSignValidator(signvalidator.Config{Secret: "secret", NonceStore: store})
+	signed, err := v.SignRequest(map[string]interface{}{"a": 1})
+	if err != nil {
+		t.Fatalf("签名请求失败: %v", err)
+	}
+	if valid, err := v.ValidateWithSignInParams(signed); err != nil || !valid {
+		t.Errorf("首次验证应通过，valid=%v err=%v", valid, err)
+	}
+	if _, err := v.ValidateWithSignInParams(signed); !errors.Is(err, signvalidator.ErrNonceReused) {
+		t.Errorf("重放的请求应返回 ErrNonceReused，实际 %v", err)
+	}
+}
+
+func TestNonceStore_Unavailable(t *testing.T) {
+	listener, _ := net.Listen("tcp", "127.0.0.1:0")
+	address := listener.Addr().String()
+	listener.Close()
+
+	var reported error
+	store, _ := NewNonceStore(Config{Address: address, Timeout: 100 * time.Millisecond, OnError: func(err error) { reported = err }})
+	if !store.Seen("n1", time.Minute) {
+		t.Error("Memcached 不可用时应拒绝请求")
+	}
+	if reported == nil {
+		t.Error("调用失败时应调用 OnError")
+	}
+
+	if _, err := NewNonceStore(Config{}); err == nil {
+		t.Error("地址为空时应返回错误")
+	}
+}
+
+func TestExpiry(t *testing.T) {
+	if got := expiry(0); got != 1 {
+		t.Errorf("expiry(0) = %d, 期望 1", got)
+	}
+	if got := expiry(90 * time.Second); got != 90 {
+		t.Errorf("expiry(90s) = %d, 期望 90", got)
+	}
+	if got := expiry(60 * 24 * time.Hour); got < time.Now().Unix() {
+		t.Errorf("超过 30 天时应使用 Unix 时间戳，实际 %d", got)
+	}
+}
@@ -0,0 +1,97 @@
+package signvalidator
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+)
+
+// errNotMultipart 请求体不是 multipart/form-data 表单
+var errNotMultipart = errors.New("请求体不是 multipart/form-data 表单")
+
+// MultipartParams 读取 multipart/form-data 请求体并转换为签名参数：普通字段的值为字段文本，
+// 文件字段的值为文件内容 SHA-256 摘要的小写十六进制字符串，使上传的文件也受签名保护；
+// 同名字段出现多次时按 []string 处理并使用 MultiValue 拼接；读取后恢复请求体，后续处理仍可调用 r.ParseMultipartForm。
+// 请求体（包括全部文件）超过 DefaultMaxBodyBytes 时返回 ErrBodyTooLarge
+func MultipartParams(r *http.Request) (map[string]interface{}, error) {
+	return multipartParams(r, DefaultMaxBodyBytes)
+}
+
+// multipartParams 同 MultipartParams，请求体最多读取 limit 字节
+func multipartParams(r *http.Request, limit int64) (map[string]interface{}, error) {
+	mediaType, mediaParams, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/form-data" || mediaParams["boundary"] == "" {
+		return nil, errNotMultipart
+	}
+
+	body, err := readBody(r, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string][]string)
+	reader := multipart.NewReader(bytes.NewReader(body), mediaParams["boundary"])
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("解析 multipart 表单失败: %w", err)
+		}
+
+		name := part.FormName()
+		if name == "" {
+			continue
+		}
+		value, err := partValue(part)
+		if err != nil {
+			return nil, fmt.Errorf("读取 multipart 字段 %s 失败: %w", name, err)
+		}
+		values[name] = append(values[name], value)
+	}
+	return multiValueParams(values), nil
+}
+
+// partValue 返回普通字段的文本或文件内容的 SHA-256 摘要
+func partValue(part *multipart.Part) (string, error) {
+	if part.FileName() == "" {
+		data, err := io.ReadAll(part)
+		return string(data), err
+	}
+	h := sha256.New()
+	if _, err := io.Copy(h, part); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// GenerateMultipartSignature 对 multipart/form-data 请求生成签名，参数规则见 MultipartParams
+func (v *SignValidator) GenerateMultipartSignature(r *http.Request) (string, error) {
+	if v.err != nil {
+		return "", v.err
+	}
+	params, err := multipartParams(r, v.config.MaxBodyBytes)
+	if err != nil {
+		return "", err
+	}
+	return v.GenerateSignature(params)
+}
+
+// ValidateMultipart 读取 multipart/form-data 请求体并验证其中签名参数（SignatureKey）的签名，读取后恢复请求体
+func (v *SignValidator) ValidateMultipart(r *http.Request) (bool, error) {
+	if v.err != nil {
+		return false, v.err
+	}
+	params, err := multipartParams(r, v.config.MaxBodyBytes)
+	if err != nil {
+		return false, err
+	}
+	return v.withIncoming(r).ValidateWithSignInParams(params)
+}
@@ -0,0 +1,91 @@
+package signvalidator
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newMultipartRequest 构造包含普通字段与文件的 multipart 请求
+func newMultipartRequest(t *testing.T, fields map[string]string, files map[string]string) *http.Request {
+	t.Helper()
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	for name, value := range fields {
+		if err := w.WriteField(name, value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for name, content := range files {
+		fw, err := w.CreateFormFile(name, name+".txt")
+		if err != nil {
+			t.Fatal(err)
+		}
+		fw.Write([]byte(content))
+	}
+	w.Close()
+
+	r := httptest.NewRequest("POST", "/upload", &buf)
+	r.Header.Set("Content-Type", w.FormDataContentType())
+	return r
+}
+
+func TestMultipartParams(t *testing.T) {
+	r := newMultipartRequest(t, map[string]string{"title": "报告"}, map[string]string{"file": "hello"})
+	params, err := MultipartParams(r)
+	if err != nil {
+		t.Fatalf("解析 multipart 失败: %v", err)
+	}
+
+	sum := sha256.Sum256([]byte("hello"))
+	if params["file"] != hex.EncodeToString(sum[:]) {
+		t.Errorf("文件字段应为内容的 SHA-256 摘要，实际 %v", params["file"])
+	}
+	if params["title"] != "报告" {
+		t.Errorf("普通字段值不正确，实际 %v", params["title"])
+	}
+
+	// 请求体应被恢复
+	if err := r.ParseMultipartForm(1 << 20); err != nil {
+		t.Fatalf("恢复后解析 multipart 失败: %v", err)
+	}
+	if r.FormValue("title") != "报告" {
+		t.Error("请求体未被恢复")
+	}
+}
+
+func TestMultipartParams_NotMultipart(t *testing.T) {
+	r := httptest.NewRequest("POST", "/upload", strings.NewReader("a=1"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if _, err := MultipartParams(r); !errors.Is(err, errNotMultipart) {
+		t.Errorf("非 multipart 请求应返回 errNotMultipart，实际 %v", err)
+	}
+}
+
+func TestValidateMultipart(t *testing.T) {
+	v := NewSignValidator(Config{Secret: "secret", Algorithm: HMAC_SHA256})
+	fields := map[string]string{"title": "报告", "timestamp": "1700000000"}
+	files := map[string]string{"file": "content"}
+
+	signature, err := v.GenerateMultipartSignature(newMultipartRequest(t, fields, files))
+	if err != nil {
+		t.Fatalf("生成签名失败: %v", err)
+	}
+
+	fields["sign"] = signature
+	valid, err := v.ValidateMultipart(newMultipartRequest(t, fields, files))
+	if err != nil || !valid {
+		t.Errorf("签名验证应通过，valid=%v err=%v", valid, err)
+	}
+
+	valid, err = v.ValidateMultipart(newMultipartRequest(t, fields, map[string]string{"file": "tampered"}))
+	if err != nil || valid {
+		t.Errorf("文件内容修改后签名验证不应通过，valid=%v err=%v", valid, err)
+	}
+}
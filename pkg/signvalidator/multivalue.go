@@ -0,0 +1,56 @@
+package signvalidator
+
+import (
+	"strconv"
+	"strings"
+)
+
+// MultiValueMode 表示多值参数的拼接方式
+type MultiValueMode string
+
+const (
+	// MultiValueJSON 序列化为 JSON 数组（默认），如 k=["a","b"]
+	MultiValueJSON MultiValueMode = ""
+	// MultiValueRepeat 重复参数名，如 k=a&k=b
+	MultiValueRepeat MultiValueMode = "repeat"
+	// MultiValueComma 以逗号连接参数值，如 k=a,b
+	MultiValueComma MultiValueMode = "comma"
+	// MultiValueIndex 参数名后追加下标，如 k[0]=a&k[1]=b
+	MultiValueIndex MultiValueMode = "index"
+)
+
+// expandMultiValue 按 MultiValue 展开多值参数，参数值按给定顺序拼接
+func (v *SignValidator) expandMultiValue(key string, values []string) ([]queryPair, error) {
+	var pairs []queryPair
+	if v.config.MultiValue == MultiValueComma {
+		parts := make([]string, 0, len(values))
+		for _, value := range values {
+			s, err := v.formatValue(key, value)
+			if err != nil {
+				return nil, err
+			}
+			parts = append(parts, s)
+		}
+		joined := strings.Join(parts, ",")
+		if joined == "" && v.config.SkipEmptyValues {
+			return nil, nil
+		}
+		return []queryPair{{key: key, value: joined}}, nil
+	}
+
+	for i, value := range values {
+		s, err := v.formatValue(key, value)
+		if err != nil {
+			return nil, err
+		}
+		if s == "" && v.config.SkipEmptyValues {
+			continue
+		}
+		name := key
+		if v.config.MultiValue == MultiValueIndex {
+			name = key + "[" + strconv.Itoa(i) + "]"
+		}
+		pairs = append(pairs, queryPair{key: name, value: s})
+	}
+	return pairs, nil
+}
@@ -0,0 +1,38 @@
+package signvalidator
+
+import "testing"
+
+func TestSignValidator_MultiValue(t *testing.T) {
+	skipFIPS(t)
+	params := map[string]interface{}{"tag": []string{"b", "a"}, "id": 1}
+
+	testCases := []struct {
+		mode     MultiValueMode
+		expected string
+	}{
+		{MultiValueJSON, `id=1&tag=["b","a"]`},
+		{MultiValueRepeat, "id=1&tag=b&tag=a"},
+		{MultiValueComma, "id=1&tag=b,a"},
+		{MultiValueIndex, "id=1&tag[0]=b&tag[1]=a"},
+	}
+
+	for _, tc := range testCases {
+		validator, err := New(Config{Algorithm: MD5, MultiValue: tc.mode})
+		if err != nil {
+			t.Fatalf("创建签名验证器失败: %v", err)
+		}
+		if s, _ := validator.buildStringToSign(params); s != tc.expected {
+			t.Errorf("%q 待签名字符串 = %s, 期望 %s", tc.mode, s, tc.expected)
+		}
+	}
+
+	// 空值按 SkipEmptyValues 跳过
+	validator := NewSignValidator(Config{Algorithm: MD5, MultiValue: MultiValueRepeat, SkipEmptyValues: true})
+	if s, _ := validator.buildStringToSign(map[string]interface{}{"tag": []string{"", "a"}, "empty": []string{}}); s != "tag=a" {
+		t.Errorf("待签名字符串 = %s", s)
+	}
+
+	if _, err := New(Config{Algorithm: MD5, MultiValue: "semicolon"}); err == nil {
+		t.Errorf("不支持的拼接方式应该返回错误")
+	}
+}
@@ -0,0 +1,128 @@
+package signvalidator
+
+import (
+	"container/list"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrNonceReused 随机数已被使用，请求可能被重放
+var ErrNonceReused = errors.New("随机数已被使用")
+
+// defaultNonceTTL 默认的随机数保存时间，覆盖默认时间偏差的过去与未来两个方向
+const defaultNonceTTL = 2 * defaultMaxSkew
+
+// defaultNonceCapacity MemoryNonceStore 默认保存的随机数数量
+const defaultNonceCapacity = 100000
+
+// NonceStore 随机数存储，用于拒绝重复使用的随机数
+type NonceStore interface {
+	// Seen 判断 nonce 在 ttl 内是否已出现过；未出现时记录该 nonce 并返回 false，检查与记录必须是原子操作
+	Seen(nonce string, ttl time.Duration) bool
+}
+
+// checkNonce 签名验证通过后检查随机数是否已被使用，未配置 NonceStore 时不检查
+func (v *SignValidator) checkNonce(params map[string]interface{}) error {
+	if v.config.NonceStore == nil {
+		return nil
+	}
+
+	value, exists := v.lookup(params, v.config.NonceKey)
+	if !exists || value == nil || value == "" {
+		return &MissingKeysError{Keys: []string{v.config.NonceKey}}
+	}
+
+	ttl := v.config.NonceTTL
+	if ttl <= 0 {
+		ttl = defaultNonceTTL
+		if v.config.MaxSkew > 0 {
+			ttl = 2 * v.config.MaxSkew
+		}
+	}
+	if v.config.NonceStore.Seen(convertToString(value), ttl) {
+		return ErrNonceReused
+	}
+	return nil
+}
+
+// MemoryNonceStore 进程内的 NonceStore，按 TTL 过期，超出容量时淘汰最早记录的随机数；
+// 多实例部署时各实例不共享，需要使用 Redis 等共享存储
+type MemoryNonceStore struct {
+	mu       sync.Mutex
+	capacity int
+	entries  map[string]*list.Element
+	order    *list.List
+	clock    Clock
+}
+
+// nonceEntry 记录的随机数及其过期时间
+type nonceEntry struct {
+	nonce   string
+	expires time.Time
+}
+
+// NewMemoryNonceStore 创建最多保存 capacity 个随机数的 MemoryNonceStore，capacity 不大于 0 时为 100000
+func NewMemoryNonceStore(capacity int) *MemoryNonceStore {
+	if capacity <= 0 {
+		capacity = defaultNonceCapacity
+	}
+	return &MemoryNonceStore{
+		capacity: capacity,
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+		clock:    SystemClock,
+	}
+}
+
+// Seen 实现 NonceStore
+func (s *MemoryNonceStore) Seen(nonce string, ttl time.Duration) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := s.clock.Now()
+	s.purge(now)
+
+	if element, exists := s.entries[nonce]; exists {
+		if now.Before(element.Value.(*nonceEntry).expires) {
+			return true
+		}
+		s.remove(element)
+	}
+
+	s.entries[nonce] = s.order.PushFront(&nonceEntry{nonce: nonce, expires: now.Add(ttl)})
+	for s.order.Len() > s.capacity {
+		s.remove(s.order.Back())
+	}
+	return false
+}
+
+// SetClock 设置判断随机数是否过期使用的时钟，默认为 SystemClock
+func (s *MemoryNonceStore) SetClock(clock Clock) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.clock = clockOrSystem(clock)
+}
+
+// Len 返回当前保存的随机数数量（含尚未清理的过期记录）
+func (s *MemoryNonceStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.order.Len()
+}
+
+// purge 从最早记录的一端清理已过期的随机数
+func (s *MemoryNonceStore) purge(now time.Time) {
+	for element := s.order.Back(); element != nil; element = s.order.Back() {
+		if now.Before(element.Value.(*nonceEntry).expires) {
+			return
+		}
+		s.remove(element)
+	}
+}
+
+// remove 删除一条记录
+func (s *MemoryNonceStore) remove(element *list.Element) {
+	s.order.Remove(element)
+	delete(s.entries, element.Value.(*nonceEntry).nonce)
+}
@@ -0,0 +1,111 @@
+package signvalidator
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMemoryNonceStore(t *testing.T) {
+	clock := NewFakeClock(time.Unix(1700000000, 0))
+	store := NewMemoryNonceStore(2)
+	store.SetClock(clock)
+
+	if store.Seen("a", time.Minute) {
+		t.Error("首次出现的随机数不应被视为已使用")
+	}
+	if !store.Seen("a", time.Minute) {
+		t.Error("重复的随机数应被视为已使用")
+	}
+
+	clock.Advance(time.Minute)
+	if store.Seen("a", time.Minute) {
+		t.Error("过期后的随机数应可以再次使用")
+	}
+
+	// 超出容量时淘汰最早记录的随机数
+	store.Seen("b", time.Hour)
+	store.Seen("c", time.Hour)
+	if store.Len() != 2 {
+		t.Errorf("记录数量 = %d, 期望 2", store.Len())
+	}
+	if store.Seen("a", time.Hour) {
+		t.Error("被淘汰的随机数应视为未使用")
+	}
+}
+
+func TestMemoryNonceStore_Purge(t *testing.T) {
+	clock := NewFakeClock(time.Unix(1700000000, 0))
+	store := NewMemoryNonceStore(0)
+	store.SetClock(clock)
+
+	for i := 0; i < 10; i++ {
+		store.Seen(fmt.Sprint(i), time.Second)
+	}
+	clock.Advance(time.Second)
+	store.Seen("new", time.Second)
+	if store.Len() != 1 {
+		t.Errorf("过期的随机数应被清理，记录数量 = %d", store.Len())
+	}
+}
+
+func TestMemoryNonceStore_Concurrent(t *testing.T) {
+	store := NewMemoryNonceStore(0)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	accepted := 0
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if !store.Seen("same", time.Minute) {
+				mu.Lock()
+				accepted++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if accepted != 1 {
+		t.Errorf("并发时同一随机数只应被接受一次，实际 %d 次", accepted)
+	}
+}
+
+func TestValidate_NonceStore(t *testing.T) {
+	v := NewSignValidator(Config{Secret: "secret", NonceStore: NewMemoryNonceStore(0)})
+	params := map[string]interface{}{"a": 1, "nonce": "n1"}
+	signature, _ := v.GenerateSignature(params)
+
+	// 无效签名不应占用随机数
+	if valid, err := v.Validate(params, "bad"); err != nil || valid {
+		t.Fatalf("无效签名验证应失败，valid=%v err=%v", valid, err)
+	}
+	if valid, err := v.Validate(params, signature); err != nil || !valid {
+		t.Fatalf("首次验证应通过，valid=%v err=%v", valid, err)
+	}
+	params["sign"] = signature
+	if valid, err := v.ValidateWithSignInParams(params); !errors.Is(err, ErrNonceReused) || valid {
+		t.Errorf("重放的请求应返回 ErrNonceReused，valid=%v err=%v", valid, err)
+	}
+
+	delete(params, "nonce")
+	signature, _ = v.GenerateSignature(params)
+	if _, err := v.Validate(params, signature); !errors.Is(err, ErrMissingKeys) {
+		t.Errorf("缺少随机数应返回 ErrMissingKeys，实际 %v", err)
+	}
+}
+
+func TestExplainMismatch_NonceStore(t *testing.T) {
+	v := NewSignValidator(Config{Secret: "secret", NonceStore: NewMemoryNonceStore(0)})
+	params := map[string]interface{}{"a": 1, "nonce": "n1"}
+	signature, _ := v.GenerateSignature(params)
+
+	if _, err := v.ExplainMismatch(params, signature); err != nil {
+		t.Fatalf("诊断失败: %v", err)
+	}
+	if valid, err := v.Validate(params, signature); err != nil || !valid {
+		t.Errorf("诊断不应记录随机数，valid=%v err=%v", valid, err)
+	}
+}
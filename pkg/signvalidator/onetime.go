@@ -0,0 +1,33 @@
+package signvalidator
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+)
+
+// ErrSignatureReused 签名已被使用，请求被重复提交
+var ErrSignatureReused = errors.New("签名已被使用")
+
+// checkSignatureReuse 签名验证通过后记录密钥标识与待签名字符串的 SHA-256 摘要，窗口内重复提交时返回 ErrSignatureReused，
+// 未配置 SignatureStore 时不检查。记录的是被签名的内容而非签名字符串，同一签名的其他编码（如十六进制大小写）
+// 或 ECDSA 的 (r, n-s) 等价签名同样被视为重复提交
+func (v *SignValidator) checkSignatureReuse(params map[string]interface{}, stringToSign string) error {
+	if v.config.SignatureStore == nil {
+		return nil
+	}
+
+	ttl := v.config.SignatureTTL
+	if ttl <= 0 {
+		ttl = defaultNonceTTL
+		if v.config.MaxSkew > 0 {
+			ttl = 2 * v.config.MaxSkew
+		}
+	}
+	keyID, _ := v.keyID(params)
+	sum := sha256.Sum256([]byte(keyID + "\n" + stringToSign))
+	if v.config.SignatureStore.Seen("sig:"+hex.EncodeToString(sum[:]), ttl) {
+		return ErrSignatureReused
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package signvalidator
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidate_SignatureStore(t *testing.T) {
+	clock := NewFakeClock(time.Now())
+	store := NewMemoryNonceStore(0)
+	store.SetClock(clock)
+	v := NewSignValidator(Config{Secret: "secret", SignatureStore: store, SignatureTTL: time.Minute})
+	params := map[string]interface{}{"order_id": "1001"}
+	signature, _ := v.GenerateSignature(params)
+
+	if valid, err := v.Validate(params, "bad"); err != nil || valid {
+		t.Fatalf("无效签名验证应失败，valid=%v err=%v", valid, err)
+	}
+	if valid, err := v.Validate(params, signature); err != nil || !valid {
+		t.Fatalf("首次验证应通过，valid=%v err=%v", valid, err)
+	}
+	if valid, err := v.Validate(params, signature); !errors.Is(err, ErrSignatureReused) || valid {
+		t.Errorf("重复提交应返回 ErrSignatureReused，valid=%v err=%v", valid, err)
+	}
+
+	// 其他签名不受影响
+	other := map[string]interface{}{"order_id": "1002"}
+	otherSignature, _ := v.GenerateSignature(other)
+	if valid, err := v.Validate(other, otherSignature); err != nil || !valid {
+		t.Errorf("其他签名验证应通过，valid=%v err=%v", valid, err)
+	}
+
+	// 窗口过后可以再次使用
+	clock.Advance(2 * time.Minute)
+	if valid, err := v.Validate(params, signature); err != nil || !valid {
+		t.Errorf("窗口过后验证应通过，valid=%v err=%v", valid, err)
+	}
+}
+
+func TestValidateBody_SignatureStore(t *testing.T) {
+	v := NewSignValidator(Config{Secret: "secret", Algorithm: HMAC_SHA256, SignatureStore: NewMemoryNonceStore(0)})
+	body := []byte(`{"amount":1}`)
+	signature, _ := v.GenerateBodySignature(body)
+
+	if valid, err := v.ValidateBody(body, signature); err != nil || !valid {
+		t.Fatalf("首次验证应通过，valid=%v err=%v", valid, err)
+	}
+	if _, err := v.ValidateBody(body, signature); !errors.Is(err, ErrSignatureReused) {
+		t.Errorf("请求体签名同样应拒绝重复提交，实际 %v", err)
+	}
+}
+
+func TestValidate_SignatureStore_Encodings(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("生成密钥失败: %v", err)
+	}
+	v := NewSignValidator(Config{
+		Algorithm:      ECDSA_P256_SHA256,
+		ECDSAFormat:    ECDSAFormatRaw,
+		PrivateKey:     key,
+		SignatureStore: NewMemoryNonceStore(0),
+	})
+	params := map[string]interface{}{"order_id": "1001"}
+	signature, err := v.GenerateSignature(params)
+	if err != nil {
+		t.Fatalf("生成签名失败: %v", err)
+	}
+	if valid, err := v.Validate(params, signature); err != nil || !valid {
+		t.Fatalf("首次验证应通过，valid=%v err=%v", valid, err)
+	}
+
+	// 十六进制大小写不同的同一签名
+	if valid, err := v.Validate(params, strings.ToUpper(signature)); !errors.Is(err, ErrSignatureReused) || valid {
+		t.Errorf("大写编码的签名应被视为重复提交，valid=%v err=%v", valid, err)
+	}
+
+	// ECDSA 的 (r, n-s) 同样是有效签名
+	raw, _ := hex.DecodeString(signature)
+	s := new(big.Int).SetBytes(raw[32:])
+	s.Sub(elliptic.P256().Params().N, s)
+	s.FillBytes(raw[32:])
+	plain := NewSignValidator(Config{Algorithm: ECDSA_P256_SHA256, ECDSAFormat: ECDSAFormatRaw, PrivateKey: key})
+	if valid, err := plain.Validate(params, hex.EncodeToString(raw)); err != nil || !valid {
+		t.Fatalf("(r, n-s) 应是有效签名，valid=%v err=%v", valid, err)
+	}
+	if valid, err := v.Validate(params, hex.EncodeToString(raw)); !errors.Is(err, ErrSignatureReused) || valid {
+		t.Errorf("ECDSA 等价签名应被视为重复提交，valid=%v err=%v", valid, err)
+	}
+
+	// 重新签名的相同请求也是重复提交
+	again, _ := v.GenerateSignature(params)
+	if valid, err := v.Validate(params, again); !errors.Is(err, ErrSignatureReused) || valid {
+		t.Errorf("重新签名的相同请求应被视为重复提交，valid=%v err=%v", valid, err)
+	}
+}
@@ -0,0 +1,51 @@
+package signvalidator
+
+import "fmt"
+
+// Param 有序参数中的一个参数
+type Param struct {
+	Key   string
+	Value interface{}
+}
+
+// GenerateSignatureOrdered 按 params 给定的顺序（而非排序后的顺序）拼接参数并生成签名，
+// 用于按文档固定顺序签名的旧系统
+func (v *SignValidator) GenerateSignatureOrdered(params []Param) (string, error) {
+	if v.err != nil {
+		return "", v.err
+	}
+	c, m, err := v.withOrder(params)
+	if err != nil {
+		return "", err
+	}
+	return c.GenerateSignature(m)
+}
+
+// ValidateOrdered 按 params 给定的顺序拼接参数并验证签名是否有效
+func (v *SignValidator) ValidateOrdered(params []Param, signature string) (bool, error) {
+	if v.err != nil {
+		return false, v.err
+	}
+	c, m, err := v.withOrder(params)
+	if err != nil {
+		return false, err
+	}
+	return c.Validate(m, signature)
+}
+
+// withOrder 返回按给定顺序拼接参数的验证器副本及参数 map，参数名重复时返回错误
+func (v *SignValidator) withOrder(params []Param) (*SignValidator, map[string]interface{}, error) {
+	order := make([]string, 0, len(params))
+	m := make(map[string]interface{}, len(params))
+	for _, param := range params {
+		if _, exists := m[param.Key]; exists {
+			return nil, nil, fmt.Errorf("参数名 %s 重复", param.Key)
+		}
+		m[param.Key] = param.Value
+		order = append(order, param.Key)
+	}
+
+	c := *v
+	c.order = order
+	return &c, m, nil
+}
@@ -0,0 +1,40 @@
+package signvalidator
+
+import (
+	"crypto/md5"
+	"fmt"
+	"testing"
+)
+
+func TestSignValidator_Ordered(t *testing.T) {
+	skipFIPS(t)
+	validator := NewSignValidator(Config{Secret: "testSecret", Algorithm: MD5, IgnoreKeys: []string{"debug"}})
+
+	params := []Param{{"timestamp", 1700000000}, {"app_id", "demo"}, {"debug", 1}, {"amount", 100}}
+	signature, err := validator.GenerateSignatureOrdered(params)
+	if err != nil {
+		t.Fatalf("生成签名失败: %v", err)
+	}
+	expected := fmt.Sprintf("%x", md5.Sum([]byte("timestamp=1700000000&app_id=demo&amount=100&key=testSecret")))
+	if signature != expected {
+		t.Errorf("签名 = %s, 期望 %s", signature, expected)
+	}
+
+	valid, err := validator.ValidateOrdered(params, signature)
+	if err != nil {
+		t.Fatalf("验证签名失败: %v", err)
+	}
+	if !valid {
+		t.Errorf("签名验证失败")
+	}
+
+	// 顺序不同时签名不同
+	reordered := []Param{{"app_id", "demo"}, {"timestamp", 1700000000}, {"amount", 100}}
+	if valid, _ := validator.ValidateOrdered(reordered, signature); valid {
+		t.Errorf("参数顺序不同时签名验证应该失败")
+	}
+
+	if _, err := validator.GenerateSignatureOrdered([]Param{{"a", 1}, {"a", 2}}); err == nil {
+		t.Errorf("重复的参数名应该返回错误")
+	}
+}
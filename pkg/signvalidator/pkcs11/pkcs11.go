@@ -0,0 +1,173 @@
+// Package pkcs11 基于 PKCS#11 令牌（HSM、智能卡等）实现 crypto.Signer，
+// 可作为 signvalidator.Config.Signer 使用，私钥始终保存在硬件中。
+//
+// 本包不直接依赖 cgo 绑定，而是通过 Session 接口调用令牌，
+// 使用 github.com/miekg/pkcs11 等绑定时只需将 C_SignInit 与 C_Sign 包装为 Session 即可。
+package pkcs11
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/asn1"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+)
+
+// MechanismType PKCS#11 签名机制类型（CKM_*）
+type MechanismType uint
+
+// 支持的签名机制，取值与 PKCS#11 规范一致
+const (
+	CKM_RSA_PKCS     MechanismType = 0x00000001
+	CKM_RSA_PKCS_PSS MechanismType = 0x0000000D
+	CKM_ECDSA        MechanismType = 0x00001041
+	CKM_EDDSA        MechanismType = 0x00001057
+)
+
+// PSS 参数中使用的摘要机制与掩码生成函数，取值与 PKCS#11 规范一致
+const (
+	CKM_SHA256 uint = 0x00000250
+	CKM_SHA384 uint = 0x00000260
+	CKM_SHA512 uint = 0x00000270
+
+	CKG_MGF1_SHA256 uint = 0x00000002
+	CKG_MGF1_SHA384 uint = 0x00000003
+	CKG_MGF1_SHA512 uint = 0x00000004
+)
+
+// PSSParams CKM_RSA_PKCS_PSS 机制参数（CK_RSA_PKCS_PSS_PARAMS）
+type PSSParams struct {
+	// HashAlg 摘要机制（CKM_SHA256 等）
+	HashAlg uint
+	// MGF 掩码生成函数（CKG_MGF1_SHA256 等）
+	MGF uint
+	// SaltLength 盐长度（字节）
+	SaltLength uint
+}
+
+// Mechanism 签名机制及其参数
+type Mechanism struct {
+	// Type 机制类型
+	Type MechanismType
+	// PSS CKM_RSA_PKCS_PSS 的参数，其余机制为 nil
+	PSS *PSSParams
+}
+
+// ObjectHandle 令牌中私钥对象的句柄（CK_OBJECT_HANDLE）
+type ObjectHandle uint
+
+// Session 已登录的 PKCS#11 会话，Sign 应依次调用 C_SignInit 与 C_Sign
+type Session interface {
+	Sign(mechanism Mechanism, key ObjectHandle, data []byte) ([]byte, error)
+}
+
+// Signer 使用 PKCS#11 令牌中的私钥签名的 crypto.Signer，支持 RSA（PKCS#1 v1.5、PSS）、ECDSA 与 Ed25519
+type Signer struct {
+	session Session
+	key     ObjectHandle
+	public  crypto.PublicKey
+}
+
+// NewSigner 创建 PKCS#11 签名器，public 为令牌中私钥对应的公钥（可从证书或 CKO_PUBLIC_KEY 对象获得）
+func NewSigner(session Session, key ObjectHandle, public crypto.PublicKey) (*Signer, error) {
+	if session == nil {
+		return nil, errors.New("PKCS#11 会话为空")
+	}
+	switch public.(type) {
+	case *rsa.PublicKey, *ecdsa.PublicKey, ed25519.PublicKey:
+	default:
+		return nil, fmt.Errorf("不支持的公钥类型: %T", public)
+	}
+	return &Signer{session: session, key: key, public: public}, nil
+}
+
+// Public 返回私钥对应的公钥
+func (s *Signer) Public() crypto.PublicKey {
+	return s.public
+}
+
+// Sign 使用令牌签名。RSA 与 ECDSA 传入摘要，Ed25519 传入原始消息；ECDSA 结果转换为 ASN.1 DER 格式
+func (s *Signer) Sign(_ io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	switch pub := s.public.(type) {
+	case *rsa.PublicKey:
+		if pssOpts, ok := opts.(*rsa.PSSOptions); ok {
+			return s.signPSS(pub, digest, pssOpts)
+		}
+		prefix, ok := digestInfoPrefixes[opts.HashFunc()]
+		if !ok {
+			return nil, fmt.Errorf("不支持的摘要算法: %v", opts.HashFunc())
+		}
+		if len(digest) != opts.HashFunc().Size() {
+			return nil, errors.New("摘要长度与摘要算法不匹配")
+		}
+		// CKM_RSA_PKCS 不计算摘要，需要调用方拼接 DigestInfo
+		data := append(append([]byte{}, prefix...), digest...)
+		return s.session.Sign(Mechanism{Type: CKM_RSA_PKCS}, s.key, data)
+	case *ecdsa.PublicKey:
+		raw, err := s.session.Sign(Mechanism{Type: CKM_ECDSA}, s.key, digest)
+		if err != nil {
+			return nil, err
+		}
+		return rawToASN1(raw, (pub.Curve.Params().BitSize+7)/8)
+	case ed25519.PublicKey:
+		if opts.HashFunc() != crypto.Hash(0) {
+			return nil, errors.New("Ed25519 只支持对原始消息签名")
+		}
+		return s.session.Sign(Mechanism{Type: CKM_EDDSA}, s.key, digest)
+	}
+	return nil, fmt.Errorf("不支持的公钥类型: %T", s.public)
+}
+
+// signPSS 使用 CKM_RSA_PKCS_PSS 对摘要签名
+func (s *Signer) signPSS(pub *rsa.PublicKey, digest []byte, opts *rsa.PSSOptions) ([]byte, error) {
+	var hashAlg, mgf uint
+	switch opts.Hash {
+	case crypto.SHA256:
+		hashAlg, mgf = CKM_SHA256, CKG_MGF1_SHA256
+	case crypto.SHA384:
+		hashAlg, mgf = CKM_SHA384, CKG_MGF1_SHA384
+	case crypto.SHA512:
+		hashAlg, mgf = CKM_SHA512, CKG_MGF1_SHA512
+	default:
+		return nil, fmt.Errorf("不支持的摘要算法: %v", opts.Hash)
+	}
+
+	saltLength := opts.SaltLength
+	switch saltLength {
+	case rsa.PSSSaltLengthEqualsHash:
+		saltLength = opts.Hash.Size()
+	case rsa.PSSSaltLengthAuto:
+		saltLength = (pub.N.BitLen()-1+7)/8 - 2 - opts.Hash.Size()
+	}
+	if saltLength < 0 {
+		return nil, errors.New("PSS 盐长度无效")
+	}
+
+	mechanism := Mechanism{
+		Type: CKM_RSA_PKCS_PSS,
+		PSS:  &PSSParams{HashAlg: hashAlg, MGF: mgf, SaltLength: uint(saltLength)},
+	}
+	return s.session.Sign(mechanism, s.key, digest)
+}
+
+// digestInfoPrefixes PKCS#1 v1.5 签名中各摘要算法的 DigestInfo 前缀
+var digestInfoPrefixes = map[crypto.Hash][]byte{
+	crypto.SHA256: {0x30, 0x31, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x01, 0x05, 0x00, 0x04, 0x20},
+	crypto.SHA384: {0x30, 0x41, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x02, 0x05, 0x00, 0x04, 0x30},
+	crypto.SHA512: {0x30, 0x51, 0x30, 0x0d, 0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, 0x03, 0x05, 0x00, 0x04, 0x40},
+}
+
+// rawToASN1 将 CKM_ECDSA 返回的定长 R||S 签名转换为 ASN.1 DER 格式
+func rawToASN1(raw []byte, size int) ([]byte, error) {
+	if len(raw) != 2*size {
+		return nil, fmt.Errorf("ECDSA 签名长度无效: %d", len(raw))
+	}
+	return asn1.Marshal(struct{ R, S *big.Int }{
+		R: new(big.Int).SetBytes(raw[:size]),
+		S: new(big.Int).SetBytes(raw[size:]),
+	})
+}
@@ -0,0 +1,119 @@
+package pkcs11
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"testing"
+
+	"github.com/huangchunlong818/sign-chao/pkg/signvalidator"
+)
+
+// softToken 按 PKCS#11 机制语义使用软件密钥签名的模拟令牌
+type softToken struct {
+	keys map[ObjectHandle]crypto.Signer
+	// mechanisms 记录调用的签名机制
+	mechanisms []Mechanism
+}
+
+func (t *softToken) Sign(mechanism Mechanism, handle ObjectHandle, data []byte) ([]byte, error) {
+	t.mechanisms = append(t.mechanisms, mechanism)
+	key, ok := t.keys[handle]
+	if !ok {
+		return nil, errors.New("CKR_KEY_HANDLE_INVALID")
+	}
+
+	switch mechanism.Type {
+	case CKM_RSA_PKCS:
+		// 对已拼接 DigestInfo 的数据直接签名
+		return rsa.SignPKCS1v15(rand.Reader, key.(*rsa.PrivateKey), crypto.Hash(0), data)
+	case CKM_RSA_PKCS_PSS:
+		hashes := map[uint]crypto.Hash{CKM_SHA256: crypto.SHA256, CKM_SHA384: crypto.SHA384, CKM_SHA512: crypto.SHA512}
+		opts := &rsa.PSSOptions{Hash: hashes[mechanism.PSS.HashAlg], SaltLength: int(mechanism.PSS.SaltLength)}
+		return rsa.SignPSS(rand.Reader, key.(*rsa.PrivateKey), opts.Hash, data, opts)
+	case CKM_ECDSA:
+		priv := key.(*ecdsa.PrivateKey)
+		r, s, err := ecdsa.Sign(rand.Reader, priv, data)
+		if err != nil {
+			return nil, err
+		}
+		size := (priv.Curve.Params().BitSize + 7) / 8
+		raw := make([]byte, 2*size)
+		r.FillBytes(raw[:size])
+		s.FillBytes(raw[size:])
+		return raw, nil
+	case CKM_EDDSA:
+		return ed25519.Sign(key.(ed25519.PrivateKey), data), nil
+	}
+	return nil, errors.New("CKR_MECHANISM_INVALID")
+}
+
+func TestSigner(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("生成密钥失败: %v", err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	if err != nil {
+		t.Fatalf("生成密钥失败: %v", err)
+	}
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("生成密钥失败: %v", err)
+	}
+
+	token := &softToken{keys: map[ObjectHandle]crypto.Signer{1: rsaKey, 2: ecKey, 3: edKey}}
+
+	testCases := []struct {
+		algorithm signvalidator.SignAlgorithm
+		handle    ObjectHandle
+		public    crypto.PublicKey
+		mechanism MechanismType
+	}{
+		{signvalidator.RSA_SHA256, 1, rsaKey.Public(), CKM_RSA_PKCS},
+		{signvalidator.RSA_PSS_SHA512, 1, rsaKey.Public(), CKM_RSA_PKCS_PSS},
+		{signvalidator.ECDSA_P384_SHA384, 2, ecKey.Public(), CKM_ECDSA},
+		{signvalidator.ED25519, 3, edKey.Public(), CKM_EDDSA},
+	}
+
+	params := map[string]interface{}{"id": 123, "name": "test"}
+	for _, tc := range testCases {
+		signer, err := NewSigner(token, tc.handle, tc.public)
+		if err != nil {
+			t.Fatalf("创建签名器失败: %v", err)
+		}
+
+		signature, err := signvalidator.NewSignValidator(signvalidator.Config{Algorithm: tc.algorithm, Signer: signer}).GenerateSignature(params)
+		if err != nil {
+			t.Fatalf("%s 生成签名失败: %v", tc.algorithm, err)
+		}
+		if last := token.mechanisms[len(token.mechanisms)-1]; last.Type != tc.mechanism {
+			t.Errorf("%s 使用的机制 = %#x, 期望 %#x", tc.algorithm, last.Type, tc.mechanism)
+		}
+
+		verifier, err := signvalidator.NewVerifier(tc.algorithm, tc.public)
+		if err != nil {
+			t.Fatalf("创建验证器失败: %v", err)
+		}
+		valid, err := verifier.Validate(params, signature)
+		if err != nil {
+			t.Fatalf("%s 验证签名失败: %v", tc.algorithm, err)
+		}
+		if !valid {
+			t.Errorf("%s 签名验证失败", tc.algorithm)
+		}
+	}
+}
+
+func TestNewSigner_Invalid(t *testing.T) {
+	if _, err := NewSigner(nil, 1, nil); err == nil {
+		t.Errorf("会话为空应该返回错误")
+	}
+	if _, err := NewSigner(&softToken{}, 1, "not a key"); err == nil {
+		t.Errorf("不支持的公钥类型应该返回错误")
+	}
+}
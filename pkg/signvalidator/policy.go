@@ -0,0 +1,87 @@
+package signvalidator
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrPolicyViolation 签名算法违反安全策略
+var ErrPolicyViolation = errors.New("签名算法违反安全策略")
+
+// ErrInsecureAlgorithm 未开启 AllowInsecure 时使用了非密码学校验算法
+var ErrInsecureAlgorithm = errors.New("非密码学校验算法需要开启 AllowInsecure")
+
+// Strength 表示签名算法的强度等级
+type Strength int
+
+const (
+	// StrengthInsecure 非密码学校验算法（CRC32C、xxHash64），只能检测意外篡改
+	StrengthInsecure Strength = iota
+	// StrengthWeak 已知存在碰撞攻击的算法（MD5、SHA1 及其 HMAC）
+	StrengthWeak
+	// StrengthStrong 当前推荐使用的算法
+	StrengthStrong
+)
+
+// Policy 签名算法安全策略
+type Policy struct {
+	// MinStrength 允许的最低算法强度，为零值时不限制
+	MinStrength Strength
+	// DeniedAlgorithms 明确禁止使用的算法列表
+	DeniedAlgorithms []SignAlgorithm
+}
+
+// PolicyError 签名算法违反安全策略时返回的错误
+type PolicyError struct {
+	// Algorithm 违反策略的算法
+	Algorithm SignAlgorithm
+	// Reason 违反策略的原因
+	Reason string
+}
+
+func (e *PolicyError) Error() string {
+	return fmt.Sprintf("%s: %s（%s）", ErrPolicyViolation, e.Algorithm, e.Reason)
+}
+
+// Unwrap 使 errors.Is(err, ErrPolicyViolation) 成立
+func (e *PolicyError) Unwrap() error {
+	return ErrPolicyViolation
+}
+
+// AlgorithmStrength 返回签名算法的强度等级
+func AlgorithmStrength(algorithm SignAlgorithm) Strength {
+	switch algorithm {
+	case CRC32C, XXHASH64:
+		return StrengthInsecure
+	case MD5, SHA1, HMAC_MD5, HMAC_SHA1:
+		return StrengthWeak
+	}
+	return StrengthStrong
+}
+
+// Check 检查签名算法是否符合策略，不符合时返回 *PolicyError
+func (p *Policy) Check(algorithm SignAlgorithm) error {
+	if p == nil {
+		return nil
+	}
+	for _, denied := range p.DeniedAlgorithms {
+		if denied == algorithm {
+			return &PolicyError{Algorithm: algorithm, Reason: "算法已被禁止"}
+		}
+	}
+	if AlgorithmStrength(algorithm) < p.MinStrength {
+		return &PolicyError{Algorithm: algorithm, Reason: "算法强度不足"}
+	}
+	return nil
+}
+
+// checkAlgorithm 检查算法是否允许使用：非密码学算法需要开启 AllowInsecure，FIPS 模式下只允许批准的算法，并且需要符合安全策略
+func (v *SignValidator) checkAlgorithm(algorithm SignAlgorithm) error {
+	if AlgorithmStrength(algorithm) == StrengthInsecure && !v.config.AllowInsecure {
+		return fmt.Errorf("%w: %s", ErrInsecureAlgorithm, algorithm)
+	}
+	if err := v.checkFIPS(algorithm); err != nil {
+		return err
+	}
+	return v.config.Policy.Check(algorithm)
+}
@@ -0,0 +1,128 @@
+package signvalidator
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPolicy_RejectAtConstruction(t *testing.T) {
+	skipFIPS(t)
+	policy := &Policy{MinStrength: StrengthStrong}
+
+	_, err := New(Config{Secret: "testSecret", Algorithm: MD5, Policy: policy})
+	var policyErr *PolicyError
+	if !errors.As(err, &policyErr) {
+		t.Fatalf("错误 = %v, 期望 *PolicyError", err)
+	}
+	if policyErr.Algorithm != MD5 {
+		t.Errorf("违规算法 = %s, 期望 %s", policyErr.Algorithm, MD5)
+	}
+	if !errors.Is(err, ErrPolicyViolation) {
+		t.Errorf("错误应该匹配 ErrPolicyViolation")
+	}
+
+	// NewSignValidator 在使用时返回同样的错误
+	validator := NewSignValidator(Config{Secret: "testSecret", Algorithm: HMAC_SHA1, Policy: policy})
+	if _, err := validator.GenerateSignature(map[string]interface{}{"id": 1}); !errors.Is(err, ErrPolicyViolation) {
+		t.Errorf("错误 = %v, 期望 ErrPolicyViolation", err)
+	}
+
+	if _, err := New(Config{Secret: "testSecret", Algorithm: HMAC_SHA256, Policy: policy}); err != nil {
+		t.Errorf("符合策略的算法不应返回错误: %v", err)
+	}
+}
+
+func TestPolicy_DeniedAlgorithms(t *testing.T) {
+	skipFIPS(t)
+	policy := &Policy{DeniedAlgorithms: []SignAlgorithm{SHA256}}
+
+	if _, err := New(Config{Secret: "testSecret", Algorithm: SHA256, Policy: policy}); !errors.Is(err, ErrPolicyViolation) {
+		t.Errorf("错误 = %v, 期望 ErrPolicyViolation", err)
+	}
+
+	if _, err := New(Config{Secret: "testSecret", Algorithm: MD5, Policy: policy}); err != nil {
+		t.Errorf("未禁止的算法不应返回错误: %v", err)
+	}
+}
+
+func TestPolicy_RejectAtVerification(t *testing.T) {
+	skipFIPS(t)
+	// 协商得到的算法在验证时检查策略
+	validator, err := New(Config{
+		Secret:       "testSecret",
+		Algorithm:    HMAC_SHA256,
+		AlgorithmKey: "sign_type",
+		Policy:       &Policy{MinStrength: StrengthStrong},
+	})
+	if err != nil {
+		t.Fatalf("创建签名验证器失败: %v", err)
+	}
+
+	_, err = validator.Validate(map[string]interface{}{"id": 1, "sign_type": "HMAC-SHA256"}, "xxx")
+	if err != nil {
+		t.Errorf("符合策略的算法不应返回错误: %v", err)
+	}
+
+	// 允许列表中包含弱算法时创建失败
+	_, err = New(Config{
+		Secret:            "testSecret",
+		Algorithm:         HMAC_SHA256,
+		AlgorithmKey:      "sign_type",
+		AllowedAlgorithms: []SignAlgorithm{HMAC_SHA256, MD5},
+		Policy:            &Policy{MinStrength: StrengthStrong},
+	})
+	if !errors.Is(err, ErrPolicyViolation) {
+		t.Errorf("错误 = %v, 期望 ErrPolicyViolation", err)
+	}
+}
+
+func TestPolicy_Insecure(t *testing.T) {
+	skipFIPS(t)
+	for _, algorithm := range []SignAlgorithm{CRC32C, XXHASH64} {
+		if _, err := New(Config{Secret: "testSecret", Algorithm: algorithm}); !errors.Is(err, ErrInsecureAlgorithm) {
+			t.Errorf("%s 错误 = %v, 期望 ErrInsecureAlgorithm", algorithm, err)
+		}
+		if _, err := New(Config{Secret: "testSecret", Algorithm: algorithm, AllowInsecure: true}); err != nil {
+			t.Errorf("%s 开启 AllowInsecure 后不应返回错误: %v", algorithm, err)
+		}
+	}
+
+	// AllowInsecure 不绕过安全策略
+	_, err := New(Config{
+		Secret:        "testSecret",
+		Algorithm:     CRC32C,
+		AllowInsecure: true,
+		Policy:        &Policy{MinStrength: StrengthWeak},
+	})
+	if !errors.Is(err, ErrPolicyViolation) {
+		t.Errorf("错误 = %v, 期望 ErrPolicyViolation", err)
+	}
+
+	// 允许列表中包含非密码学算法同样需要开启 AllowInsecure
+	validator := NewSignValidator(Config{
+		Secret:            "testSecret",
+		AlgorithmKey:      "sign_type",
+		AllowedAlgorithms: []SignAlgorithm{SHA256, XXHASH64},
+	})
+	if _, err := validator.GenerateSignature(map[string]interface{}{"id": 1, "sign_type": "xxhash64"}); !errors.Is(err, ErrInsecureAlgorithm) {
+		t.Errorf("错误 = %v, 期望 ErrInsecureAlgorithm", err)
+	}
+}
+
+func TestAlgorithmStrength(t *testing.T) {
+	for _, algorithm := range []SignAlgorithm{CRC32C, XXHASH64} {
+		if AlgorithmStrength(algorithm) != StrengthInsecure {
+			t.Errorf("%s 应该是非密码学算法", algorithm)
+		}
+	}
+	for _, algorithm := range []SignAlgorithm{MD5, SHA1, HMAC_MD5, HMAC_SHA1} {
+		if AlgorithmStrength(algorithm) != StrengthWeak {
+			t.Errorf("%s 应该是弱算法", algorithm)
+		}
+	}
+	for _, algorithm := range []SignAlgorithm{SHA256, HMAC_SHA256, RSA_SHA256, SM3} {
+		if AlgorithmStrength(algorithm) != StrengthStrong {
+			t.Errorf("%s 应该是强算法", algorithm)
+		}
+	}
+}
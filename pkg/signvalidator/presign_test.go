@@ -0,0 +1,81 @@
+package signvalidator
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestSignURL(t *testing.T) {
+	clock := NewFakeClock(time.Unix(1700000000, 0))
+	v := NewSignValidator(Config{Secret: "secret", Algorithm: HMAC_SHA256, Clock: clock})
+
+	signed, err := v.SignURL("get", "https://CDN.example.com/files/报告.pdf?download=1&sign=old", 10*time.Minute)
+	if err != nil {
+		t.Fatalf("生成预签名链接失败: %v", err)
+	}
+	u, _ := url.Parse(signed)
+	query := u.Query()
+	if query.Get("expires") != "1700000600" {
+		t.Errorf("过期时间 = %s, 期望 1700000600", query.Get("expires"))
+	}
+	if query.Get("sign") == "old" || query.Get("download") != "1" {
+		t.Errorf("查询参数不正确: %v", query)
+	}
+
+	valid, err := v.VerifySignedURL("GET", signed)
+	if err != nil || !valid {
+		t.Errorf("预签名链接验证应通过，valid=%v err=%v", valid, err)
+	}
+
+	tampered := []func(url.Values){
+		func(q url.Values) { q.Set("download", "2") },
+		func(q url.Values) { q.Set("expires", "1800000000") },
+		func(q url.Values) { q.Add("extra", "x") },
+	}
+	for i, tamper := range tampered {
+		q := u.Query()
+		tamper(q)
+		modified := *u
+		modified.RawQuery = q.Encode()
+		if valid, _ := v.VerifySignedURL("GET", modified.String()); valid {
+			t.Errorf("第 %d 种修改后验证不应通过", i+1)
+		}
+	}
+	if valid, _ := v.VerifySignedURL("POST", signed); valid {
+		t.Error("请求方法不同时验证不应通过")
+	}
+	other := *u
+	other.Host = "evil.example.com"
+	if valid, _ := v.VerifySignedURL("GET", other.String()); valid {
+		t.Error("主机名不同时验证不应通过")
+	}
+
+	clock.Advance(10 * time.Minute)
+	if valid, err := v.VerifySignedURL("GET", signed); !errors.Is(err, ErrURLExpired) || valid {
+		t.Errorf("过期的链接应返回 ErrURLExpired，valid=%v err=%v", valid, err)
+	}
+}
+
+func TestSignURL_Invalid(t *testing.T) {
+	v := NewSignValidator(Config{Secret: "secret", ExpiresKey: "X-Expires", SignatureKey: "X-Signature"})
+	if _, err := v.SignURL("GET", "https://example.com/a", 0); err == nil {
+		t.Error("有效期为 0 时应返回错误")
+	}
+
+	signed, err := v.SignURL("GET", "https://example.com/a", time.Minute)
+	if err != nil {
+		t.Fatalf("生成预签名链接失败: %v", err)
+	}
+	u, _ := url.Parse(signed)
+	if u.Query().Get("X-Expires") == "" || u.Query().Get("X-Signature") == "" {
+		t.Errorf("应使用配置的参数名: %s", signed)
+	}
+
+	for _, rawURL := range []string{"https://example.com/a", "https://example.com/a?X-Expires=1"} {
+		if _, err := v.VerifySignedURL("GET", rawURL); !errors.Is(err, ErrMissingKeys) {
+			t.Errorf("%s: 缺少参数应返回 ErrMissingKeys，实际 %v", rawURL, err)
+		}
+	}
+}
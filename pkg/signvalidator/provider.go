@@ -0,0 +1,79 @@
+package signvalidator
+
+import (
+	"context"
+	"errors"
+)
+
+// SecretProvider 按请求参数（如 app_id、merchant_id）查找签名密钥，用于多租户网关在验证时动态获取各租户的密钥
+type SecretProvider func(ctx context.Context, params map[string]interface{}) (string, error)
+
+// errEmptyProvidedSecret 密钥提供者返回空密钥
+var errEmptyProvidedSecret = errors.New("密钥提供者返回了空密钥")
+
+// validatorContextKey 传给 SecretProvider 的上下文中保存调用方验证器的键
+type validatorContextKey struct{}
+
+// lookupContext 按 ctx 中验证器的规则（CaseInsensitiveKeys）查找参数，供内置的 SecretProvider 使用；
+// ctx 中没有验证器时按参数名精确匹配
+func lookupContext(ctx context.Context, params map[string]interface{}, key string) (interface{}, bool) {
+	if v, ok := ctx.Value(validatorContextKey{}).(*SignValidator); ok {
+		return v.lookup(params, key)
+	}
+	value, exists := params[key]
+	return value, exists
+}
+
+// checkSecretProvider 检查 SecretProvider 是否与其他密钥来源冲突
+func (v *SignValidator) checkSecretProvider() error {
+	if v.config.SecretProvider == nil {
+		return nil
+	}
+	if len(v.config.Keys) > 0 || len(v.config.Secrets) > 0 || len(v.config.PreviousSecrets) > 0 {
+		return errors.New("配置了 SecretProvider 时不能同时设置 Keys、Secrets 或 PreviousSecrets")
+	}
+	return nil
+}
+
+// ValidateContext 验证签名是否有效，ctx 传给 SecretProvider
+func (v *SignValidator) ValidateContext(ctx context.Context, params map[string]interface{}, signature string) (bool, error) {
+	return v.withContext(ctx).Validate(params, signature)
+}
+
+// GenerateSignatureContext 生成签名，ctx 传给 SecretProvider
+func (v *SignValidator) GenerateSignatureContext(ctx context.Context, params map[string]interface{}) (string, error) {
+	return v.withContext(ctx).GenerateSignature(params)
+}
+
+// withContext 返回使用 ctx 调用 SecretProvider 的验证器副本，未配置 SecretProvider 时返回 v
+func (v *SignValidator) withContext(ctx context.Context) *SignValidator {
+	if v.config.SecretProvider == nil {
+		return v
+	}
+	c := *v
+	c.ctx = ctx
+	return &c
+}
+
+// withProvidedSecret 返回使用 SecretProvider 所返回密钥的验证器副本，未配置 SecretProvider 时返回 v
+func (v *SignValidator) withProvidedSecret(params map[string]interface{}) (*SignValidator, error) {
+	if v.config.SecretProvider == nil {
+		return v, nil
+	}
+
+	ctx := v.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	secret, err := v.config.SecretProvider(context.WithValue(ctx, validatorContextKey{}, v), params)
+	if err != nil {
+		return nil, err
+	}
+	if secret == "" {
+		return nil, errEmptyProvidedSecret
+	}
+
+	c := *v
+	c.config.Secret = secret
+	return &c, nil
+}
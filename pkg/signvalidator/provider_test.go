@@ -0,0 +1,94 @@
+package signvalidator
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// tenantKey 测试用的上下文键
+type tenantKey struct{}
+
+func TestSecretProvider(t *testing.T) {
+	secrets := map[string]string{"app-a": "secret-a", "app-b": "secret-b"}
+	errUnknownApp := errors.New("未知的 app_id")
+	v := NewSignValidator(Config{
+		Algorithm: HMAC_SHA256,
+		SecretProvider: func(ctx context.Context, params map[string]interface{}) (string, error) {
+			secret, exists := secrets[convertToString(params["app_id"])]
+			if !exists {
+				return "", errUnknownApp
+			}
+			return secret, nil
+		},
+	})
+
+	params := map[string]interface{}{"app_id": "app-b", "order_id": "1001"}
+	signature, err := v.GenerateSignature(params)
+	if err != nil {
+		t.Fatalf("生成签名失败: %v", err)
+	}
+	expected, _ := NewSignValidator(Config{Algorithm: HMAC_SHA256, Secret: "secret-b"}).GenerateSignature(params)
+	if signature != expected {
+		t.Errorf("签名 = %s, 应使用 app-b 的密钥生成 %s", signature, expected)
+	}
+	if valid, err := v.Validate(params, signature); err != nil || !valid {
+		t.Errorf("验证应通过，valid=%v err=%v", valid, err)
+	}
+	if valid, err := v.ValidateStrings(map[string]string{"app_id": "app-b", "order_id": "1001"}, signature); err != nil || !valid {
+		t.Errorf("ValidateStrings 同样应使用 SecretProvider，valid=%v err=%v", valid, err)
+	}
+
+	params["app_id"] = "app-a"
+	if valid, _ := v.Validate(params, signature); valid {
+		t.Error("使用其他租户的密钥时验证不应通过")
+	}
+	params["app_id"] = "app-c"
+	if _, err := v.Validate(params, signature); !errors.Is(err, errUnknownApp) {
+		t.Errorf("应返回密钥提供者的错误，实际 %v", err)
+	}
+}
+
+func TestSecretProvider_Context(t *testing.T) {
+	v := NewSignValidator(Config{
+		Algorithm: HMAC_SHA256,
+		HKDF:      &HKDF{InfoKey: "app_id"},
+		SecretProvider: func(ctx context.Context, params map[string]interface{}) (string, error) {
+			tenant, _ := ctx.Value(tenantKey{}).(string)
+			return "secret-" + tenant, nil
+		},
+	})
+	ctx := context.WithValue(context.Background(), tenantKey{}, "t1")
+	params := map[string]interface{}{"app_id": "a"}
+
+	signature, err := v.GenerateSignatureContext(ctx, params)
+	if err != nil {
+		t.Fatalf("生成签名失败: %v", err)
+	}
+	if valid, err := v.ValidateContext(ctx, params, signature); err != nil || !valid {
+		t.Errorf("相同上下文验证应通过，valid=%v err=%v", valid, err)
+	}
+	if valid, _ := v.Validate(params, signature); valid {
+		t.Error("上下文不同时验证不应通过")
+	}
+
+	r := httptest.NewRequest("POST", "/orders?app_id=a", strings.NewReader(`{}`)).WithContext(ctx)
+	requestSignature, _ := v.GenerateRequestSignature(r)
+	r = httptest.NewRequest("POST", "/orders?app_id=a", strings.NewReader(`{}`)).WithContext(ctx)
+	if valid, err := v.ValidateRequest(r, requestSignature); err != nil || !valid {
+		t.Errorf("HTTP 请求应使用请求的上下文，valid=%v err=%v", valid, err)
+	}
+}
+
+func TestSecretProvider_Invalid(t *testing.T) {
+	provider := func(ctx context.Context, params map[string]interface{}) (string, error) { return "", nil }
+	v := NewSignValidator(Config{SecretProvider: provider})
+	if _, err := v.GenerateSignature(map[string]interface{}{"a": "1"}); err == nil {
+		t.Error("密钥提供者返回空密钥时应返回错误")
+	}
+	if _, err := New(Config{SecretProvider: provider, Secrets: []Secret{{Name: "a", Value: "1"}}}); err == nil {
+		t.Error("SecretProvider 与 Secrets 同时设置时应返回错误")
+	}
+}
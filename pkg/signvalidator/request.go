@@ -0,0 +1,302 @@
+package signvalidator
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// DefaultMaxBodyBytes 未配置 MaxBodyBytes 时读取 HTTP 请求体的最大字节数
+const DefaultMaxBodyBytes = 4 << 20
+
+// ErrBodyTooLarge 请求体超过大小限制
+var ErrBodyTooLarge = errors.New("请求体超过大小限制")
+
+// ErrDuplicateParam 参数同时出现在查询参数与请求体中
+var ErrDuplicateParam = errors.New("参数同时出现在查询参数与请求体中")
+
+// CanonicalRequest HTTP 请求规范化配置
+type CanonicalRequest struct {
+	// SignedHeaders 参与签名的请求头名称（不区分大小写），"host" 取自请求的 Host
+	SignedHeaders []string
+	// BodyHash 请求体摘要算法，默认为 SHA256
+	BodyHash SignAlgorithm
+}
+
+// canonicalRequest 规范化后的 HTTP 请求
+type canonicalRequest struct {
+	canonical string
+	// bodyHash 请求体摘要的十六进制字符串，仅 withRequest 设置
+	bodyHash string
+}
+
+// checkCanonicalRequest 检查 HTTP 请求规范化配置
+func (v *SignValidator) checkCanonicalRequest() error {
+	if v.config.CanonicalRequest == nil {
+		return nil
+	}
+	if _, err := v.hashFunc(v.bodyHash()); err != nil {
+		return fmt.Errorf("请求体摘要算法: %w", err)
+	}
+	return nil
+}
+
+// bodyHash 返回请求体摘要算法
+func (v *SignValidator) bodyHash() SignAlgorithm {
+	if v.config.CanonicalRequest == nil || v.config.CanonicalRequest.BodyHash == "" {
+		return SHA256
+	}
+	return v.config.CanonicalRequest.BodyHash
+}
+
+// GenerateRequestSignature 对 HTTP 请求生成签名，待签名字符串由请求方法、路径、排序后的查询参数、
+// SignedHeaders 指定的请求头及请求体摘要组成；查询参数同时用于算法协商与密钥派生
+func (v *SignValidator) GenerateRequestSignature(r *http.Request) (string, error) {
+	if v.err != nil {
+		return "", v.err
+	}
+	c, params, err := v.withContext(r.Context()).withRequest(r)
+	if err != nil {
+		return "", err
+	}
+	return c.GenerateSignature(params)
+}
+
+// ValidateRequest 验证 HTTP 请求的签名是否有效
+func (v *SignValidator) ValidateRequest(r *http.Request, signature string) (bool, error) {
+	if v.err != nil {
+		return false, v.err
+	}
+	c, params, err := v.withIncoming(r).withRequest(r)
+	if err != nil {
+		return false, err
+	}
+	return c.Validate(params, signature)
+}
+
+// withRequest 返回使用规范化请求构建待签名字符串的验证器副本及请求的查询参数，读取后恢复请求体
+func (v *SignValidator) withRequest(r *http.Request) (*SignValidator, map[string]interface{}, error) {
+	body, err := readBody(r, v.config.MaxBodyBytes)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	h, err := v.hashFunc(v.bodyHash())
+	if err != nil {
+		return nil, nil, err
+	}
+	bodyHash := h()
+	bodyHash.Write(body)
+
+	query := r.URL.Query()
+	params := queryParams(query)
+
+	path := r.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+
+	digest := hex.EncodeToString(bodyHash.Sum(nil))
+	headers, signedHeaders := v.canonicalHeaders(r)
+	canonical := strings.Join([]string{
+		r.Method,
+		path,
+		v.canonicalQuery(query),
+		headers,
+		signedHeaders,
+		digest,
+	}, "\n")
+
+	c := *v
+	c.request = &canonicalRequest{canonical: canonical, bodyHash: digest}
+	return &c, params, nil
+}
+
+// withIncoming 返回记录请求上下文、来源地址与密钥标识请求头的验证器副本，仅复制需要的信息
+func (v *SignValidator) withIncoming(r *http.Request) *SignValidator {
+	return v.withContext(r.Context()).withRemoteAddr(r).withKeyIDHeader(r)
+}
+
+// RequestParams 合并查询参数与请求体参数作为签名参数，读取后恢复请求体；同名参数同时出现在两处时返回 ErrDuplicateParam，
+// 避免签名覆盖其中一处的值而处理器读取另一处未签名的值。
+// 请求体按 Content-Type 解析：表单按 FormParams、multipart 按 MultipartParams、JSON 对象按 json.Number 保留数字；
+// 其他类型的非空请求体返回错误，避免未签名的请求体被当作已验证的内容；查询参数同名出现多次时按 MultiValue 拼接。
+// 请求体超过 DefaultMaxBodyBytes 时返回 ErrBodyTooLarge
+func RequestParams(r *http.Request) (map[string]interface{}, error) {
+	return requestParams(r, DefaultMaxBodyBytes)
+}
+
+// requestParams 同 RequestParams，请求体最多读取 limit 字节
+func requestParams(r *http.Request, limit int64) (map[string]interface{}, error) {
+	params := multiValueParams(r.URL.Query())
+
+	body, err := readBody(r, limit)
+	if err != nil {
+		return nil, err
+	}
+	if len(body) == 0 && r.PostForm == nil {
+		return params, nil
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	var bodyParams map[string]interface{}
+	switch {
+	case mediaType == "application/x-www-form-urlencoded":
+		bodyParams, err = formParams(r, limit)
+	case mediaType == "multipart/form-data":
+		bodyParams, err = multipartParams(r, limit)
+	case mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"):
+		if len(body) == 0 {
+			return params, nil
+		}
+		var value interface{}
+		if value, err = decodeJSON(body); err != nil {
+			return nil, fmt.Errorf("请求体不是有效的 JSON: %w", err)
+		}
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, errors.New("JSON 请求体必须是对象")
+		}
+		bodyParams = object
+	default:
+		if len(body) == 0 {
+			return params, nil
+		}
+		return nil, fmt.Errorf("不支持的请求体类型: %s", r.Header.Get("Content-Type"))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for key, value := range bodyParams {
+		if _, exists := params[key]; exists {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicateParam, key)
+		}
+		params[key] = value
+	}
+	return params, nil
+}
+
+// ValidateRequestParams 按 RequestParams 读取 HTTP 请求参数并验证签名，signature 为空时按 SignatureSources 查找，
+// 未配置或未找到时取参数中的签名参数；TimestampSources 与 NonceSources 找到的值写入参数，配置了 ContentDigest 时
+// 检查摘要请求头并将其值写入参数。返回读取到的参数；与 ValidateRequest 相同，记录请求上下文、来源地址与密钥标识请求头
+func (v *SignValidator) ValidateRequestParams(r *http.Request, signature string) (map[string]interface{}, bool, error) {
+	if v.err != nil {
+		return nil, false, v.err
+	}
+	params, err := requestParams(r, v.config.MaxBodyBytes)
+	if err != nil {
+		return nil, false, err
+	}
+	if v.hasSources() {
+		extracted, err := v.applySources(r, params)
+		if err != nil {
+			return nil, false, err
+		}
+		if signature == "" {
+			signature = extracted
+		}
+	}
+	c := v.withIncoming(r)
+	if v.config.ContentDigest != nil {
+		digest, err := c.verifyContentDigest(r)
+		if err != nil {
+			c.report(params, err)
+			return nil, false, err
+		}
+		params[v.config.ContentDigest.key()] = digest
+	}
+	var valid bool
+	if signature == "" {
+		valid, err = c.ValidateWithSignInParams(params)
+	} else {
+		valid, err = c.Validate(params, signature)
+	}
+	return params, valid, err
+}
+
+// readBody 读取请求体并恢复，使后续处理仍可读取；limit 大于 0 时请求体超过 limit 字节返回 ErrBodyTooLarge
+func readBody(r *http.Request, limit int64) ([]byte, error) {
+	if r.Body == nil {
+		return nil, nil
+	}
+	if limit > 0 && r.ContentLength > limit {
+		r.Body.Close()
+		return nil, fmt.Errorf("%w: 超过 %d 字节", ErrBodyTooLarge, limit)
+	}
+	reader := io.Reader(r.Body)
+	if limit > 0 {
+		reader = io.LimitReader(r.Body, limit+1)
+	}
+	body, err := io.ReadAll(reader)
+	r.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("读取请求体失败: %w", err)
+	}
+	if limit > 0 && int64(len(body)) > limit {
+		return nil, fmt.Errorf("%w: 超过 %d 字节", ErrBodyTooLarge, limit)
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+// queryParams 返回查询参数，重复的参数取第一个值
+func queryParams(query url.Values) map[string]interface{} {
+	params := make(map[string]interface{}, len(query))
+	for key, values := range query {
+		params[key] = values[0]
+	}
+	return params
+}
+
+// canonicalQuery 按参数名和值排序并编码查询参数，签名参数与忽略的参数不参与拼接
+func (v *SignValidator) canonicalQuery(query url.Values) string {
+	var pairs []string
+	for key, values := range query {
+		if v.isExcludedKey(key, nil) {
+			continue
+		}
+		for _, value := range values {
+			pairs = append(pairs, percentEncode(key)+"="+percentEncode(value))
+		}
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, "&")
+}
+
+// canonicalHeaders 返回规范化的请求头（每行 "名称:值"）及分号连接的请求头名称列表
+func (v *SignValidator) canonicalHeaders(r *http.Request) (string, string) {
+	if v.config.CanonicalRequest == nil {
+		return "", ""
+	}
+
+	names := make([]string, 0, len(v.config.CanonicalRequest.SignedHeaders))
+	for _, name := range v.config.CanonicalRequest.SignedHeaders {
+		names = append(names, strings.ToLower(strings.TrimSpace(name)))
+	}
+	sort.Strings(names)
+
+	var builder strings.Builder
+	for _, name := range names {
+		var values []string
+		if name == "host" {
+			values = []string{r.Host}
+		} else {
+			for _, value := range r.Header.Values(name) {
+				values = append(values, strings.Join(strings.Fields(value), " "))
+			}
+		}
+		builder.WriteString(name)
+		builder.WriteString(":")
+		builder.WriteString(strings.Join(values, ","))
+		builder.WriteString("\n")
+	}
+	return builder.String(), strings.Join(names, ";")
+}
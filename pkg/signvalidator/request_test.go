@@ -0,0 +1,209 @@
+package signvalidator
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSignValidator_Request(t *testing.T) {
+	validator, err := New(Config{
+		Secret:           "testSecret",
+		Algorithm:        HMAC_SHA256,
+		CanonicalRequest: &CanonicalRequest{SignedHeaders: []string{"X-Timestamp", "Host"}},
+	})
+	if err != nil {
+		t.Fatalf("创建签名验证器失败: %v", err)
+	}
+
+	body := `{"amount":100}`
+	r := httptest.NewRequest("POST", "http://api.example.com/v1/orders?b=2&a=x%20y&a=1&sign=ignored", strings.NewReader(body))
+	r.Header.Set("X-Timestamp", "  1700000000 ")
+
+	signature, err := validator.GenerateRequestSignature(r)
+	if err != nil {
+		t.Fatalf("生成签名失败: %v", err)
+	}
+
+	bodyHash := sha256.Sum256([]byte(body))
+	canonical := "POST\n/v1/orders\na=1&a=x%20y&b=2\nhost:api.example.com\nx-timestamp:1700000000\n\nhost;x-timestamp\n" + hex.EncodeToString(bodyHash[:])
+	mac := hmac.New(sha256.New, []byte("testSecret"))
+	mac.Write([]byte(canonical + "&key=testSecret"))
+	if expected := hex.EncodeToString(mac.Sum(nil)); signature != expected {
+		t.Errorf("签名 = %s, 期望 %s", signature, expected)
+	}
+
+	// 读取后请求体仍可使用
+	if data, _ := io.ReadAll(r.Body); string(data) != body {
+		t.Errorf("请求体 = %s, 期望 %s", data, body)
+	}
+	r.Body = io.NopCloser(strings.NewReader(body))
+
+	valid, err := validator.ValidateRequest(r, signature)
+	if err != nil {
+		t.Fatalf("验证签名失败: %v", err)
+	}
+	if !valid {
+		t.Errorf("签名验证失败")
+	}
+
+	// 篡改请求头或请求体后验证失败
+	r.Header.Set("X-Timestamp", "1700000001")
+	if valid, _ := validator.ValidateRequest(r, signature); valid {
+		t.Errorf("篡改请求头后签名验证应该失败")
+	}
+	r = httptest.NewRequest("POST", "http://api.example.com/v1/orders?b=2&a=x%20y&a=1", strings.NewReader(`{"amount":1}`))
+	r.Header.Set("X-Timestamp", "1700000000")
+	if valid, _ := validator.ValidateRequest(r, signature); valid {
+		t.Errorf("篡改请求体后签名验证应该失败")
+	}
+}
+
+func TestSignValidator_RequestBodyHash(t *testing.T) {
+	skipFIPS(t)
+	if _, err := New(Config{Secret: "testSecret", CanonicalRequest: &CanonicalRequest{BodyHash: HMAC_SHA256}}); err == nil {
+		t.Errorf("带密钥的请求体摘要算法应该返回错误")
+	}
+
+	validator, err := New(Config{Secret: "testSecret", Algorithm: SHA256, CanonicalRequest: &CanonicalRequest{BodyHash: SM3}})
+	if err != nil {
+		t.Fatalf("创建签名验证器失败: %v", err)
+	}
+	r := httptest.NewRequest("GET", "http://api.example.com", nil)
+	signature, err := validator.GenerateRequestSignature(r)
+	if err != nil {
+		t.Fatalf("生成签名失败: %v", err)
+	}
+
+	// 空路径规范化为 "/"，无请求体时对空字符串计算摘要
+	c, _, _ := validator.withRequest(r)
+	if s, _ := c.buildStringToSign(nil); !strings.HasPrefix(s, "GET\n/\n\n\n\n") || !strings.HasSuffix(s, "&key=testSecret") {
+		t.Errorf("待签名字符串 = %q", s)
+	}
+	if valid, err := validator.ValidateRequest(r, signature); err != nil || !valid {
+		t.Errorf("签名验证失败: %v", err)
+	}
+}
+
+func TestRequestParams(t *testing.T) {
+	r := httptest.NewRequest("POST", "/orders?app_id=a&sign=abc", strings.NewReader(`{"amount":100.50,"items":["x","y"]}`))
+	r.Header.Set("Content-Type", "application/json; charset=utf-8")
+	params, err := RequestParams(r)
+	if err != nil {
+		t.Fatalf("读取请求参数失败: %v", err)
+	}
+	if params["app_id"] != "a" || params["sign"] != "abc" {
+		t.Errorf("查询参数不正确: %v", params)
+	}
+	if amount, _ := params["amount"].(json.Number); amount != "100.50" {
+		t.Errorf("amount = %v, 应使用请求体中的 json.Number", params["amount"])
+	}
+	if body, _ := io.ReadAll(r.Body); len(body) == 0 {
+		t.Error("读取后应恢复请求体")
+	}
+
+	r = httptest.NewRequest("POST", "/orders?a=1", strings.NewReader("b=2&b=3"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if params, err := RequestParams(r); err != nil || params["a"] != "1" || len(params["b"].([]string)) != 2 {
+		t.Errorf("表单参数不正确: %v, err=%v", params, err)
+	}
+
+	// 请求体中的参数不能覆盖同名查询参数，反之亦然
+	for _, contentType := range []string{"application/json", "application/x-www-form-urlencoded"} {
+		body := `{"amount":"100"}`
+		if contentType != "application/json" {
+			body = "amount=100"
+		}
+		r = httptest.NewRequest("POST", "/orders?amount=999", strings.NewReader(body))
+		r.Header.Set("Content-Type", contentType)
+		if _, err := RequestParams(r); !errors.Is(err, ErrDuplicateParam) {
+			t.Errorf("%s: 同名参数同时出现在查询参数与请求体中时应返回 ErrDuplicateParam: %v", contentType, err)
+		}
+	}
+
+	r = httptest.NewRequest("GET", "/orders?a=1", nil)
+	if params, err := RequestParams(r); err != nil || len(params) != 1 {
+		t.Errorf("无请求体时应只返回查询参数: %v, err=%v", params, err)
+	}
+
+	for contentType, body := range map[string]string{
+		"application/json":         `[1,2]`,
+		"application/problem+json": `{`,
+		"text/plain":               "hello",
+	} {
+		r := httptest.NewRequest("POST", "/", strings.NewReader(body))
+		r.Header.Set("Content-Type", contentType)
+		if _, err := RequestParams(r); err == nil {
+			t.Errorf("%s %s: 应返回错误", contentType, body)
+		}
+	}
+}
+
+func TestSignValidator_ValidateRequestParams(t *testing.T) {
+	validator, err := New(Config{Secret: "testSecret", Algorithm: HMAC_SHA256})
+	if err != nil {
+		t.Fatalf("创建签名验证器失败: %v", err)
+	}
+	signature, err := validator.GenerateSignature(map[string]interface{}{"a": "1", "b": "2"})
+	if err != nil {
+		t.Fatalf("生成签名失败: %v", err)
+	}
+
+	r := httptest.NewRequest("POST", "/orders?a=1&sign="+signature, strings.NewReader("b=2"))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	params, valid, err := validator.ValidateRequestParams(r, "")
+	if err != nil || !valid {
+		t.Fatalf("签名取自参数时应验证通过: valid=%v, err=%v", valid, err)
+	}
+	if params["b"] != "2" {
+		t.Errorf("应返回读取到的参数: %v", params)
+	}
+
+	r = httptest.NewRequest("GET", "/orders?a=1&b=2", nil)
+	if _, valid, err := validator.ValidateRequestParams(r, signature); err != nil || !valid {
+		t.Errorf("传入签名时应验证通过: valid=%v, err=%v", valid, err)
+	}
+	r = httptest.NewRequest("GET", "/orders?a=1&b=3", nil)
+	if _, valid, _ := validator.ValidateRequestParams(r, signature); valid {
+		t.Error("参数被篡改时应验证失败")
+	}
+}
+
+func TestMaxBodyBytes(t *testing.T) {
+	v := NewSignValidator(Config{Secret: "testSecret", Algorithm: HMAC_SHA256, MaxBodyBytes: 16})
+	body := `{"amount":"1000"}`
+
+	r := httptest.NewRequest("POST", "/orders", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+	if _, _, err := v.ValidateRequestParams(r, "sig"); !errors.Is(err, ErrBodyTooLarge) {
+		t.Errorf("请求体超过 MaxBodyBytes 时应返回 ErrBodyTooLarge: %v", err)
+	}
+
+	// 未声明长度的请求体同样受限
+	r = httptest.NewRequest("POST", "/orders", io.MultiReader(strings.NewReader(body)))
+	r.ContentLength = -1
+	r.Header.Set("Content-Type", "application/json")
+	if _, err := v.VerifyHTTPRequest(r); !errors.Is(err, ErrBodyTooLarge) {
+		t.Errorf("分块请求体超过限制时应返回 ErrBodyTooLarge: %v", err)
+	}
+
+	unlimited := NewSignValidator(Config{Secret: "testSecret", Algorithm: HMAC_SHA256, MaxBodyBytes: -1})
+	signature, _ := unlimited.GenerateSignature(map[string]interface{}{"amount": "1000"})
+	r = httptest.NewRequest("POST", "/orders", strings.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+	if _, valid, err := unlimited.ValidateRequestParams(r, signature); err != nil || !valid {
+		t.Errorf("MaxBodyBytes 为负数时不应限制: valid=%v, err=%v", valid, err)
+	}
+
+	r = httptest.NewRequest("POST", "/orders", strings.NewReader(strings.Repeat("a", DefaultMaxBodyBytes+1)))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if _, err := RequestParams(r); !errors.Is(err, ErrBodyTooLarge) {
+		t.Errorf("RequestParams 应使用 DefaultMaxBodyBytes: %v", err)
+	}
+}
@@ -0,0 +1,40 @@
+package signvalidator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrMissingKeys 缺少必需的参数
+var ErrMissingKeys = errors.New("缺少必需的参数")
+
+// MissingKeysError 缺少 RequiredKeys 中的参数时返回的错误
+type MissingKeysError struct {
+	// Keys 缺少的参数名
+	Keys []string
+}
+
+func (e *MissingKeysError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrMissingKeys, strings.Join(e.Keys, ", "))
+}
+
+// Unwrap 使 errors.Is(err, ErrMissingKeys) 成立
+func (e *MissingKeysError) Unwrap() error {
+	return ErrMissingKeys
+}
+
+// checkRequiredKeys 检查 RequiredKeys 中的参数是否存在且不为空，缺少时返回 *MissingKeysError
+func (v *SignValidator) checkRequiredKeys(params map[string]interface{}) error {
+	var missing []string
+	for _, key := range v.config.RequiredKeys {
+		value, exists := v.lookup(params, key)
+		if !exists || value == nil || value == "" {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) > 0 {
+		return &MissingKeysError{Keys: missing}
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package signvalidator
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestSignValidator_RequiredKeys(t *testing.T) {
+	validator := NewSignValidator(Config{Secret: "testSecret", RequiredKeys: []string{"app_id", "timestamp", "nonce"}})
+
+	params := map[string]interface{}{"app_id": "demo", "timestamp": 1700000000, "nonce": "abc"}
+	signature, err := validator.GenerateSignature(params)
+	if err != nil {
+		t.Fatalf("生成签名失败: %v", err)
+	}
+	if valid, err := validator.Validate(params, signature); err != nil || !valid {
+		t.Errorf("签名验证失败: %v", err)
+	}
+
+	// 缺少参数或参数值为空时返回 *MissingKeysError
+	incomplete := map[string]interface{}{"app_id": "demo", "nonce": ""}
+	var missingErr *MissingKeysError
+	if _, err := validator.Validate(incomplete, signature); !errors.As(err, &missingErr) {
+		t.Fatalf("错误 = %v, 期望 *MissingKeysError", err)
+	}
+	if !reflect.DeepEqual(missingErr.Keys, []string{"timestamp", "nonce"}) {
+		t.Errorf("缺少的参数 = %v, 期望 [timestamp nonce]", missingErr.Keys)
+	}
+	if _, err := validator.GenerateSignature(incomplete); !errors.Is(err, ErrMissingKeys) {
+		t.Errorf("错误 = %v, 期望 ErrMissingKeys", err)
+	}
+}
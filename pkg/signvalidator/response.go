@@ -0,0 +1,151 @@
+package signvalidator
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// ErrInvalidResponseSignature 响应签名无效
+var ErrInvalidResponseSignature = errors.New("响应签名无效")
+
+// defaultResponseHeader 未配置 SignatureHeader 时的响应签名头，与 httpmw.SignResponses 的默认值一致
+const defaultResponseHeader = "X-Signature"
+
+// VerifyHTTPResponse 验证服务端（如 httpmw.SignResponses）签名的 HTTP 响应：签名取自 SignatureHeader 响应头（默认为 "X-Signature"），
+// 按 ValidateBody 验证响应体；响应头缺失且响应为包含 SignatureKey 字段的 JSON 对象时按 ValidateWithSignInParams 验证该对象。
+// 读取后恢复响应体，调用方仍可照常读取；响应体超过 MaxBodyBytes 时返回 ErrBodyTooLarge。
+// 不希望在验证前读取完整响应体时使用 VerifyResponseBody
+func (v *SignValidator) VerifyHTTPResponse(resp *http.Response) (bool, error) {
+	if v.err != nil {
+		return false, v.err
+	}
+	var body []byte
+	if resp.Body != nil {
+		limit := v.config.MaxBodyBytes
+		if limit > 0 && resp.ContentLength > limit {
+			resp.Body.Close()
+			return false, fmt.Errorf("%w: 超过 %d 字节", ErrBodyTooLarge, limit)
+		}
+		reader := io.Reader(resp.Body)
+		if limit > 0 {
+			reader = io.LimitReader(resp.Body, limit+1)
+		}
+		var err error
+		body, err = io.ReadAll(reader)
+		resp.Body.Close()
+		if err != nil {
+			return false, fmt.Errorf("读取响应体失败: %w", err)
+		}
+		if limit > 0 && int64(len(body)) > limit {
+			return false, fmt.Errorf("%w: 超过 %d 字节", ErrBodyTooLarge, limit)
+		}
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	return v.verifyResponse(responseContext(resp), resp.Header, body)
+}
+
+// VerifyResponseBody 替换响应体为边读取边验证的 io.ReadCloser，规则同 VerifyHTTPResponse：数据照常交给调用方，
+// 读到末尾时验证签名，签名无效时 Read 返回 ErrInvalidResponseSignature（或验证错误）而不是 io.EOF。
+// 调用方应在读到 io.EOF 后才使用数据；签名需要完整响应体，读取过的内容仍保留在内存中直至验证完成，
+// 超过 MaxBodyBytes 时 Read 返回 ErrBodyTooLarge
+func (v *SignValidator) VerifyResponseBody(resp *http.Response) error {
+	if v.err != nil {
+		return v.err
+	}
+	body := resp.Body
+	if body == nil {
+		body = http.NoBody
+	}
+	resp.Body = &verifyingBody{
+		ReadCloser: body,
+		limit:      v.config.MaxBodyBytes,
+		verify: func(data []byte) error {
+			valid, err := v.verifyResponse(responseContext(resp), resp.Header, data)
+			if err != nil {
+				return err
+			}
+			if !valid {
+				return ErrInvalidResponseSignature
+			}
+			return nil
+		},
+	}
+	return nil
+}
+
+// verifyResponse 按响应头或 JSON 对象中的签名字段验证响应体
+func (v *SignValidator) verifyResponse(ctx context.Context, header http.Header, body []byte) (bool, error) {
+	// 响应签名不携带时间戳、随机数与序号，请求侧的防重放配置不适用于响应
+	c := *v.withContext(ctx)
+	c.config.TimestampKey, c.config.NonceStore, c.config.SequenceStore = "", nil, nil
+	// 一次性签名与失败次数限制针对调用方的请求，同样不作用于响应
+	c.config.SignatureStore = nil
+	c.config.FailureLimit, c.failures = nil, nil
+	name := v.config.SignatureHeader
+	if name == "" {
+		name = defaultResponseHeader
+	}
+	if signature := header.Get(name); signature != "" {
+		return c.ValidateBody(body, signature)
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(header.Get("Content-Type"))
+	if mediaType == "application/json" || strings.HasSuffix(mediaType, "+json") {
+		if value, err := decodeJSON(body); err == nil {
+			if object, ok := value.(map[string]interface{}); ok {
+				if _, exists := object[v.config.SignatureKey]; exists {
+					return c.ValidateWithSignInParams(object)
+				}
+			}
+		}
+	}
+	return false, &MissingKeysError{Keys: []string{name}}
+}
+
+// responseContext 返回发出响应对应请求的上下文，传给 SecretProvider
+func responseContext(resp *http.Response) context.Context {
+	if resp.Request != nil {
+		return resp.Request.Context()
+	}
+	return context.Background()
+}
+
+// verifyingBody 缓存读取的响应体，读到末尾时验证签名
+type verifyingBody struct {
+	io.ReadCloser
+	verify func(data []byte) error
+	// limit 缓存的最大字节数，不大于 0 时不限制
+	limit int64
+	buf   bytes.Buffer
+	err   error
+	done  bool
+}
+
+// Read 读取响应体，读到末尾时以验证结果代替 io.EOF
+func (b *verifyingBody) Read(p []byte) (int, error) {
+	if b.done {
+		return 0, b.err
+	}
+	n, err := b.ReadCloser.Read(p)
+	if b.limit > 0 && int64(b.buf.Len()+n) > b.limit {
+		b.done, b.err = true, fmt.Errorf("%w: 超过 %d 字节", ErrBodyTooLarge, b.limit)
+		b.buf = bytes.Buffer{}
+		return 0, b.err
+	}
+	b.buf.Write(p[:n])
+	if err != io.EOF {
+		return n, err
+	}
+	b.done, b.err = true, io.EOF
+	if verr := b.verify(b.buf.Bytes()); verr != nil {
+		b.err = verr
+	}
+	b.buf = bytes.Buffer{}
+	return n, b.err
+}
@@ -0,0 +1,134 @@
+package signvalidator
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func newResponse(header http.Header, body string) *http.Response {
+	return &http.Response{StatusCode: http.StatusOK, Header: header, Body: io.NopCloser(strings.NewReader(body))}
+}
+
+func TestVerifyHTTPResponse(t *testing.T) {
+	v := NewSignValidator(Config{Secret: "testSecret", Algorithm: HMAC_SHA256})
+	body := `{"code":0,"data":{"id":1}}`
+	signature, err := v.GenerateBodySignature([]byte(body))
+	if err != nil {
+		t.Fatalf("生成签名失败: %v", err)
+	}
+
+	resp := newResponse(http.Header{"X-Signature": {signature}}, body)
+	if valid, err := v.VerifyHTTPResponse(resp); err != nil || !valid {
+		t.Errorf("响应签名应验证通过: valid=%v, err=%v", valid, err)
+	}
+	if data, _ := io.ReadAll(resp.Body); string(data) != body {
+		t.Errorf("验证后响应体应可读取: %q", data)
+	}
+
+	resp = newResponse(http.Header{"X-Signature": {signature}}, `{"code":0,"data":{"id":2}}`)
+	if valid, err := v.VerifyHTTPResponse(resp); err != nil || valid {
+		t.Errorf("篡改的响应应验证失败: valid=%v, err=%v", valid, err)
+	}
+
+	var missing *MissingKeysError
+	if _, err := v.VerifyHTTPResponse(newResponse(http.Header{}, body)); !errors.As(err, &missing) {
+		t.Errorf("缺少签名响应头时应返回 MissingKeysError: %v", err)
+	}
+
+	custom := NewSignValidator(Config{Secret: "testSecret", Algorithm: HMAC_SHA256, SignatureHeader: "X-Response-Signature"})
+	if valid, err := custom.VerifyHTTPResponse(newResponse(http.Header{"X-Response-Signature": {signature}}, body)); err != nil || !valid {
+		t.Errorf("应从 SignatureHeader 响应头读取签名: valid=%v, err=%v", valid, err)
+	}
+}
+
+func TestVerifyHTTPResponse_Field(t *testing.T) {
+	v := NewSignValidator(Config{Secret: "testSecret", Algorithm: HMAC_SHA256})
+	signature, err := v.GenerateSignature(map[string]interface{}{"code": "0", "amount": "100.50"})
+	if err != nil {
+		t.Fatalf("生成签名失败: %v", err)
+	}
+	header := http.Header{"Content-Type": {"application/json"}}
+	body := `{"code":0,"amount":100.50,"sign":"` + signature + `"}`
+	if valid, err := v.VerifyHTTPResponse(newResponse(header, body)); err != nil || !valid {
+		t.Errorf("签名字段应验证通过: valid=%v, err=%v", valid, err)
+	}
+	if valid, _ := v.VerifyHTTPResponse(newResponse(header, strings.Replace(body, "100.50", "100.5", 1))); valid {
+		t.Error("篡改的 JSON 响应应验证失败")
+	}
+}
+
+func TestVerifyResponseBody(t *testing.T) {
+	v := NewSignValidator(Config{Secret: "testSecret", Algorithm: HMAC_SHA256})
+	body := strings.Repeat("chunk;", 2048)
+	signature, err := v.GenerateBodySignature([]byte(body))
+	if err != nil {
+		t.Fatalf("生成签名失败: %v", err)
+	}
+
+	resp := newResponse(http.Header{"X-Signature": {signature}}, body)
+	if err := v.VerifyResponseBody(resp); err != nil {
+		t.Fatalf("包装响应体失败: %v", err)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil || string(data) != body {
+		t.Errorf("签名有效时应完整读取响应体: len=%d, err=%v", len(data), err)
+	}
+
+	resp = newResponse(http.Header{"X-Signature": {signature}}, body+"tampered")
+	v.VerifyResponseBody(resp)
+	if _, err := io.ReadAll(resp.Body); !errors.Is(err, ErrInvalidResponseSignature) {
+		t.Errorf("篡改的响应应在读取末尾返回 ErrInvalidResponseSignature: %v", err)
+	}
+	if _, err := resp.Body.Read(make([]byte, 1)); !errors.Is(err, ErrInvalidResponseSignature) {
+		t.Errorf("之后的读取应返回相同错误: %v", err)
+	}
+}
+
+func TestVerifyHTTPResponse_MaxBodyBytes(t *testing.T) {
+	v := NewSignValidator(Config{Secret: "testSecret", Algorithm: HMAC_SHA256, MaxBodyBytes: 16})
+	body := strings.Repeat("x", 17)
+	signature, _ := v.GenerateBodySignature([]byte(body))
+
+	if _, err := v.VerifyHTTPResponse(newResponse(http.Header{"X-Signature": {signature}}, body)); !errors.Is(err, ErrBodyTooLarge) {
+		t.Errorf("超过 MaxBodyBytes 的响应应返回 ErrBodyTooLarge: %v", err)
+	}
+	resp := newResponse(http.Header{"X-Signature": {signature}}, body)
+	resp.ContentLength = int64(len(body))
+	if _, err := v.VerifyHTTPResponse(resp); !errors.Is(err, ErrBodyTooLarge) {
+		t.Errorf("Content-Length 超过 MaxBodyBytes 时应返回 ErrBodyTooLarge: %v", err)
+	}
+
+	resp = newResponse(http.Header{"X-Signature": {signature}}, body)
+	v.VerifyResponseBody(resp)
+	if _, err := io.ReadAll(resp.Body); !errors.Is(err, ErrBodyTooLarge) {
+		t.Errorf("边读取边验证时超过 MaxBodyBytes 应返回 ErrBodyTooLarge: %v", err)
+	}
+
+	body = body[:16]
+	signature, _ = v.GenerateBodySignature([]byte(body))
+	if valid, err := v.VerifyHTTPResponse(newResponse(http.Header{"X-Signature": {signature}}, body)); err != nil || !valid {
+		t.Errorf("未超过 MaxBodyBytes 的响应应验证通过: valid=%v, err=%v", valid, err)
+	}
+}
+
+func TestVerifyHTTPResponse_RequestSideLimits(t *testing.T) {
+	v := NewSignValidator(Config{
+		Secret:         "testSecret",
+		Algorithm:      HMAC_SHA256,
+		SignatureStore: NewMemoryNonceStore(0),
+		FailureLimit:   &FailureLimit{MaxFailures: 1},
+	})
+	body := `{"code":0}`
+	signature, _ := v.GenerateBodySignature([]byte(body))
+
+	// 失败不计入失败次数，相同的响应签名可重复验证
+	v.VerifyHTTPResponse(newResponse(http.Header{"X-Signature": {signature}}, `{"code":1}`))
+	for i := 0; i < 2; i++ {
+		if valid, err := v.VerifyHTTPResponse(newResponse(http.Header{"X-Signature": {signature}}, body)); err != nil || !valid {
+			t.Errorf("第 %d 次验证响应应通过: valid=%v, err=%v", i+1, valid, err)
+		}
+	}
+}
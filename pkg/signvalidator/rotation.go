@@ -0,0 +1,68 @@
+package signvalidator
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// PreviousSecret 轮换前的旧密钥，在 ExpiresAt 之前仍可用于验证签名
+type PreviousSecret struct {
+	// Secret 旧密钥
+	Secret string
+	// ExpiresAt 旧密钥停止接受的时间
+	ExpiresAt time.Time
+}
+
+// checkPreviousSecrets 检查 PreviousSecrets 配置，并复制以免拉伸密钥时修改调用方的切片
+func (v *SignValidator) checkPreviousSecrets() error {
+	if len(v.config.PreviousSecrets) == 0 {
+		return nil
+	}
+	if v.config.Secret == "" {
+		return errors.New("PreviousSecrets 需要配置当前密钥 Secret")
+	}
+
+	previous := make([]PreviousSecret, len(v.config.PreviousSecrets))
+	for i, secret := range v.config.PreviousSecrets {
+		switch {
+		case secret.Secret == "":
+			return fmt.Errorf("第 %d 个旧密钥为空", i+1)
+		case secret.ExpiresAt.IsZero():
+			return fmt.Errorf("第 %d 个旧密钥未设置过期时间", i+1)
+		}
+		previous[i] = secret
+	}
+	v.config.PreviousSecrets = previous
+	return nil
+}
+
+// verificationSecrets 返回验证签名时依次尝试的密钥：当前密钥、尚未过期的旧密钥及 Secrets，
+// 仅配置了 Secrets 时不尝试空的 Secret
+func (v *SignValidator) verificationSecrets() []Secret {
+	if len(v.config.PreviousSecrets) == 0 && len(v.config.Secrets) == 0 {
+		return []Secret{{Value: v.config.Secret}}
+	}
+
+	var secrets []Secret
+	if v.config.Secret != "" || len(v.config.Secrets) == 0 {
+		secrets = append(secrets, Secret{Value: v.config.Secret})
+	}
+	now := v.now()
+	for _, previous := range v.config.PreviousSecrets {
+		if now.Before(previous.ExpiresAt) {
+			secrets = append(secrets, Secret{Value: previous.Secret})
+		}
+	}
+	return append(secrets, v.config.Secrets...)
+}
+
+// withSecret 返回使用指定密钥、其余配置相同的验证器副本
+func (v *SignValidator) withSecret(secret string) *SignValidator {
+	if secret == v.config.Secret {
+		return v
+	}
+	c := *v
+	c.config.Secret = secret
+	return &c
+}
@@ -0,0 +1,74 @@
+package signvalidator
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPreviousSecrets(t *testing.T) {
+	clock := NewFakeClock(time.Unix(1700000000, 0))
+	params := map[string]interface{}{"order_id": "1001"}
+
+	old := NewSignValidator(Config{Secret: "old-secret", Algorithm: HMAC_SHA256})
+	oldSignature, _ := old.GenerateSignature(params)
+
+	v := NewSignValidator(Config{
+		Secret:    "new-secret",
+		Algorithm: HMAC_SHA256,
+		Clock:     clock,
+		PreviousSecrets: []PreviousSecret{
+			{Secret: "old-secret", ExpiresAt: clock.Now().Add(time.Hour)},
+			{Secret: "older-secret", ExpiresAt: clock.Now().Add(-time.Hour)},
+		},
+	})
+
+	// 生成签名始终使用当前密钥
+	signature, _ := v.GenerateSignature(params)
+	current, _ := NewSignValidator(Config{Secret: "new-secret", Algorithm: HMAC_SHA256}).GenerateSignature(params)
+	if signature != current {
+		t.Errorf("签名 = %s, 应使用当前密钥生成 %s", signature, current)
+	}
+	if valid, err := v.Validate(params, signature); err != nil || !valid {
+		t.Errorf("当前密钥的签名验证应通过，valid=%v err=%v", valid, err)
+	}
+	if valid, err := v.ValidateStrings(map[string]string{"order_id": "1001"}, oldSignature); err != nil || !valid {
+		t.Errorf("过渡期内旧密钥的签名验证应通过，valid=%v err=%v", valid, err)
+	}
+
+	older, _ := NewSignValidator(Config{Secret: "older-secret", Algorithm: HMAC_SHA256}).GenerateSignature(params)
+	if valid, _ := v.Validate(params, older); valid {
+		t.Error("已过期的旧密钥签名验证不应通过")
+	}
+
+	clock.Advance(time.Hour)
+	if valid, _ := v.Validate(params, oldSignature); valid {
+		t.Error("过渡期结束后旧密钥签名验证不应通过")
+	}
+}
+
+func TestPreviousSecrets_Stretch(t *testing.T) {
+	stretch := &SecretStretch{Iterations: 1000, Salt: "salt"}
+	params := map[string]interface{}{"a": "1"}
+	previous := []PreviousSecret{{Secret: "old-password", ExpiresAt: time.Now().Add(time.Hour)}}
+
+	oldSignature, _ := NewSignValidator(Config{Secret: "old-password", Algorithm: HMAC_SHA256, Stretch: stretch}).GenerateSignature(params)
+	v := NewSignValidator(Config{Secret: "new-password", Algorithm: HMAC_SHA256, Stretch: stretch, PreviousSecrets: previous})
+	if valid, err := v.Validate(params, oldSignature); err != nil || !valid {
+		t.Errorf("旧密钥同样应经过拉伸，valid=%v err=%v", valid, err)
+	}
+	if previous[0].Secret != "old-password" {
+		t.Error("不应修改调用方的 PreviousSecrets")
+	}
+}
+
+func TestPreviousSecrets_Invalid(t *testing.T) {
+	for _, config := range []Config{
+		{PreviousSecrets: []PreviousSecret{{Secret: "old", ExpiresAt: time.Now()}}},
+		{Secret: "new", PreviousSecrets: []PreviousSecret{{ExpiresAt: time.Now()}}},
+		{Secret: "new", PreviousSecrets: []PreviousSecret{{Secret: "old"}}},
+	} {
+		if _, err := New(config); err == nil {
+			t.Errorf("%+v: 应返回错误", config.PreviousSecrets)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package signvalidator
+
+import (
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha3"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"strings"
+
+	"github.com/huangchunlong818/sign-chao/pkg/signvalidator/internal/blake2b"
+	"github.com/huangchunlong818/sign-chao/pkg/signvalidator/internal/blake3"
+	"github.com/huangchunlong818/sign-chao/pkg/signvalidator/internal/sm3"
+	"github.com/huangchunlong818/sign-chao/pkg/signvalidator/internal/xxhash"
+)
+
+var errRoundsAsymmetric = errors.New("非对称算法不支持追加摘要轮次")
+
+// DigestRound 在签名结果上追加的一轮摘要，用于表达 md5(md5(str+key)+key) 等旧式签名方案
+type DigestRound struct {
+	// Algorithm 本轮摘要算法，仅支持不带密钥的哈希算法（如 MD5、SHA256、SM3）
+	Algorithm SignAlgorithm
+	// AppendSecret 是否在上一轮结果后直接拼接 Secret（不带 "&key=" 前缀）
+	AppendSecret bool
+	// UpperCase 上一轮结果是否以大写十六进制参与本轮计算
+	UpperCase bool
+}
+
+// newDigest 创建不带密钥的哈希算法实例
+func newDigest(algorithm SignAlgorithm) (hash.Hash, error) {
+	switch algorithm {
+	case MD5:
+		return md5.New(), nil
+	case SHA1:
+		return sha1.New(), nil
+	case SHA256:
+		return sha256.New(), nil
+	case SM3:
+		return sm3.New(), nil
+	case CRC32C:
+		return crc32.New(crc32.MakeTable(crc32.Castagnoli)), nil
+	case XXHASH64:
+		return xxhash.New(), nil
+	case SHA3_256:
+		return sha3.New256(), nil
+	case SHA3_512:
+		return sha3.New512(), nil
+	case BLAKE2B_256:
+		return blake2b.New256(nil)
+	case BLAKE2B_512:
+		return blake2b.New512(nil)
+	case BLAKE3:
+		return blake3.New(), nil
+	}
+	return nil, fmt.Errorf("不支持的摘要算法: %s", algorithm)
+}
+
+// checkRounds 检查摘要轮次配置
+func (v *SignValidator) checkRounds() error {
+	if len(v.config.Rounds) > 0 && isAsymmetric(v.config.Algorithm) {
+		return errRoundsAsymmetric
+	}
+	for i, round := range v.config.Rounds {
+		if _, err := newDigest(round.Algorithm); err != nil {
+			return fmt.Errorf("第 %d 轮摘要: %w", i+1, err)
+		}
+		if err := v.checkAlgorithm(round.Algorithm); err != nil {
+			return fmt.Errorf("第 %d 轮摘要: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+// applyRounds 依次对上一轮结果的十六进制字符串计算摘要
+func (v *SignValidator) applyRounds(signBytes []byte) ([]byte, error) {
+	if len(v.config.Rounds) > 0 && isAsymmetric(v.config.Algorithm) {
+		return nil, errRoundsAsymmetric
+	}
+
+	for _, round := range v.config.Rounds {
+		data := hex.EncodeToString(signBytes)
+		if round.UpperCase {
+			data = strings.ToUpper(data)
+		}
+		if round.AppendSecret {
+			data += v.config.Secret
+		}
+
+		h, err := newDigest(round.Algorithm)
+		if err != nil {
+			return nil, err
+		}
+		if signBytes, err = calculateHash(h, data); err != nil {
+			return nil, err
+		}
+	}
+	return signBytes, nil
+}
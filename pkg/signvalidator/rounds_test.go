@@ -0,0 +1,80 @@
+package signvalidator
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRounds_DoubleMD5(t *testing.T) {
+	skipFIPS(t)
+	params := map[string]interface{}{
+		"id":     123,
+		"name":   "test",
+		"amount": 100.50,
+	}
+
+	testCases := []struct {
+		name      string
+		algorithm SignAlgorithm
+		rounds    []DigestRound
+		expected  string
+	}{
+		// md5(md5(str&key=secret)+secret)
+		{"md5+md5", MD5, []DigestRound{{Algorithm: MD5, AppendSecret: true}}, "283a92898c4cbb83b15ac3df3b0b8f39"},
+		// md5(upper(sha256(str&key=secret)))
+		{"sha256+md5", SHA256, []DigestRound{{Algorithm: MD5, UpperCase: true}}, "c2ae72ba1528d645878e798a9bf77356"},
+	}
+
+	for _, tc := range testCases {
+		validator := NewSignValidator(Config{
+			Secret:    "testSecret",
+			Algorithm: tc.algorithm,
+			Rounds:    tc.rounds,
+		})
+
+		signature, err := validator.GenerateSignature(params)
+		if err != nil {
+			t.Fatalf("生成签名失败: %v", err)
+		}
+		if signature != tc.expected {
+			t.Errorf("%s 签名 = %s, 期望 %s", tc.name, signature, tc.expected)
+		}
+
+		valid, err := validator.Validate(params, signature)
+		if err != nil {
+			t.Fatalf("验证签名失败: %v", err)
+		}
+		if !valid {
+			t.Errorf("%s 签名验证失败", tc.name)
+		}
+	}
+}
+
+func TestRounds_InvalidConfig(t *testing.T) {
+	skipFIPS(t)
+	// 摘要轮次仅支持不带密钥的哈希算法
+	if _, err := New(Config{Secret: "testSecret", Algorithm: MD5, Rounds: []DigestRound{{Algorithm: HMAC_SHA256}}}); err == nil {
+		t.Errorf("HMAC 摘要轮次应该返回错误")
+	}
+
+	// 摘要轮次同样受安全策略限制
+	_, err := New(Config{
+		Secret:    "testSecret",
+		Algorithm: SHA256,
+		Rounds:    []DigestRound{{Algorithm: MD5}},
+		Policy:    &Policy{MinStrength: StrengthStrong},
+	})
+	if !errors.Is(err, ErrPolicyViolation) {
+		t.Errorf("错误 = %v, 期望 ErrPolicyViolation", err)
+	}
+
+	// 非对称算法不支持摘要轮次
+	key, err := GenerateSM2Key()
+	if err != nil {
+		t.Fatalf("生成密钥失败: %v", err)
+	}
+	validator := NewSignValidator(Config{Algorithm: SM2, PrivateKey: key, Rounds: []DigestRound{{Algorithm: SM3}}})
+	if _, err := validator.GenerateSignature(map[string]interface{}{"id": 1}); err == nil {
+		t.Errorf("非对称算法追加摘要轮次应该返回错误")
+	}
+}
@@ -0,0 +1,54 @@
+package signvalidator
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Secret 多个可信客户端共用接口时，用于验证签名的命名密钥
+type Secret struct {
+	// Name 密钥名称（如客户端名称），验证通过时由 ValidateSecrets 返回
+	Name string
+	// Value 密钥
+	Value string
+}
+
+// checkSecrets 检查 Secrets 配置，并复制以免拉伸密钥时修改调用方的切片
+func (v *SignValidator) checkSecrets() error {
+	if len(v.config.Secrets) == 0 {
+		return nil
+	}
+	if len(v.config.Keys) > 0 {
+		return errors.New("配置了密钥环 Keys 时不能同时设置 Secrets")
+	}
+
+	secrets := make([]Secret, len(v.config.Secrets))
+	names := make(map[string]bool, len(v.config.Secrets))
+	for i, secret := range v.config.Secrets {
+		switch {
+		case secret.Name == "":
+			return fmt.Errorf("第 %d 个密钥未设置名称", i+1)
+		case secret.Value == "":
+			return fmt.Errorf("密钥 %s 为空", secret.Name)
+		case names[secret.Name]:
+			return fmt.Errorf("密钥名称重复: %s", secret.Name)
+		}
+		names[secret.Name] = true
+		secrets[i] = secret
+	}
+	v.config.Secrets = secrets
+	return nil
+}
+
+// ValidateSecrets 验证签名是否有效，并返回验证通过的密钥名称：Secrets 中密钥的 Name，
+// Secret 或 PreviousSecrets 验证通过时为空字符串
+func (v *SignValidator) ValidateSecrets(params map[string]interface{}, signature string) (string, bool, error) {
+	if v.err != nil {
+		return "", false, v.err
+	}
+	_, name, valid, err := v.validateAlgorithms(params, signature)
+	if !valid {
+		v.report(params, err)
+	}
+	return name, valid, err
+}
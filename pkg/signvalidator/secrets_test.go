@@ -0,0 +1,75 @@
+package signvalidator
+
+import (
+	"testing"
+)
+
+func TestValidateSecrets(t *testing.T) {
+	params := map[string]interface{}{"order_id": "1001"}
+	sign := func(secret string) string {
+		signature, _ := NewSignValidator(Config{Secret: secret, Algorithm: HMAC_SHA256}).GenerateSignature(params)
+		return signature
+	}
+
+	v := NewSignValidator(Config{
+		Algorithm: HMAC_SHA256,
+		Secrets: []Secret{
+			{Name: "partner-a", Value: "secret-a"},
+			{Name: "partner-b", Value: "secret-b"},
+		},
+	})
+	for _, tc := range []struct{ secret, name string }{
+		{"secret-a", "partner-a"},
+		{"secret-b", "partner-b"},
+	} {
+		name, valid, err := v.ValidateSecrets(params, sign(tc.secret))
+		if err != nil || !valid || name != tc.name {
+			t.Errorf("%s: name=%q valid=%v err=%v, 期望 %s", tc.secret, name, valid, err, tc.name)
+		}
+	}
+	if name, valid, _ := v.ValidateSecrets(params, sign("secret-c")); valid || name != "" {
+		t.Errorf("未知密钥的签名验证不应通过，name=%q valid=%v", name, valid)
+	}
+	if valid, _ := v.ValidateStrings(map[string]string{"order_id": "1001"}, sign("secret-b")); !valid {
+		t.Error("ValidateStrings 同样应尝试 Secrets")
+	}
+	if valid, _ := v.Validate(params, sign("")); valid {
+		t.Error("仅配置 Secrets 时不应接受空密钥的签名")
+	}
+
+	// Secret 验证通过时名称为空
+	v = NewSignValidator(Config{Algorithm: HMAC_SHA256, Secret: "own", Secrets: []Secret{{Name: "partner-a", Value: "secret-a"}}})
+	if name, valid, err := v.ValidateSecrets(params, sign("own")); err != nil || !valid || name != "" {
+		t.Errorf("Secret 验证应通过且名称为空，name=%q valid=%v err=%v", name, valid, err)
+	}
+}
+
+func TestSecrets_Stretch(t *testing.T) {
+	stretch := &SecretStretch{Iterations: 1000, Salt: "salt"}
+	params := map[string]interface{}{"a": "1"}
+	signature, _ := NewSignValidator(Config{Secret: "password-a", Algorithm: HMAC_SHA256, Stretch: stretch}).GenerateSignature(params)
+
+	secrets := []Secret{{Name: "a", Value: "password-a"}}
+	v := NewSignValidator(Config{Algorithm: HMAC_SHA256, Stretch: stretch, Secrets: secrets})
+	if name, valid, err := v.ValidateSecrets(params, signature); err != nil || !valid || name != "a" {
+		t.Errorf("Secrets 同样应经过拉伸，name=%q valid=%v err=%v", name, valid, err)
+	}
+	if secrets[0].Value != "password-a" {
+		t.Error("不应修改调用方的 Secrets")
+	}
+}
+
+func TestSecrets_Invalid(t *testing.T) {
+	for _, secrets := range [][]Secret{
+		{{Value: "secret"}},
+		{{Name: "a"}},
+		{{Name: "a", Value: "1"}, {Name: "a", Value: "2"}},
+	} {
+		if _, err := New(Config{Secrets: secrets}); err == nil {
+			t.Errorf("%+v: 应返回错误", secrets)
+		}
+	}
+	if _, err := New(Config{Keys: map[string]string{"a": "1"}, KeyID: "a", Secrets: []Secret{{Name: "b", Value: "2"}}}); err == nil {
+		t.Error("Keys 与 Secrets 同时设置时应返回错误")
+	}
+}
@@ -0,0 +1,93 @@
+package signvalidator
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+)
+
+// ErrSequenceNotIncreasing 序号未大于已接受的序号，请求可能被重放
+var ErrSequenceNotIncreasing = errors.New("序号未递增")
+
+// SequenceStore 序号存储，用于要求每个调用方的序号严格递增
+type SequenceStore interface {
+	// Advance 当 sequence 大于 scope 已记录的序号（或 scope 尚无记录）时记录 sequence 并返回 true，否则返回 false；
+	// 比较与记录必须是原子操作
+	Advance(scope string, sequence uint64) bool
+}
+
+// checkSequence 签名验证通过后检查 SequenceKey 参数是否大于同一 SequenceScopeKey 下已接受的序号，
+// 未配置 SequenceStore 时不检查
+func (v *SignValidator) checkSequence(params map[string]interface{}) error {
+	if v.config.SequenceStore == nil {
+		return nil
+	}
+
+	var missing []string
+	value, exists := v.lookup(params, v.sequenceKey())
+	if !exists || value == nil || value == "" {
+		missing = append(missing, v.sequenceKey())
+	}
+	var scope string
+	if v.config.SequenceScopeKey != "" {
+		scopeValue, exists := v.lookup(params, v.config.SequenceScopeKey)
+		if !exists || scopeValue == nil || scopeValue == "" {
+			missing = append(missing, v.config.SequenceScopeKey)
+		}
+		scope = convertToString(scopeValue)
+	}
+	if len(missing) > 0 {
+		return &MissingKeysError{Keys: missing}
+	}
+
+	sequence, err := strconv.ParseUint(convertToString(value), 10, 64)
+	if err != nil {
+		return fmt.Errorf("序号格式错误: %v", value)
+	}
+	if !v.config.SequenceStore.Advance(scope, sequence) {
+		return ErrSequenceNotIncreasing
+	}
+	return nil
+}
+
+// sequenceKey 返回序号参数名，未配置 SequenceKey 时为 "seq"
+func (v *SignValidator) sequenceKey() string {
+	if v.config.SequenceKey == "" {
+		return "seq"
+	}
+	return v.config.SequenceKey
+}
+
+// MemorySequenceStore 进程内的 SequenceStore，记录每个 scope 最后接受的序号；
+// 进程重启后记录丢失，多实例部署时需要使用数据库等共享存储
+type MemorySequenceStore struct {
+	mu   sync.Mutex
+	last map[string]uint64
+}
+
+// NewMemorySequenceStore 创建 MemorySequenceStore
+func NewMemorySequenceStore() *MemorySequenceStore {
+	return &MemorySequenceStore{last: make(map[string]uint64)}
+}
+
+// Advance 实现 SequenceStore
+func (s *MemorySequenceStore) Advance(scope string, sequence uint64) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if last, exists := s.last[scope]; exists && sequence <= last {
+		return false
+	}
+	s.last[scope] = sequence
+	return true
+}
+
+// Last 返回 scope 最后接受的序号，尚无记录时第二个返回值为 false
+func (s *MemorySequenceStore) Last(scope string) (uint64, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	last, exists := s.last[scope]
+	return last, exists
+}
@@ -0,0 +1,95 @@
+package signvalidator
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestValidate_Sequence(t *testing.T) {
+	store := NewMemorySequenceStore()
+	v := NewSignValidator(Config{
+		Secret:           "secret",
+		SequenceStore:    store,
+		SequenceKey:      "request_id",
+		SequenceScopeKey: "app_id",
+	})
+	signed := func(appID string, requestID interface{}) (map[string]interface{}, string) {
+		params := map[string]interface{}{"app_id": appID, "request_id": requestID}
+		signature, _ := v.GenerateSignature(params)
+		return params, signature
+	}
+
+	params, signature := signed("a", 1)
+	if valid, err := v.Validate(params, "bad"); err != nil || valid {
+		t.Fatalf("无效签名验证应失败，valid=%v err=%v", valid, err)
+	}
+	if _, exists := store.Last("a"); exists {
+		t.Error("无效签名不应推进序号")
+	}
+	if valid, err := v.Validate(params, signature); err != nil || !valid {
+		t.Fatalf("首次验证应通过，valid=%v err=%v", valid, err)
+	}
+	if valid, err := v.Validate(params, signature); !errors.Is(err, ErrSequenceNotIncreasing) || valid {
+		t.Errorf("重复的序号应返回 ErrSequenceNotIncreasing，valid=%v err=%v", valid, err)
+	}
+
+	params, signature = signed("a", "5")
+	if valid, err := v.Validate(params, signature); err != nil || !valid {
+		t.Errorf("递增的序号验证应通过，valid=%v err=%v", valid, err)
+	}
+	params, signature = signed("a", 3)
+	if _, err := v.Validate(params, signature); !errors.Is(err, ErrSequenceNotIncreasing) {
+		t.Errorf("减小的序号应返回 ErrSequenceNotIncreasing，实际 %v", err)
+	}
+	if last, _ := store.Last("a"); last != 5 {
+		t.Errorf("最后接受的序号 = %d, 期望 5", last)
+	}
+
+	// 不同调用方的序号互不影响
+	params, signature = signed("b", 1)
+	if valid, err := v.Validate(params, signature); err != nil || !valid {
+		t.Errorf("其他调用方验证应通过，valid=%v err=%v", valid, err)
+	}
+}
+
+func TestValidate_SequenceInvalid(t *testing.T) {
+	v := NewSignValidator(Config{Secret: "secret", SequenceStore: NewMemorySequenceStore(), SequenceScopeKey: "app_id"})
+
+	params := map[string]interface{}{"a": "1"}
+	signature, _ := v.GenerateSignature(params)
+	var missing *MissingKeysError
+	if _, err := v.Validate(params, signature); !errors.As(err, &missing) || len(missing.Keys) != 2 {
+		t.Errorf("缺少序号与调用方应返回 *MissingKeysError，实际 %v", err)
+	}
+
+	for _, seq := range []interface{}{"-1", "abc", 1.5} {
+		params := map[string]interface{}{"app_id": "a", "seq": seq}
+		signature, _ := v.GenerateSignature(params)
+		if _, err := v.Validate(params, signature); err == nil || errors.Is(err, ErrSequenceNotIncreasing) {
+			t.Errorf("%v: 序号格式错误应返回错误，实际 %v", seq, err)
+		}
+	}
+}
+
+func TestMemorySequenceStore_Concurrent(t *testing.T) {
+	store := NewMemorySequenceStore()
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	accepted := 0
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if store.Advance("a", 1) {
+				mu.Lock()
+				accepted++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if accepted != 1 {
+		t.Errorf("同一序号被接受 %d 次，期望 1 次", accepted)
+	}
+}
@@ -0,0 +1,79 @@
+package signvalidator
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/huangchunlong818/sign-chao/pkg/signvalidator/internal/chacha20poly1305"
+)
+
+// nonceSize 随机数的字节数，编码为 32 个十六进制字符
+const nonceSize = 16
+
+// SignRequest 复制 params，补充时间戳、随机数与密钥标识（配置了 KeyID 且密钥标识不经 Authorization 请求头传递时）参数后生成签名，返回包含签名参数的完整参数，用于客户端发起请求。
+// 时间戳参数名为 TimestampKey（默认 "timestamp"），按 TimestampUnit 取当前时间；随机数参数名为 NonceKey，
+// 值为密码学安全随机数的十六进制字符串（CHACHA20_POLY1305 时为 12 字节）；params 中已有的时间戳、随机数与密钥标识保持不变
+func (v *SignValidator) SignRequest(params map[string]interface{}) (map[string]interface{}, error) {
+	signed, signature, err := v.SignRequestDetached(params)
+	if err != nil {
+		return nil, err
+	}
+	signed[v.config.SignatureKey] = signature
+	return signed, nil
+}
+
+// SignRequestDetached 与 SignRequest 相同地补充参数并生成签名，但签名单独返回、不写入参数，
+// 用于签名放在请求头或 gRPC 元数据中的场景
+func (v *SignValidator) SignRequestDetached(params map[string]interface{}) (map[string]interface{}, string, error) {
+	if v.err != nil {
+		return nil, "", v.err
+	}
+
+	signed, err := v.stamp(params)
+	if err != nil {
+		return nil, "", err
+	}
+	signature, err := v.GenerateSignature(signed)
+	if err != nil {
+		return nil, "", err
+	}
+	return signed, signature, nil
+}
+
+// stamp 复制 params 并补充缺失的密钥标识、时间戳与随机数参数
+func (v *SignValidator) stamp(params map[string]interface{}) (map[string]interface{}, error) {
+	signed := make(map[string]interface{}, len(params)+3)
+	for k, value := range params {
+		signed[k] = value
+	}
+	if v.config.KeyID != "" && v.config.Authorization == nil {
+		if _, exists := v.lookup(signed, v.config.KeyIDKey); !exists {
+			signed[v.config.KeyIDKey] = v.config.KeyID
+		}
+	}
+	if _, exists := v.lookup(signed, v.timestampKey()); !exists {
+		signed[v.timestampKey()] = v.formatTimestamp(v.now())
+	}
+	if _, exists := v.lookup(signed, v.config.NonceKey); !exists {
+		nonce, err := v.newNonce()
+		if err != nil {
+			return nil, err
+		}
+		signed[v.config.NonceKey] = nonce
+	}
+	return signed, nil
+}
+
+// newNonce 生成随机数参数的值
+func (v *SignValidator) newNonce() (string, error) {
+	size := nonceSize
+	if v.config.Algorithm == CHACHA20_POLY1305 {
+		size = chacha20poly1305.NonceSize
+	}
+	nonce := make([]byte, size)
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("生成随机数失败: %w", err)
+	}
+	return hex.EncodeToString(nonce), nil
+}
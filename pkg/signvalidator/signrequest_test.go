@@ -0,0 +1,85 @@
+package signvalidator
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSignRequest(t *testing.T) {
+	clock := NewFakeClock(time.Unix(1700000000, 0))
+	v := NewSignValidator(Config{
+		Secret:       "secret",
+		Algorithm:    HMAC_SHA256,
+		TimestampKey: "ts",
+		Clock:        clock,
+	})
+	params := map[string]interface{}{"order_id": "1001"}
+
+	signed, err := v.SignRequest(params)
+	if err != nil {
+		t.Fatalf("签名请求失败: %v", err)
+	}
+	if len(params) != 1 {
+		t.Errorf("不应修改传入的参数: %v", params)
+	}
+	if signed["ts"] != "1700000000" {
+		t.Errorf("时间戳 = %v, 期望 1700000000", signed["ts"])
+	}
+	nonce, _ := signed["nonce"].(string)
+	if len(nonce) != 2*nonceSize {
+		t.Errorf("随机数长度 = %d, 期望 %d", len(nonce), 2*nonceSize)
+	}
+
+	valid, err := v.ValidateWithSignInParams(signed)
+	if err != nil || !valid {
+		t.Errorf("签名验证应通过，valid=%v err=%v", valid, err)
+	}
+
+	again, _ := v.SignRequest(params)
+	if again["nonce"] == signed["nonce"] {
+		t.Error("每次签名应生成不同的随机数")
+	}
+}
+
+func TestSignRequest_KeepExisting(t *testing.T) {
+	v := NewSignValidator(Config{Secret: "secret", TimestampUnit: TimestampMillis, NonceKey: "nonce_str"})
+	signed, err := v.SignRequest(map[string]interface{}{"timestamp": 1, "nonce_str": "fixed"})
+	if err != nil {
+		t.Fatalf("签名请求失败: %v", err)
+	}
+	if signed["timestamp"] != 1 || signed["nonce_str"] != "fixed" {
+		t.Errorf("已有的时间戳与随机数应保持不变: %v", signed)
+	}
+	if _, exists := signed["nonce"]; exists {
+		t.Error("应使用 NonceKey 作为随机数参数名")
+	}
+}
+
+func TestSignRequest_Poly1305(t *testing.T) {
+	skipFIPS(t)
+	v := NewSignValidator(Config{Secret: "0123456789abcdef0123456789abcdef", Algorithm: CHACHA20_POLY1305})
+	signed, err := v.SignRequest(map[string]interface{}{"a": 1})
+	if err != nil {
+		t.Fatalf("签名请求失败: %v", err)
+	}
+	if valid, err := v.ValidateWithSignInParams(signed); err != nil || !valid {
+		t.Errorf("签名验证应通过，valid=%v err=%v", valid, err)
+	}
+}
+
+func TestSignRequestDetached(t *testing.T) {
+	v := NewSignValidator(Config{Secret: "secret", Algorithm: HMAC_SHA256, TimestampKey: "ts"})
+	signed, signature, err := v.SignRequestDetached(map[string]interface{}{"order_id": "1001"})
+	if err != nil {
+		t.Fatalf("签名请求失败: %v", err)
+	}
+	if _, exists := signed["sign"]; exists {
+		t.Error("签名不应写入参数")
+	}
+	if signed["ts"] == nil || signed["nonce"] == nil {
+		t.Errorf("应补充时间戳与随机数: %v", signed)
+	}
+	if valid, err := v.Validate(signed, signature); err != nil || !valid {
+		t.Errorf("签名应验证通过: valid=%v, err=%v", valid, err)
+	}
+}
@@ -2,17 +2,29 @@
 package signvalidator
 
 import (
+	"context"
+	"crypto"
+	"crypto/aes"
 	"crypto/hmac"
 	"crypto/md5"
 	"crypto/sha1"
 	"crypto/sha256"
+	"crypto/sha3"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"hash"
+	"math/big"
 	"sort"
 	"strings"
+	"time"
+
+	"github.com/huangchunlong818/sign-chao/pkg/signvalidator/internal/blake2b"
+	"github.com/huangchunlong818/sign-chao/pkg/signvalidator/internal/blake3"
+	"github.com/huangchunlong818/sign-chao/pkg/signvalidator/internal/chacha20poly1305"
+	"github.com/huangchunlong818/sign-chao/pkg/signvalidator/internal/cmac"
+	"github.com/huangchunlong818/sign-chao/pkg/signvalidator/internal/sm3"
 )
 
 // SignAlgorithm 表示签名算法类型
@@ -31,6 +43,91 @@ const (
 	HMAC_SHA1 SignAlgorithm = "hmac_sha1"
 	// HMAC_SHA256 算法
 	HMAC_SHA256 SignAlgorithm = "hmac_sha256"
+	// SM3 国密杂凑算法
+	SM3 SignAlgorithm = "sm3"
+	// HMAC_SM3 基于 SM3 的 HMAC 算法
+	HMAC_SM3 SignAlgorithm = "hmac_sm3"
+	// SM2 国密非对称签名算法（SM2withSM3），本地私钥签名不是常数时间的，服务端签名建议通过 Signer 使用 KMS 或 HSM
+	SM2 SignAlgorithm = "sm2"
+	// RSA_SHA256 RSA 签名算法（SHA256withRSA，即支付宝 RSA2）
+	RSA_SHA256 SignAlgorithm = "rsa_sha256"
+	// RSA_PSS_SHA256 RSASSA-PSS 签名算法（SHA-256）
+	RSA_PSS_SHA256 SignAlgorithm = "rsa_pss_sha256"
+	// RSA_PSS_SHA512 RSASSA-PSS 签名算法（SHA-512）
+	RSA_PSS_SHA512 SignAlgorithm = "rsa_pss_sha512"
+	// ECDSA_P256_SHA256 ECDSA 签名算法（P-256 曲线，SHA-256）
+	ECDSA_P256_SHA256 SignAlgorithm = "ecdsa_p256_sha256"
+	// ECDSA_P384_SHA384 ECDSA 签名算法（P-384 曲线，SHA-384）
+	ECDSA_P384_SHA384 SignAlgorithm = "ecdsa_p384_sha384"
+	// ED25519 Ed25519 签名算法
+	ED25519 SignAlgorithm = "ed25519"
+	// BLAKE2B_256 BLAKE2b-256 哈希算法
+	BLAKE2B_256 SignAlgorithm = "blake2b_256"
+	// BLAKE2B_512 BLAKE2b-512 哈希算法
+	BLAKE2B_512 SignAlgorithm = "blake2b_512"
+	// KEYED_BLAKE2B_256 带密钥的 BLAKE2b-256，Secret 作为 MAC 密钥而非追加到待签名字符串
+	KEYED_BLAKE2B_256 SignAlgorithm = "keyed_blake2b_256"
+	// KEYED_BLAKE2B_512 带密钥的 BLAKE2b-512，Secret 作为 MAC 密钥而非追加到待签名字符串
+	KEYED_BLAKE2B_512 SignAlgorithm = "keyed_blake2b_512"
+	// BLAKE3 BLAKE3 哈希算法
+	BLAKE3 SignAlgorithm = "blake3"
+	// KEYED_BLAKE3 带密钥的 BLAKE3，Secret 必须为 32 字节，作为 MAC 密钥而非追加到待签名字符串
+	KEYED_BLAKE3 SignAlgorithm = "keyed_blake3"
+	// SHA3_256 SHA3-256 哈希算法
+	SHA3_256 SignAlgorithm = "sha3_256"
+	// SHA3_512 SHA3-512 哈希算法
+	SHA3_512 SignAlgorithm = "sha3_512"
+	// HMAC_SHA3_256 基于 SHA3-256 的 HMAC 算法
+	HMAC_SHA3_256 SignAlgorithm = "hmac_sha3_256"
+	// HMAC_SHA3_512 基于 SHA3-512 的 HMAC 算法
+	HMAC_SHA3_512 SignAlgorithm = "hmac_sha3_512"
+	// CMAC_AES128 AES-128-CMAC 算法，Secret 为 16 字节 AES 密钥
+	CMAC_AES128 SignAlgorithm = "cmac_aes128"
+	// CMAC_AES256 AES-256-CMAC 算法，Secret 为 32 字节 AES 密钥
+	CMAC_AES256 SignAlgorithm = "cmac_aes256"
+	// CRC32C CRC-32C 校验和，非密码学算法，仅用于检测意外篡改，需要开启 AllowInsecure
+	CRC32C SignAlgorithm = "crc32c"
+	// XXHASH64 xxHash64 校验和，非密码学算法，仅用于检测意外篡改，需要开启 AllowInsecure
+	XXHASH64 SignAlgorithm = "xxhash64"
+	// CHACHA20_POLY1305 ChaCha20-Poly1305 认证标签，Secret 为 32 字节密钥，
+	// 待签名字符串作为附加数据，nonce 取自 NonceKey 参数（24 位十六进制）
+	CHACHA20_POLY1305 SignAlgorithm = "chacha20_poly1305"
+)
+
+// URLEncodeMode 表示待签名字符串中参数的百分号编码方式
+type URLEncodeMode string
+
+const (
+	// URLEncodeNone 不编码（默认）
+	URLEncodeNone URLEncodeMode = ""
+	// URLEncodeValues 仅编码参数值
+	URLEncodeValues URLEncodeMode = "values"
+	// URLEncodeKeys 仅编码参数名
+	URLEncodeKeys URLEncodeMode = "keys"
+	// URLEncodeAll 同时编码参数名和参数值
+	URLEncodeAll URLEncodeMode = "all"
+)
+
+// KeyCase 表示参数名的大小写规范化方式
+type KeyCase string
+
+const (
+	// KeyPreserve 保留参数名原样（默认）
+	KeyPreserve KeyCase = "preserve"
+	// KeyLower 参数名转换为小写后排序拼接
+	KeyLower KeyCase = "lower"
+)
+
+// SecretJoinMode 表示密钥追加到待签名字符串的方式
+type SecretJoinMode string
+
+const (
+	// SecretJoinAmpersand 以 "&key=secret" 形式追加（默认）
+	SecretJoinAmpersand SecretJoinMode = "ampersand"
+	// SecretJoinRaw 直接拼接在参数字符串之后，不带分隔符和 "key="
+	SecretJoinRaw SecretJoinMode = "raw"
+	// SecretJoinNone 不追加密钥
+	SecretJoinNone SecretJoinMode = "none"
 )
 
 // Validator 签名验证器接口
@@ -41,133 +138,892 @@ type Validator interface {
 	GenerateSignature(params map[string]interface{}) (string, error)
 }
 
+// Verifier 签名验证接口，非对称算法下仅需公钥即可使用
+type Verifier interface {
+	// Validate 验证签名是否有效
+	Validate(params map[string]interface{}, signature string) (bool, error)
+	// ValidateWithSignInParams 从参数中提取签名并验证
+	ValidateWithSignInParams(params map[string]interface{}) (bool, error)
+}
+
+// MACBackend 远程计算 MAC 的后端，密钥保存在后端中，不进入进程
+type MACBackend interface {
+	// MAC 使用 algorithm 对 data 计算 MAC，不支持的算法应返回错误
+	MAC(algorithm SignAlgorithm, data []byte) ([]byte, error)
+}
+
 // Config 签名验证器配置
 type Config struct {
 	// Secret 密钥
 	Secret string
+	// SecretProvider 按请求参数动态查找签名密钥，设置后生成与验证签名时使用其返回的密钥代替 Secret（不经过 Stretch）
+	SecretProvider SecretProvider
+	// Secrets 多个可信客户端的命名密钥，验证签名时依次尝试，ValidateSecrets 返回验证通过的密钥名称；生成签名仍使用 Secret
+	Secrets []Secret
+	// PreviousSecrets 轮换前的旧密钥，验证签名时在各自的 ExpiresAt 之前仍被接受，生成签名始终使用 Secret
+	PreviousSecrets []PreviousSecret
+	// Stretch 将低熵口令 Secret 拉伸为签名密钥的配置（PBKDF2 或 scrypt），为空时直接使用 Secret
+	Stretch *SecretStretch
+	// HKDF 从 Secret 派生各上下文独立签名密钥的配置，为空时直接使用 Secret
+	HKDF *HKDF
 	// Algorithm 签名算法
 	Algorithm SignAlgorithm
 	// SignatureKey 签名参数名
 	SignatureKey string
-	// IgnoreKeys 在签名计算中忽略的参数名列表
+	// SignatureHeader 签名请求头（如 "X-Signature"），设置后 SignHTTPRequest 将签名写入该请求头，而非 SignatureKey 查询参数；
+	// 也是 VerifyHTTPResponse 读取的签名响应头，为空时为 "X-Signature"
+	SignatureHeader string
+	// SignatureSources 验证 HTTP 请求时依次查找签名的位置（请求头、查询参数、表单字段或 JSON 字段），取第一个非空值，
+	// 优先于 SignatureHeader 与 SignatureKey 参数
+	SignatureSources []ValueSource
+	// TimestampSources 验证 HTTP 请求时依次查找时间戳的位置，找到的值作为 TimestampKey 参数参与签名与时间戳检查
+	TimestampSources []ValueSource
+	// NonceSources 验证 HTTP 请求时依次查找随机数的位置，找到的值作为 NonceKey 参数参与签名与随机数检查
+	NonceSources []ValueSource
+	// Authorization Authorization 请求头的签名格式（如 "ACS app_id:signature"），设置后验证 HTTP 请求时从该请求头读取签名与密钥标识，
+	// SignHTTPRequest 以 KeyID 与签名生成该请求头
+	Authorization *AuthorizationScheme
+	// ContentDigest 请求体摘要请求头（RFC 9530 Content-Digest 或 Digest）配置，设置后 SignHTTPRequest 生成该请求头，
+	// 验证 HTTP 请求时检查其与请求体一致，参数签名模式下其值作为 ContentDigest.Key 参数参与签名
+	ContentDigest *ContentDigest
+	// NonceKey 随机数参数名，默认为 "nonce"
+	NonceKey string
+	// ExpiresKey 预签名链接（SignURL、VerifySignedURL）的过期时间参数名，默认为 "expires"
+	ExpiresKey string
+	// AlgorithmKey 签名算法参数名（如 "sign_type"），设置后按请求参数选择签名算法，参数缺失时使用 Algorithm
+	AlgorithmKey string
+	// AllowedAlgorithms 通过 AlgorithmKey 协商时允许的算法列表，为空时仅允许 Algorithm
+	AllowedAlgorithms []SignAlgorithm
+	// AcceptAlgorithms 验证时按顺序尝试的候选算法列表，为空时仅使用 Algorithm；生成签名始终使用 Algorithm
+	AcceptAlgorithms []SignAlgorithm
+	// FIPS 是否启用 FIPS 模式，启用后只允许 FIPS 批准的算法；使用 fips 构建标签编译时始终启用
+	FIPS bool
+	// AllowInsecure 是否允许使用 CRC32C、XXHASH64 等非密码学校验算法
+	AllowInsecure bool
+	// Policy 算法安全策略，创建时检查 Algorithm、AllowedAlgorithms 与 AcceptAlgorithms，协商时检查请求指定的算法
+	Policy *Policy
+	// Rounds 在签名结果上依次追加的摘要轮次，每轮对上一轮结果的十六进制字符串计算摘要
+	Rounds []DigestRound
+	// PairSeparator 待签名字符串中参数对之间的分隔符，为 nil 时默认为 "&"，可设为空字符串
+	PairSeparator *string
+	// KVSeparator 待签名字符串中参数名与值之间的分隔符，为 nil 时默认为 "="，可设为空字符串
+	KVSeparator *string
+	// CanonicalRequest 签名 HTTP 请求（GenerateRequestSignature、ValidateRequest）时的规范化配置，为空时使用默认配置
+	CanonicalRequest *CanonicalRequest
+	// MaxBodyBytes 验证或签名 HTTP 请求时读取请求体的最大字节数，默认为 DefaultMaxBodyBytes（4 MiB），为负数时不限制；
+	// 请求体在验证签名前读入内存，超过限制时返回 ErrBodyTooLarge
+	MaxBodyBytes int64
+	// CanonicalBody 签名请求体（GenerateBodySignature、ValidateBody）前是否按 RFC 8785 重新编码 JSON，默认使用原始字节
+	CanonicalBody bool
+	// SignedPaths 请求体签名时仅提取并签名的 JSON 路径（如 "$.order.id"、"$.items[0].sku"），设置后以去掉 "$." 的路径为参数名、
+	// 路径对应的值为参数值按参数规则签名，信封中可变的字段不参与签名；路径不存在时返回错误
+	SignedPaths []string
+	// Template 待签名字符串模板（如 "{method}\n{path}\n{params}\n{secret}"），设置后按模板展开，
+	// {params} 为排序拼接后的参数，{secret} 为密钥，其余占位符取同名参数的值
+	Template string
+	// Components 待签名字符串的组成部分（如 []string{"method", "path", "params"}），设置后各部分按
+	// ComponentSeparator 连接，名称含义与 Template 占位符相同，不能与 Template 同时设置
+	Components []string
+	// ComponentSeparator 组成部分之间的分隔符，为 nil 时默认为 "\n"
+	ComponentSeparator *string
+	// SecretJoin 密钥追加到待签名字符串的方式，默认为 SecretJoinAmpersand
+	SecretJoin SecretJoinMode
+	// MultiValue 多值参数（[]string，如 url.Values 中重复的参数）的拼接方式，默认序列化为 JSON 数组
+	MultiValue MultiValueMode
+	// Compat 兼容其他语言签名实现的预设（如 CompatPHP），未单独设置的相关选项使用预设值
+	Compat Compat
+	// URLEncode 拼接前对参数名和/或参数值进行 RFC 3986 百分号编码的方式，默认不编码
+	URLEncode URLEncodeMode
+	// TrimValues 是否去除参数值首尾的空白字符
+	TrimValues bool
+	// NormalizeNewlines 是否将参数值中的 "\r\n" 与 "\r" 统一为 "\n"
+	NormalizeNewlines bool
+	// NormalizeUnicode 是否将参数值规范化为 Unicode NFC 形式，使 iOS 等客户端发送的 NFD 字符串签名一致
+	NormalizeUnicode bool
+	// ValueEncoder 自定义参数值编码函数，可按参数名或类型覆盖默认转换（如金额格式化为整数分），
+	// 返回 ErrUseDefaultEncoding 时使用默认转换
+	ValueEncoder func(key string, value interface{}) (string, error)
+	// NilMode nil 参数值的处理方式，默认为 NilEmpty
+	NilMode NilMode
+	// BoolFormat 布尔参数值的格式，默认为 BoolLower
+	BoolFormat BoolFormat
+	// BytesEncoding []byte 参数值的编码方式，为空时序列化为带引号的 JSON Base64 字符串
+	BytesEncoding BytesEncoding
+	// FloatFormat 浮点数参数值的格式，为空时使用 "%.6f"
+	FloatFormat *FloatFormat
+	// UseStringer 实现 fmt.Stringer 的参数值（如 decimal.Decimal）是否使用 String() 的结果，
+	// 使金额等数值按发送方的格式参与签名，而不经过 float64 或 JSON 序列化
+	UseStringer bool
+	// CanonicalJSON 复杂类型（map、slice、struct）的参数值是否按 RFC 8785 规范化 JSON 序列化，默认使用 json.Marshal
+	CanonicalJSON bool
+	// SortFunc 参数名排序比较函数，a 应排在 b 之前时返回 true，为空时按字节序排序
+	SortFunc func(a, b string) bool
+	// KeyCase 参数名的大小写规范化方式，默认为 KeyPreserve
+	KeyCase KeyCase
+	// CaseInsensitiveKeys 比较参数名（签名参数、忽略的参数、模板占位符）时是否忽略大小写
+	CaseInsensitiveKeys bool
+	// SkipEmptyValues 是否在签名计算中跳过值转换为字符串后为空的参数
+	SkipEmptyValues bool
+	// TimestampKey 时间戳参数名（如 "timestamp"），设置后验证签名时检查该参数与当前时间的偏差不超过 MaxSkew，
+	// 超出时返回 ErrTimestampExpired，参数缺失时返回 *MissingKeysError
+	TimestampKey string
+	// TimestampUnit 时间戳参数的单位，默认为 TimestampSeconds
+	TimestampUnit TimestampUnit
+	// MaxSkew 允许的时间偏差（过去与未来方向），默认为 5 分钟
+	MaxSkew time.Duration
+	// Clock 时间戳校验、随机数过期、令牌与预签名链接使用的时钟，默认为 SystemClock，测试时可使用 FakeClock
+	Clock Clock
+	// NonceStore 随机数存储，设置后签名验证通过时检查 NonceKey 参数是否已被使用，重复时返回 ErrNonceReused
+	NonceStore NonceStore
+	// NonceTTL 随机数的保存时间，默认为 MaxSkew 的两倍（未设置 MaxSkew 时为 10 分钟）
+	NonceTTL time.Duration
+	// SignatureStore 一次性签名存储，设置后签名验证通过时记录签名的摘要，窗口内重复提交同一签名返回 ErrSignatureReused，
+	// 用于调用方未使用随机数时保护非幂等接口
+	SignatureStore NonceStore
+	// SignatureTTL 签名摘要的保存时间，默认与 NonceTTL 的默认值相同
+	SignatureTTL time.Duration
+	// SequenceStore 序号存储，设置后签名验证通过时要求 SequenceKey 参数大于同一调用方已接受的序号，
+	// 否则返回 ErrSequenceNotIncreasing，用于以递增计数器代替随机数的协议
+	SequenceStore SequenceStore
+	// SequenceKey 序号参数名（十进制无符号整数），默认为 "seq"
+	SequenceKey string
+	// SequenceScopeKey 区分调用方的参数名（如 "app_id"），每个取值的序号单独递增；为空时所有请求共用一个序号
+	SequenceScopeKey string
+	// FailureLimit 签名验证失败次数限制，调用方在统计窗口内签名无效的次数达到上限后，请求直接返回 ErrTooManyFailures
+	FailureLimit *FailureLimit
+	// OnVerificationFailed 签名不匹配、格式错误、缺少参数或失败次数过多时调用
+	OnVerificationFailed func(event SecurityEvent)
+	// OnReplayDetected 随机数、一次性签名重复使用或序号未递增时调用
+	OnReplayDetected func(event SecurityEvent)
+	// OnExpired 时间戳超出偏差、预签名链接或令牌过期时调用
+	OnExpired func(event SecurityEvent)
+	// RequiredKeys 必需的参数名列表，生成或验证签名时参数缺失或值为空返回 *MissingKeysError
+	RequiredKeys []string
+	// IncludeKeys 参与签名计算的参数名白名单，设置后仅列表中的参数参与签名，IgnoreKeys 仍然生效
+	IncludeKeys []string
+	// IgnoreKeys 在签名计算中忽略的参数名列表，支持通配符（如 "debug_*"）和以 "re:" 开头的正则表达式
 	IgnoreKeys []string
-	// UpperCase 签名是否使用大写
+	// Encoding 签名结果的编码方式，默认为 EncodingHex
+	Encoding SignatureEncoding
+	// UpperCase 十六进制签名是否使用大写
 	UpperCase bool
+	// TruncateLength 签名截断长度（字符数），大于 0 时生成和验证都只使用签名的前 TruncateLength 个字符，
+	// 不适用于非对称算法
+	TruncateLength int
+	// SignaturePrefix 签名前缀（如 GitHub Webhook 的 "sha256="），生成时添加在签名之前，验证时签名必须以该前缀开头
+	SignaturePrefix string
+	// PrivateKey 私钥，非对称算法生成签名时使用
+	PrivateKey crypto.PrivateKey
+	// PublicKey 公钥，非对称算法验证签名时使用，为空时从 Signer 或 PrivateKey 推导
+	PublicKey crypto.PublicKey
+	// KeyProvider 验证公钥提供者（如 JWKSProvider），设置后按 KeyIDKey 参数选择公钥，优先于 PublicKey 使用
+	KeyProvider KeyProvider
+	// KeyIDKey 密钥标识参数名，默认为 "kid"
+	KeyIDKey string
+	// Keys 密钥环，键为密钥标识；设置后验证签名时按 KeyIDKey 参数（或 KeyIDHeader 请求头）选择密钥，
+	// 生成签名使用 KeyID 对应的密钥，此时不能设置 Secret
+	Keys map[string]string
+	// KeyID 当前密钥的标识，生成签名时使用，SignRequest 会将其写入 KeyIDKey 参数
+	KeyID string
+	// KeyIDHeader 密钥标识请求头（如 "X-Key-Id"），验证 HTTP 请求且 KeyIDKey 参数缺失时读取
+	KeyIDHeader string
+	// Signer 签名器（如 HSM、智能卡中的密钥），非对称算法生成签名时优先于 PrivateKey 使用。
+	// SM2 与 Ed25519 算法传入 Sign 的是原始消息，其余算法传入的是摘要
+	Signer crypto.Signer
+	// MACBackend 远程 MAC 后端（如 KMS、Vault），设置后对称算法的签名由后端计算，进程内无需持有 Secret
+	MACBackend MACBackend
+	// PrivateKeyPEM PEM 或 Base64 编码的私钥，PrivateKey 为空时解析使用
+	PrivateKeyPEM string
+	// PublicKeyPEM PEM 或 Base64 编码的公钥，PublicKey 为空时解析使用
+	PublicKeyPEM string
+	// PSSSaltLength RSA-PSS 盐长度（字节），0 表示与摘要长度相同，PSSSaltLengthAuto 表示验证时自动识别
+	PSSSaltLength int
+	// ECDSAFormat ECDSA 签名格式，默认为 ECDSAFormatASN1
+	ECDSAFormat ECDSAFormat
 }
 
 // SignValidator 签名验证器实现
 type SignValidator struct {
 	config Config
+	// template 解析后的待签名字符串模板，未配置 Template 时为空
+	template []templateSegment
+	// ignorePatterns IgnoreKeys 中的通配符与正则表达式
+	ignorePatterns []ignorePattern
+	// paths 解析后的 SignedPaths
+	paths []jsonPath
+	// order 调用方指定的参数顺序，仅在使用有序参数签名或验证时设置
+	order []string
+	// request 规范化后的 HTTP 请求，仅在签名或验证 HTTP 请求时设置
+	request *canonicalRequest
+	// ctx 传给 SecretProvider 的上下文，仅在配置了 SecretProvider 时设置
+	ctx context.Context
+	// headerKeyID 验证 HTTP 请求时 KeyIDHeader 请求头（配置了密钥环时）或 Authorization 请求头中的密钥标识
+	headerKeyID string
+	// remoteAddr 请求的来源地址，仅在配置了安全事件回调且验证 HTTP 请求时设置
+	remoteAddr string
+	// failures 各调用方的签名失败次数，仅在配置了 FailureLimit 时设置，在副本之间共享
+	failures *failureTracker
+	// trace 记录签名过程的详细信息，仅在 GenerateSignatureWithDetails 时设置
+	trace *SignatureDetails
+	// err 创建时的配置错误，在生成或验证签名时返回
+	err error
+}
+
+// New 创建新的签名验证器，配置无效时返回错误
+func New(config Config) (*SignValidator, error) {
+	v := NewSignValidator(config)
+	if v.err != nil {
+		return nil, v.err
+	}
+	return v, nil
 }
 
-// NewSignValidator 创建新的签名验证器
+// NewSignValidator 创建新的签名验证器，配置错误会在生成或验证签名时返回
 func NewSignValidator(config Config) *SignValidator {
 	// 如果没有指定签名参数名，默认为 "sign"
 	if config.SignatureKey == "" {
 		config.SignatureKey = "sign"
 	}
 
+	// 如果没有指定随机数参数名，默认为 "nonce"
+	if config.NonceKey == "" {
+		config.NonceKey = "nonce"
+	}
+
+	// 如果没有指定密钥标识参数名，默认为 "kid"
+	if config.KeyIDKey == "" {
+		config.KeyIDKey = "kid"
+	}
+
+	// 如果没有指定请求体大小限制，默认为 4 MiB
+	if config.MaxBodyBytes == 0 {
+		config.MaxBodyBytes = DefaultMaxBodyBytes
+	}
+
 	// 如果没有指定算法，默认为 MD5
 	if config.Algorithm == "" {
 		config.Algorithm = SHA256
 	}
 
-	return &SignValidator{
+	v := &SignValidator{
 		config: config,
 	}
+	v.err = v.init()
+	return v
+}
+
+// init 解析算法名称、检查算法策略并解析配置中的密钥材料
+func (v *SignValidator) init() error {
+	if err := v.applyCompat(); err != nil {
+		return err
+	}
+	if err := v.parseAlgorithms(); err != nil {
+		return err
+	}
+	if err := v.checkAlgorithm(v.config.Algorithm); err != nil {
+		return err
+	}
+	for _, algorithms := range [][]SignAlgorithm{v.config.AllowedAlgorithms, v.config.AcceptAlgorithms} {
+		for _, algorithm := range algorithms {
+			if err := v.checkAlgorithm(algorithm); err != nil {
+				return err
+			}
+		}
+	}
+	switch v.config.KeyCase {
+	case "", KeyPreserve, KeyLower:
+	default:
+		return fmt.Errorf("不支持的参数名大小写方式: %s", v.config.KeyCase)
+	}
+	switch v.config.MultiValue {
+	case MultiValueJSON, MultiValueRepeat, MultiValueComma, MultiValueIndex:
+	default:
+		return fmt.Errorf("不支持的多值参数拼接方式: %s", v.config.MultiValue)
+	}
+	switch v.config.SecretJoin {
+	case "", SecretJoinAmpersand, SecretJoinRaw, SecretJoinNone:
+	default:
+		return fmt.Errorf("不支持的密钥拼接方式: %s", v.config.SecretJoin)
+	}
+	switch v.config.URLEncode {
+	case URLEncodeNone, URLEncodeValues, URLEncodeKeys, URLEncodeAll:
+	default:
+		return fmt.Errorf("不支持的参数编码方式: %s", v.config.URLEncode)
+	}
+	if err := v.checkTimestampConfig(); err != nil {
+		return err
+	}
+	if err := v.checkEncoding(); err != nil {
+		return err
+	}
+	if err := v.compileIgnoreKeys(); err != nil {
+		return err
+	}
+	if err := v.checkValueFormat(); err != nil {
+		return err
+	}
+	if err := v.checkCanonicalRequest(); err != nil {
+		return err
+	}
+	if err := v.checkSources(); err != nil {
+		return err
+	}
+	if err := v.checkAuthorization(); err != nil {
+		return err
+	}
+	if err := v.checkContentDigest(); err != nil {
+		return err
+	}
+	if err := v.compileSignedPaths(); err != nil {
+		return err
+	}
+	if err := v.initTemplate(); err != nil {
+		return err
+	}
+	if err := v.checkRounds(); err != nil {
+		return err
+	}
+	if err := v.checkKeyRing(); err != nil {
+		return err
+	}
+	if err := v.checkSecretProvider(); err != nil {
+		return err
+	}
+	if err := v.checkSecrets(); err != nil {
+		return err
+	}
+	if err := v.checkPreviousSecrets(); err != nil {
+		return err
+	}
+	if err := v.stretchSecret(); err != nil {
+		return err
+	}
+	if err := v.checkHKDF(); err != nil {
+		return err
+	}
+	if err := v.checkFailureLimit(); err != nil {
+		return err
+	}
+
+	if v.config.PrivateKey == nil && v.config.PrivateKeyPEM != "" {
+		key, err := ParsePrivateKey(v.config.PrivateKeyPEM)
+		if err != nil {
+			return fmt.Errorf("解析私钥失败: %w", err)
+		}
+		v.config.PrivateKey = key
+	}
+
+	if v.config.PublicKey == nil && v.config.PublicKeyPEM != "" {
+		key, err := ParsePublicKey(v.config.PublicKeyPEM)
+		if err != nil {
+			return fmt.Errorf("解析公钥失败: %w", err)
+		}
+		v.config.PublicKey = key
+	}
+
+	return nil
 }
 
 // Validate 验证签名是否有效
 func (v *SignValidator) Validate(params map[string]interface{}, signature string) (bool, error) {
-	expectedSign, err := v.GenerateSignature(params)
+	_, valid, err := v.ValidateAlgorithms(params, signature)
+	return valid, err
+}
+
+// ValidateAlgorithms 验证签名是否有效，并返回验证通过的签名算法。
+// 配置了 AcceptAlgorithms 且请求未通过 AlgorithmKey 指定算法时，按顺序尝试各候选算法
+func (v *SignValidator) ValidateAlgorithms(params map[string]interface{}, signature string) (SignAlgorithm, bool, error) {
+	if v.err != nil {
+		return "", false, v.err
+	}
+	algorithm, _, valid, err := v.validateAlgorithms(params, signature)
+	if !valid {
+		v.report(params, err)
+	}
+	return algorithm, valid, err
+}
+
+// validateAlgorithms 依次使用候选密钥与算法验证签名，返回验证通过的算法与密钥名称，验证通过后检查随机数、一次性签名与序号
+func (v *SignValidator) validateAlgorithms(params map[string]interface{}, signature string) (SignAlgorithm, string, bool, error) {
+	if err := v.checkFailures(params); err != nil {
+		return "", "", false, err
+	}
+	if err := v.checkRequiredKeys(params); err != nil {
+		return "", "", false, err
+	}
+	if err := v.checkTimestamp(params); err != nil {
+		return "", "", false, err
+	}
+
+	candidates, err := v.candidates(params)
+	if err != nil {
+		return "", "", false, err
+	}
+
+	v, err = v.withProvidedKey(params)
+	if err != nil {
+		return "", "", false, err
+	}
+	v, err = v.withKeyRing(params)
+	if err != nil {
+		return "", "", false, err
+	}
+	v, err = v.withProvidedSecret(params)
+	if err != nil {
+		return "", "", false, err
+	}
+
+	var firstErr error
+	for _, secret := range v.verificationSecrets() {
+		s, err := v.withSecret(secret.Value).withContextSecret(params)
+		if err != nil {
+			return "", "", false, err
+		}
+		for _, algorithm := range candidates {
+			stringToSign, valid, err := s.withAlgorithm(algorithm).validate(params, signature)
+			if err != nil {
+				// 记录错误并继续尝试其他候选算法与密钥
+				if firstErr == nil {
+					firstErr = err
+				}
+				continue
+			}
+			if !valid {
+				continue
+			}
+
+			// 签名有效后再记录随机数、签名与序号，避免无效请求占用随机数或推进序号
+			if err := v.checkNonce(params); err != nil {
+				return "", "", false, err
+			}
+			if err := v.checkSignatureReuse(params, stringToSign); err != nil {
+				return "", "", false, err
+			}
+			if err := v.checkSequence(params); err != nil {
+				return "", "", false, err
+			}
+			return algorithm, secret.Name, true, nil
+		}
+	}
+
+	v.recordFailure(params)
+	return "", "", false, firstErr
+}
+
+// validate 使用当前算法验证签名，返回待签名字符串
+func (v *SignValidator) validate(params map[string]interface{}, signature string) (string, bool, error) {
+	stringToSign, err := v.buildStringToSign(params)
+	if err != nil {
+		return "", false, err
+	}
+	valid, err := v.validateString(params, stringToSign, signature)
+	return stringToSign, valid, err
+}
+
+// validateString 验证待签名字符串的签名，params 用于读取 nonce 等算法参数
+func (v *SignValidator) validateString(params map[string]interface{}, stringToSign, signature string) (bool, error) {
+	// 非对称算法无法重新生成签名，需要使用公钥验证
+	if isAsymmetric(v.config.Algorithm) {
+		return v.verifyAsymmetric(stringToSign, signature)
+	}
+
+	expectedSign, err := v.sign(params, stringToSign)
 	if err != nil {
 		return false, err
 	}
 
-	return expectedSign == signature, nil
+	return hmac.Equal([]byte(expectedSign), []byte(signature)), nil
+}
+
+// SignatureKey 返回签名参数名，即 Config.SignatureKey（默认为 "sign"）
+func (v *SignValidator) SignatureKey() string {
+	return v.config.SignatureKey
 }
 
 // GenerateSignature 生成签名
 func (v *SignValidator) GenerateSignature(params map[string]interface{}) (string, error) {
-	// 创建参数副本，避免修改原始参数
-	paramsCopy := make(map[string]interface{})
-	for k, v := range params {
-		paramsCopy[k] = v
+	if v.err != nil {
+		return "", v.err
 	}
 
-	// 移除签名参数和忽略的参数
-	delete(paramsCopy, v.config.SignatureKey)
-	for _, key := range v.config.IgnoreKeys {
-		delete(paramsCopy, key)
+	if err := v.checkRequiredKeys(params); err != nil {
+		return "", err
 	}
 
-	// 按键排序
-	keys := make([]string, 0, len(paramsCopy))
-	for k := range paramsCopy {
-		keys = append(keys, k)
+	algorithm, err := v.negotiate(params)
+	if err != nil {
+		return "", err
+	}
+
+	v, err = v.withProvidedSecret(params)
+	if err != nil {
+		return "", err
+	}
+	v, err = v.withAlgorithm(algorithm).withContextSecret(params)
+	if err != nil {
+		return "", err
+	}
+	stringToSign, err := v.buildStringToSign(params)
+	if err != nil {
+		return "", err
+	}
+	if v.trace != nil {
+		v.trace.Algorithm = v.config.Algorithm
+		v.trace.StringToSign = stringToSign
+	}
+	return v.sign(params, stringToSign)
+}
+
+// buildStringToSign 构建待签名字符串
+func (v *SignValidator) buildStringToSign(params map[string]interface{}) (string, error) {
+	if v.template != nil && v.request == nil {
+		return v.expandTemplate(params)
 	}
-	sort.Strings(keys)
 
-	// 构建待签名字符串
 	var builder strings.Builder
-	for i, key := range keys {
-		if i > 0 {
-			builder.WriteString("&")
+	if v.request != nil {
+		builder.WriteString(v.request.canonical)
+	} else {
+		paramString, err := v.buildParamString(params, nil)
+		if err != nil {
+			return "", err
 		}
-		builder.WriteString(key)
-		builder.WriteString("=")
-		builder.WriteString(convertToString(paramsCopy[key]))
+		builder.WriteString(paramString)
 	}
 
-	// 如果有密钥，添加到字符串末尾
-	if v.config.Secret != "" {
-		builder.WriteString("&key=")
+	v.appendSecret(&builder)
+	return builder.String(), nil
+}
+
+// appendSecret 按 SecretJoin 将密钥追加到待签名字符串末尾（非对称算法和带密钥哈希算法不追加）
+func (v *SignValidator) appendSecret(builder *strings.Builder) {
+	if v.config.Secret == "" || !appendsSecret(v.config.Algorithm) {
+		return
+	}
+	pairSeparator, kvSeparator := v.separators()
+	switch v.config.SecretJoin {
+	case SecretJoinRaw:
+		builder.WriteString(v.config.Secret)
+	case SecretJoinNone:
+	default:
+		builder.WriteString(pairSeparator)
+		builder.WriteString("key")
+		builder.WriteString(kvSeparator)
 		builder.WriteString(v.config.Secret)
 	}
+}
+
+// buildParamString 将排序后的参数拼接为字符串，exclude 中的参数不参与拼接
+func (v *SignValidator) buildParamString(params map[string]interface{}, exclude []string) (string, error) {
+	// 移除签名参数和忽略的参数，转换参数值，按需跳过空值
+	values := make(map[string][]queryPair, len(params))
+	for k, value := range params {
+		if v.isExcludedKey(k, exclude) {
+			continue
+		}
+		if value == nil && v.config.NilMode == NilSkip {
+			continue
+		}
+
+		var pairs []queryPair
+		if multi, ok := value.([]string); ok && v.config.MultiValue != MultiValueJSON {
+			var err error
+			if pairs, err = v.expandMultiValue(k, multi); err != nil {
+				return "", err
+			}
+		} else if v.config.Compat == CompatPHP && isPHPArray(value) {
+			var err error
+			if pairs, err = v.flattenPHP(nil, k, value); err != nil {
+				return "", err
+			}
+		} else {
+			s, err := v.formatValue(k, value)
+			if err != nil {
+				return "", err
+			}
+			if s == "" && v.config.SkipEmptyValues {
+				continue
+			}
+			pairs = []queryPair{{key: k, value: s}}
+		}
+
+		if v.config.KeyCase == KeyLower {
+			k = strings.ToLower(k)
+			if _, exists := values[k]; exists {
+				return "", fmt.Errorf("参数名 %s 转换为小写后重复", k)
+			}
+			for i := range pairs {
+				pairs[i].key = strings.ToLower(pairs[i].key)
+			}
+		}
+		values[k] = pairs
+	}
+
+	// 按键排序，调用方指定顺序时按给定顺序
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	if v.order != nil {
+		keys = keys[:0]
+		for _, k := range v.order {
+			if v.config.KeyCase == KeyLower {
+				k = strings.ToLower(k)
+			}
+			if _, exists := values[k]; exists {
+				keys = append(keys, k)
+			}
+		}
+	} else if v.config.SortFunc != nil {
+		sort.SliceStable(keys, func(i, j int) bool { return v.config.SortFunc(keys[i], keys[j]) })
+	} else {
+		sort.Strings(keys)
+	}
+
+	if v.trace != nil {
+		v.trace.record(keys, values)
+	}
+
+	// 构建参数字符串
+	pairSeparator, kvSeparator := v.separators()
+	var builder strings.Builder
+	encodeKeys := v.config.URLEncode == URLEncodeKeys || v.config.URLEncode == URLEncodeAll
+	encodeValues := v.config.URLEncode == URLEncodeValues || v.config.URLEncode == URLEncodeAll
+	first := true
+	for _, k := range keys {
+		for _, pair := range values[k] {
+			if !first {
+				builder.WriteString(pairSeparator)
+			}
+			first = false
+			key, value := pair.key, pair.value
+			if encodeKeys {
+				key = v.escape(key)
+			}
+			if encodeValues {
+				value = v.escape(value)
+			}
+			builder.WriteString(key)
+			builder.WriteString(kvSeparator)
+			builder.WriteString(value)
+		}
+	}
+
+	return builder.String(), nil
+}
+
+// queryPair 待拼接的参数名与参数值
+type queryPair struct {
+	key   string
+	value string
+}
+
+// SortByLength 先按参数名长度、再按字节序排序，可作为 SortFunc 使用
+func SortByLength(a, b string) bool {
+	if len(a) != len(b) {
+		return len(a) < len(b)
+	}
+	return a < b
+}
+
+// SortCaseInsensitive 忽略大小写按字节序排序，可作为 SortFunc 使用
+func SortCaseInsensitive(a, b string) bool {
+	if la, lb := strings.ToLower(a), strings.ToLower(b); la != lb {
+		return la < lb
+	}
+	return a < b
+}
+
+// isExcludedKey 判断参数是否为签名参数、忽略的参数、不在 IncludeKeys 中的参数或 exclude 中的参数
+func (v *SignValidator) isExcludedKey(key string, exclude []string) bool {
+	if v.sameKey(key, v.config.SignatureKey) {
+		return true
+	}
+	if len(v.config.IncludeKeys) > 0 && !v.isIncludedKey(key) {
+		return true
+	}
+	for _, ignored := range v.config.IgnoreKeys {
+		if v.sameKey(key, ignored) {
+			return true
+		}
+	}
+	if v.ignoreKey(key) {
+		return true
+	}
+	for _, excluded := range exclude {
+		if v.sameKey(key, excluded) {
+			return true
+		}
+	}
+	return false
+}
+
+// isIncludedKey 判断参数是否在 IncludeKeys 中
+func (v *SignValidator) isIncludedKey(key string) bool {
+	for _, included := range v.config.IncludeKeys {
+		if v.sameKey(key, included) {
+			return true
+		}
+	}
+	return false
+}
+
+// sameKey 比较两个参数名，开启 CaseInsensitiveKeys 时忽略大小写
+func (v *SignValidator) sameKey(a, b string) bool {
+	if v.config.CaseInsensitiveKeys {
+		return strings.EqualFold(a, b)
+	}
+	return a == b
+}
+
+// lookup 按参数名查找参数值，开启 CaseInsensitiveKeys 时忽略大小写
+func (v *SignValidator) lookup(params map[string]interface{}, key string) (interface{}, bool) {
+	if value, exists := params[key]; exists || !v.config.CaseInsensitiveKeys {
+		return value, exists
+	}
+	for k, value := range params {
+		if strings.EqualFold(k, key) {
+			return value, true
+		}
+	}
+	return nil, false
+}
+
+// separators 返回参数对分隔符与键值分隔符，未配置时使用 "&" 与 "="
+func (v *SignValidator) separators() (string, string) {
+	pairSeparator, kvSeparator := "&", "="
+	if v.config.PairSeparator != nil {
+		pairSeparator = *v.config.PairSeparator
+	}
+	if v.config.KVSeparator != nil {
+		kvSeparator = *v.config.KVSeparator
+	}
+	return pairSeparator, kvSeparator
+}
+
+// percentEncode 按 RFC 3986 编码字符串，仅保留非保留字符 A-Z a-z 0-9 - . _ ~
+func percentEncode(s string) string {
+	const hexDigits = "0123456789ABCDEF"
+	var builder strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || c == '-' || c == '.' || c == '_' || c == '~' {
+			builder.WriteByte(c)
+			continue
+		}
+		builder.WriteByte('%')
+		builder.WriteByte(hexDigits[c>>4])
+		builder.WriteByte(hexDigits[c&0x0f])
+	}
+	return builder.String()
+}
+
+// Separator 返回分隔符的指针，用于设置 PairSeparator 与 KVSeparator（包括空字符串）
+func Separator(s string) *string {
+	return &s
+}
+
+// sign 对待签名字符串计算签名，params 用于读取 nonce 等算法参数
+func (v *SignValidator) sign(params map[string]interface{}, stringToSign string) (string, error) {
+	var signBytes []byte
+	var err error
+
+	if v.config.MACBackend != nil && !isAsymmetric(v.config.Algorithm) {
+		// 由远程后端计算 MAC，密钥不进入进程
+		signBytes, err = v.config.MACBackend.MAC(v.config.Algorithm, []byte(stringToSign))
+	} else {
+		signBytes, err = v.calculate(params, stringToSign)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	// 按配置追加摘要轮次
+	if signBytes, err = v.applyRounds(signBytes); err != nil {
+		return "", err
+	}
 
-	stringToSign := builder.String()
+	return v.encodeSignature(signBytes), nil
+}
 
-	// 根据算法计算签名
+// calculate 根据算法在本地计算签名
+func (v *SignValidator) calculate(params map[string]interface{}, stringToSign string) ([]byte, error) {
 	var signBytes []byte
 	var err error
 
 	switch v.config.Algorithm {
-	case MD5:
-		signBytes, err = calculateHash(md5.New(), stringToSign)
-	case SHA1:
-		signBytes, err = calculateHash(sha1.New(), stringToSign)
-	case SHA256:
-		signBytes, err = calculateHash(sha256.New(), stringToSign)
+	case MD5, SHA1, SHA256, SM3, CRC32C, XXHASH64, SHA3_256, SHA3_512, BLAKE2B_256, BLAKE2B_512, BLAKE3:
+		var h hash.Hash
+		if h, err = newDigest(v.config.Algorithm); err == nil {
+			signBytes, err = calculateHash(h, stringToSign)
+		}
+	case KEYED_BLAKE2B_256, KEYED_BLAKE2B_512:
+		if v.config.Secret == "" {
+			return nil, fmt.Errorf("签名算法 %s 需要配置密钥", v.config.Algorithm)
+		}
+		size := blake2b.Size256
+		if v.config.Algorithm == KEYED_BLAKE2B_512 {
+			size = blake2b.Size
+		}
+		signBytes, err = calculateBLAKE2b(size, []byte(v.config.Secret), stringToSign)
+	case KEYED_BLAKE3:
+		var h hash.Hash
+		if h, err = blake3.NewKeyed([]byte(v.config.Secret)); err == nil {
+			signBytes, err = calculateHash(h, stringToSign)
+		}
 	case HMAC_MD5:
 		signBytes, err = calculateHMAC(md5.New, []byte(v.config.Secret), stringToSign)
 	case HMAC_SHA1:
 		signBytes, err = calculateHMAC(sha1.New, []byte(v.config.Secret), stringToSign)
 	case HMAC_SHA256:
 		signBytes, err = calculateHMAC(sha256.New, []byte(v.config.Secret), stringToSign)
+	case HMAC_SM3:
+		signBytes, err = calculateHMAC(sm3.New, []byte(v.config.Secret), stringToSign)
+	case HMAC_SHA3_256:
+		signBytes, err = calculateHMAC(newSHA3_256, []byte(v.config.Secret), stringToSign)
+	case HMAC_SHA3_512:
+		signBytes, err = calculateHMAC(newSHA3_512, []byte(v.config.Secret), stringToSign)
+	case CMAC_AES128, CMAC_AES256:
+		signBytes, err = v.calculateCMAC(stringToSign)
+	case CHACHA20_POLY1305:
+		signBytes, err = v.calculatePoly1305(params, stringToSign)
+	case SM2, RSA_SHA256, RSA_PSS_SHA256, RSA_PSS_SHA512, ECDSA_P256_SHA256, ECDSA_P384_SHA384, ED25519:
+		signBytes, err = v.signAsymmetric(stringToSign)
 	default:
-		return "", fmt.Errorf("不支持的签名算法: %s", v.config.Algorithm)
-	}
-
-	if err != nil {
-		return "", err
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedAlgorithm, v.config.Algorithm)
 	}
 
-	// 转换为十六进制字符串
-	signature := hex.EncodeToString(signBytes)
+	return signBytes, err
+}
 
-	// 根据配置转换大小写
-	if v.config.UpperCase {
+// encodeSignature 将签名结果编码为字符串
+func (v *SignValidator) encodeSignature(signBytes []byte) string {
+	// 按配置编码，十六进制签名根据配置转换大小写
+	signature := v.config.Encoding.encode(signBytes)
+	if v.config.Encoding.isHex() && v.config.UpperCase {
 		signature = strings.ToUpper(signature)
-	} else {
-		signature = strings.ToLower(signature)
 	}
 
-	return signature, nil
+	// 根据配置截断签名
+	if v.config.TruncateLength > 0 && len(signature) > v.config.TruncateLength {
+		signature = signature[:v.config.TruncateLength]
+	}
+
+	return v.config.SignaturePrefix + signature
+}
+
+// decodeSignature 将签名字符串解码为字节
+func (v *SignValidator) decodeSignature(signature string) ([]byte, error) {
+	signature, ok := strings.CutPrefix(signature, v.config.SignaturePrefix)
+	if !ok {
+		return nil, errors.New("签名缺少前缀")
+	}
+	return v.config.Encoding.decode(signature)
 }
 
 // ValidateWithSignInParams 从参数中提取签名并验证
 func (v *SignValidator) ValidateWithSignInParams(params map[string]interface{}) (bool, error) {
-	signValue, exists := params[v.config.SignatureKey]
+	signValue, exists := v.lookup(params, v.config.SignatureKey)
 	if !exists {
 		return false, errors.New("签名参数不存在")
 	}
@@ -189,6 +1045,66 @@ func calculateHash(h hash.Hash, data string) ([]byte, error) {
 	return h.Sum(nil), nil
 }
 
+// newSHA3_256 以 hash.Hash 形式创建 SHA3-256 实例，供 HMAC 使用
+func newSHA3_256() hash.Hash { return sha3.New256() }
+
+// newSHA3_512 以 hash.Hash 形式创建 SHA3-512 实例，供 HMAC 使用
+func newSHA3_512() hash.Hash { return sha3.New512() }
+
+// 计算BLAKE2b值，key 非空时为带密钥模式
+func calculateBLAKE2b(size int, key []byte, data string) ([]byte, error) {
+	h, err := blake2b.New(size, key)
+	if err != nil {
+		return nil, err
+	}
+	return calculateHash(h, data)
+}
+
+// calculateCMAC 使用 Secret 作为 AES 密钥计算CMAC值
+func (v *SignValidator) calculateCMAC(data string) ([]byte, error) {
+	keySize := 16
+	if v.config.Algorithm == CMAC_AES256 {
+		keySize = 32
+	}
+	if len(v.config.Secret) != keySize {
+		return nil, fmt.Errorf("签名算法 %s 需要 %d 字节的密钥", v.config.Algorithm, keySize)
+	}
+
+	block, err := aes.NewCipher([]byte(v.config.Secret))
+	if err != nil {
+		return nil, err
+	}
+	h, err := cmac.New(block)
+	if err != nil {
+		return nil, err
+	}
+	return calculateHash(h, data)
+}
+
+// calculatePoly1305 使用 Secret 作为密钥、NonceKey 参数作为 nonce 计算 ChaCha20-Poly1305 认证标签
+func (v *SignValidator) calculatePoly1305(params map[string]interface{}, data string) ([]byte, error) {
+	nonceValue, exists := v.lookup(params, v.config.NonceKey)
+	if !exists {
+		return nil, fmt.Errorf("签名算法 %s 需要 %s 参数", v.config.Algorithm, v.config.NonceKey)
+	}
+
+	nonce, err := hex.DecodeString(convertToString(nonceValue))
+	if err != nil || len(nonce) != chacha20poly1305.NonceSize {
+		return nil, fmt.Errorf("%s 参数必须为 %d 字节的十六进制字符串", v.config.NonceKey, chacha20poly1305.NonceSize)
+	}
+
+	return chacha20poly1305.Tag([]byte(v.config.Secret), nonce, []byte(data))
+}
+
+// appendsSecret 判断算法是否需要将密钥以 "&key=" 形式追加到待签名字符串
+func appendsSecret(algorithm SignAlgorithm) bool {
+	switch algorithm {
+	case KEYED_BLAKE2B_256, KEYED_BLAKE2B_512, KEYED_BLAKE3, CMAC_AES128, CMAC_AES256, CHACHA20_POLY1305:
+		return false
+	}
+	return !isAsymmetric(algorithm)
+}
+
 // 计算HMAC值
 func calculateHMAC(hashFunc func() hash.Hash, key []byte, data string) ([]byte, error) {
 	h := hmac.New(hashFunc, key)
@@ -215,6 +1131,22 @@ func convertToString(value interface{}) string {
 		return "false"
 	case nil:
 		return ""
+	case json.Number:
+		return v.String()
+	case *big.Int:
+		if v == nil {
+			return ""
+		}
+		return v.String()
+	case big.Int:
+		return v.String()
+	case *big.Float:
+		if v == nil {
+			return ""
+		}
+		return v.Text('f', -1)
+	case big.Float:
+		return v.Text('f', -1)
 	default:
 		// 尝试使用 JSON 序列化复杂类型
 		jsonBytes, err := json.Marshal(v)
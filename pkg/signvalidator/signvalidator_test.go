@@ -1,10 +1,18 @@
 package signvalidator
 
 import (
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
 	"testing"
 )
 
 func TestSignValidator_MD5(t *testing.T) {
+	skipFIPS(t)
 	config := Config{
 		Secret:       "testSecret",
 		Algorithm:    MD5,
@@ -84,7 +92,323 @@ func TestSignValidator_HMAC_SHA256(t *testing.T) {
 	}
 }
 
+func TestSignValidator_SM3(t *testing.T) {
+	skipFIPS(t)
+	params := map[string]interface{}{
+		"id":     123,
+		"name":   "test",
+		"amount": 100.50,
+	}
+
+	testCases := []struct {
+		algorithm SignAlgorithm
+		expected  string
+	}{
+		{SM3, "3831b6663d11368909f9b6613a660315495e53441d2b9d2aea72660b1232f93c"},
+		{HMAC_SM3, "60554117ff68a8bf1f856b952ed0751fe154b944bdb9b5b7768d27dd1602170e"},
+	}
+
+	for _, tc := range testCases {
+		validator := NewSignValidator(Config{
+			Secret:    "testSecret",
+			Algorithm: tc.algorithm,
+		})
+
+		signature, err := validator.GenerateSignature(params)
+		if err != nil {
+			t.Fatalf("生成签名失败: %v", err)
+		}
+
+		if signature != tc.expected {
+			t.Errorf("%s 签名 = %s, 期望 %s", tc.algorithm, signature, tc.expected)
+		}
+	}
+}
+
+func TestSignValidator_SHA3(t *testing.T) {
+	params := map[string]interface{}{
+		"id":     123,
+		"name":   "test",
+		"amount": 100.50,
+	}
+
+	testCases := []struct {
+		algorithm SignAlgorithm
+		expected  string
+	}{
+		{SHA3_256, "92201a1272465a316043c1ed75c233c3f39978d7a9c7d52cd0fa79f612846d85"},
+		{SHA3_512, "d6ecfd24ed64a691ad179a41528053f867757a098648236537f622205a5e584fb639dc3757e5b88e7d783a00f663f490856f31fca135bc121f3a132ee28618fa"},
+		{HMAC_SHA3_256, "dc24e87eba466d4f4f90d575a79fecc96253bd84b3e8ae87ef40f2c3c2a73aa0"},
+		{HMAC_SHA3_512, "ef8a674a990fcdde90f7b1827bac3396ae6649d2f00666b8481d3168bca372a7de5f04abec94eb8a741a96a4cd23f719c9cb5473869a16172145a750f95fd221"},
+	}
+
+	for _, tc := range testCases {
+		validator := NewSignValidator(Config{
+			Secret:    "testSecret",
+			Algorithm: tc.algorithm,
+		})
+
+		signature, err := validator.GenerateSignature(params)
+		if err != nil {
+			t.Fatalf("生成签名失败: %v", err)
+		}
+
+		if signature != tc.expected {
+			t.Errorf("%s 签名 = %s, 期望 %s", tc.algorithm, signature, tc.expected)
+		}
+	}
+}
+
+func TestSignValidator_BLAKE2b(t *testing.T) {
+	skipFIPS(t)
+	params := map[string]interface{}{
+		"id":     123,
+		"name":   "test",
+		"amount": 100.50,
+	}
+
+	testCases := []struct {
+		algorithm SignAlgorithm
+		expected  string
+	}{
+		{BLAKE2B_256, "7ae60ce8d95a67e1b36da6d43030dc7b992f666e442057b201edbdca12a2b6a3"},
+		{BLAKE2B_512, "9d675c1618ea981b8292547e4ce6d71e37c4098536a5f44b48a71ecc8d35fab3f0087a69eb41951d1828cfc1980e16a7446de57e25c82a7dc9c67a5a551bafa2"},
+		// 带密钥模式不追加 "&key="
+		{KEYED_BLAKE2B_256, "edfc32cfbd8d51c9d667dc49ac2d1307cbd7cbb6d76ccb7b9bc65bde37d8cac3"},
+		{KEYED_BLAKE2B_512, "b629e5ca0a7de181c3a0aa12f85fa9552c3d6ff0b94274fc9a60d5a446eb6fa22260d803720074404d59b40650dfbc78d6e005d2cf766563062a8c75f8226d27"},
+	}
+
+	for _, tc := range testCases {
+		validator := NewSignValidator(Config{
+			Secret:    "testSecret",
+			Algorithm: tc.algorithm,
+		})
+
+		signature, err := validator.GenerateSignature(params)
+		if err != nil {
+			t.Fatalf("生成签名失败: %v", err)
+		}
+
+		if signature != tc.expected {
+			t.Errorf("%s 签名 = %s, 期望 %s", tc.algorithm, signature, tc.expected)
+		}
+	}
+
+	// 带密钥模式必须配置密钥
+	validator := NewSignValidator(Config{Algorithm: KEYED_BLAKE2B_256})
+	if _, err := validator.GenerateSignature(params); err == nil {
+		t.Errorf("未配置密钥时应该返回错误")
+	}
+}
+
+func TestSignValidator_BLAKE3(t *testing.T) {
+	skipFIPS(t)
+	params := map[string]interface{}{
+		"id":     123,
+		"name":   "test",
+		"amount": 100.50,
+	}
+
+	hashValidator := NewSignValidator(Config{
+		Secret:    "testSecret",
+		Algorithm: BLAKE3,
+	})
+	signature, err := hashValidator.GenerateSignature(params)
+	if err != nil {
+		t.Fatalf("生成签名失败: %v", err)
+	}
+	if len(signature) != 64 {
+		t.Errorf("签名长度 = %d, 期望 64", len(signature))
+	}
+
+	keyedValidator := NewSignValidator(Config{
+		Secret:    "0123456789abcdef0123456789abcdef",
+		Algorithm: KEYED_BLAKE3,
+	})
+	keyedSignature, err := keyedValidator.GenerateSignature(params)
+	if err != nil {
+		t.Fatalf("生成签名失败: %v", err)
+	}
+
+	valid, err := keyedValidator.Validate(params, keyedSignature)
+	if err != nil {
+		t.Fatalf("验证签名失败: %v", err)
+	}
+	if !valid {
+		t.Errorf("签名验证失败")
+	}
+
+	// 密钥不同时签名不同
+	otherValidator := NewSignValidator(Config{
+		Secret:    "fedcba9876543210fedcba9876543210",
+		Algorithm: KEYED_BLAKE3,
+	})
+	valid, err = otherValidator.Validate(params, keyedSignature)
+	if err != nil {
+		t.Fatalf("验证签名失败: %v", err)
+	}
+	if valid {
+		t.Errorf("使用不同密钥验证应该失败，但通过了")
+	}
+
+	// 密钥长度必须为 32 字节
+	invalidValidator := NewSignValidator(Config{
+		Secret:    "testSecret",
+		Algorithm: KEYED_BLAKE3,
+	})
+	if _, err := invalidValidator.GenerateSignature(params); err == nil {
+		t.Errorf("密钥长度无效时应该返回错误")
+	}
+}
+
+func TestSignValidator_CMAC(t *testing.T) {
+	params := map[string]interface{}{
+		"id":     123,
+		"name":   "test",
+		"amount": 100.50,
+	}
+
+	testCases := []struct {
+		algorithm SignAlgorithm
+		secret    string
+		expected  string
+	}{
+		{CMAC_AES128, "0123456789abcdef", "36da981e92cc8c715184834a23f40d03"},
+		{CMAC_AES256, "0123456789abcdef0123456789abcdef", "56e0bac20facc37c60afd082d35fb30b"},
+	}
+
+	for _, tc := range testCases {
+		validator := NewSignValidator(Config{
+			Secret:    tc.secret,
+			Algorithm: tc.algorithm,
+		})
+
+		signature, err := validator.GenerateSignature(params)
+		if err != nil {
+			t.Fatalf("生成签名失败: %v", err)
+		}
+
+		if signature != tc.expected {
+			t.Errorf("%s 签名 = %s, 期望 %s", tc.algorithm, signature, tc.expected)
+		}
+	}
+
+	// 密钥长度必须与算法匹配
+	validator := NewSignValidator(Config{
+		Secret:    "0123456789abcdef",
+		Algorithm: CMAC_AES256,
+	})
+	if _, err := validator.GenerateSignature(params); err == nil {
+		t.Errorf("密钥长度无效时应该返回错误")
+	}
+}
+
+func TestSignValidator_ChaCha20Poly1305_CaseInsensitive(t *testing.T) {
+	skipFIPS(t)
+	validator := NewSignValidator(Config{
+		Secret:              "0123456789abcdef0123456789abcdef",
+		Algorithm:           CHACHA20_POLY1305,
+		CaseInsensitiveKeys: true,
+	})
+
+	// 大小写不同的随机数参数同样作为 nonce
+	params := map[string]interface{}{"id": 123, "NONCE": "000102030405060708090a0b"}
+	signature, err := validator.GenerateSignature(params)
+	if err != nil {
+		t.Fatalf("生成签名失败: %v", err)
+	}
+	if valid, err := validator.Validate(params, signature); err != nil || !valid {
+		t.Errorf("签名验证失败: valid=%v, err=%v", valid, err)
+	}
+}
+
+func TestSignValidator_ChaCha20Poly1305(t *testing.T) {
+	skipFIPS(t)
+	validator := NewSignValidator(Config{
+		Secret:    "0123456789abcdef0123456789abcdef",
+		Algorithm: CHACHA20_POLY1305,
+	})
+
+	params := map[string]interface{}{
+		"id":     123,
+		"name":   "test",
+		"amount": 100.50,
+		"nonce":  "000102030405060708090a0b",
+	}
+
+	// 与 ChaCha20-Poly1305 Seal(nil, nonce, nil, stringToSign) 结果一致
+	signature, err := validator.GenerateSignature(params)
+	if err != nil {
+		t.Fatalf("生成签名失败: %v", err)
+	}
+	if signature != "79b26952b9d557ec07100e329ecafc3e" {
+		t.Errorf("签名 = %s", signature)
+	}
+
+	// 更换 nonce 后签名不同
+	params["nonce"] = "0b0a09080706050403020100"
+	valid, err := validator.Validate(params, signature)
+	if err != nil {
+		t.Fatalf("验证签名失败: %v", err)
+	}
+	if valid {
+		t.Errorf("nonce 被修改后验证应该失败，但通过了")
+	}
+
+	// nonce 缺失或格式错误时返回错误
+	delete(params, "nonce")
+	if _, err := validator.GenerateSignature(params); err == nil {
+		t.Errorf("缺少 nonce 时应该返回错误")
+	}
+	params["nonce"] = "short"
+	if _, err := validator.GenerateSignature(params); err == nil {
+		t.Errorf("nonce 格式错误时应该返回错误")
+	}
+}
+
+func TestSignValidator_Checksum(t *testing.T) {
+	skipFIPS(t)
+	params := map[string]interface{}{
+		"id":     123,
+		"name":   "test",
+		"amount": 100.50,
+	}
+
+	testCases := []struct {
+		algorithm SignAlgorithm
+		expected  string
+	}{
+		{CRC32C, "4c0c8731"},
+		{XXHASH64, "f885e1a16ff09ca0"},
+	}
+
+	for _, tc := range testCases {
+		validator := NewSignValidator(Config{
+			Secret:        "testSecret",
+			Algorithm:     tc.algorithm,
+			AllowInsecure: true,
+		})
+
+		signature, err := validator.GenerateSignature(params)
+		if err != nil {
+			t.Fatalf("生成签名失败: %v", err)
+		}
+		if signature != tc.expected {
+			t.Errorf("%s 签名 = %s, 期望 %s", tc.algorithm, signature, tc.expected)
+		}
+
+		valid, err := validator.Validate(params, signature)
+		if err != nil {
+			t.Fatalf("验证签名失败: %v", err)
+		}
+		if !valid {
+			t.Errorf("%s 签名验证失败", tc.algorithm)
+		}
+	}
+}
+
 func TestSignValidator_IgnoreKeys(t *testing.T) {
+	skipFIPS(t)
 	config := Config{
 		Secret:       "testSecret",
 		Algorithm:    SHA1,
@@ -137,6 +461,386 @@ func TestSignValidator_IgnoreKeys(t *testing.T) {
 	}
 }
 
+func TestSignValidator_TruncateLength(t *testing.T) {
+	params := map[string]interface{}{
+		"id":   123,
+		"name": "test",
+	}
+
+	full, err := NewSignValidator(Config{Secret: "testSecret", Algorithm: HMAC_SHA256}).GenerateSignature(params)
+	if err != nil {
+		t.Fatalf("生成签名失败: %v", err)
+	}
+
+	validator := NewSignValidator(Config{
+		Secret:         "testSecret",
+		Algorithm:      HMAC_SHA256,
+		TruncateLength: 16,
+		UpperCase:      true,
+	})
+
+	signature, err := validator.GenerateSignature(params)
+	if err != nil {
+		t.Fatalf("生成签名失败: %v", err)
+	}
+	if signature != strings.ToUpper(full[:16]) {
+		t.Errorf("截断签名 = %s, 期望 %s", signature, strings.ToUpper(full[:16]))
+	}
+
+	valid, err := validator.Validate(params, signature)
+	if err != nil {
+		t.Fatalf("验证签名失败: %v", err)
+	}
+	if !valid {
+		t.Errorf("截断签名验证失败")
+	}
+
+	// 完整签名与截断后的签名不一致
+	valid, err = validator.Validate(params, strings.ToUpper(full))
+	if err != nil {
+		t.Fatalf("验证签名失败: %v", err)
+	}
+	if valid {
+		t.Errorf("未截断的签名验证应该失败，但通过了")
+	}
+
+	// 非对称算法不支持截断
+	key, err := GenerateSM2Key()
+	if err != nil {
+		t.Fatalf("生成密钥失败: %v", err)
+	}
+	asymmetric := NewSignValidator(Config{Algorithm: SM2, PrivateKey: key, TruncateLength: 16})
+	if _, err := asymmetric.GenerateSignature(params); err == nil {
+		t.Errorf("非对称算法截断签名应该返回错误")
+	}
+}
+
+func TestSignValidator_SignaturePrefix(t *testing.T) {
+	// GitHub Webhook 文档中的示例
+	validator := NewSignValidator(Config{
+		Secret:          "It's a Secret to Everybody",
+		Algorithm:       HMAC_SHA256,
+		SecretJoin:      SecretJoinNone,
+		SignaturePrefix: "sha256=",
+	})
+	want := "sha256=757107ea0eb2509fc211221cce984b8a37570b6d7586c22c46f4379c8b043e17"
+
+	signature, err := validator.GenerateBodySignature([]byte("Hello, World!"))
+	if err != nil {
+		t.Fatalf("生成签名失败: %v", err)
+	}
+	if signature != want {
+		t.Errorf("签名 = %s, 期望 %s", signature, want)
+	}
+
+	valid, err := validator.ValidateBody([]byte("Hello, World!"), want)
+	if err != nil || !valid {
+		t.Errorf("带前缀的签名验证应通过，valid=%v err=%v", valid, err)
+	}
+	valid, err = validator.ValidateBody([]byte("Hello, World!"), strings.TrimPrefix(want, "sha256="))
+	if err != nil || valid {
+		t.Errorf("缺少前缀的签名验证应该失败，valid=%v err=%v", valid, err)
+	}
+}
+
+func TestSignValidator_SignaturePrefixAsymmetric(t *testing.T) {
+	privateKeyPEM, publicKeyPEM, err := GenerateKeyPair(ED25519)
+	if err != nil {
+		t.Fatalf("生成密钥对失败: %v", err)
+	}
+	params := map[string]interface{}{"id": 1}
+
+	signature, err := NewSignValidator(Config{Algorithm: ED25519, PrivateKeyPEM: privateKeyPEM, SignaturePrefix: "v1="}).GenerateSignature(params)
+	if err != nil {
+		t.Fatalf("生成签名失败: %v", err)
+	}
+	if !strings.HasPrefix(signature, "v1=") {
+		t.Errorf("签名应以前缀开头: %s", signature)
+	}
+
+	verifier := NewSignValidator(Config{Algorithm: ED25519, PublicKeyPEM: publicKeyPEM, SignaturePrefix: "v1="})
+	if valid, err := verifier.Validate(params, signature); err != nil || !valid {
+		t.Errorf("签名验证应通过，valid=%v err=%v", valid, err)
+	}
+	if valid, err := verifier.Validate(params, strings.TrimPrefix(signature, "v1=")); err != nil || valid {
+		t.Errorf("缺少前缀的签名验证应该失败，valid=%v err=%v", valid, err)
+	}
+}
+
+// remoteMAC 模拟只在后端持有密钥的 MAC 服务
+type remoteMAC struct {
+	key   []byte
+	calls int
+}
+
+func (r *remoteMAC) MAC(algorithm SignAlgorithm, data []byte) ([]byte, error) {
+	r.calls++
+	if algorithm != HMAC_SHA256 {
+		return nil, ErrUnsupportedAlgorithm
+	}
+	return calculateHMAC(sha256.New, r.key, string(data))
+}
+
+func TestSignValidator_MACBackend(t *testing.T) {
+	backend := &remoteMAC{key: []byte("remoteSecret")}
+	validator := NewSignValidator(Config{Algorithm: HMAC_SHA256, MACBackend: backend})
+
+	params := map[string]interface{}{"id": 123, "name": "test"}
+	signature, err := validator.GenerateSignature(params)
+	if err != nil {
+		t.Fatalf("生成签名失败: %v", err)
+	}
+
+	// 未配置 Secret 时待签名字符串不追加 "&key="
+	expected, _ := calculateHMAC(sha256.New, backend.key, "id=123&name=test")
+	if signature != hex.EncodeToString(expected) {
+		t.Errorf("签名 = %s, 期望 %x", signature, expected)
+	}
+
+	valid, err := validator.Validate(params, signature)
+	if err != nil {
+		t.Fatalf("验证签名失败: %v", err)
+	}
+	if !valid {
+		t.Errorf("签名验证失败")
+	}
+	if backend.calls != 2 {
+		t.Errorf("后端调用次数 = %d, 期望 2", backend.calls)
+	}
+
+	// 后端不支持的算法返回错误
+	if _, err := NewSignValidator(Config{Algorithm: MD5, MACBackend: backend}).GenerateSignature(params); err == nil {
+		t.Errorf("后端不支持的算法应该返回错误")
+	}
+}
+
+func TestSignValidator_Separators(t *testing.T) {
+	skipFIPS(t)
+	params := map[string]interface{}{"id": 123, "name": "test"}
+
+	testCases := []struct {
+		pairSeparator *string
+		kvSeparator   *string
+		expected      string
+	}{
+		{nil, nil, "id=123&name=test&key=testSecret"},
+		{Separator(""), Separator(""), "id123nametestkeytestSecret"},
+		{Separator(","), nil, "id=123,name=test,key=testSecret"},
+		{nil, Separator(":"), "id:123&name:test&key:testSecret"},
+	}
+
+	for _, tc := range testCases {
+		validator := NewSignValidator(Config{
+			Secret:        "testSecret",
+			Algorithm:     MD5,
+			PairSeparator: tc.pairSeparator,
+			KVSeparator:   tc.kvSeparator,
+		})
+		if s, _ := validator.buildStringToSign(params); s != tc.expected {
+			t.Errorf("待签名字符串 = %s, 期望 %s", s, tc.expected)
+		}
+
+		signature, err := validator.GenerateSignature(params)
+		if err != nil {
+			t.Fatalf("生成签名失败: %v", err)
+		}
+		if expected := fmt.Sprintf("%x", md5.Sum([]byte(tc.expected))); signature != expected {
+			t.Errorf("签名 = %s, 期望 %s", signature, expected)
+		}
+	}
+}
+
+func TestSignValidator_SkipEmptyValues(t *testing.T) {
+	skipFIPS(t)
+	params := map[string]interface{}{"id": 123, "memo": "", "extra": nil, "name": "test"}
+
+	validator := NewSignValidator(Config{Secret: "testSecret", Algorithm: MD5, SkipEmptyValues: true})
+	if s, _ := validator.buildStringToSign(params); s != "id=123&name=test&key=testSecret" {
+		t.Errorf("待签名字符串 = %s, 期望跳过空值参数", s)
+	}
+
+	// 跳过空值后与不含空值参数的签名一致
+	signature, err := validator.GenerateSignature(params)
+	if err != nil {
+		t.Fatalf("生成签名失败: %v", err)
+	}
+	valid, err := validator.Validate(map[string]interface{}{"id": 123, "name": "test"}, signature)
+	if err != nil {
+		t.Fatalf("验证签名失败: %v", err)
+	}
+	if !valid {
+		t.Errorf("签名验证失败")
+	}
+
+	// 默认保留空值参数
+	validator = NewSignValidator(Config{Secret: "testSecret", Algorithm: MD5})
+	if s, _ := validator.buildStringToSign(params); s != "extra=&id=123&memo=&name=test&key=testSecret" {
+		t.Errorf("待签名字符串 = %s, 期望保留空值参数", s)
+	}
+}
+
+func TestSignValidator_SecretJoin(t *testing.T) {
+	skipFIPS(t)
+	params := map[string]interface{}{"id": 123, "name": "test"}
+
+	testCases := []struct {
+		mode     SecretJoinMode
+		expected string
+	}{
+		{"", "id=123&name=test&key=testSecret"},
+		{SecretJoinAmpersand, "id=123&name=test&key=testSecret"},
+		{SecretJoinRaw, "id=123&name=testtestSecret"},
+		{SecretJoinNone, "id=123&name=test"},
+	}
+
+	for _, tc := range testCases {
+		validator, err := New(Config{Secret: "testSecret", Algorithm: MD5, SecretJoin: tc.mode})
+		if err != nil {
+			t.Fatalf("创建签名验证器失败: %v", err)
+		}
+		if s, _ := validator.buildStringToSign(params); s != tc.expected {
+			t.Errorf("%q 待签名字符串 = %s, 期望 %s", tc.mode, s, tc.expected)
+		}
+
+		signature, err := validator.GenerateSignature(params)
+		if err != nil {
+			t.Fatalf("生成签名失败: %v", err)
+		}
+		if expected := fmt.Sprintf("%x", md5.Sum([]byte(tc.expected))); signature != expected {
+			t.Errorf("签名 = %s, 期望 %s", signature, expected)
+		}
+	}
+
+	if _, err := New(Config{Secret: "testSecret", SecretJoin: "prefix"}); err == nil {
+		t.Errorf("不支持的拼接方式应该返回错误")
+	}
+}
+
+func TestSignValidator_KeyCase(t *testing.T) {
+	skipFIPS(t)
+	params := map[string]interface{}{"Content-Type": "json", "X-Nonce": "abc", "Sign": "xxx", "Debug": 1}
+
+	validator, err := New(Config{
+		Algorithm:           MD5,
+		KeyCase:             KeyLower,
+		CaseInsensitiveKeys: true,
+		IgnoreKeys:          []string{"debug"},
+	})
+	if err != nil {
+		t.Fatalf("创建签名验证器失败: %v", err)
+	}
+	if s, _ := validator.buildStringToSign(params); s != "content-type=json&x-nonce=abc" {
+		t.Errorf("待签名字符串 = %s", s)
+	}
+
+	// 签名参数名忽略大小写
+	signature, err := validator.GenerateSignature(params)
+	if err != nil {
+		t.Fatalf("生成签名失败: %v", err)
+	}
+	params["Sign"] = signature
+	valid, err := validator.ValidateWithSignInParams(params)
+	if err != nil {
+		t.Fatalf("验证签名失败: %v", err)
+	}
+	if !valid {
+		t.Errorf("签名验证失败")
+	}
+
+	// 默认区分大小写并保留参数名
+	validator = NewSignValidator(Config{Algorithm: MD5, IgnoreKeys: []string{"debug"}})
+	if s, _ := validator.buildStringToSign(params); s != "Content-Type=json&Debug=1&Sign="+signature+"&X-Nonce=abc" {
+		t.Errorf("待签名字符串 = %s", s)
+	}
+
+	// 转换为小写后重复的参数名返回错误
+	validator = NewSignValidator(Config{Algorithm: MD5, KeyCase: KeyLower})
+	if _, err := validator.GenerateSignature(map[string]interface{}{"a": 1, "A": 2}); err == nil {
+		t.Errorf("重复的参数名应该返回错误")
+	}
+
+	if _, err := New(Config{Algorithm: MD5, KeyCase: "upper"}); err == nil {
+		t.Errorf("不支持的大小写方式应该返回错误")
+	}
+}
+
+func TestSignValidator_SortFunc(t *testing.T) {
+	params := map[string]interface{}{"bb": 1, "a": 2, "B": 3, "aaa": 4}
+
+	testCases := []struct {
+		sortFunc func(a, b string) bool
+		expected string
+	}{
+		{nil, "B=3&a=2&aaa=4&bb=1"},
+		{SortByLength, "B=3&a=2&bb=1&aaa=4"},
+		{SortCaseInsensitive, "a=2&aaa=4&B=3&bb=1"},
+		{func(a, b string) bool { return a > b }, "bb=1&aaa=4&a=2&B=3"},
+	}
+
+	for _, tc := range testCases {
+		validator := NewSignValidator(Config{Algorithm: MD5, SortFunc: tc.sortFunc})
+		if s, _ := validator.buildStringToSign(params); s != tc.expected {
+			t.Errorf("待签名字符串 = %s, 期望 %s", s, tc.expected)
+		}
+	}
+}
+
+func TestSignValidator_URLEncode(t *testing.T) {
+	params := map[string]interface{}{"q": "a&b=c d", "名称": "中文~"}
+
+	testCases := []struct {
+		mode     URLEncodeMode
+		expected string
+	}{
+		{URLEncodeNone, "q=a&b=c d&名称=中文~"},
+		{URLEncodeValues, "q=a%26b%3Dc%20d&名称=%E4%B8%AD%E6%96%87~"},
+		{URLEncodeKeys, "q=a&b=c d&%E5%90%8D%E7%A7%B0=中文~"},
+		{URLEncodeAll, "q=a%26b%3Dc%20d&%E5%90%8D%E7%A7%B0=%E4%B8%AD%E6%96%87~"},
+	}
+
+	for _, tc := range testCases {
+		validator, err := New(Config{Algorithm: SHA256, URLEncode: tc.mode})
+		if err != nil {
+			t.Fatalf("创建签名验证器失败: %v", err)
+		}
+		if s, _ := validator.buildStringToSign(params); s != tc.expected {
+			t.Errorf("%q 待签名字符串 = %s, 期望 %s", tc.mode, s, tc.expected)
+		}
+	}
+
+	if _, err := New(Config{Algorithm: SHA256, URLEncode: "query"}); err == nil {
+		t.Errorf("不支持的编码方式应该返回错误")
+	}
+}
+
+func TestSignValidator_JSONNumber(t *testing.T) {
+	skipFIPS(t)
+	// 使用 UseNumber 解码的大整数 ID 与发送方签名一致
+	decoder := json.NewDecoder(strings.NewReader(`{"id":9007199254740993,"name":"test"}`))
+	decoder.UseNumber()
+	var params map[string]interface{}
+	if err := decoder.Decode(&params); err != nil {
+		t.Fatalf("解码参数失败: %v", err)
+	}
+
+	validator := NewSignValidator(Config{Secret: "testSecret", Algorithm: MD5})
+	if s, _ := validator.buildStringToSign(params); s != "id=9007199254740993&name=test&key=testSecret" {
+		t.Errorf("待签名字符串 = %s", s)
+	}
+
+	signature, err := validator.GenerateSignature(map[string]interface{}{"id": uint64(9007199254740993), "name": "test"})
+	if err != nil {
+		t.Fatalf("生成签名失败: %v", err)
+	}
+	valid, err := validator.Validate(params, signature)
+	if err != nil {
+		t.Fatalf("验证签名失败: %v", err)
+	}
+	if !valid {
+		t.Errorf("签名验证失败")
+	}
+}
+
 func TestConvertToString(t *testing.T) {
 	testCases := []struct {
 		input    interface{}
@@ -150,6 +854,12 @@ func TestConvertToString(t *testing.T) {
 		{nil, ""},
 		{[]string{"a", "b"}, `["a","b"]`},
 		{map[string]int{"a": 1, "b": 2}, `{"a":1,"b":2}`},
+		{json.Number("9007199254740993"), "9007199254740993"},
+		{json.Number("99.90"), "99.90"},
+		{new(big.Int).Lsh(big.NewInt(1), 64), "18446744073709551616"},
+		{*big.NewInt(-42), "-42"},
+		{(*big.Int)(nil), ""},
+		{big.NewFloat(99.99), "99.99"},
 	}
 
 	for _, tc := range testCases {
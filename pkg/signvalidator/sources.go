@@ -0,0 +1,196 @@
+package signvalidator
+
+import (
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// SourceKind 表示值在 HTTP 请求中的位置
+type SourceKind string
+
+const (
+	// SourceHeader 请求头
+	SourceHeader SourceKind = "header"
+	// SourceQuery 查询参数
+	SourceQuery SourceKind = "query"
+	// SourceForm application/x-www-form-urlencoded 表单字段
+	SourceForm SourceKind = "form"
+	// SourceJSON JSON 请求体字段
+	SourceJSON SourceKind = "json"
+)
+
+// ValueSource 签名、时间戳或随机数在 HTTP 请求中的位置
+type ValueSource struct {
+	// From 所在位置
+	From SourceKind
+	// Name 请求头名称、查询参数名、表单字段名或 JSON 请求体的顶层字段名；JSON 字段以 "$" 开头时为 JSON 路径，如 "$.auth.sign"
+	Name string
+}
+
+// checkSources 检查 SignatureSources、TimestampSources 与 NonceSources 配置
+func (v *SignValidator) checkSources() error {
+	for field, sources := range map[string][]ValueSource{
+		"SignatureSources": v.config.SignatureSources,
+		"TimestampSources": v.config.TimestampSources,
+		"NonceSources":     v.config.NonceSources,
+	} {
+		for i, source := range sources {
+			if source.Name == "" {
+				return fmt.Errorf("%s 第 %d 项缺少名称", field, i+1)
+			}
+			switch source.From {
+			case SourceHeader, SourceQuery, SourceForm:
+			case SourceJSON:
+				if strings.HasPrefix(source.Name, "$") {
+					if _, err := parseJSONPath(source.Name); err != nil {
+						return fmt.Errorf("%s 第 %d 项: %w", field, i+1, err)
+					}
+				}
+			default:
+				return fmt.Errorf("%s 第 %d 项的位置无效: %s", field, i+1, source.From)
+			}
+		}
+	}
+	return nil
+}
+
+// hasSources 判断是否配置了任一取值位置或 Authorization
+func (v *SignValidator) hasSources() bool {
+	return len(v.config.SignatureSources) > 0 || len(v.config.TimestampSources) > 0 || len(v.config.NonceSources) > 0 ||
+		v.config.Authorization != nil
+}
+
+// applySources 按 TimestampSources 与 NonceSources 将找到的时间戳与随机数写入 params 的 TimestampKey 与 NonceKey 参数，
+// 并按 SignatureSources 返回第一个非空的签名，未找到时为 Authorization 请求头中的签名，均未找到时返回空字符串。值来自查询参数、表单或 JSON 顶层字段时，
+// 与目标参数不同名的原字段从 params 中移除，使其只以目标参数名参与签名
+func (v *SignValidator) applySources(r *http.Request, params map[string]interface{}) (string, error) {
+	reader := &sourceReader{r: r, limit: v.config.MaxBodyBytes}
+	for _, target := range []struct {
+		sources []ValueSource
+		key     string
+	}{
+		{v.config.TimestampSources, v.timestampKey()},
+		{v.config.NonceSources, v.config.NonceKey},
+	} {
+		value, source, err := reader.first(target.sources)
+		if err != nil {
+			return "", err
+		}
+		if value != "" {
+			source.move(params, target.key)
+			params[target.key] = value
+		}
+	}
+
+	signature, source, err := reader.first(v.config.SignatureSources)
+	if err != nil {
+		return "", err
+	}
+	if signature != "" {
+		source.move(params, v.config.SignatureKey)
+		return signature, nil
+	}
+
+	auth, err := v.authorization(r)
+	if err != nil || auth == nil {
+		return "", err
+	}
+	return auth.Signature, nil
+}
+
+// move 值来自参数中的字段且与 key 不同名时，从 params 中移除该字段
+func (s ValueSource) move(params map[string]interface{}, key string) {
+	if s.From != SourceHeader && !strings.HasPrefix(s.Name, "$") && s.Name != key {
+		delete(params, s.Name)
+	}
+}
+
+// sourceReader 按需读取并缓存 HTTP 请求的表单与 JSON 请求体
+type sourceReader struct {
+	r        *http.Request
+	limit    int64
+	form     url.Values
+	document interface{}
+	formRead bool
+	jsonRead bool
+}
+
+// first 返回第一个非空值及其位置
+func (s *sourceReader) first(sources []ValueSource) (string, ValueSource, error) {
+	for _, source := range sources {
+		value, err := s.value(source)
+		if err != nil {
+			return "", ValueSource{}, err
+		}
+		if value != "" {
+			return value, source, nil
+		}
+	}
+	return "", ValueSource{}, nil
+}
+
+// value 返回指定位置的值，请求体不是对应类型时返回空字符串
+func (s *sourceReader) value(source ValueSource) (string, error) {
+	switch source.From {
+	case SourceHeader:
+		return s.r.Header.Get(source.Name), nil
+	case SourceQuery:
+		return s.r.URL.Query().Get(source.Name), nil
+	case SourceForm:
+		if !s.formRead {
+			form, err := readForm(s.r, s.limit)
+			if err != nil && !errors.Is(err, errNotForm) {
+				return "", err
+			}
+			s.form, s.formRead = form, true
+		}
+		return s.form.Get(source.Name), nil
+	case SourceJSON:
+		if !s.jsonRead {
+			if err := s.readJSON(); err != nil {
+				return "", err
+			}
+		}
+		return s.jsonValue(source.Name)
+	}
+	return "", nil
+}
+
+// readJSON 读取 JSON 请求体，Content-Type 不是 JSON 或请求体为空时不读取
+func (s *sourceReader) readJSON() error {
+	s.jsonRead = true
+	mediaType, _, _ := mime.ParseMediaType(s.r.Header.Get("Content-Type"))
+	if mediaType != "application/json" && !strings.HasSuffix(mediaType, "+json") {
+		return nil
+	}
+	body, err := readBody(s.r, s.limit)
+	if err != nil || len(body) == 0 {
+		return err
+	}
+	if s.document, err = decodeJSON(body); err != nil {
+		return fmt.Errorf("请求体不是有效的 JSON: %w", err)
+	}
+	return nil
+}
+
+// jsonValue 返回 JSON 请求体中的字段值，字段不存在或为 null 时返回空字符串
+func (s *sourceReader) jsonValue(name string) (string, error) {
+	var value interface{}
+	if strings.HasPrefix(name, "$") {
+		path, err := parseJSONPath(name)
+		if err != nil {
+			return "", err
+		}
+		value, _ = path.lookup(s.document)
+	} else if object, ok := s.document.(map[string]interface{}); ok {
+		value = object[name]
+	}
+	if value == nil {
+		return "", nil
+	}
+	return convertToString(value), nil
+}
@@ -0,0 +1,105 @@
+package signvalidator
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValueSources(t *testing.T) {
+	v := NewSignValidator(Config{
+		Secret:       "testSecret",
+		Algorithm:    HMAC_SHA256,
+		TimestampKey: "timestamp",
+		NonceStore:   NewMemoryNonceStore(0),
+		Clock:        NewFakeClock(time.Unix(1700000000, 0)),
+		SignatureSources: []ValueSource{
+			{From: SourceHeader, Name: "X-Signature"},
+			{From: SourceJSON, Name: "signature"},
+			{From: SourceForm, Name: "sig"},
+		},
+		TimestampSources: []ValueSource{{From: SourceHeader, Name: "X-Timestamp"}, {From: SourceJSON, Name: "$.meta.ts"}},
+		NonceSources:     []ValueSource{{From: SourceHeader, Name: "X-Nonce"}, {From: SourceQuery, Name: "n"}},
+	})
+	signature, err := v.GenerateSignature(map[string]interface{}{
+		"amount":    "100",
+		"timestamp": "1700000000",
+		"nonce":     "n1",
+	})
+	if err != nil {
+		t.Fatalf("生成签名失败: %v", err)
+	}
+
+	r := httptest.NewRequest("POST", "/orders", strings.NewReader(`{"amount":"100"}`))
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set("X-Signature", signature)
+	r.Header.Set("X-Timestamp", "1700000000")
+	r.Header.Set("X-Nonce", "n1")
+	params, valid, err := v.ValidateRequestParams(r, "")
+	if err != nil || !valid {
+		t.Fatalf("签名取自请求头时应验证通过: valid=%v, err=%v", valid, err)
+	}
+	if params["timestamp"] != "1700000000" || params["nonce"] != "n1" {
+		t.Errorf("时间戳与随机数应写入参数: %v", params)
+	}
+
+	if _, valid, err := v.ValidateRequestParams(r, ""); valid || err == nil {
+		t.Errorf("随机数重复时应返回错误: valid=%v, err=%v", valid, err)
+	}
+
+	signature, _ = v.GenerateSignature(map[string]interface{}{"amount": "100", "timestamp": "1700000000", "nonce": "n2"})
+	r = httptest.NewRequest("POST", "/orders?n=n2", strings.NewReader(`{"amount":"100","signature":"`+signature+`","meta":{"ts":1700000000}}`))
+	r.Header.Set("Content-Type", "application/json")
+	config := v.config
+	config.IgnoreKeys = []string{"meta"}
+	ignoreMeta := NewSignValidator(config)
+	if _, valid, err := ignoreMeta.ValidateRequestParams(r, ""); err != nil || !valid {
+		t.Errorf("签名取自 JSON 字段时应验证通过: valid=%v, err=%v", valid, err)
+	}
+
+	signature, _ = v.GenerateSignature(map[string]interface{}{"amount": "100", "timestamp": "1700000000", "nonce": "n3"})
+	r = httptest.NewRequest("POST", "/orders?n=n3", strings.NewReader("amount=100&sig="+signature))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	r.Header.Set("X-Timestamp", "1700000000")
+	if _, valid, err := v.ValidateRequestParams(r, ""); err != nil || !valid {
+		t.Errorf("签名取自表单字段时应验证通过: valid=%v, err=%v", valid, err)
+	}
+}
+
+func TestValueSources_VerifyHTTPRequest(t *testing.T) {
+	v := NewSignValidator(Config{
+		Secret:           "testSecret",
+		Algorithm:        HMAC_SHA256,
+		CanonicalRequest: &CanonicalRequest{},
+		SignatureSources: []ValueSource{{From: SourceHeader, Name: "Authorization-Signature"}},
+	})
+	r := httptest.NewRequest("GET", "/orders?a=1", nil)
+	signature, err := v.GenerateRequestSignature(r)
+	if err != nil {
+		t.Fatalf("生成签名失败: %v", err)
+	}
+	r.Header.Set("Authorization-Signature", signature)
+	if result, err := v.VerifyHTTPRequest(r); err != nil || !result.Valid {
+		t.Errorf("签名取自配置的请求头时应验证通过: %+v, err=%v", result, err)
+	}
+}
+
+func TestValueSources_Invalid(t *testing.T) {
+	for _, config := range []Config{
+		{Secret: "s", SignatureSources: []ValueSource{{From: SourceHeader}}},
+		{Secret: "s", TimestampSources: []ValueSource{{From: "cookie", Name: "ts"}}},
+		{Secret: "s", NonceSources: []ValueSource{{From: SourceJSON, Name: "$.a["}}},
+	} {
+		if _, err := New(config); err == nil {
+			t.Errorf("%+v: 应返回错误", config)
+		}
+	}
+
+	v := NewSignValidator(Config{Secret: "s", SignatureSources: []ValueSource{{From: SourceJSON, Name: "sign"}}})
+	r := httptest.NewRequest("POST", "/", strings.NewReader("{"))
+	r.Header.Set("Content-Type", "application/json")
+	if _, _, err := v.ValidateRequestParams(r, ""); err == nil {
+		t.Error("无效的 JSON 请求体应返回错误")
+	}
+}
@@ -0,0 +1,109 @@
+package signvalidator
+
+import (
+	"sort"
+	"strings"
+)
+
+// GenerateSignatureStrings 对 map[string]string 参数生成签名，省去 interface{} 转换与类型判断，
+// 结果与 GenerateSignature 相同；配置了需要读取参数或尝试多个密钥的选项（如 AlgorithmKey、HKDF、Keys、Secrets、SecretProvider、RequiredKeys、TimestampKey、NonceStore、FailureLimit、ValueEncoder、模板）时
+// 转换后使用通用流程
+func (v *SignValidator) GenerateSignatureStrings(params map[string]string) (string, error) {
+	if v.err != nil {
+		return "", v.err
+	}
+	if !v.stringsFastPath() {
+		return v.GenerateSignature(stringParams(params))
+	}
+	return v.sign(nil, v.buildStringToSignStrings(params))
+}
+
+// ValidateStrings 验证 map[string]string 参数的签名是否有效
+func (v *SignValidator) ValidateStrings(params map[string]string, signature string) (bool, error) {
+	if v.err != nil {
+		return false, v.err
+	}
+	if !v.stringsFastPath() || len(v.config.AcceptAlgorithms) > 0 {
+		return v.Validate(stringParams(params), signature)
+	}
+	return v.validateString(nil, v.buildStringToSignStrings(params), signature)
+}
+
+// stringsFastPath 判断是否可以不经转换直接对字符串参数签名
+func (v *SignValidator) stringsFastPath() bool {
+	return v.config.ValueEncoder == nil &&
+		v.template == nil &&
+		v.config.AlgorithmKey == "" &&
+		v.config.HKDF == nil &&
+		v.config.KeyProvider == nil &&
+		len(v.config.RequiredKeys) == 0 &&
+		v.config.TimestampKey == "" &&
+		v.config.NonceStore == nil &&
+		v.config.SignatureStore == nil &&
+		v.config.SequenceStore == nil &&
+		v.config.FailureLimit == nil &&
+		len(v.config.PreviousSecrets) == 0 &&
+		len(v.config.Keys) == 0 &&
+		len(v.config.Secrets) == 0 &&
+		v.config.SecretProvider == nil &&
+		!v.hasEventHooks() &&
+		v.config.KeyCase != KeyLower &&
+		v.config.Algorithm != CHACHA20_POLY1305
+}
+
+// buildStringToSignStrings 构建字符串参数的待签名字符串，规则与 buildStringToSign 相同
+func (v *SignValidator) buildStringToSignStrings(params map[string]string) string {
+	keys := make([]string, 0, len(params))
+	size := 0
+	for k, value := range params {
+		if v.isExcludedKey(k, nil) {
+			continue
+		}
+		keys = append(keys, k)
+		size += len(k) + len(value) + 2
+	}
+	if v.config.SortFunc != nil {
+		sort.SliceStable(keys, func(i, j int) bool { return v.config.SortFunc(keys[i], keys[j]) })
+	} else {
+		sort.Strings(keys)
+	}
+
+	pairSeparator, kvSeparator := v.separators()
+	encodeKeys := v.config.URLEncode == URLEncodeKeys || v.config.URLEncode == URLEncodeAll
+	encodeValues := v.config.URLEncode == URLEncodeValues || v.config.URLEncode == URLEncodeAll
+
+	var builder strings.Builder
+	builder.Grow(size + len(v.config.Secret) + 5)
+	first := true
+	for _, key := range keys {
+		value := v.normalizeValue(params[key])
+		if value == "" && v.config.SkipEmptyValues {
+			continue
+		}
+		if !first {
+			builder.WriteString(pairSeparator)
+		}
+		first = false
+		if encodeKeys {
+			key = v.escape(key)
+		}
+		if encodeValues {
+			value = v.escape(value)
+		}
+		builder.WriteString(key)
+		builder.WriteString(kvSeparator)
+		builder.WriteString(value)
+	}
+
+	v.appendSecret(&builder)
+	return builder.String()
+}
+
+// stringParams 将字符串参数转换为通用参数
+func stringParams(params map[string]string) map[string]interface{} {
+	m := make(map[string]interface{}, len(params))
+	for k, value := range params {
+		m[k] = value
+	}
+	return m
+}
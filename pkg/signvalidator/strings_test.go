@@ -0,0 +1,121 @@
+package signvalidator
+
+import (
+	"testing"
+)
+
+func TestGenerateSignatureStrings_MatchesGenerateSignature(t *testing.T) {
+	params := map[string]string{
+		"b":     "2",
+		"a":     " 1 ",
+		"empty": "",
+		"sign":  "ignored",
+		"text":  "a b/c",
+	}
+
+	configs := map[string]Config{
+		"默认":     {Secret: "secret"},
+		"HMAC":   {Secret: "secret", Algorithm: HMAC_SHA256},
+		"跳过空值":   {Secret: "secret", SkipEmptyValues: true, TrimValues: true},
+		"URL编码":  {Secret: "secret", URLEncode: URLEncodeAll},
+		"PHP":    {Secret: "secret", Compat: CompatPHP},
+		"Java":   {Secret: "secret", Compat: CompatJava},
+		"分隔符":    {Secret: "secret", PairSeparator: Separator(""), KVSeparator: Separator(""), SecretJoin: SecretJoinRaw},
+		"排序函数":   {Secret: "secret", SortFunc: SortByLength, IgnoreKeys: []string{"emp*"}},
+		"小写参数名":  {Secret: "secret", KeyCase: KeyLower},
+		"必填参数":   {Secret: "secret", RequiredKeys: []string{"a"}},
+		"HKDF派生": {Secret: "secret", Algorithm: HMAC_SHA256, HKDF: &HKDF{Info: "ctx"}},
+	}
+
+	for name, config := range configs {
+		v := NewSignValidator(config)
+		want, err := v.GenerateSignature(stringParams(params))
+		if err != nil {
+			t.Fatalf("%s: GenerateSignature 失败: %v", name, err)
+		}
+		got, err := v.GenerateSignatureStrings(params)
+		if err != nil {
+			t.Fatalf("%s: GenerateSignatureStrings 失败: %v", name, err)
+		}
+		if got != want {
+			t.Errorf("%s: 签名不一致，期望 %s，实际 %s", name, want, got)
+		}
+
+		valid, err := v.ValidateStrings(params, want)
+		if err != nil || !valid {
+			t.Errorf("%s: ValidateStrings 应通过，valid=%v err=%v", name, valid, err)
+		}
+		valid, err = v.ValidateStrings(params, "bad")
+		if err != nil || valid {
+			t.Errorf("%s: 错误签名不应通过，valid=%v err=%v", name, valid, err)
+		}
+	}
+}
+
+func TestGenerateSignatureStrings_Fallback(t *testing.T) {
+	skipFIPS(t)
+	v := NewSignValidator(Config{Secret: "secret", RequiredKeys: []string{"timestamp"}})
+	if _, err := v.GenerateSignatureStrings(map[string]string{"a": "1"}); err == nil {
+		t.Error("缺少必填参数时应返回错误")
+	}
+
+	v = NewSignValidator(Config{
+		Secret:            "secret",
+		Algorithm:         HMAC_SHA256,
+		AlgorithmKey:      "sign_type",
+		AllowedAlgorithms: []SignAlgorithm{HMAC_SHA256, MD5},
+	})
+	params := map[string]string{"a": "1", "sign_type": "MD5"}
+	signature, err := v.GenerateSignatureStrings(params)
+	if err != nil {
+		t.Fatalf("生成签名失败: %v", err)
+	}
+	want, _ := NewSignValidator(Config{Secret: "secret", Algorithm: MD5}).GenerateSignature(stringParams(params))
+	if signature != want {
+		t.Errorf("应按 sign_type 协商算法，期望 %s，实际 %s", want, signature)
+	}
+}
+
+func TestGenerateSignatureStrings_ConfigError(t *testing.T) {
+	v := NewSignValidator(Config{Secret: "secret", Algorithm: "unknown"})
+	if _, err := v.GenerateSignatureStrings(map[string]string{"a": "1"}); err == nil {
+		t.Error("配置错误时应返回错误")
+	}
+	if _, err := v.ValidateStrings(map[string]string{"a": "1"}, "x"); err == nil {
+		t.Error("配置错误时验证应返回错误")
+	}
+}
+
+func benchmarkParams() map[string]string {
+	return map[string]string{
+		"appid":     "wx1234567890",
+		"mch_id":    "1900000109",
+		"nonce_str": "5K8264ILTKCH16CQ2502SI8ZNMTM67VS",
+		"body":      "test",
+		"out_trade": "20150806125346",
+		"total_fee": "88",
+		"timestamp": "1700000000",
+	}
+}
+
+func BenchmarkGenerateSignatureStrings(b *testing.B) {
+	v := NewSignValidator(Config{Secret: "secret", Algorithm: HMAC_SHA256})
+	params := benchmarkParams()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := v.GenerateSignatureStrings(params); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGenerateSignature(b *testing.B) {
+	v := NewSignValidator(Config{Secret: "secret", Algorithm: HMAC_SHA256})
+	params := stringParams(benchmarkParams())
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		if _, err := v.GenerateSignature(params); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package signvalidator
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+)
+
+// errNotStruct 传入的值不是结构体或结构体指针
+var errNotStruct = errors.New("参数必须为结构体或结构体指针")
+
+// SignStruct 对结构体生成签名，字段按 sign 标签转换为参数，用于直接对请求 DTO 签名。
+// 标签格式为 `sign:"user_id,omitempty"`：名称为空时使用 json 标签名称，再为空时使用字段名；
+// omitempty 跳过零值字段；"-" 忽略该字段；未导出字段被忽略，导出的匿名嵌入结构体字段展开到上层
+func (v *SignValidator) SignStruct(value interface{}) (string, error) {
+	if v.err != nil {
+		return "", v.err
+	}
+	params, err := StructParams(value)
+	if err != nil {
+		return "", err
+	}
+	return v.GenerateSignature(params)
+}
+
+// ValidateStruct 验证结构体的签名是否有效，字段转换规则与 SignStruct 相同
+func (v *SignValidator) ValidateStruct(value interface{}, signature string) (bool, error) {
+	if v.err != nil {
+		return false, v.err
+	}
+	params, err := StructParams(value)
+	if err != nil {
+		return false, err
+	}
+	return v.Validate(params, signature)
+}
+
+// StructParams 按 sign 标签将结构体转换为参数 map，指针字段为 nil 时参数值为 nil
+func StructParams(value interface{}) (map[string]interface{}, error) {
+	rv := reflect.ValueOf(value)
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil, errNotStruct
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, errNotStruct
+	}
+
+	params := make(map[string]interface{}, rv.NumField())
+	collectStructParams(rv, params)
+	return params, nil
+}
+
+// collectStructParams 将结构体字段写入 params，上层字段优先于嵌入结构体中的同名字段
+func collectStructParams(rv reflect.Value, params map[string]interface{}) {
+	rt := rv.Type()
+	var embedded []reflect.Value
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		name, omitEmpty, skip := parseSignTag(field)
+		if skip {
+			continue
+		}
+
+		fv := rv.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		if field.Anonymous && name == "" {
+			// 匿名嵌入的结构体（或其指针）展开，稍后处理以保证上层字段优先
+			if ev, ok := embeddedStruct(fv); ok {
+				embedded = append(embedded, ev)
+				continue
+			}
+		}
+		if omitEmpty && fv.IsZero() {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+		params[name] = fieldValue(fv)
+	}
+
+	for _, ev := range embedded {
+		inner := make(map[string]interface{})
+		collectStructParams(ev, inner)
+		for k, value := range inner {
+			if _, exists := params[k]; !exists {
+				params[k] = value
+			}
+		}
+	}
+}
+
+// parseSignTag 解析字段的 sign 标签，未设置时回退到 json 标签名称
+func parseSignTag(field reflect.StructField) (name string, omitEmpty, skip bool) {
+	tag, ok := field.Tag.Lookup("sign")
+	if !ok {
+		tag, ok = field.Tag.Lookup("json")
+		if ok {
+			// json 标签仅用于确定名称，omitempty 等选项不影响签名
+			tag, _, _ = strings.Cut(tag, ",")
+		}
+	}
+	if tag == "-" {
+		return "", false, true
+	}
+
+	name, options, _ := strings.Cut(tag, ",")
+	for _, option := range strings.Split(options, ",") {
+		if option == "omitempty" {
+			omitEmpty = true
+		}
+	}
+	return name, omitEmpty, false
+}
+
+// embeddedStruct 返回匿名字段对应的结构体值，nil 指针或非结构体返回 false
+func embeddedStruct(fv reflect.Value) (reflect.Value, bool) {
+	if fv.Kind() == reflect.Pointer {
+		if fv.IsNil() {
+			return reflect.Value{}, false
+		}
+		fv = fv.Elem()
+	}
+	return fv, fv.Kind() == reflect.Struct
+}
+
+// fieldValue 返回字段的参数值，指针字段解引用，nil 指针返回 nil
+func fieldValue(fv reflect.Value) interface{} {
+	for fv.Kind() == reflect.Pointer {
+		if fv.IsNil() {
+			return nil
+		}
+		fv = fv.Elem()
+	}
+	return fv.Interface()
+}
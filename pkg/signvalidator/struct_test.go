@@ -0,0 +1,110 @@
+package signvalidator
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type structBase struct {
+	AppID string `sign:"app_id"`
+}
+
+type StructCommon struct {
+	Timestamp int64  `sign:"timestamp"`
+	Nonce     string `sign:"nonce"`
+	UserID    string `sign:"user_id"`
+}
+
+type structOrder struct {
+	StructCommon
+	*structBase
+	UserID   string   `sign:"user_id,omitempty"`
+	Amount   float64  `json:"amount,omitempty"`
+	Remark   *string  `sign:"remark"`
+	Tags     []string `sign:"tags,omitempty"`
+	Internal string   `sign:"-"`
+	Sign     string   `sign:"sign"`
+	Plain    bool
+	secret   string
+}
+
+func TestStructParams(t *testing.T) {
+	order := &structOrder{
+		StructCommon: StructCommon{Timestamp: 1700000000, Nonce: "abc", UserID: "inner"},
+		structBase:   &structBase{AppID: "app"},
+		UserID:       "u1",
+		Internal:     "x",
+		Sign:         "s",
+		secret:       "hidden",
+	}
+
+	params, err := StructParams(order)
+	if err != nil {
+		t.Fatalf("转换结构体失败: %v", err)
+	}
+	want := map[string]interface{}{
+		"timestamp": int64(1700000000),
+		"nonce":     "abc",
+		"user_id":   "u1",
+		"amount":    float64(0),
+		"remark":    nil,
+		"sign":      "s",
+		"Plain":     false,
+	}
+	if !reflect.DeepEqual(params, want) {
+		t.Errorf("参数不正确，期望 %v，实际 %v", want, params)
+	}
+
+	order.UserID = ""
+	params, _ = StructParams(order)
+	if params["user_id"] != "inner" {
+		t.Errorf("omitempty 跳过后应使用嵌入结构体的字段，实际 %v", params["user_id"])
+	}
+}
+
+func TestStructParams_NotStruct(t *testing.T) {
+	for _, value := range []interface{}{nil, 1, map[string]string{}, (*structOrder)(nil)} {
+		if _, err := StructParams(value); !errors.Is(err, errNotStruct) {
+			t.Errorf("%#v 应返回 errNotStruct，实际 %v", value, err)
+		}
+	}
+}
+
+func TestSignStruct(t *testing.T) {
+	v := NewSignValidator(Config{Secret: "secret", Algorithm: HMAC_SHA256})
+	remark := "备注"
+	order := structOrder{
+		StructCommon: StructCommon{Timestamp: 1700000000, Nonce: "abc"},
+		UserID:       "u1",
+		Amount:       9.9,
+		Remark:       &remark,
+	}
+
+	signature, err := v.SignStruct(order)
+	if err != nil {
+		t.Fatalf("生成签名失败: %v", err)
+	}
+	want, _ := v.GenerateSignature(map[string]interface{}{
+		"timestamp": int64(1700000000),
+		"nonce":     "abc",
+		"user_id":   "u1",
+		"amount":    9.9,
+		"remark":    "备注",
+		"Plain":     false,
+		"sign":      "",
+	})
+	if signature != want {
+		t.Errorf("结构体签名应与等价参数一致，期望 %s，实际 %s", want, signature)
+	}
+
+	order.Sign = signature
+	valid, err := v.ValidateStruct(&order, signature)
+	if err != nil || !valid {
+		t.Errorf("签名验证应通过，valid=%v err=%v", valid, err)
+	}
+	order.Amount = 10
+	if valid, _ := v.ValidateStruct(&order, signature); valid {
+		t.Error("字段修改后签名验证不应通过")
+	}
+}
@@ -0,0 +1,113 @@
+package signvalidator
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// errTemplatePlaceholder 模板占位符格式错误
+var errTemplatePlaceholder = errors.New("模板占位符格式错误")
+
+// errTemplateConflict 同时设置了模板与组成部分
+var errTemplateConflict = errors.New("Template 与 Components 不能同时设置")
+
+// templateSegment 模板片段，name 不为空时为占位符，否则为原样输出的文本
+type templateSegment struct {
+	text string
+	name string
+}
+
+// parseTemplate 解析待签名字符串模板，占位符格式为 {name}
+func parseTemplate(template string) ([]templateSegment, error) {
+	var segments []templateSegment
+	for template != "" {
+		start := strings.IndexAny(template, "{}")
+		if start < 0 {
+			segments = append(segments, templateSegment{text: template})
+			break
+		}
+		if template[start] == '}' {
+			return nil, fmt.Errorf("%w: 多余的 \"}\"", errTemplatePlaceholder)
+		}
+		if start > 0 {
+			segments = append(segments, templateSegment{text: template[:start]})
+		}
+
+		end := strings.IndexAny(template[start+1:], "{}")
+		if end < 0 || template[start+1+end] != '}' {
+			return nil, fmt.Errorf("%w: 未闭合的 \"{\"", errTemplatePlaceholder)
+		}
+		name := strings.TrimSpace(template[start+1 : start+1+end])
+		if name == "" {
+			return nil, fmt.Errorf("%w: 占位符名称为空", errTemplatePlaceholder)
+		}
+		segments = append(segments, templateSegment{name: name})
+		template = template[start+end+2:]
+	}
+	return segments, nil
+}
+
+// initTemplate 解析 Template 或按 Components 生成模板
+func (v *SignValidator) initTemplate() error {
+	switch {
+	case v.config.Template != "" && len(v.config.Components) > 0:
+		return errTemplateConflict
+	case v.config.Template != "":
+		template, err := parseTemplate(v.config.Template)
+		if err != nil {
+			return err
+		}
+		v.template = template
+	case len(v.config.Components) > 0:
+		separator := "\n"
+		if v.config.ComponentSeparator != nil {
+			separator = *v.config.ComponentSeparator
+		}
+		for i, component := range v.config.Components {
+			name := strings.TrimSpace(component)
+			if name == "" {
+				return fmt.Errorf("%w: 组成部分名称为空", errTemplatePlaceholder)
+			}
+			if i > 0 && separator != "" {
+				v.template = append(v.template, templateSegment{text: separator})
+			}
+			v.template = append(v.template, templateSegment{name: name})
+		}
+	}
+	return nil
+}
+
+// expandTemplate 按模板构建待签名字符串，被占位符引用的参数不再出现在 {params} 中
+func (v *SignValidator) expandTemplate(params map[string]interface{}) (string, error) {
+	var referenced []string
+	for _, segment := range v.template {
+		if segment.name != "" {
+			referenced = append(referenced, segment.name)
+		}
+	}
+
+	var builder strings.Builder
+	for _, segment := range v.template {
+		switch segment.name {
+		case "":
+			builder.WriteString(segment.text)
+		case "params":
+			paramString, err := v.buildParamString(params, referenced)
+			if err != nil {
+				return "", err
+			}
+			builder.WriteString(paramString)
+		case "secret":
+			builder.WriteString(v.config.Secret)
+		default:
+			param, _ := v.lookup(params, segment.name)
+			value, err := v.formatValue(segment.name, param)
+			if err != nil {
+				return "", err
+			}
+			builder.WriteString(value)
+		}
+	}
+	return builder.String(), nil
+}
@@ -0,0 +1,106 @@
+package signvalidator
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+func TestSignValidator_Template(t *testing.T) {
+	validator, err := New(Config{
+		Secret:    "testSecret",
+		Algorithm: SHA256,
+		Template:  "{method}\n{path}\n{params}\n{secret}",
+	})
+	if err != nil {
+		t.Fatalf("创建签名验证器失败: %v", err)
+	}
+
+	params := map[string]interface{}{"method": "POST", "path": "/v1/orders", "id": 123, "name": "test"}
+	expected := "POST\n/v1/orders\nid=123&name=test\ntestSecret"
+	if s, _ := validator.buildStringToSign(params); s != expected {
+		t.Errorf("待签名字符串 = %q, 期望 %q", s, expected)
+	}
+
+	signature, err := validator.GenerateSignature(params)
+	if err != nil {
+		t.Fatalf("生成签名失败: %v", err)
+	}
+	sum := sha256.Sum256([]byte(expected))
+	if signature != hex.EncodeToString(sum[:]) {
+		t.Errorf("签名 = %s, 期望 %x", signature, sum)
+	}
+
+	valid, err := validator.Validate(params, signature)
+	if err != nil {
+		t.Fatalf("验证签名失败: %v", err)
+	}
+	if !valid {
+		t.Errorf("签名验证失败")
+	}
+
+	// 缺失的参数展开为空字符串
+	if s, _ := validator.buildStringToSign(map[string]interface{}{"id": 1}); s != "\n\nid=1\ntestSecret" {
+		t.Errorf("待签名字符串 = %q", s)
+	}
+}
+
+func TestSignValidator_Components(t *testing.T) {
+	params := map[string]interface{}{"method": "GET", "path": "/", "timestamp": 1700000000, "a": 1, "b": 2}
+
+	validator, err := New(Config{
+		Secret:     "testSecret",
+		Algorithm:  HMAC_SHA256,
+		Components: []string{"method", "path", "params", "timestamp"},
+	})
+	if err != nil {
+		t.Fatalf("创建签名验证器失败: %v", err)
+	}
+	if s, _ := validator.buildStringToSign(params); s != "GET\n/\na=1&b=2\n1700000000" {
+		t.Errorf("待签名字符串 = %q", s)
+	}
+
+	// 自定义组成部分分隔符
+	validator, err = New(Config{
+		Algorithm:          SHA256,
+		Components:         []string{"method", "params"},
+		ComponentSeparator: Separator("|"),
+		PairSeparator:      Separator("\n"),
+	})
+	if err != nil {
+		t.Fatalf("创建签名验证器失败: %v", err)
+	}
+	if s, _ := validator.buildStringToSign(params); s != "GET|a=1\nb=2\npath=/\ntimestamp=1700000000" {
+		t.Errorf("待签名字符串 = %q", s)
+	}
+
+	if _, err := New(Config{Template: "{params}", Components: []string{"params"}}); !errors.Is(err, errTemplateConflict) {
+		t.Errorf("错误 = %v, 期望 errTemplateConflict", err)
+	}
+	if _, err := New(Config{Components: []string{"method", " "}}); !errors.Is(err, errTemplatePlaceholder) {
+		t.Errorf("错误 = %v, 期望模板格式错误", err)
+	}
+}
+
+func TestParseTemplate(t *testing.T) {
+	segments, err := parseTemplate("a{ x }b{params}")
+	if err != nil {
+		t.Fatalf("解析模板失败: %v", err)
+	}
+	expected := []templateSegment{{text: "a"}, {name: "x"}, {text: "b"}, {name: "params"}}
+	if len(segments) != len(expected) {
+		t.Fatalf("片段数量 = %d, 期望 %d", len(segments), len(expected))
+	}
+	for i := range expected {
+		if segments[i] != expected[i] {
+			t.Errorf("片段 %d = %+v, 期望 %+v", i, segments[i], expected[i])
+		}
+	}
+
+	for _, template := range []string{"{params", "params}", "{}", "{a{b}}"} {
+		if _, err := New(Config{Template: template}); !errors.Is(err, errTemplatePlaceholder) {
+			t.Errorf("%q 错误 = %v, 期望模板格式错误", template, err)
+		}
+	}
+}
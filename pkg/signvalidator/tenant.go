@@ -0,0 +1,117 @@
+package signvalidator
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// ErrUnknownTenant 租户未注册
+var ErrUnknownTenant = errors.New("未知的租户")
+
+// TenantRegistry 保存各租户（商户、应用）的完整配置，按租户标识选择验证器，可在多个 goroutine 中使用；
+// 各租户可以使用不同的算法、密钥与规范化选项
+type TenantRegistry struct {
+	tenantKey string
+
+	mu      sync.RWMutex
+	tenants map[string]*SignValidator
+}
+
+// NewTenantRegistry 创建租户注册表，tenantKey 为请求中的租户标识参数名，为空时为 "app_id"
+func NewTenantRegistry(tenantKey string) *TenantRegistry {
+	if tenantKey == "" {
+		tenantKey = "app_id"
+	}
+	return &TenantRegistry{tenantKey: tenantKey, tenants: make(map[string]*SignValidator)}
+}
+
+// Register 按 config 创建租户的验证器并注册，已注册的租户被替换；配置无效时返回错误且不修改注册表
+func (t *TenantRegistry) Register(tenantID string, config Config) error {
+	if tenantID == "" {
+		return errors.New("租户标识不能为空")
+	}
+	v, err := New(config)
+	if err != nil {
+		return fmt.Errorf("租户 %s 的配置无效: %w", tenantID, err)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.tenants[tenantID] = v
+	return nil
+}
+
+// Remove 删除租户
+func (t *TenantRegistry) Remove(tenantID string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.tenants, tenantID)
+}
+
+// Validator 返回租户的验证器
+func (t *TenantRegistry) Validator(tenantID string) (*SignValidator, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	v, exists := t.tenants[tenantID]
+	return v, exists
+}
+
+// Tenants 返回已注册的租户标识，按字典序排列
+func (t *TenantRegistry) Tenants() []string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	tenants := make([]string, 0, len(t.tenants))
+	for tenantID := range t.tenants {
+		tenants = append(tenants, tenantID)
+	}
+	sort.Strings(tenants)
+	return tenants
+}
+
+// ValidateForTenant 使用租户的配置验证签名，租户未注册时返回 ErrUnknownTenant
+func (t *TenantRegistry) ValidateForTenant(tenantID string, params map[string]interface{}, signature string) (bool, error) {
+	v, err := t.lookup(tenantID)
+	if err != nil {
+		return false, err
+	}
+	return v.Validate(params, signature)
+}
+
+// GenerateForTenant 使用租户的配置生成签名，租户未注册时返回 ErrUnknownTenant
+func (t *TenantRegistry) GenerateForTenant(tenantID string, params map[string]interface{}) (string, error) {
+	v, err := t.lookup(tenantID)
+	if err != nil {
+		return "", err
+	}
+	return v.GenerateSignature(params)
+}
+
+// Validate 按租户标识参数选择租户并验证签名，参数缺失时返回 *MissingKeysError，租户未注册时返回 ErrUnknownTenant
+func (t *TenantRegistry) Validate(params map[string]interface{}, signature string) (bool, error) {
+	v, err := t.ValidatorFor(params)
+	if err != nil {
+		return false, err
+	}
+	return v.Validate(params, signature)
+}
+
+// ValidatorFor 按租户标识参数返回租户的验证器（如为验证通过的请求签名响应），
+// 参数缺失时返回 *MissingKeysError，租户未注册时返回 ErrUnknownTenant
+func (t *TenantRegistry) ValidatorFor(params map[string]interface{}) (*SignValidator, error) {
+	value, exists := params[t.tenantKey]
+	if !exists || value == nil || value == "" {
+		return nil, &MissingKeysError{Keys: []string{t.tenantKey}}
+	}
+	return t.lookup(convertToString(value))
+}
+
+// lookup 返回租户的验证器，租户未注册时返回 ErrUnknownTenant
+func (t *TenantRegistry) lookup(tenantID string) (*SignValidator, error) {
+	v, exists := t.Validator(tenantID)
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownTenant, tenantID)
+	}
+	return v, nil
+}
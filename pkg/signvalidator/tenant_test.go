@@ -0,0 +1,111 @@
+package signvalidator
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestTenantRegistry(t *testing.T) {
+	skipFIPS(t)
+	registry := NewTenantRegistry("")
+	if err := registry.Register("m1", Config{Secret: "secret-1", Algorithm: MD5}); err != nil {
+		t.Fatalf("注册租户失败: %v", err)
+	}
+	if err := registry.Register("m2", Config{Secret: "secret-2", Algorithm: HMAC_SHA256, Compat: CompatPHP}); err != nil {
+		t.Fatalf("注册租户失败: %v", err)
+	}
+	if tenants := registry.Tenants(); !reflect.DeepEqual(tenants, []string{"m1", "m2"}) {
+		t.Errorf("租户列表 = %v", tenants)
+	}
+
+	params := map[string]interface{}{"app_id": "m2", "name": "a b"}
+	signature, err := registry.GenerateForTenant("m2", params)
+	if err != nil {
+		t.Fatalf("生成签名失败: %v", err)
+	}
+	expected, _ := NewSignValidator(Config{Secret: "secret-2", Algorithm: HMAC_SHA256, Compat: CompatPHP}).GenerateSignature(params)
+	if signature != expected {
+		t.Errorf("签名 = %s, 应使用租户 m2 的配置生成 %s", signature, expected)
+	}
+
+	if valid, err := registry.Validate(params, signature); err != nil || !valid {
+		t.Errorf("按参数选择租户的验证应通过，valid=%v err=%v", valid, err)
+	}
+	if valid, _ := registry.ValidateForTenant("m1", params, signature); valid {
+		t.Error("使用其他租户的配置时验证不应通过")
+	}
+
+	params["app_id"] = "m3"
+	if _, err := registry.Validate(params, signature); !errors.Is(err, ErrUnknownTenant) {
+		t.Errorf("未注册的租户应返回 ErrUnknownTenant，实际 %v", err)
+	}
+	if _, err := registry.Validate(map[string]interface{}{"name": "a"}, signature); !errors.Is(err, ErrMissingKeys) {
+		t.Errorf("缺少租户标识应返回 ErrMissingKeys，实际 %v", err)
+	}
+
+	registry.Remove("m2")
+	if _, err := registry.ValidateForTenant("m2", params, signature); !errors.Is(err, ErrUnknownTenant) {
+		t.Errorf("删除后的租户应返回 ErrUnknownTenant，实际 %v", err)
+	}
+}
+
+func TestTenantRegistry_Register(t *testing.T) {
+	registry := NewTenantRegistry("merchant_id")
+	if err := registry.Register("", Config{Secret: "s"}); err == nil {
+		t.Error("租户标识为空时应返回错误")
+	}
+	registry.Register("m1", Config{Secret: "old"})
+	if err := registry.Register("m1", Config{Secret: "new", Algorithm: "unknown"}); err == nil {
+		t.Error("配置无效时应返回错误")
+	}
+	v, _ := registry.Validator("m1")
+	if v.config.Secret != "old" {
+		t.Error("配置无效时不应替换已注册的租户")
+	}
+
+	params := map[string]interface{}{"merchant_id": "m1"}
+	signature, _ := registry.GenerateForTenant("m1", params)
+	if valid, err := registry.Validate(params, signature); err != nil || !valid {
+		t.Errorf("应按 merchant_id 参数选择租户，valid=%v err=%v", valid, err)
+	}
+}
+
+func TestTenantRegistry_Concurrent(t *testing.T) {
+	registry := NewTenantRegistry("")
+	registry.Register("m1", Config{Secret: "s"})
+	params := map[string]interface{}{"app_id": "m1"}
+	signature, _ := registry.GenerateForTenant("m1", params)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			registry.Validate(params, signature)
+		}()
+		go func() {
+			defer wg.Done()
+			registry.Register("m1", Config{Secret: "s"})
+		}()
+	}
+	wg.Wait()
+}
+
+func TestTenantRegistry_ValidatorFor(t *testing.T) {
+	registry := NewTenantRegistry("")
+	if err := registry.Register("app_1", Config{Secret: "secret-1"}); err != nil {
+		t.Fatalf("注册租户失败: %v", err)
+	}
+	if v, err := registry.ValidatorFor(map[string]interface{}{"app_id": "app_1"}); err != nil || v == nil {
+		t.Errorf("应返回租户的验证器: %v", err)
+	}
+	var missing *MissingKeysError
+	if _, err := registry.ValidatorFor(map[string]interface{}{}); !errors.As(err, &missing) {
+		t.Errorf("缺少租户标识时应返回 *MissingKeysError，实际 %v", err)
+	}
+	if _, err := registry.ValidatorFor(map[string]interface{}{"app_id": "app_2"}); !errors.Is(err, ErrUnknownTenant) {
+		t.Errorf("租户未注册时应返回 ErrUnknownTenant，实际 %v", err)
+	}
+}
@@ -0,0 +1,109 @@
+package signvalidator
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrTimestampExpired 时间戳超出允许的时间偏差，签名已过期或来自未来
+var ErrTimestampExpired = errors.New("时间戳超出允许的时间范围")
+
+// defaultMaxSkew 默认允许的时间偏差
+const defaultMaxSkew = 5 * time.Minute
+
+// TimestampUnit 表示时间戳参数的单位
+type TimestampUnit string
+
+const (
+	// TimestampSeconds Unix 秒（默认）
+	TimestampSeconds TimestampUnit = "seconds"
+	// TimestampMillis Unix 毫秒
+	TimestampMillis TimestampUnit = "millis"
+)
+
+// checkTimestampConfig 检查时间戳配置
+func (v *SignValidator) checkTimestampConfig() error {
+	switch v.config.TimestampUnit {
+	case "", TimestampSeconds, TimestampMillis:
+	default:
+		return fmt.Errorf("不支持的时间戳单位: %s", v.config.TimestampUnit)
+	}
+	if v.config.MaxSkew < 0 {
+		return errors.New("MaxSkew 不能为负数")
+	}
+	return nil
+}
+
+// checkTimestamp 验证时检查 TimestampKey 参数与当前时间的偏差，未配置 TimestampKey 时不检查
+func (v *SignValidator) checkTimestamp(params map[string]interface{}) error {
+	if v.config.TimestampKey == "" {
+		return nil
+	}
+
+	value, exists := v.lookup(params, v.config.TimestampKey)
+	if !exists || value == nil || value == "" {
+		return &MissingKeysError{Keys: []string{v.config.TimestampKey}}
+	}
+	timestamp, err := parseTimestamp(value, v.config.TimestampUnit)
+	if err != nil {
+		return err
+	}
+
+	maxSkew := v.config.MaxSkew
+	if maxSkew == 0 {
+		maxSkew = defaultMaxSkew
+	}
+
+	skew := v.now().Sub(timestamp)
+	if skew > maxSkew || skew < -maxSkew {
+		return fmt.Errorf("%w: %s 与当前时间相差 %s", ErrTimestampExpired, v.config.TimestampKey, skew.Round(time.Second))
+	}
+	return nil
+}
+
+// now 返回当前时间
+func (v *SignValidator) now() time.Time {
+	return clockOrSystem(v.config.Clock).Now()
+}
+
+// timestampKey 返回时间戳参数名，未配置 TimestampKey 时为 "timestamp"
+func (v *SignValidator) timestampKey() string {
+	if v.config.TimestampKey == "" {
+		return "timestamp"
+	}
+	return v.config.TimestampKey
+}
+
+// formatTimestamp 按 TimestampUnit 格式化时间戳
+func (v *SignValidator) formatTimestamp(t time.Time) string {
+	if v.config.TimestampUnit == TimestampMillis {
+		return strconv.FormatInt(t.UnixMilli(), 10)
+	}
+	return strconv.FormatInt(t.Unix(), 10)
+}
+
+// parseTimestamp 将整数（或整数值的浮点数，如 JSON 解码结果）时间戳转换为时间
+func parseTimestamp(value interface{}, unit TimestampUnit) (time.Time, error) {
+	var n int64
+	switch t := value.(type) {
+	case float64:
+		if t != math.Trunc(t) || math.Abs(t) > math.MaxInt64/2 {
+			return time.Time{}, fmt.Errorf("时间戳格式错误: %v", value)
+		}
+		n = int64(t)
+	default:
+		var err error
+		if n, err = strconv.ParseInt(strings.TrimSpace(convertToString(value)), 10, 64); err != nil {
+			return time.Time{}, fmt.Errorf("时间戳格式错误: %v", value)
+		}
+	}
+
+	if unit == TimestampMillis {
+		return time.UnixMilli(n), nil
+	}
+	return time.Unix(n, 0), nil
+}
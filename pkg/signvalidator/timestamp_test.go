@@ -0,0 +1,94 @@
+package signvalidator
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCheckTimestamp(t *testing.T) {
+	clock := NewFakeClock(time.Unix(1700000000, 0))
+	v := NewSignValidator(Config{
+		Secret:       "secret",
+		TimestampKey: "timestamp",
+		MaxSkew:      time.Minute,
+		Clock:        clock,
+	})
+
+	tests := []struct {
+		name      string
+		timestamp interface{}
+		err       error
+	}{
+		{"当前时间", int64(1700000000), nil},
+		{"字符串", "1700000030", nil},
+		{"json.Number", json.Number("1699999950"), nil},
+		{"JSON 浮点数", float64(1700000060), nil},
+		{"已过期", int64(1699999939), ErrTimestampExpired},
+		{"来自未来", "1700000061", ErrTimestampExpired},
+		{"缺失", nil, ErrMissingKeys},
+	}
+	for _, tt := range tests {
+		params := map[string]interface{}{"a": 1}
+		if tt.timestamp != nil {
+			params["timestamp"] = tt.timestamp
+		}
+		signature, err := v.GenerateSignature(params)
+		if err != nil {
+			t.Fatalf("%s: 生成签名不应检查时间戳: %v", tt.name, err)
+		}
+
+		valid, err := v.Validate(params, signature)
+		if !errors.Is(err, tt.err) {
+			t.Errorf("%s: 期望错误 %v，实际 %v", tt.name, tt.err, err)
+		}
+		if tt.err == nil && !valid {
+			t.Errorf("%s: 签名验证应通过", tt.name)
+		}
+	}
+
+	for _, timestamp := range []interface{}{"abc", 1.5, "1.7e9"} {
+		if _, err := v.Validate(map[string]interface{}{"timestamp": timestamp}, "x"); err == nil || errors.Is(err, ErrTimestampExpired) {
+			t.Errorf("%v: 应返回格式错误，实际 %v", timestamp, err)
+		}
+	}
+}
+
+func TestCheckTimestamp_Millis(t *testing.T) {
+	clock := NewFakeClock(time.UnixMilli(1700000000123))
+	v := NewSignValidator(Config{
+		Secret:        "secret",
+		TimestampKey:  "ts",
+		TimestampUnit: TimestampMillis,
+		Clock:         clock,
+	})
+
+	params := map[string]interface{}{"ts": "1700000000000"}
+	signature, _ := v.GenerateSignature(params)
+	if valid, err := v.Validate(params, signature); err != nil || !valid {
+		t.Errorf("毫秒时间戳验证应通过，valid=%v err=%v", valid, err)
+	}
+
+	// 默认允许偏差为 5 分钟
+	params = map[string]interface{}{"ts": clock.Now().Add(-6 * time.Minute).UnixMilli()}
+	signature, _ = v.GenerateSignature(params)
+	if _, err := v.Validate(params, signature); !errors.Is(err, ErrTimestampExpired) {
+		t.Errorf("超出默认偏差应返回 ErrTimestampExpired，实际 %v", err)
+	}
+
+	if valid, err := v.ValidateStrings(map[string]string{"ts": "1700000000000"}, signature); err != nil || valid {
+		t.Errorf("字符串参数同样应检查时间戳，valid=%v err=%v", valid, err)
+	}
+}
+
+func TestCheckTimestampConfig(t *testing.T) {
+	for _, config := range []Config{
+		{Secret: "secret", TimestampKey: "ts", TimestampUnit: "minutes"},
+		{Secret: "secret", TimestampKey: "ts", MaxSkew: -time.Second},
+	} {
+		if _, err := NewSignValidator(config).GenerateSignature(nil); err == nil {
+			t.Errorf("无效的时间戳配置 %+v 应返回错误", config)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package signvalidator
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrInvalidToken 令牌格式错误或签名无效
+var ErrInvalidToken = errors.New("令牌无效")
+
+// ErrTokenExpired 令牌已过期
+var ErrTokenExpired = errors.New("令牌已过期")
+
+// TokenExpiredError 令牌过期时返回的错误
+type TokenExpiredError struct {
+	// ExpiredAt 令牌的过期时间
+	ExpiredAt time.Time
+}
+
+func (e *TokenExpiredError) Error() string {
+	return fmt.Sprintf("%s: 过期时间 %s", ErrTokenExpired, e.ExpiredAt.Format(time.RFC3339))
+}
+
+// Unwrap 使 errors.Is(err, ErrTokenExpired) 成立
+func (e *TokenExpiredError) Unwrap() error {
+	return ErrTokenExpired
+}
+
+// TokenSigner 将参数与过期时间序列化为紧凑的 URL 安全令牌（"载荷.签名"，均为不带填充的 Base64URL），
+// 用于邮件链接、下载授权等场景；签名使用验证器的算法与密钥，Encoding 与 SignaturePrefix 固定为令牌格式，
+// 令牌按自身的过期时间失效，不使用验证器的 TimestampKey、NonceStore、SequenceStore、SignatureStore 与 FailureLimit，
+// 载荷不是 JSON 请求体，也不使用 SignedPaths 与 CanonicalBody
+type TokenSigner struct {
+	validator *SignValidator
+}
+
+// tokenPayload 令牌载荷
+type tokenPayload struct {
+	Params  map[string]interface{} `json:"p"`
+	Expires int64                  `json:"exp,omitempty"`
+}
+
+// NewTokenSigner 创建使用 v 的算法与密钥签名的 TokenSigner
+func NewTokenSigner(v *SignValidator) *TokenSigner {
+	c := *v
+	c.config.Encoding = EncodingBase64URL
+	c.config.SignaturePrefix = ""
+	c.config.TimestampKey, c.config.NonceStore, c.config.SequenceStore = "", nil, nil
+	c.config.SignatureStore = nil
+	c.config.FailureLimit, c.failures = nil, nil
+	c.config.SignedPaths, c.paths = nil, nil
+	c.config.CanonicalBody = false
+	return &TokenSigner{validator: &c}
+}
+
+// Sign 生成包含 params 的令牌，expiry 大于 0 时令牌在 expiry 之后过期，否则永不过期
+func (s *TokenSigner) Sign(params map[string]interface{}, expiry time.Duration) (string, error) {
+	payload := tokenPayload{Params: params}
+	if expiry > 0 {
+		payload.Expires = s.validator.now().Add(expiry).Unix()
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("序列化令牌参数失败: %w", err)
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(data)
+	signature, err := s.validator.GenerateBodySignature([]byte(encoded))
+	if err != nil {
+		return "", err
+	}
+	return encoded + "." + signature, nil
+}
+
+// Verify 验证令牌并返回其中的参数（数字为 json.Number），签名无效时返回 ErrInvalidToken，
+// 已过期时返回 *TokenExpiredError
+func (s *TokenSigner) Verify(token string) (map[string]interface{}, error) {
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return nil, ErrInvalidToken
+	}
+	valid, err := s.validator.ValidateBody([]byte(encoded), signature)
+	if err != nil {
+		return nil, err
+	}
+	if !valid {
+		return nil, ErrInvalidToken
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var payload tokenPayload
+	if err := decoder.Decode(&payload); err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	if payload.Expires > 0 {
+		expiredAt := time.Unix(payload.Expires, 0)
+		if !s.validator.now().Before(expiredAt) {
+			err := &TokenExpiredError{ExpiredAt: expiredAt}
+			s.validator.report(payload.Params, err)
+			return nil, err
+		}
+	}
+	if payload.Params == nil {
+		payload.Params = map[string]interface{}{}
+	}
+	return payload.Params, nil
+}
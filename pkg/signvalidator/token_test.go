@@ -0,0 +1,109 @@
+package signvalidator
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTokenSigner(t *testing.T) {
+	clock := NewFakeClock(time.Unix(1700000000, 0))
+	v := NewSignValidator(Config{Secret: "secret", Algorithm: HMAC_SHA256, Clock: clock})
+	signer := NewTokenSigner(v)
+
+	token, err := signer.Sign(map[string]interface{}{"user_id": 42, "file": "报告.pdf"}, time.Hour)
+	if err != nil {
+		t.Fatalf("生成令牌失败: %v", err)
+	}
+	if strings.ContainsAny(token, "+/= ") {
+		t.Errorf("令牌应为 URL 安全的字符: %s", token)
+	}
+
+	params, err := signer.Verify(token)
+	if err != nil {
+		t.Fatalf("验证令牌失败: %v", err)
+	}
+	if params["user_id"] != json.Number("42") || params["file"] != "报告.pdf" {
+		t.Errorf("参数不正确: %v", params)
+	}
+
+	clock.Advance(time.Hour)
+	_, err = signer.Verify(token)
+	var expired *TokenExpiredError
+	if !errors.As(err, &expired) || !errors.Is(err, ErrTokenExpired) {
+		t.Fatalf("过期的令牌应返回 *TokenExpiredError，实际 %v", err)
+	}
+	if !expired.ExpiredAt.Equal(time.Unix(1700003600, 0)) {
+		t.Errorf("过期时间 = %s", expired.ExpiredAt)
+	}
+}
+
+func TestTokenSigner_BodyConfig(t *testing.T) {
+	store := NewMemoryNonceStore(0)
+	testCases := []Config{
+		{Secret: "secret", Algorithm: HMAC_SHA256, SignedPaths: []string{"$.order.id"}},
+		{Secret: "secret", Algorithm: HMAC_SHA256, CanonicalBody: true},
+		{Secret: "secret", Algorithm: HMAC_SHA256, SignatureStore: store, FailureLimit: &FailureLimit{MaxFailures: 1}},
+	}
+	for i, config := range testCases {
+		signer := NewTokenSigner(NewSignValidator(config))
+		token, err := signer.Sign(map[string]interface{}{"user_id": 42}, time.Hour)
+		if err != nil {
+			t.Fatalf("用例 %d 生成令牌失败: %v", i, err)
+		}
+		// 请求侧的一次性签名与失败次数限制不作用于令牌，可重复验证
+		for j := 0; j < 2; j++ {
+			params, err := signer.Verify(token)
+			if err != nil {
+				t.Fatalf("用例 %d 第 %d 次验证令牌失败: %v", i, j+1, err)
+			}
+			if params["user_id"] != json.Number("42") {
+				t.Errorf("用例 %d 参数不正确: %v", i, params)
+			}
+		}
+		if _, err := signer.Verify(token + "x"); !errors.Is(err, ErrInvalidToken) {
+			t.Errorf("用例 %d 篡改的令牌应返回 ErrInvalidToken，实际 %v", i, err)
+		}
+		if _, err := signer.Verify(token); err != nil {
+			t.Errorf("用例 %d 验证失败后不应锁定: %v", i, err)
+		}
+	}
+}
+
+func TestTokenSigner_Invalid(t *testing.T) {
+	signer := NewTokenSigner(NewSignValidator(Config{Secret: "secret", Algorithm: HMAC_SHA256}))
+	token, _ := signer.Sign(map[string]interface{}{"a": 1}, 0)
+
+	payload, signature, _ := strings.Cut(token, ".")
+	other, _ := NewTokenSigner(NewSignValidator(Config{Secret: "other", Algorithm: HMAC_SHA256})).Sign(map[string]interface{}{"a": 2}, 0)
+	otherPayload, _, _ := strings.Cut(other, ".")
+
+	for _, invalid := range []string{"", "abc", payload + ".", otherPayload + "." + signature, token + "x"} {
+		if _, err := signer.Verify(invalid); !errors.Is(err, ErrInvalidToken) {
+			t.Errorf("%q 应返回 ErrInvalidToken，实际 %v", invalid, err)
+		}
+	}
+
+	if params, err := signer.Verify(token); err != nil || params["a"] != json.Number("1") {
+		t.Errorf("不过期的令牌验证应通过，params=%v err=%v", params, err)
+	}
+}
+
+func TestTokenSigner_Asymmetric(t *testing.T) {
+	privateKeyPEM, publicKeyPEM, err := GenerateKeyPair(ED25519)
+	if err != nil {
+		t.Fatalf("生成密钥对失败: %v", err)
+	}
+	token, err := NewTokenSigner(NewSignValidator(Config{Algorithm: ED25519, PrivateKeyPEM: privateKeyPEM, Encoding: EncodingHex})).
+		Sign(map[string]interface{}{"grant": "download"}, time.Minute)
+	if err != nil {
+		t.Fatalf("生成令牌失败: %v", err)
+	}
+
+	params, err := NewTokenSigner(NewSignValidator(Config{Algorithm: ED25519, PublicKeyPEM: publicKeyPEM})).Verify(token)
+	if err != nil || params["grant"] != "download" {
+		t.Errorf("公钥验证令牌应通过，params=%v err=%v", params, err)
+	}
+}
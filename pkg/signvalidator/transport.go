@@ -0,0 +1,54 @@
+package signvalidator
+
+import (
+	"errors"
+	"net/http"
+)
+
+// SigningTransport 按 SignHTTPRequest 为发出的请求自动签名的 http.RoundTripper，使 SDK 只需替换 http.Client 的 Transport 即可获得签名；
+// 签名参数与服务端的 ValidateRequestParams、httpmw 中间件一致
+type SigningTransport struct {
+	// Validator 签名验证器
+	Validator *SignValidator
+	// Base 实际发送请求的 RoundTripper，为 nil 时使用 http.DefaultTransport
+	Base http.RoundTripper
+	// SignatureHeader 签名请求头（如 "X-Signature"），为空时使用 Config.SignatureHeader，均为空时签名写入 SignatureKey 查询参数
+	SignatureHeader string
+}
+
+// RoundTrip 复制请求并写入签名后交给 Base 发送，不修改调用方的请求
+func (t *SigningTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	signed, err := t.sign(r)
+	if err != nil {
+		if r.Body != nil {
+			r.Body.Close()
+		}
+		return nil, err
+	}
+
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return base.RoundTrip(signed)
+}
+
+// sign 返回按 SignHTTPRequest 签名的请求副本
+func (t *SigningTransport) sign(r *http.Request) (*http.Request, error) {
+	if t.Validator == nil {
+		return nil, errors.New("签名验证器为空")
+	}
+	if t.Validator.err != nil {
+		return nil, t.Validator.err
+	}
+
+	header := t.SignatureHeader
+	if header == "" {
+		header = t.Validator.config.SignatureHeader
+	}
+	c := r.Clone(r.Context())
+	if err := t.Validator.signHTTPRequest(c, header); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
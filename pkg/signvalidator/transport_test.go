@@ -0,0 +1,74 @@
+package signvalidator
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSigningTransport(t *testing.T) {
+	v := NewSignValidator(Config{Secret: "testSecret", Algorithm: HMAC_SHA256})
+	var valid bool
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var err error
+		if _, valid, err = v.ValidateRequestParams(r, r.Header.Get("X-Signature")); err != nil {
+			t.Errorf("验证签名失败: %v", err)
+		}
+		data, _ := io.ReadAll(r.Body)
+		body = string(data)
+	}))
+	defer server.Close()
+
+	for _, header := range []string{"", "X-Signature"} {
+		client := &http.Client{Transport: &SigningTransport{Validator: v, SignatureHeader: header}}
+		r, _ := http.NewRequest("POST", server.URL+"/orders?app_id=a", strings.NewReader(`{"amount":100}`))
+		r.Header.Set("Content-Type", "application/json")
+		resp, err := client.Do(r)
+		if err != nil {
+			t.Fatalf("发送请求失败: %v", err)
+		}
+		resp.Body.Close()
+		if !valid {
+			t.Errorf("SignatureHeader=%q: 服务端验证签名失败", header)
+		}
+		if body != `{"amount":100}` {
+			t.Errorf("服务端收到的请求体 = %q", body)
+		}
+		if r.URL.RawQuery != "app_id=a" || r.Header.Get("X-Signature") != "" {
+			t.Errorf("不应修改调用方的请求: %s %v", r.URL, r.Header)
+		}
+	}
+}
+
+func TestSigningTransport_CanonicalRequest(t *testing.T) {
+	v := NewSignValidator(Config{
+		Secret:           "testSecret",
+		Algorithm:        HMAC_SHA256,
+		CanonicalRequest: &CanonicalRequest{SignedHeaders: []string{"Host"}},
+	})
+	var valid bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		valid, _ = v.ValidateRequest(r, r.URL.Query().Get("sign"))
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: &SigningTransport{Validator: v}}
+	resp, err := client.Post(server.URL+"/upload?b=2&a=1", "application/octet-stream", strings.NewReader("raw bytes"))
+	if err != nil {
+		t.Fatalf("发送请求失败: %v", err)
+	}
+	resp.Body.Close()
+	if !valid {
+		t.Error("服务端应按规范化请求验证通过")
+	}
+}
+
+func TestSigningTransport_Error(t *testing.T) {
+	client := &http.Client{Transport: &SigningTransport{Validator: NewSignValidator(Config{Secret: "testSecret"})}}
+	if _, err := client.Post("http://127.0.0.1:1/", "text/plain", strings.NewReader("hello")); err == nil {
+		t.Error("无法解析的请求体应返回错误")
+	}
+}
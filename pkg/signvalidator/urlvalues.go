@@ -0,0 +1,44 @@
+package signvalidator
+
+import (
+	"net/http"
+	"net/url"
+)
+
+// GenerateSignatureValues 对 url.Values（如查询参数、表单）生成签名，只有一个值的参数按字符串处理，
+// 多个值的参数按 []string 处理并使用 MultiValue 拼接
+func (v *SignValidator) GenerateSignatureValues(values url.Values) (string, error) {
+	return v.GenerateSignature(multiValueParams(values))
+}
+
+// ValidateValues 验证 url.Values 的签名是否有效
+func (v *SignValidator) ValidateValues(values url.Values, signature string) (bool, error) {
+	return v.Validate(multiValueParams(values), signature)
+}
+
+// GenerateSignatureHeader 对 http.Header 生成签名，参数名为规范化后的请求头名称（如 "X-App-Id"），
+// 可配合 KeyLower 与 CaseInsensitiveKeys 使用
+func (v *SignValidator) GenerateSignatureHeader(header http.Header) (string, error) {
+	return v.GenerateSignature(multiValueParams(header))
+}
+
+// ValidateHeader 验证 http.Header 的签名是否有效
+func (v *SignValidator) ValidateHeader(header http.Header, signature string) (bool, error) {
+	return v.Validate(multiValueParams(header), signature)
+}
+
+// multiValueParams 将多值参数转换为签名参数，没有值的参数转换为空字符串
+func multiValueParams(values map[string][]string) map[string]interface{} {
+	params := make(map[string]interface{}, len(values))
+	for key, vs := range values {
+		switch len(vs) {
+		case 0:
+			params[key] = ""
+		case 1:
+			params[key] = vs[0]
+		default:
+			params[key] = vs
+		}
+	}
+	return params
+}
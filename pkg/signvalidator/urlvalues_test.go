@@ -0,0 +1,64 @@
+package signvalidator
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+)
+
+func TestSignValidator_Values(t *testing.T) {
+	validator := NewSignValidator(Config{Secret: "testSecret", Algorithm: HMAC_SHA256, MultiValue: MultiValueRepeat})
+
+	values, _ := url.ParseQuery("id=123&tag=b&tag=a&name=test")
+	signature, err := validator.GenerateSignatureValues(values)
+	if err != nil {
+		t.Fatalf("生成签名失败: %v", err)
+	}
+
+	// 与等价的 map 参数签名一致
+	expected, err := validator.GenerateSignature(map[string]interface{}{"id": "123", "name": "test", "tag": []string{"b", "a"}})
+	if err != nil {
+		t.Fatalf("生成签名失败: %v", err)
+	}
+	if signature != expected {
+		t.Errorf("签名 = %s, 期望 %s", signature, expected)
+	}
+
+	valid, err := validator.ValidateValues(values, signature)
+	if err != nil {
+		t.Fatalf("验证签名失败: %v", err)
+	}
+	if !valid {
+		t.Errorf("签名验证失败")
+	}
+
+	values.Add("tag", "c")
+	if valid, _ := validator.ValidateValues(values, signature); valid {
+		t.Errorf("追加参数值后签名验证应该失败")
+	}
+}
+
+func TestSignValidator_Header(t *testing.T) {
+	validator := NewSignValidator(Config{Secret: "testSecret", KeyCase: KeyLower, IncludeKeys: []string{"X-App-Id", "X-Timestamp"}})
+
+	header := http.Header{}
+	header.Set("X-App-Id", "demo")
+	header.Set("X-Timestamp", "1700000000")
+	header.Set("User-Agent", "test")
+
+	if s, _ := validator.buildStringToSign(multiValueParams(header)); s != "x-app-id=demo&x-timestamp=1700000000&key=testSecret" {
+		t.Errorf("待签名字符串 = %s", s)
+	}
+
+	signature, err := validator.GenerateSignatureHeader(header)
+	if err != nil {
+		t.Fatalf("生成签名失败: %v", err)
+	}
+	valid, err := validator.ValidateHeader(header, signature)
+	if err != nil {
+		t.Fatalf("验证签名失败: %v", err)
+	}
+	if !valid {
+		t.Errorf("签名验证失败")
+	}
+}
@@ -0,0 +1,218 @@
+package signvalidator
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/huangchunlong818/sign-chao/pkg/signvalidator/internal/nfc"
+)
+
+// ErrUseDefaultEncoding 由 ValueEncoder 返回，表示该参数使用默认转换
+var ErrUseDefaultEncoding = errors.New("使用默认参数编码")
+
+// BytesEncoding 表示 []byte 参数值的编码方式
+type BytesEncoding string
+
+const (
+	// BytesHex 小写十六进制
+	BytesHex BytesEncoding = "hex"
+	// BytesBase64 标准 Base64（带填充）
+	BytesBase64 BytesEncoding = "base64"
+	// BytesBase64URL URL 安全的 Base64（不带填充）
+	BytesBase64URL BytesEncoding = "base64url"
+)
+
+// NilMode 表示 nil 参数值的处理方式
+type NilMode string
+
+const (
+	// NilEmpty 转换为空字符串（默认）
+	NilEmpty NilMode = "empty"
+	// NilSkip 跳过该参数
+	NilSkip NilMode = "skip"
+	// NilLiteral 转换为 "null"
+	NilLiteral NilMode = "null"
+)
+
+// BoolFormat 表示布尔参数值的格式
+type BoolFormat string
+
+const (
+	// BoolLower "true" / "false"（默认）
+	BoolLower BoolFormat = "lower"
+	// BoolTitle "True" / "False"（Python 等）
+	BoolTitle BoolFormat = "title"
+	// BoolUpper "TRUE" / "FALSE"
+	BoolUpper BoolFormat = "upper"
+	// BoolNumeric "1" / "0"（PHP 等）
+	BoolNumeric BoolFormat = "numeric"
+)
+
+// formatBool 按格式转换布尔值
+func formatBool(format BoolFormat, b bool) string {
+	switch format {
+	case BoolTitle:
+		if b {
+			return "True"
+		}
+		return "False"
+	case BoolUpper:
+		if b {
+			return "TRUE"
+		}
+		return "FALSE"
+	case BoolNumeric:
+		if b {
+			return "1"
+		}
+		return "0"
+	default:
+		return strconv.FormatBool(b)
+	}
+}
+
+// encodeBytes 按编码方式编码二进制参数值
+func encodeBytes(encoding BytesEncoding, data []byte) string {
+	switch encoding {
+	case BytesHex:
+		return hex.EncodeToString(data)
+	case BytesBase64URL:
+		return base64.RawURLEncoding.EncodeToString(data)
+	default:
+		return base64.StdEncoding.EncodeToString(data)
+	}
+}
+
+// checkValueFormat 检查参数值格式配置
+func (v *SignValidator) checkValueFormat() error {
+	switch v.config.BytesEncoding {
+	case "", BytesHex, BytesBase64, BytesBase64URL:
+	default:
+		return fmt.Errorf("不支持的二进制编码方式: %s", v.config.BytesEncoding)
+	}
+	switch v.config.NilMode {
+	case "", NilEmpty, NilSkip, NilLiteral:
+	default:
+		return fmt.Errorf("不支持的 nil 处理方式: %s", v.config.NilMode)
+	}
+	switch v.config.BoolFormat {
+	case "", BoolLower, BoolTitle, BoolUpper, BoolNumeric:
+	default:
+		return fmt.Errorf("不支持的布尔值格式: %s", v.config.BoolFormat)
+	}
+	return nil
+}
+
+// FloatFormat 浮点数参数值的格式
+type FloatFormat struct {
+	// Precision 小数位数，Shortest 为 true 时忽略
+	Precision int
+	// TrimZeros 是否去除小数部分末尾的零（及随之多余的小数点）
+	TrimZeros bool
+	// Shortest 是否使用可精确还原该值的最短十进制表示（不使用科学计数法），如 99.99、0.1
+	Shortest bool
+}
+
+// format 按配置格式化浮点数，bitSize 为 32 或 64
+func (f *FloatFormat) format(value float64, bitSize int) string {
+	precision := f.Precision
+	if f.Shortest {
+		precision = -1
+	}
+	s := strconv.FormatFloat(value, 'f', precision, bitSize)
+	if f.TrimZeros && strings.Contains(s, ".") {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	return s
+}
+
+// formatValue 将参与签名的参数值转换为字符串，并按配置规范化空白字符与 Unicode 形式
+func (v *SignValidator) formatValue(key string, value interface{}) (string, error) {
+	s, err := v.encodeValue(key, value)
+	if err != nil {
+		return "", err
+	}
+	return v.normalizeValue(s), nil
+}
+
+// normalizeValue 按配置规范化参数值中的空白字符与 Unicode 形式
+func (v *SignValidator) normalizeValue(s string) string {
+	if v.config.NormalizeNewlines {
+		s = strings.ReplaceAll(s, "\r\n", "\n")
+		s = strings.ReplaceAll(s, "\r", "\n")
+	}
+	if v.config.TrimValues {
+		s = strings.TrimSpace(s)
+	}
+	if v.config.NormalizeUnicode {
+		s = nfc.String(s)
+	}
+	return s
+}
+
+// encodeValue 将参数值转换为字符串，配置了 ValueEncoder 时优先使用
+func (v *SignValidator) encodeValue(key string, value interface{}) (string, error) {
+	if v.config.ValueEncoder != nil {
+		s, err := v.config.ValueEncoder(key, value)
+		if !errors.Is(err, ErrUseDefaultEncoding) {
+			if err != nil {
+				return "", fmt.Errorf("编码参数 %s 失败: %w", key, err)
+			}
+			return s, nil
+		}
+	}
+	if stringer, ok := value.(fmt.Stringer); ok && v.config.UseStringer {
+		if rv := reflect.ValueOf(value); rv.Kind() == reflect.Pointer && rv.IsNil() {
+			return "", nil
+		}
+		return stringer.String(), nil
+	}
+	if value == nil && v.config.NilMode == NilLiteral {
+		return "null", nil
+	}
+	if b, ok := value.(bool); ok {
+		return formatBool(v.config.BoolFormat, b), nil
+	}
+	if data, ok := value.([]byte); ok && v.config.BytesEncoding != "" {
+		return encodeBytes(v.config.BytesEncoding, data), nil
+	}
+	if v.config.FloatFormat == nil && v.config.Compat == CompatJava {
+		switch f := value.(type) {
+		case float32:
+			return formatJavaDouble(float64(f), 32), nil
+		case float64:
+			return formatJavaDouble(f, 64), nil
+		}
+	}
+	if v.config.FloatFormat != nil {
+		switch f := value.(type) {
+		case float32:
+			return v.config.FloatFormat.format(float64(f), 32), nil
+		case float64:
+			return v.config.FloatFormat.format(f, 64), nil
+		}
+	}
+	if v.config.CanonicalJSON && isComplexValue(value) {
+		if data, err := CanonicalJSON(value); err == nil {
+			return string(data), nil
+		}
+	}
+	return convertToString(value), nil
+}
+
+// isComplexValue 判断参数值是否需要序列化为 JSON
+func isComplexValue(value interface{}) bool {
+	switch value.(type) {
+	case string, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64, bool, nil,
+		json.Number, *big.Int, big.Int, *big.Float, big.Float:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,237 @@
+package signvalidator
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestSignValidator_ValueEncoder(t *testing.T) {
+	skipFIPS(t)
+	encoder := func(key string, value interface{}) (string, error) {
+		if key == "amount" {
+			amount, ok := value.(float64)
+			if !ok {
+				return "", errors.New("金额类型错误")
+			}
+			return fmt.Sprintf("%d", int64(math.Round(amount*100))), nil
+		}
+		return "", ErrUseDefaultEncoding
+	}
+
+	validator := NewSignValidator(Config{Secret: "testSecret", Algorithm: MD5, ValueEncoder: encoder})
+	params := map[string]interface{}{"amount": 99.99, "id": 123}
+	if s, _ := validator.buildStringToSign(params); s != "amount=9999&id=123&key=testSecret" {
+		t.Errorf("待签名字符串 = %s", s)
+	}
+
+	// 编码错误在生成和验证签名时返回
+	params["amount"] = "99.99"
+	if _, err := validator.GenerateSignature(params); err == nil {
+		t.Errorf("编码失败时应该返回错误")
+	}
+	if _, err := validator.Validate(params, "xxx"); err == nil {
+		t.Errorf("编码失败时应该返回错误")
+	}
+
+	// 模板占位符同样使用 ValueEncoder
+	validator = NewSignValidator(Config{Algorithm: MD5, ValueEncoder: encoder, Template: "{amount}|{params}"})
+	if s, _ := validator.buildStringToSign(map[string]interface{}{"amount": 1.5, "id": 1}); s != "150|id=1" {
+		t.Errorf("待签名字符串 = %s", s)
+	}
+}
+
+func TestSignValidator_FloatFormat(t *testing.T) {
+	testCases := []struct {
+		format   *FloatFormat
+		value    interface{}
+		expected string
+	}{
+		{nil, 99.99, "99.990000"},
+		{&FloatFormat{Precision: 2}, 99.99, "99.99"},
+		{&FloatFormat{Precision: 2}, 100.0, "100.00"},
+		{&FloatFormat{Precision: 0}, 99.5, "100"},
+		{&FloatFormat{Precision: 6, TrimZeros: true}, 99.90, "99.9"},
+		{&FloatFormat{Precision: 2, TrimZeros: true}, 100.0, "100"},
+		{&FloatFormat{Shortest: true}, 0.1, "0.1"},
+		{&FloatFormat{Shortest: true}, 1e21, "1000000000000000000000"},
+		{&FloatFormat{Shortest: true}, float32(0.1), "0.1"},
+		// 整数不受影响
+		{&FloatFormat{Precision: 2}, 100, "100"},
+	}
+
+	for _, tc := range testCases {
+		validator := NewSignValidator(Config{Algorithm: MD5, FloatFormat: tc.format})
+		if s, _ := validator.buildStringToSign(map[string]interface{}{"v": tc.value}); s != "v="+tc.expected {
+			t.Errorf("%+v 格式化 %v = %s, 期望 v=%s", tc.format, tc.value, s, tc.expected)
+		}
+	}
+}
+
+// fixedDecimal 模拟 decimal.Decimal，按十进制定点数保存金额
+type fixedDecimal struct {
+	value int64
+	exp   int
+}
+
+func (d fixedDecimal) String() string {
+	s := fmt.Sprintf("%0*d", d.exp+1, d.value)
+	return s[:len(s)-d.exp] + "." + s[len(s)-d.exp:]
+}
+
+func TestSignValidator_UseStringer(t *testing.T) {
+	params := map[string]interface{}{"amount": fixedDecimal{value: 9990, exp: 2}, "fee": (*fixedDecimal)(nil), "id": 1}
+
+	validator := NewSignValidator(Config{Algorithm: MD5, UseStringer: true})
+	if s, _ := validator.buildStringToSign(params); s != "amount=99.90&fee=&id=1" {
+		t.Errorf("待签名字符串 = %s", s)
+	}
+
+	// 默认不使用 String()
+	validator = NewSignValidator(Config{Algorithm: MD5})
+	if s, _ := validator.buildStringToSign(params); s != "amount={}&fee=null&id=1" {
+		t.Errorf("待签名字符串 = %s", s)
+	}
+}
+
+func TestSignValidator_BytesEncoding(t *testing.T) {
+	skipFIPS(t)
+	params := map[string]interface{}{"data": []byte{0xfb, 0xff, 0x01}}
+
+	testCases := []struct {
+		encoding BytesEncoding
+		expected string
+	}{
+		{"", `data="+/8B"`},
+		{BytesHex, "data=fbff01"},
+		{BytesBase64, "data=+/8B"},
+		{BytesBase64URL, "data=-_8B"},
+	}
+
+	for _, tc := range testCases {
+		validator, err := New(Config{Algorithm: MD5, BytesEncoding: tc.encoding})
+		if err != nil {
+			t.Fatalf("创建签名验证器失败: %v", err)
+		}
+		if s, _ := validator.buildStringToSign(params); s != tc.expected {
+			t.Errorf("%q 待签名字符串 = %s, 期望 %s", tc.encoding, s, tc.expected)
+		}
+	}
+
+	if _, err := New(Config{Algorithm: MD5, BytesEncoding: "base32"}); err == nil {
+		t.Errorf("不支持的编码方式应该返回错误")
+	}
+}
+
+func TestSignValidator_BoolFormat(t *testing.T) {
+	skipFIPS(t)
+	params := map[string]interface{}{"a": true, "b": false}
+
+	testCases := []struct {
+		format   BoolFormat
+		expected string
+	}{
+		{"", "a=true&b=false"},
+		{BoolLower, "a=true&b=false"},
+		{BoolTitle, "a=True&b=False"},
+		{BoolUpper, "a=TRUE&b=FALSE"},
+		{BoolNumeric, "a=1&b=0"},
+	}
+
+	for _, tc := range testCases {
+		validator, err := New(Config{Algorithm: MD5, BoolFormat: tc.format})
+		if err != nil {
+			t.Fatalf("创建签名验证器失败: %v", err)
+		}
+		if s, _ := validator.buildStringToSign(params); s != tc.expected {
+			t.Errorf("%q 待签名字符串 = %s, 期望 %s", tc.format, s, tc.expected)
+		}
+	}
+
+	if _, err := New(Config{Algorithm: MD5, BoolFormat: "yes"}); err == nil {
+		t.Errorf("不支持的布尔值格式应该返回错误")
+	}
+}
+
+func TestSignValidator_NilMode(t *testing.T) {
+	skipFIPS(t)
+	params := map[string]interface{}{"a": nil, "b": "", "c": 1}
+
+	testCases := []struct {
+		mode     NilMode
+		expected string
+	}{
+		{"", "a=&b=&c=1"},
+		{NilEmpty, "a=&b=&c=1"},
+		{NilSkip, "b=&c=1"},
+		{NilLiteral, "a=null&b=&c=1"},
+	}
+
+	for _, tc := range testCases {
+		validator, err := New(Config{Algorithm: MD5, NilMode: tc.mode})
+		if err != nil {
+			t.Fatalf("创建签名验证器失败: %v", err)
+		}
+		if s, _ := validator.buildStringToSign(params); s != tc.expected {
+			t.Errorf("%q 待签名字符串 = %s, 期望 %s", tc.mode, s, tc.expected)
+		}
+	}
+
+	if _, err := New(Config{Algorithm: MD5, NilMode: "none"}); err == nil {
+		t.Errorf("不支持的处理方式应该返回错误")
+	}
+}
+
+func TestSignValidator_Whitespace(t *testing.T) {
+	skipFIPS(t)
+	params := map[string]interface{}{"memo": " line1\r\nline2\rline3\n ", "name": "\ttest "}
+
+	testCases := []struct {
+		trim      bool
+		normalize bool
+		expected  string
+	}{
+		{false, false, "memo= line1\r\nline2\rline3\n &name=\ttest "},
+		{true, false, "memo=line1\r\nline2\rline3&name=test"},
+		{false, true, "memo= line1\nline2\nline3\n &name=\ttest "},
+		{true, true, "memo=line1\nline2\nline3&name=test"},
+	}
+
+	for _, tc := range testCases {
+		validator := NewSignValidator(Config{Algorithm: MD5, TrimValues: tc.trim, NormalizeNewlines: tc.normalize})
+		if s, _ := validator.buildStringToSign(params); s != tc.expected {
+			t.Errorf("待签名字符串 = %q, 期望 %q", s, tc.expected)
+		}
+	}
+
+	// 代理修改空白字符后签名仍然有效
+	validator := NewSignValidator(Config{Secret: "testSecret", Algorithm: MD5, TrimValues: true, NormalizeNewlines: true})
+	signature, err := validator.GenerateSignature(map[string]interface{}{"memo": "a\nb"})
+	if err != nil {
+		t.Fatalf("生成签名失败: %v", err)
+	}
+	if valid, err := validator.Validate(map[string]interface{}{"memo": "a\r\nb "}, signature); err != nil || !valid {
+		t.Errorf("签名验证失败: %v", err)
+	}
+}
+
+func TestSignValidator_NormalizeUnicode(t *testing.T) {
+	skipFIPS(t)
+	validator := NewSignValidator(Config{Secret: "testSecret", Algorithm: MD5, NormalizeUnicode: true})
+
+	// NFC 形式生成的签名可以用 NFD 形式验证
+	signature, err := validator.GenerateSignature(map[string]interface{}{"name": "Caf\u00e9"})
+	if err != nil {
+		t.Fatalf("生成签名失败: %v", err)
+	}
+	if valid, err := validator.Validate(map[string]interface{}{"name": "Cafe\u0301"}, signature); err != nil || !valid {
+		t.Errorf("签名验证失败: %v", err)
+	}
+
+	// 默认不规范化
+	validator = NewSignValidator(Config{Secret: "testSecret", Algorithm: MD5})
+	if valid, _ := validator.Validate(map[string]interface{}{"name": "Cafe\u0301"}, signature); valid {
+		t.Errorf("未开启规范化时 NFD 形式不应验证通过")
+	}
+}
@@ -0,0 +1,310 @@
+// Package vault 使用 HashiCorp Vault transit 引擎计算签名：对称算法通过 hmac 接口，非对称算法通过 sign 接口，
+// 密钥始终保存在 Vault 中，进程内只持有密钥名称。
+package vault
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/huangchunlong818/sign-chao/pkg/signvalidator"
+)
+
+// Config Vault transit 配置
+type Config struct {
+	// Address Vault 地址，如 "https://vault.example.com:8200"
+	Address string
+	// Token 访问令牌
+	Token string
+	// Namespace 企业版命名空间，可为空
+	Namespace string
+	// Mount transit 引擎挂载路径，默认为 "transit"
+	Mount string
+	// KeyName transit 密钥名称
+	KeyName string
+	// KeyVersion 使用的密钥版本，0 表示最新版本
+	KeyVersion int
+	// Client HTTP 客户端，默认为 http.DefaultClient
+	Client *http.Client
+	// Timeout 单次调用超时时间，默认为 5 秒
+	Timeout time.Duration
+}
+
+// APIError Vault 返回的错误
+type APIError struct {
+	// StatusCode HTTP 状态码
+	StatusCode int
+	// Errors 错误信息
+	Errors []string `json:"errors"`
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("Vault 错误（HTTP %d）: %s", e.StatusCode, strings.Join(e.Errors, "; "))
+}
+
+// client Vault transit 客户端
+type client struct {
+	config Config
+}
+
+// newClient 检查配置并填充默认值
+func newClient(config Config) (*client, error) {
+	if config.Address == "" {
+		return nil, errors.New("Vault 地址为空")
+	}
+	if config.KeyName == "" {
+		return nil, errors.New("Vault 密钥名称为空")
+	}
+	if config.Mount == "" {
+		config.Mount = "transit"
+	}
+	if config.Client == nil {
+		config.Client = http.DefaultClient
+	}
+	if config.Timeout <= 0 {
+		config.Timeout = 5 * time.Second
+	}
+	config.Address = strings.TrimRight(config.Address, "/")
+	return &client{config: config}, nil
+}
+
+// call 调用 transit 接口，body 为 nil 时使用 GET
+func (c *client) call(path string, body, output interface{}) error {
+	ctx, cancel := context.WithTimeout(context.Background(), c.config.Timeout)
+	defer cancel()
+
+	method := http.MethodGet
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		method = http.MethodPost
+		reader = bytes.NewReader(data)
+	}
+
+	endpoint := c.config.Address + "/v1/" + strings.Trim(c.config.Mount, "/") + "/" + path
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-Vault-Token", c.config.Token)
+	if c.config.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", c.config.Namespace)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.config.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		apiErr := &APIError{StatusCode: resp.StatusCode}
+		json.Unmarshal(data, apiErr)
+		return apiErr
+	}
+	if err := json.Unmarshal(data, output); err != nil {
+		return fmt.Errorf("解析 Vault 响应失败: %w", err)
+	}
+	return nil
+}
+
+// request 构建带输入及密钥版本的请求体
+func (c *client) request(input []byte, extra map[string]interface{}) map[string]interface{} {
+	body := map[string]interface{}{"input": base64.StdEncoding.EncodeToString(input)}
+	if c.config.KeyVersion > 0 {
+		body["key_version"] = c.config.KeyVersion
+	}
+	for k, v := range extra {
+		body[k] = v
+	}
+	return body
+}
+
+// decodeValue 解码 "vault:v<版本>:<Base64>" 格式的结果
+func decodeValue(value string) ([]byte, error) {
+	parts := strings.SplitN(value, ":", 3)
+	if len(parts) != 3 || parts[0] != "vault" {
+		return nil, fmt.Errorf("Vault 返回值格式无效: %s", value)
+	}
+	return base64.StdEncoding.DecodeString(parts[2])
+}
+
+// hmacAlgorithms 签名算法对应的 transit HMAC 算法
+var hmacAlgorithms = map[signvalidator.SignAlgorithm]string{
+	signvalidator.HMAC_SHA256:   "sha2-256",
+	signvalidator.HMAC_SHA3_256: "sha3-256",
+	signvalidator.HMAC_SHA3_512: "sha3-512",
+}
+
+// MACBackend 调用 transit hmac 接口的 signvalidator.MACBackend
+type MACBackend struct {
+	client *client
+}
+
+// NewMACBackend 创建 Vault MAC 后端
+func NewMACBackend(config Config) (*MACBackend, error) {
+	c, err := newClient(config)
+	if err != nil {
+		return nil, err
+	}
+	return &MACBackend{client: c}, nil
+}
+
+// MAC 使用 transit 密钥计算 MAC，支持 HMAC_SHA256、HMAC_SHA3_256 与 HMAC_SHA3_512
+func (b *MACBackend) MAC(algorithm signvalidator.SignAlgorithm, data []byte) ([]byte, error) {
+	hmacAlgorithm, ok := hmacAlgorithms[algorithm]
+	if !ok {
+		return nil, fmt.Errorf("%w: Vault 不支持 %s", signvalidator.ErrUnsupportedAlgorithm, algorithm)
+	}
+
+	var output struct {
+		Data struct {
+			HMAC string `json:"hmac"`
+		} `json:"data"`
+	}
+	path := "hmac/" + url.PathEscape(b.client.config.KeyName) + "/" + hmacAlgorithm
+	if err := b.client.call(path, b.client.request(data, nil), &output); err != nil {
+		return nil, fmt.Errorf("Vault hmac 失败: %w", err)
+	}
+	return decodeValue(output.Data.HMAC)
+}
+
+// Signer 使用 transit 非对称密钥签名的 crypto.Signer，支持 RSA（PKCS#1 v1.5、PSS）、ECDSA 与 Ed25519
+type Signer struct {
+	client *client
+	public crypto.PublicKey
+	// version 公钥对应的密钥版本，签名时始终指定该版本，使 Vault 轮换密钥后签名仍与 Public 一致
+	version int
+}
+
+// NewSigner 创建 Vault 签名器，创建时读取密钥的公钥；KeyVersion 为 0 时固定使用创建时的最新版本，
+// 轮换密钥后需重新创建签名器才会使用新版本
+func NewSigner(config Config) (*Signer, error) {
+	c, err := newClient(config)
+	if err != nil {
+		return nil, err
+	}
+
+	var output struct {
+		Data struct {
+			LatestVersion int `json:"latest_version"`
+			Keys          map[string]struct {
+				PublicKey string `json:"public_key"`
+			} `json:"keys"`
+		} `json:"data"`
+	}
+	if err := c.call("keys/"+url.PathEscape(config.KeyName), nil, &output); err != nil {
+		return nil, fmt.Errorf("读取 Vault 密钥失败: %w", err)
+	}
+
+	version := config.KeyVersion
+	if version <= 0 {
+		version = output.Data.LatestVersion
+	}
+	key, ok := output.Data.Keys[strconv.Itoa(version)]
+	if !ok || key.PublicKey == "" {
+		return nil, fmt.Errorf("Vault 密钥 %s 没有版本 %d 的公钥", config.KeyName, version)
+	}
+
+	public, err := parsePublicKey(key.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	return &Signer{client: c, public: public, version: version}, nil
+}
+
+// parsePublicKey 解析 transit 返回的公钥：RSA 与 ECDSA 为 PEM，Ed25519 为 Base64
+func parsePublicKey(value string) (crypto.PublicKey, error) {
+	if strings.Contains(value, "-----BEGIN") {
+		public, err := signvalidator.ParsePublicKey(value)
+		if err != nil {
+			return nil, fmt.Errorf("解析 Vault 公钥失败: %w", err)
+		}
+		switch public.(type) {
+		case *rsa.PublicKey, *ecdsa.PublicKey:
+			return public, nil
+		}
+		return nil, fmt.Errorf("不支持的公钥类型: %T", public)
+	}
+	return signvalidator.ParseEd25519PublicKey(value)
+}
+
+// Public 返回 transit 密钥的公钥
+func (s *Signer) Public() crypto.PublicKey {
+	return s.public
+}
+
+// KeyVersion 返回签名使用的密钥版本
+func (s *Signer) KeyVersion() int {
+	return s.version
+}
+
+// Sign 使用 transit 签名：RSA 与 ECDSA 传入摘要（prehashed），Ed25519 传入原始消息；ECDSA 签名为 ASN.1 DER 格式
+func (s *Signer) Sign(_ io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	extra := map[string]interface{}{"key_version": s.version}
+	path := "sign/" + url.PathEscape(s.client.config.KeyName)
+
+	switch s.public.(type) {
+	case ed25519.PublicKey:
+		if opts.HashFunc() != crypto.Hash(0) {
+			return nil, errors.New("Ed25519 只支持对原始消息签名")
+		}
+	default:
+		hashes := map[crypto.Hash]string{crypto.SHA256: "sha2-256", crypto.SHA384: "sha2-384", crypto.SHA512: "sha2-512"}
+		hashAlgorithm, ok := hashes[opts.HashFunc()]
+		if !ok {
+			return nil, fmt.Errorf("Vault 不支持的摘要算法: %v", opts.HashFunc())
+		}
+		if len(digest) != opts.HashFunc().Size() {
+			return nil, errors.New("摘要长度与摘要算法不匹配")
+		}
+		path += "/" + hashAlgorithm
+		extra["prehashed"] = true
+
+		if _, ok := s.public.(*rsa.PublicKey); ok {
+			extra["signature_algorithm"] = "pkcs1v15"
+			if pss, ok := opts.(*rsa.PSSOptions); ok {
+				if pss.SaltLength != rsa.PSSSaltLengthEqualsHash && pss.SaltLength != opts.HashFunc().Size() {
+					return nil, errors.New("Vault 签名的 PSS 盐长度必须与摘要长度相同")
+				}
+				extra["signature_algorithm"] = "pss"
+				extra["salt_length"] = "hash"
+			}
+		} else {
+			extra["marshaling_algorithm"] = "asn1"
+		}
+	}
+
+	var output struct {
+		Data struct {
+			Signature string `json:"signature"`
+		} `json:"data"`
+	}
+	if err := s.client.call(path, s.client.request(digest, extra), &output); err != nil {
+		return nil, fmt.Errorf("Vault sign 失败: %w", err)
+	}
+	return decodeValue(output.Data.Signature)
+}
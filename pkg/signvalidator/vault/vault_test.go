@@ -0,0 +1,203 @@
+package vault
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/huangchunlong818/sign-chao/pkg/signvalidator"
+)
+
+// fakeTransit 模拟 Vault transit 引擎，密钥只保存在服务端
+type fakeTransit struct {
+	hmacKey []byte
+	signers map[string]crypto.Signer
+	// versions 每次签名请求的 key_version
+	versions []int
+}
+
+func (f *fakeTransit) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("X-Vault-Token") != "test-token" {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"errors":["permission denied"]}`))
+		return
+	}
+
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/v1/transit/"), "/")
+	var input struct {
+		Input              string `json:"input"`
+		Prehashed          bool   `json:"prehashed"`
+		SignatureAlgorithm string `json:"signature_algorithm"`
+		KeyVersion         int    `json:"key_version"`
+	}
+	json.NewDecoder(r.Body).Decode(&input)
+	data, _ := base64.StdEncoding.DecodeString(input.Input)
+
+	var output interface{}
+	switch parts[0] {
+	case "hmac":
+		if parts[2] != "sha2-256" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		h := hmac.New(sha256.New, f.hmacKey)
+		h.Write(data)
+		output = map[string]interface{}{"data": map[string]string{"hmac": "vault:v1:" + base64.StdEncoding.EncodeToString(h.Sum(nil))}}
+	case "keys":
+		signer, ok := f.signers[parts[1]]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errors":[]}`))
+			return
+		}
+		var public string
+		if key, ok := signer.Public().(ed25519.PublicKey); ok {
+			public = base64.StdEncoding.EncodeToString(key)
+		} else {
+			public, _ = signvalidator.MarshalPublicKeyPEM(signer.Public())
+		}
+		output = map[string]interface{}{"data": map[string]interface{}{
+			"latest_version": 1,
+			"keys":           map[string]interface{}{"1": map[string]string{"public_key": public}},
+		}}
+	case "sign":
+		f.versions = append(f.versions, input.KeyVersion)
+		signer := f.signers[parts[1]]
+		var opts crypto.SignerOpts = crypto.Hash(0)
+		if input.Prehashed {
+			opts = crypto.SHA256
+			if input.SignatureAlgorithm == "pss" {
+				opts = &rsa.PSSOptions{Hash: crypto.SHA256, SaltLength: rsa.PSSSaltLengthEqualsHash}
+			}
+		}
+		signature, err := signer.Sign(rand.Reader, data, opts)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		output = map[string]interface{}{"data": map[string]string{"signature": "vault:v1:" + base64.StdEncoding.EncodeToString(signature)}}
+	}
+	json.NewEncoder(w).Encode(output)
+}
+
+func newFakeTransit(t *testing.T) (*fakeTransit, string) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("生成密钥失败: %v", err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("生成密钥失败: %v", err)
+	}
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("生成密钥失败: %v", err)
+	}
+
+	transit := &fakeTransit{
+		hmacKey: []byte("vaultHmacKey"),
+		signers: map[string]crypto.Signer{"rsa": rsaKey, "ec": ecKey, "ed": edKey},
+	}
+	server := httptest.NewServer(transit)
+	t.Cleanup(server.Close)
+	return transit, server.URL
+}
+
+func TestMACBackend(t *testing.T) {
+	transit, address := newFakeTransit(t)
+
+	backend, err := NewMACBackend(Config{Address: address, Token: "test-token", KeyName: "partner"})
+	if err != nil {
+		t.Fatalf("创建 MAC 后端失败: %v", err)
+	}
+	validator := signvalidator.NewSignValidator(signvalidator.Config{Algorithm: signvalidator.HMAC_SHA256, MACBackend: backend})
+
+	params := map[string]interface{}{"id": 123, "name": "test"}
+	signature, err := validator.GenerateSignature(params)
+	if err != nil {
+		t.Fatalf("生成签名失败: %v", err)
+	}
+
+	h := hmac.New(sha256.New, transit.hmacKey)
+	h.Write([]byte("id=123&name=test"))
+	if expected := hex.EncodeToString(h.Sum(nil)); signature != expected {
+		t.Errorf("签名 = %s, 期望 %s", signature, expected)
+	}
+
+	valid, err := validator.Validate(params, signature)
+	if err != nil {
+		t.Fatalf("验证签名失败: %v", err)
+	}
+	if !valid {
+		t.Errorf("签名验证失败")
+	}
+
+	// 令牌无效
+	denied, _ := NewMACBackend(Config{Address: address, Token: "bad", KeyName: "partner"})
+	var apiErr *APIError
+	if _, err := denied.MAC(signvalidator.HMAC_SHA256, []byte("data")); !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusForbidden {
+		t.Errorf("错误 = %v, 期望 HTTP 403", err)
+	}
+}
+
+func TestSigner(t *testing.T) {
+	transit, address := newFakeTransit(t)
+
+	testCases := []struct {
+		keyName   string
+		algorithm signvalidator.SignAlgorithm
+	}{
+		{"rsa", signvalidator.RSA_SHA256},
+		{"rsa", signvalidator.RSA_PSS_SHA256},
+		{"ec", signvalidator.ECDSA_P256_SHA256},
+		{"ed", signvalidator.ED25519},
+	}
+
+	params := map[string]interface{}{"id": 123, "name": "test"}
+	for _, tc := range testCases {
+		signer, err := NewSigner(Config{Address: address, Token: "test-token", KeyName: tc.keyName})
+		if err != nil {
+			t.Fatalf("创建签名器失败: %v", err)
+		}
+		validator := signvalidator.NewSignValidator(signvalidator.Config{Algorithm: tc.algorithm, Signer: signer})
+
+		signature, err := validator.GenerateSignature(params)
+		if err != nil {
+			t.Fatalf("%s 生成签名失败: %v", tc.algorithm, err)
+		}
+		valid, err := validator.Validate(params, signature)
+		if err != nil {
+			t.Fatalf("%s 验证签名失败: %v", tc.algorithm, err)
+		}
+		if !valid {
+			t.Errorf("%s 签名验证失败", tc.algorithm)
+		}
+	}
+
+	// 未指定 KeyVersion 时签名请求应固定为公钥对应的最新版本
+	for _, version := range transit.versions {
+		if version != 1 {
+			t.Errorf("签名请求的 key_version = %d, 期望 1", version)
+		}
+	}
+	if len(transit.versions) == 0 {
+		t.Error("未记录签名请求")
+	}
+
+	if _, err := NewSigner(Config{Address: address, Token: "test-token", KeyName: "missing"}); err == nil {
+		t.Errorf("密钥不存在应该返回错误")
+	}
+}
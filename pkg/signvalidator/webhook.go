@@ -0,0 +1,139 @@
+package signvalidator
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultWebhookTolerance Webhook 签名时间戳的默认允许偏差，与 Stripe 的默认值一致
+const defaultWebhookTolerance = 5 * time.Minute
+
+// WebhookConfig Webhook 签名配置，签名请求头形如 "t=1700000000,v1=签名,v1=签名2"，
+// 签名为 HMAC-SHA256(Secret, "时间戳.请求体") 的十六进制小写字符串
+type WebhookConfig struct {
+	// Secret 签名密钥，按原样使用（如 Stripe 的 "whsec_..."）
+	Secret string
+	// Scheme 签名方案名称，默认为 "v1"，其他方案（如 "v0"）的签名被忽略
+	Scheme string
+	// Tolerance 时间戳与当前时间允许的偏差（过去与未来方向），默认为 5 分钟
+	Tolerance time.Duration
+	// Clock 读取当前时间的时钟，默认为 SystemClock
+	Clock Clock
+}
+
+// WebhookHeader 解析后的 Webhook 签名请求头
+type WebhookHeader struct {
+	// Timestamp t 字段的 Unix 时间戳（秒）
+	Timestamp int64
+	// RawTimestamp t 字段的原始字符串，发送方对该字符串签名，验证时按原样使用（如 "01700000000"）
+	RawTimestamp string
+	// Signatures 各方案的签名，如 {"v1": ["...", "..."]}
+	Signatures map[string][]string
+}
+
+// ParseWebhookHeader 解析 "t=时间戳,方案=签名,..." 形式的签名请求头，同一方案可出现多次；
+// 缺少或无法解析 t 字段时返回错误，格式错误的字段被忽略
+func ParseWebhookHeader(value string) (*WebhookHeader, error) {
+	header := &WebhookHeader{Signatures: map[string][]string{}}
+	var timestamp string
+	for _, field := range strings.Split(value, ",") {
+		name, value, ok := strings.Cut(strings.TrimSpace(field), "=")
+		if !ok || value == "" {
+			continue
+		}
+		if name == "t" {
+			timestamp = value
+			continue
+		}
+		header.Signatures[name] = append(header.Signatures[name], value)
+	}
+	if timestamp == "" {
+		return nil, errors.New("签名请求头缺少时间戳 t")
+	}
+	t, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("签名请求头的时间戳格式错误: %s", timestamp)
+	}
+	header.Timestamp, header.RawTimestamp = t, timestamp
+	return header, nil
+}
+
+// WebhookSigner 生成与验证 Webhook 签名请求头
+type WebhookSigner struct {
+	config WebhookConfig
+}
+
+// NewWebhookSigner 创建 Webhook 签名器，未设置 Secret 时返回错误
+func NewWebhookSigner(config WebhookConfig) (*WebhookSigner, error) {
+	switch {
+	case config.Secret == "":
+		return nil, errors.New("Webhook 签名密钥不能为空")
+	case config.Tolerance < 0:
+		return nil, errors.New("Webhook 时间戳允许的偏差不能为负数")
+	case strings.ContainsAny(config.Scheme, ",= "):
+		return nil, fmt.Errorf("Webhook 签名方案名称无效: %s", config.Scheme)
+	}
+	if config.Scheme == "" {
+		config.Scheme = "v1"
+	}
+	if config.Tolerance == 0 {
+		config.Tolerance = defaultWebhookTolerance
+	}
+	return &WebhookSigner{config: config}, nil
+}
+
+// Sign 以当前时间为 payload 生成签名请求头的值
+func (s *WebhookSigner) Sign(payload []byte) string {
+	timestamp := strconv.FormatInt(s.now().Unix(), 10)
+	return "t=" + timestamp + "," + s.config.Scheme + "=" + hex.EncodeToString(s.mac(timestamp, payload))
+}
+
+// Verify 验证 payload 的签名请求头，任一 Scheme 签名匹配即有效（发送方轮换密钥时会同时携带新旧密钥的签名）；
+// 签名有效但时间戳超出 Tolerance 时返回 ErrTimestampExpired，请求头格式错误时返回错误
+func (s *WebhookSigner) Verify(payload []byte, header string) (bool, error) {
+	parsed, err := ParseWebhookHeader(header)
+	if err != nil {
+		return false, err
+	}
+	signatures := parsed.Signatures[s.config.Scheme]
+	if len(signatures) == 0 {
+		return false, &MissingKeysError{Keys: []string{s.config.Scheme}}
+	}
+
+	expected := s.mac(parsed.RawTimestamp, payload)
+	valid := false
+	for _, signature := range signatures {
+		if decoded, err := hex.DecodeString(signature); err == nil && hmac.Equal(decoded, expected) {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return false, nil
+	}
+
+	if skew := s.now().Sub(time.Unix(parsed.Timestamp, 0)); skew > s.config.Tolerance || skew < -s.config.Tolerance {
+		return false, fmt.Errorf("%w: 偏差 %s", ErrTimestampExpired, skew.Round(time.Second))
+	}
+	return true, nil
+}
+
+// mac 计算 HMAC-SHA256(Secret, "timestamp.payload")
+func (s *WebhookSigner) mac(timestamp string, payload []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(s.config.Secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
+
+// now 返回当前时间
+func (s *WebhookSigner) now() time.Time {
+	return clockOrSystem(s.config.Clock).Now()
+}
@@ -0,0 +1,100 @@
+package signvalidator
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWebhookSigner(t *testing.T) {
+	clock := NewFakeClock(time.Unix(1700000000, 0))
+	signer, err := NewWebhookSigner(WebhookConfig{Secret: "whsec_test", Clock: clock})
+	if err != nil {
+		t.Fatalf("创建签名器失败: %v", err)
+	}
+
+	payload := []byte(`{"id":"evt_1"}`)
+	header := signer.Sign(payload)
+	if expected := "t=1700000000,v1=c89214b5b5da833daed6f0b8c5bb6bd58cea9022bd80ccc78230f3942d632925"; header != expected {
+		t.Errorf("签名请求头 = %s, 期望 %s", header, expected)
+	}
+	if valid, err := signer.Verify(payload, header); err != nil || !valid {
+		t.Errorf("签名应验证通过: valid=%v, err=%v", valid, err)
+	}
+
+	rotated := "t=1700000000, v1=00ff, v0=abc, v1=c89214b5b5da833daed6f0b8c5bb6bd58cea9022bd80ccc78230f3942d632925"
+	if valid, err := signer.Verify(payload, rotated); err != nil || !valid {
+		t.Errorf("任一 v1 签名匹配即应验证通过: valid=%v, err=%v", valid, err)
+	}
+	if valid, err := signer.Verify([]byte(`{"id":"evt_2"}`), header); err != nil || valid {
+		t.Errorf("请求体被篡改时应验证失败: valid=%v, err=%v", valid, err)
+	}
+
+	clock.Advance(6 * time.Minute)
+	if _, err := signer.Verify(payload, header); !errors.Is(err, ErrTimestampExpired) {
+		t.Errorf("超过允许偏差时应返回 ErrTimestampExpired，实际 %v", err)
+	}
+}
+
+func TestWebhookSigner_RawTimestamp(t *testing.T) {
+	clock := NewFakeClock(time.Unix(1700000000, 0))
+	signer, err := NewWebhookSigner(WebhookConfig{Secret: "whsec_test", Clock: clock})
+	if err != nil {
+		t.Fatalf("创建签名器失败: %v", err)
+	}
+	payload := []byte(`{"id":"evt_1"}`)
+
+	// 发送方对 t 字段的原始字符串签名，非规范的写法同样应验证通过
+	for _, timestamp := range []string{"01700000000", "+1700000000"} {
+		mac := hmac.New(sha256.New, []byte("whsec_test"))
+		mac.Write([]byte(timestamp + "." + string(payload)))
+		header := "t=" + timestamp + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+		if valid, err := signer.Verify(payload, header); err != nil || !valid {
+			t.Errorf("t=%s 时验证应通过: valid=%v, err=%v", timestamp, valid, err)
+		}
+	}
+
+	// 按规范化的时间戳签名不能用于非规范的 t 字段
+	header := strings.Replace(signer.Sign(payload), "t=1700000000", "t=01700000000", 1)
+	if valid, _ := signer.Verify(payload, header); valid {
+		t.Error("修改 t 字段的写法后签名应无效")
+	}
+}
+
+func TestParseWebhookHeader(t *testing.T) {
+	header, err := ParseWebhookHeader("t=1700000000,v1=a,v1=b,v0=c,broken")
+	if err != nil {
+		t.Fatalf("解析失败: %v", err)
+	}
+	if header.Timestamp != 1700000000 || len(header.Signatures["v1"]) != 2 || header.Signatures["v0"][0] != "c" {
+		t.Errorf("解析结果 = %+v", header)
+	}
+
+	for _, value := range []string{"", "v1=a", "t=abc,v1=a"} {
+		if _, err := ParseWebhookHeader(value); err == nil {
+			t.Errorf("%q: 应返回错误", value)
+		}
+	}
+
+	signer, _ := NewWebhookSigner(WebhookConfig{Secret: "whsec_test"})
+	var missing *MissingKeysError
+	if _, err := signer.Verify(nil, "t=1700000000,v0=abc"); !errors.As(err, &missing) {
+		t.Errorf("缺少 v1 签名时应返回 *MissingKeysError，实际 %v", err)
+	}
+}
+
+func TestNewWebhookSigner_Invalid(t *testing.T) {
+	for _, config := range []WebhookConfig{
+		{},
+		{Secret: "s", Tolerance: -time.Second},
+		{Secret: "s", Scheme: "v=1"},
+	} {
+		if _, err := NewWebhookSigner(config); err == nil {
+			t.Errorf("%+v: 应返回错误", config)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package signvalidator
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// ParseXMLParams 将扁平的 XML 文档（如微信支付 v2 的 <xml><appid><![CDATA[wx]]></appid>...</xml>）解析为参数，
+// 根元素名称不限，子元素名称为参数名、文本（含 CDATA）为参数值；子元素嵌套或重复时返回错误
+func ParseXMLParams(data []byte) (map[string]interface{}, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	params := make(map[string]interface{})
+
+	depth := 0
+	root := false
+	var key string
+	var value strings.Builder
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("解析 XML 失败: %w", err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			depth++
+			switch depth {
+			case 1:
+				if root {
+					return nil, errors.New("XML 只能有一个根元素")
+				}
+				root = true
+			case 2:
+				key = t.Name.Local
+				value.Reset()
+				if _, exists := params[key]; exists {
+					return nil, fmt.Errorf("XML 参数 %s 重复", key)
+				}
+			default:
+				return nil, fmt.Errorf("XML 参数 %s 不支持嵌套元素", key)
+			}
+		case xml.EndElement:
+			if depth == 2 {
+				params[key] = value.String()
+			}
+			depth--
+		case xml.CharData:
+			if depth == 2 {
+				value.Write(t)
+			}
+		}
+	}
+	if !root || depth != 0 {
+		return nil, errors.New("XML 文档不完整")
+	}
+	return params, nil
+}
+
+// ValidateXML 解析 XML 参数并验证其中签名参数（SignatureKey）的签名，用于微信支付 v2 等 XML 回调
+func (v *SignValidator) ValidateXML(data []byte) (bool, error) {
+	if v.err != nil {
+		return false, v.err
+	}
+	params, err := ParseXMLParams(data)
+	if err != nil {
+		return false, err
+	}
+	return v.ValidateWithSignInParams(params)
+}
+
+// SignXML 对参数生成签名，返回包含签名节点的 XML 文档（根元素为 <xml>，参数按名称排序，值使用 CDATA），
+// 用于构造 XML 请求或回调响应
+func (v *SignValidator) SignXML(params map[string]interface{}) ([]byte, error) {
+	signature, err := v.GenerateSignature(params)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(params))
+	for k := range params {
+		if k == v.config.SignatureKey {
+			continue
+		}
+		if !isXMLName(k) {
+			return nil, fmt.Errorf("参数名 %s 不是有效的 XML 元素名", k)
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var buf bytes.Buffer
+	buf.WriteString("<xml>")
+	for _, k := range keys {
+		value, err := v.encodeValue(k, params[k])
+		if err != nil {
+			return nil, err
+		}
+		writeXMLElement(&buf, k, value)
+	}
+	writeXMLElement(&buf, v.config.SignatureKey, signature)
+	buf.WriteString("</xml>")
+	return buf.Bytes(), nil
+}
+
+// writeXMLElement 写入值为 CDATA 的元素，值中的 "]]>" 拆分到相邻的 CDATA 段
+func writeXMLElement(buf *bytes.Buffer, name, value string) {
+	buf.WriteString("<" + name + "><![CDATA[")
+	buf.WriteString(strings.ReplaceAll(value, "]]>", "]]]]><![CDATA[>"))
+	buf.WriteString("]]></" + name + ">")
+}
+
+// isXMLName 判断参数名是否可用作 XML 元素名（字母或下划线开头，仅含字母、数字、"_"、"-" 与 "."）
+func isXMLName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for i, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r == '_':
+		case i > 0 && (r >= '0' && r <= '9' || r == '-' || r == '.'):
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,114 @@
+package signvalidator
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// 微信支付 v2 文档中的签名示例
+const wechatXML = `<xml>
+  <appid><![CDATA[wxd930ea5d5a258f4f]]></appid>
+  <mch_id>10000100</mch_id>
+  <device_info>1000</device_info>
+  <body><![CDATA[test]]></body>
+  <nonce_str><![CDATA[ibuaiVcKdpRxkhJA]]></nonce_str>
+  <sign><![CDATA[9A0A8659F005D6984697E2CA0A9CF3B7]]></sign>
+</xml>`
+
+func wechatValidator() *SignValidator {
+	return NewSignValidator(Config{Secret: "192006250b4c09247ec02edce69f6a2d", Algorithm: MD5, UpperCase: true})
+}
+
+func TestParseXMLParams(t *testing.T) {
+	params, err := ParseXMLParams([]byte(wechatXML))
+	if err != nil {
+		t.Fatalf("解析 XML 失败: %v", err)
+	}
+	want := map[string]interface{}{
+		"appid":       "wxd930ea5d5a258f4f",
+		"mch_id":      "10000100",
+		"device_info": "1000",
+		"body":        "test",
+		"nonce_str":   "ibuaiVcKdpRxkhJA",
+		"sign":        "9A0A8659F005D6984697E2CA0A9CF3B7",
+	}
+	if !reflect.DeepEqual(params, want) {
+		t.Errorf("参数不正确，期望 %v，实际 %v", want, params)
+	}
+}
+
+func TestParseXMLParams_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"空文档":   "",
+		"嵌套元素":  "<xml><a><b>1</b></a></xml>",
+		"重复参数":  "<xml><a>1</a><a>2</a></xml>",
+		"多个根元素": "<xml></xml><xml></xml>",
+		"未闭合":   "<xml><a>1</a>",
+	}
+	for name, data := range tests {
+		if _, err := ParseXMLParams([]byte(data)); err == nil {
+			t.Errorf("%s: 应返回错误", name)
+		}
+	}
+}
+
+func TestValidateXML(t *testing.T) {
+	skipFIPS(t)
+	v := wechatValidator()
+	valid, err := v.ValidateXML([]byte(wechatXML))
+	if err != nil || !valid {
+		t.Errorf("微信支付示例签名验证应通过，valid=%v err=%v", valid, err)
+	}
+
+	tampered := strings.Replace(wechatXML, "<mch_id>10000100", "<mch_id>10000101", 1)
+	if valid, _ := v.ValidateXML([]byte(tampered)); valid {
+		t.Error("参数修改后签名验证不应通过")
+	}
+}
+
+func TestSignXML(t *testing.T) {
+	skipFIPS(t)
+	v := wechatValidator()
+	data, err := v.SignXML(map[string]interface{}{
+		"appid":       "wxd930ea5d5a258f4f",
+		"mch_id":      10000100,
+		"device_info": 1000,
+		"body":        "test",
+		"nonce_str":   "ibuaiVcKdpRxkhJA",
+		"sign":        "stale",
+	})
+	if err != nil {
+		t.Fatalf("生成 XML 失败: %v", err)
+	}
+	want := "<xml><appid><![CDATA[wxd930ea5d5a258f4f]]></appid><body><![CDATA[test]]></body>" +
+		"<device_info><![CDATA[1000]]></device_info><mch_id><![CDATA[10000100]]></mch_id>" +
+		"<nonce_str><![CDATA[ibuaiVcKdpRxkhJA]]></nonce_str><sign><![CDATA[9A0A8659F005D6984697E2CA0A9CF3B7]]></sign></xml>"
+	if string(data) != want {
+		t.Errorf("XML 不正确，期望 %s，实际 %s", want, data)
+	}
+}
+
+func TestSignXML_CDATAEscape(t *testing.T) {
+	skipFIPS(t)
+	v := wechatValidator()
+	params := map[string]interface{}{"attach": "a]]>b<c>"}
+	data, err := v.SignXML(params)
+	if err != nil {
+		t.Fatalf("生成 XML 失败: %v", err)
+	}
+	parsed, err := ParseXMLParams(data)
+	if err != nil {
+		t.Fatalf("解析生成的 XML 失败: %v", err)
+	}
+	if parsed["attach"] != "a]]>b<c>" {
+		t.Errorf("CDATA 中的 \"]]>\" 应被正确拆分，实际 %q", parsed["attach"])
+	}
+	if valid, err := v.ValidateXML(data); err != nil || !valid {
+		t.Errorf("生成的 XML 签名验证应通过，valid=%v err=%v", valid, err)
+	}
+
+	if _, err := v.SignXML(map[string]interface{}{"1a": "x"}); err == nil {
+		t.Error("无效的元素名应返回错误")
+	}
+}